@@ -25,6 +25,7 @@ type Client interface {
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
+	UpgradeVM(ctx context.Context, chain string, options ...rpc.Option) error
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
@@ -90,6 +91,12 @@ func (c *client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID
 	return res.NewVMs, res.FailedVMs, err
 }
 
+func (c *client) UpgradeVM(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.upgradeVM", &UpgradeVMArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) SetLoggerLevel(
 	ctx context.Context,
 	loggerName,
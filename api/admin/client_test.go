@@ -147,6 +147,18 @@ func TestAliasChain(t *testing.T) {
 	}
 }
 
+func TestUpgradeVM(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.UpgradeVM(context.Background(), "chain")
+		require.ErrorIs(err, test.Err)
+	}
+}
+
 func TestGetChainAliases(t *testing.T) {
 	t.Run("successful", func(t *testing.T) {
 		require := require.New(t)
@@ -345,3 +345,27 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// UpgradeVMArgs are the arguments for calling UpgradeVM
+type UpgradeVMArgs struct {
+	Chain string `json:"chain"`
+}
+
+// UpgradeVM replaces the VM of a running chain with the plugin of its VM in
+// the plugin directory. If the new VM can't be started, the chain keeps
+// running the previous VM. If the new VM doesn't resume from the chain's last
+// accepted block or isn't healthy, the previous VM is restarted and an error
+// is returned.
+func (a *Admin) UpgradeVM(r *http.Request, args *UpgradeVMArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "upgradeVM"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.UpgradeVM(r.Context(), chainID)
+}
@@ -36,8 +36,10 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -51,9 +53,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/metervm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 	"github.com/ava-labs/avalanchego/vms/tracedvm"
 
 	dbManager "github.com/ava-labs/avalanchego/database/manager"
@@ -87,10 +92,22 @@ var (
 	// Bootstrapping prefixes for ChainVMs
 	bootstrappingDB = []byte("bs")
 
+	// Prefix for the progress of the VMs that stream their transactions
+	txStreamDBPrefix = []byte("tx_stream")
+
+	// Fxs that network upgrades added to the X-chain after its genesis
+	xChainUpgradeFxIDs = []ids.ID{
+		secp256r1fx.ID,
+		htlcfx.ID,
+	}
+
 	errUnknownVMType          = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM       = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped        = errors.New("subnets not bootstrapped")
 	errNoPrimaryNetworkConfig = errors.New("no subnet config for primary network found")
+	errUnknownChain           = errors.New("unknown chain")
+	errVMNotUpgradable        = errors.New("vm can't be upgraded")
+	errVMNotSandboxable       = errors.New("vm can't be sandboxed")
 
 	_ Manager = (*manager)(nil)
 )
@@ -126,6 +143,10 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// UpgradeVM replaces the VM of the running chain with the plugin of its
+	// VM in the plugin directory.
+	UpgradeVM(ctx context.Context, chainID ids.ID) error
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -155,14 +176,19 @@ type chain struct {
 	VM      common.VM
 	Handler handler.Handler
 	Beacons validators.Set
+	// Upgrader is nil if the VM can't be upgraded while the chain is running.
+	Upgrader vms.Upgrader
 }
 
 // ChainConfig is configuration settings for the current execution.
 // [Config] is the user-provided config blob for the chain.
 // [Upgrade] is a chain-specific blob for coordinating upgrades.
+// [Sandbox], if non-nil, is the sandbox the processes of the chain's VM are
+// started in.
 type ChainConfig struct {
 	Config  []byte
 	Upgrade []byte
+	Sandbox *subprocess.SandboxConfig
 }
 
 type ManagerConfig struct {
@@ -178,6 +204,7 @@ type ManagerConfig struct {
 	BlockAcceptorGroup          snow.AcceptorGroup
 	TxAcceptorGroup             snow.AcceptorGroup
 	VertexAcceptorGroup         snow.AcceptorGroup
+	EventPublisher              eventbus.Publisher
 	DBManager                   dbManager.Manager
 	MsgCreator                  message.OutboundMsgBuilder // message creator, shared with network
 	Router                      router.Router              // Routes incoming messages to the appropriate chain
@@ -221,6 +248,9 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Divides the node's message processing between chains.
+	CPUScheduler scheduler.Scheduler
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -250,6 +280,9 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The chain's VM, if it can be upgraded
+	upgraders map[ids.ID]vms.Upgrader
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -262,6 +295,7 @@ func New(config *ManagerConfig) Manager {
 		ManagerConfig:          *config,
 		subnets:                make(map[ids.ID]subnets.Subnet),
 		chains:                 make(map[ids.ID]handler.Handler),
+		upgraders:              make(map[ids.ID]vms.Upgrader),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -380,6 +414,9 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	if chain.Upgrader != nil {
+		m.upgraders[chainParams.ID] = chain.Upgrader
+	}
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -483,6 +520,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		BlockAcceptor:       m.BlockAcceptorGroup,
 		TxAcceptor:          m.TxAcceptorGroup,
 		VertexAcceptor:      m.VertexAcceptorGroup,
+		EventPublisher:      m.EventPublisher,
 		Registerer:          consensusMetrics,
 		AvalancheRegisterer: avalancheConsensusMetrics,
 	}
@@ -493,15 +531,39 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, fmt.Errorf("error while getting vmFactory: %w", err)
 	}
 
+	chainConfig, err := m.getChainConfig(chainParams.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching chain config: %w", err)
+	}
+
 	// Create the chain
-	vm, err := vmFactory.New(chainLog)
+	var vm interface{}
+	if chainConfig.Sandbox == nil {
+		vm, err = vmFactory.New(chainLog)
+	} else {
+		// Only VMs that run in their own processes can be sandboxed.
+		sandboxedFactory, ok := vmFactory.(vms.SandboxedFactory)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errVMNotSandboxable, chainParams.VMID)
+		}
+		vm, err = sandboxedFactory.NewSandboxed(chainLog, chainConfig.Sandbox)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while creating vm: %w", err)
 	}
 	// TODO: Shutdown VM if an error occurs
 
-	fxs := make([]*common.Fx, len(chainParams.FxIDs))
-	for i, fxID := range chainParams.FxIDs {
+	fxIDs := chainParams.FxIDs
+	if chainParams.ID == m.XChainID {
+		// The fxs of the X-chain are fixed by the genesis, so fxs added by
+		// network upgrades are appended to them here.
+		fxIDs = make([]ids.ID, 0, len(chainParams.FxIDs)+len(xChainUpgradeFxIDs))
+		fxIDs = append(fxIDs, chainParams.FxIDs...)
+		fxIDs = append(fxIDs, xChainUpgradeFxIDs...)
+	}
+
+	fxs := make([]*common.Fx, len(fxIDs))
+	for i, fxID := range fxIDs {
 		// Get a factory for the fx we want to use on our chain
 		fxFactory, err := m.VMManager.GetFactory(fxID)
 		if err != nil {
@@ -572,6 +634,20 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, err
 	}
 
+	// The transactions streamed by Snowman VMs are indexed like the
+	// transactions of DAG VMs.
+	if streamer, ok := vm.(vms.TxStreamer); ok {
+		chainDB := prefixdb.New(ctx.ChainID[:], m.DBManager.Current().Database)
+		txStreamDB := prefixdb.New(txStreamDBPrefix, chainDB)
+		ctx.Lock.Lock()
+		ctx.TxsStreamed, err = streamer.StreamTxs(ctx, txStreamDB)
+		ctx.Lock.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("error while streaming txs: %w", err)
+		}
+	}
+
+	chain.Upgrader, _ = vm.(vms.Upgrader)
 	return chain, nil
 }
 
@@ -813,6 +889,24 @@ func (m *manager) createAvalancheChain(
 	}
 	vdrs.RegisterCallbackListener(connectedValidators)
 
+	sbConfig := sb.Config()
+	cpuScheduler, err := m.CPUScheduler.Register(
+		ctx.ChainID,
+		ctx.SubnetID,
+		sbConfig.CPUShare,
+		sbConfig.ChainCPUShares[ctx.ChainID],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error registering chain with the cpu scheduler: %w", err)
+	}
+	// The registration is released unless the chain is fully created.
+	created := false
+	defer func() {
+		if !created {
+			cpuScheduler.Deregister()
+		}
+	}()
+
 	// Asynchronously passes messages from the network to the consensus engine
 	h, err := handler.New(
 		ctx,
@@ -821,6 +915,7 @@ func (m *manager) createAvalancheChain(
 		m.AcceptedFrontierGossipFrequency,
 		m.ConsensusAppConcurrency,
 		m.ResourceTracker,
+		cpuScheduler,
 		validators.UnhandledSubnetConnector, // avalanche chains don't use subnet connector
 		sb,
 		connectedValidators,
@@ -969,6 +1064,7 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
+	created = true
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
@@ -1155,6 +1251,24 @@ func (m *manager) createSnowmanChain(
 	}
 	vdrs.RegisterCallbackListener(connectedValidators)
 
+	sbConfig := sb.Config()
+	cpuScheduler, err := m.CPUScheduler.Register(
+		ctx.ChainID,
+		ctx.SubnetID,
+		sbConfig.CPUShare,
+		sbConfig.ChainCPUShares[ctx.ChainID],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register chain with the cpu scheduler: %w", err)
+	}
+	// The registration is released unless the chain is fully created.
+	created := false
+	defer func() {
+		if !created {
+			cpuScheduler.Deregister()
+		}
+	}()
+
 	// Asynchronously passes messages from the network to the consensus engine
 	h, err := handler.New(
 		ctx,
@@ -1163,6 +1277,7 @@ func (m *manager) createSnowmanChain(
 		m.AcceptedFrontierGossipFrequency,
 		m.ConsensusAppConcurrency,
 		m.ResourceTracker,
+		cpuScheduler,
 		subnetConnector,
 		sb,
 		connectedValidators,
@@ -1275,6 +1390,7 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
+	created = true
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
@@ -1385,6 +1501,32 @@ func (m *manager) Shutdown() {
 	m.ManagerConfig.Router.Shutdown(context.TODO())
 }
 
+func (m *manager) UpgradeVM(ctx context.Context, chainID ids.ID) error {
+	m.chainsLock.Lock()
+	_, exists := m.chains[chainID]
+	upgrader, upgradable := m.upgraders[chainID]
+	m.chainsLock.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	if !upgradable {
+		return fmt.Errorf("%w: %s", errVMNotUpgradable, chainID)
+	}
+
+	m.Log.Info("upgrading vm",
+		zap.Stringer("chainID", chainID),
+	)
+	if err := upgrader.Upgrade(ctx); err != nil {
+		m.Log.Warn("failed to upgrade vm",
+			zap.Stringer("chainID", chainID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
 // LookupVM returns the ID of the VM associated with an alias
 func (m *manager) LookupVM(alias string) (ids.ID, error) {
 	return m.VMManager.Lookup(alias)
@@ -33,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -51,11 +52,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
 const (
 	chainConfigFileName  = "config"
 	chainUpgradeFileName = "upgrade"
+	chainSandboxFileName = "sandbox"
 	subnetConfigFileExt  = ".json"
 	ipResolutionTimeout  = 30 * time.Second
 )
@@ -80,6 +84,8 @@ var (
 	errSybilProtectionDisabledOnPublicNetwork = errors.New("sybil protection disabled on public network")
 	errAuthPasswordTooWeak                    = errors.New("API auth password is not strong enough")
 	errInvalidUptimeRequirement               = errors.New("uptime requirement must be in the range [0, 1]")
+	errInvalidUptimeHealthCheckMargin         = errors.New("uptime health check margin must be in the range [0, 1]")
+	errInvalidUptimeHealthCheckAssumedUptime  = errors.New("uptime health check assumed uptime must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax              = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee                   = errors.New("delegation fee must be in the range [0, 1,000,000]")
 	errInvalidMinStakeDuration                = errors.New("min stake duration must be > 0")
@@ -235,6 +241,7 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			EventsAPIEnabled:   v.GetBool(EventsAPIEnabledKey),
 		},
 		HTTPHost:           v.GetString(HTTPHostKey),
 		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
@@ -300,15 +307,18 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 
 func getGossipConfig(v *viper.Viper) subnets.GossipConfig {
 	return subnets.GossipConfig{
-		AcceptedFrontierValidatorSize:    uint(v.GetUint32(ConsensusGossipAcceptedFrontierValidatorSizeKey)),
-		AcceptedFrontierNonValidatorSize: uint(v.GetUint32(ConsensusGossipAcceptedFrontierNonValidatorSizeKey)),
-		AcceptedFrontierPeerSize:         uint(v.GetUint32(ConsensusGossipAcceptedFrontierPeerSizeKey)),
-		OnAcceptValidatorSize:            uint(v.GetUint32(ConsensusGossipOnAcceptValidatorSizeKey)),
-		OnAcceptNonValidatorSize:         uint(v.GetUint32(ConsensusGossipOnAcceptNonValidatorSizeKey)),
-		OnAcceptPeerSize:                 uint(v.GetUint32(ConsensusGossipOnAcceptPeerSizeKey)),
-		AppGossipValidatorSize:           uint(v.GetUint32(AppGossipValidatorSizeKey)),
-		AppGossipNonValidatorSize:        uint(v.GetUint32(AppGossipNonValidatorSizeKey)),
-		AppGossipPeerSize:                uint(v.GetUint32(AppGossipPeerSizeKey)),
+		AcceptedFrontierValidatorSize:     uint(v.GetUint32(ConsensusGossipAcceptedFrontierValidatorSizeKey)),
+		AcceptedFrontierNonValidatorSize:  uint(v.GetUint32(ConsensusGossipAcceptedFrontierNonValidatorSizeKey)),
+		AcceptedFrontierPeerSize:          uint(v.GetUint32(ConsensusGossipAcceptedFrontierPeerSizeKey)),
+		OnAcceptValidatorSize:             uint(v.GetUint32(ConsensusGossipOnAcceptValidatorSizeKey)),
+		OnAcceptNonValidatorSize:          uint(v.GetUint32(ConsensusGossipOnAcceptNonValidatorSizeKey)),
+		OnAcceptPeerSize:                  uint(v.GetUint32(ConsensusGossipOnAcceptPeerSizeKey)),
+		AppGossipValidatorSize:            uint(v.GetUint32(AppGossipValidatorSizeKey)),
+		AppGossipNonValidatorSize:         uint(v.GetUint32(AppGossipNonValidatorSizeKey)),
+		AppGossipPeerSize:                 uint(v.GetUint32(AppGossipPeerSizeKey)),
+		BlockAnnouncementValidatorSize:    uint(v.GetUint32(ConsensusBlockAnnouncementValidatorSizeKey)),
+		BlockAnnouncementNonValidatorSize: uint(v.GetUint32(ConsensusBlockAnnouncementNonValidatorSizeKey)),
+		BlockAnnouncementPeerSize:         uint(v.GetUint32(ConsensusBlockAnnouncementPeerSizeKey)),
 	}
 }
 
@@ -993,6 +1003,41 @@ func getChainAliases(v *viper.Viper) (map[ids.ID][]string, error) {
 	return getAliases(v, "chain aliases", ChainAliasesContentKey, ChainAliasesFileKey)
 }
 
+func getRemoteVMs(v *viper.Viper) (map[ids.ID]remote.VMConfig, error) {
+	var fileBytes []byte
+	if v.IsSet(RemoteVMsContentKey) {
+		var err error
+		fileBytes, err = base64.StdEncoding.DecodeString(v.GetString(RemoteVMsContentKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content for remote vms: %w", err)
+		}
+	} else {
+		filePath := filepath.Clean(GetExpandedArg(v, RemoteVMsFileKey))
+		exists, err := storage.FileExists(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			if v.IsSet(RemoteVMsFileKey) {
+				return nil, fmt.Errorf("%w: %s", errFileDoesNotExist, filePath)
+			}
+			return nil, nil
+		}
+
+		fileBytes, err = os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	remoteVMs := make(map[ids.ID]remote.VMConfig)
+	if err := json.Unmarshal(fileBytes, &remoteVMs); err != nil {
+		return nil, fmt.Errorf("%w on remote vms: %s", errUnmarshalling, err)
+	}
+	return remoteVMs, nil
+}
+
 func getVMAliaser(v *viper.Viper) (ids.Aliaser, error) {
 	vmAliases, err := getVMAliases(v)
 	if err != nil {
@@ -1039,6 +1084,14 @@ func getChainConfigsFromFlag(v *viper.Viper) (map[string]chains.ChainConfig, err
 	if err := json.Unmarshal(chainConfigContent, &chainConfigs); err != nil {
 		return nil, fmt.Errorf("could not unmarshal JSON: %w", err)
 	}
+	for chain, chainConfig := range chainConfigs {
+		if chainConfig.Sandbox == nil {
+			continue
+		}
+		if err := chainConfig.Sandbox.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid sandbox for chain %s: %w", chain, err)
+		}
+	}
 	return chainConfigs, nil
 }
 
@@ -1093,14 +1146,39 @@ func readChainConfigPath(chainConfigPath string) (map[string]chains.ChainConfig,
 			return chainConfigMap, err
 		}
 
+		// chainconfigdir/chainId/sandbox.*
+		sandbox, err := readChainSandbox(chainDir)
+		if err != nil {
+			return chainConfigMap, err
+		}
+
 		chainConfigMap[dirInfo.Name()] = chains.ChainConfig{
 			Config:  configData,
 			Upgrade: upgradeData,
+			Sandbox: sandbox,
 		}
 	}
 	return chainConfigMap, nil
 }
 
+// readChainSandbox returns the sandbox configured in [chainDir]. Returns nil if
+// the chain's VM isn't sandboxed.
+func readChainSandbox(chainDir string) (*subprocess.SandboxConfig, error) {
+	sandboxData, err := storage.ReadFileWithName(chainDir, chainSandboxFileName)
+	if err != nil || len(sandboxData) == 0 {
+		return nil, err
+	}
+
+	sandbox := &subprocess.SandboxConfig{}
+	if err := json.Unmarshal(sandboxData, sandbox); err != nil {
+		return nil, fmt.Errorf("%w on sandbox of chain %s: %s", errUnmarshalling, filepath.Base(chainDir), err)
+	}
+	if err := sandbox.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid sandbox for chain %s: %w", filepath.Base(chainDir), err)
+	}
+	return sandbox, nil
+}
+
 // getSubnetConfigsFromFlags reads subnet configs from the correct place
 // (flag or file) and returns a non-nil map.
 func getSubnetConfigs(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]subnets.Config, error) {
@@ -1195,7 +1273,20 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 		ValidatorOnly:         false,
 		GossipConfig:          getGossipConfig(v),
 		ProposerMinBlockDelay: proposervm.DefaultMinBlockDelay,
+		CPUShare:              scheduler.DefaultShare,
+	}
+}
+
+func getCPUSchedulerConfig(v *viper.Viper) (scheduler.Config, error) {
+	config := scheduler.Config{
+		MaxProcessing:          int(v.GetUint(ConsensusCPUSchedulerMaxProcessingKey)),
+		PrimaryNetworkMinShare: v.GetFloat64(ConsensusCPUSchedulerPrimaryNetworkMinShareKey),
+		UsageHalflife:          v.GetDuration(ConsensusCPUSchedulerUsageHalflifeKey),
+	}
+	if err := config.Verify(); err != nil {
+		return scheduler.Config{}, fmt.Errorf("invalid CPU scheduler config: %w", err)
 	}
+	return config, nil
 }
 
 func getCPUTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
@@ -1331,8 +1422,17 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("%s must be > 0", ConsensusAppConcurrencyKey)
 	}
 
+	// Cross-chain scheduling
+	nodeConfig.CPUSchedulerConfig, err = getCPUSchedulerConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.UseCurrentHeight = v.GetBool(ProposerVMUseCurrentHeightKey)
 
+	nodeConfig.ValidatorSetCheckpointInterval = v.GetUint64(ValidatorSetCheckpointIntervalKey)
+	nodeConfig.ValidatorSetCheckpointRetention = v.GetUint64(ValidatorSetCheckpointRetentionKey)
+
 	// Logging
 	nodeConfig.LoggingConfig, err = getLoggingConfig(v)
 	if err != nil {
@@ -1385,6 +1485,15 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if healthCheckAveragerHalflife <= 0 {
 		return node.Config{}, fmt.Errorf("%s must be positive", HealthCheckAveragerHalflifeKey)
 	}
+	nodeConfig.UptimeHealthCheckEnabled = v.GetBool(UptimeHealthCheckEnabledKey)
+	nodeConfig.UptimeHealthCheckMargin = v.GetFloat64(UptimeHealthCheckMarginKey)
+	if nodeConfig.UptimeHealthCheckMargin < 0 || nodeConfig.UptimeHealthCheckMargin > 1 {
+		return node.Config{}, errInvalidUptimeHealthCheckMargin
+	}
+	nodeConfig.UptimeHealthCheckAssumedUptime = v.GetFloat64(UptimeHealthCheckAssumedUptimeKey)
+	if nodeConfig.UptimeHealthCheckAssumedUptime < 0 || nodeConfig.UptimeHealthCheckAssumedUptime > 1 {
+		return node.Config{}, errInvalidUptimeHealthCheckAssumedUptime
+	}
 
 	// Router
 	nodeConfig.ConsensusRouter = &router.ChainRouter{}
@@ -1470,6 +1579,10 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, err
 	}
+	nodeConfig.RemoteVMs, err = getRemoteVMs(v)
+	if err != nil {
+		return node.Config{}, err
+	}
 	// Chain aliases
 	nodeConfig.ChainAliases, err = getChainAliases(v)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/spf13/pflag"
@@ -21,6 +22,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -91,6 +94,53 @@ func TestGetChainConfigsFromFiles(t *testing.T) {
 	}
 }
 
+func TestGetChainConfigsSandbox(t *testing.T) {
+	tests := map[string]struct {
+		sandbox     string
+		expected    *subprocess.SandboxConfig
+		expectedErr error
+	}{
+		"valid sandbox": {
+			sandbox: `{"memoryMax": 1073741824, "cgroupParent": "/sys/fs/cgroup/vms", "readOnlyRoot": true}`,
+			expected: &subprocess.SandboxConfig{
+				MemoryMax:    1073741824,
+				CgroupParent: "/sys/fs/cgroup/vms",
+				ReadOnlyRoot: true,
+			},
+			expectedErr: nil,
+		},
+		"malformed sandbox": {
+			sandbox:     `{"memoryMax": "a lot"}`,
+			expected:    nil,
+			expectedErr: errUnmarshalling,
+		},
+	}
+	if runtime.GOOS != "linux" {
+		test := tests["valid sandbox"]
+		test.expected = nil
+		test.expectedErr = subprocess.ErrSandboxUnsupported
+		tests["valid sandbox"] = test
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			root := t.TempDir()
+			configJSON := fmt.Sprintf(`{%q: %q}`, ChainConfigDirKey, root)
+			configFile := setupConfigJSON(t, root, configJSON)
+			setupFile(t, filepath.Join(root, "C"), chainSandboxFileName+".json", test.sandbox)
+
+			v := setupViper(configFile)
+			chainConfigs, err := getChainConfigs(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expected, chainConfigs["C"].Sandbox)
+		})
+	}
+}
+
 func TestGetChainConfigsDirNotExist(t *testing.T) {
 	tests := map[string]struct {
 		structure   string
@@ -322,6 +372,57 @@ func TestGetVMAliasesFromFlag(t *testing.T) {
 	}
 }
 
+func TestGetRemoteVMsFromFlag(t *testing.T) {
+	tests := map[string]struct {
+		givenJSON   string
+		expected    map[ids.ID]remote.VMConfig
+		expectedErr error
+	}{
+		"wrong vm id": {
+			givenJSON:   `{"wrongVmId": {"address": "127.0.0.1:9660"}}`,
+			expected:    nil,
+			expectedErr: errUnmarshalling,
+		},
+		"vm id": {
+			givenJSON: `{"2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i": {
+				"address": "10.0.0.2:9660",
+				"listenHost": "10.0.0.1",
+				"certFile": "node.crt",
+				"keyFile": "node.key",
+				"caFile": "ca.crt"
+			}}`,
+			expected: func() map[ids.ID]remote.VMConfig {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				return map[ids.ID]remote.VMConfig{
+					id: {
+						Address:    "10.0.0.2:9660",
+						ListenHost: "10.0.0.1",
+						CertFile:   "node.crt",
+						KeyFile:    "node.key",
+						CAFile:     "ca.crt",
+					},
+				}
+			}(),
+			expectedErr: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			encodedFileContent := base64.StdEncoding.EncodeToString([]byte(test.givenJSON))
+
+			// build viper config
+			v := setupViperFlags()
+			v.Set(RemoteVMsContentKey, encodedFileContent)
+
+			remoteVMs, err := getRemoteVMs(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, remoteVMs)
+		})
+	}
+}
+
 func TestGetVMAliasesDefaultDir(t *testing.T) {
 	require := require.New(t)
 	root := t.TempDir()
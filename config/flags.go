@@ -47,6 +47,7 @@ var (
 	defaultVMConfigDir          = filepath.Join(defaultConfigDir, "vms")
 	defaultVMAliasFilePath      = filepath.Join(defaultVMConfigDir, "aliases.json")
 	defaultChainAliasFilePath   = filepath.Join(defaultChainConfigDir, "aliases.json")
+	defaultRemoteVMsFilePath    = filepath.Join(defaultVMConfigDir, "remote.json")
 	defaultSubnetConfigDir      = filepath.Join(defaultConfigDir, "subnets")
 	defaultPluginDir            = filepath.Join(defaultUnexpandedDataDir, "plugins")
 	defaultChainDataDir         = filepath.Join(defaultUnexpandedDataDir, "chainData")
@@ -188,6 +189,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(ConsensusAcceptedFrontierGossipFrequencyKey, constants.DefaultAcceptedFrontierGossipFrequency, "Frequency of gossiping accepted frontiers")
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
+	fs.Uint(ConsensusCPUSchedulerMaxProcessingKey, 0, "Maximum number of consensus messages, across all chains, to process concurrently. Chains contending for processing are scheduled by their subnet's cpuShare. If 0, the scheduler is disabled")
+	fs.Float64(ConsensusCPUSchedulerPrimaryNetworkMinShareKey, constants.DefaultConsensusCPUSchedulerPrimaryNetworkMinShare, "Minimum portion of consensus message processing reserved for the primary network's chains. Value should be in range [0, 1]")
+	fs.Duration(ConsensusCPUSchedulerUsageHalflifeKey, constants.DefaultConsensusCPUSchedulerUsageHalflife, "Halflife to use for the per-chain processing usage of the consensus scheduler. Larger halflife --> past usage is taken into account for longer")
 	fs.Uint(ConsensusGossipAcceptedFrontierValidatorSizeKey, constants.DefaultConsensusGossipAcceptedFrontierValidatorSize, "Number of validators to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipAcceptedFrontierNonValidatorSizeKey, constants.DefaultConsensusGossipAcceptedFrontierNonValidatorSize, "Number of non-validators to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipAcceptedFrontierPeerSizeKey, constants.DefaultConsensusGossipAcceptedFrontierPeerSize, "Number of peers to gossip to when gossiping accepted frontier")
@@ -197,6 +201,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(AppGossipValidatorSizeKey, constants.DefaultAppGossipValidatorSize, "Number of validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipNonValidatorSizeKey, constants.DefaultAppGossipNonValidatorSize, "Number of non-validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipPeerSizeKey, constants.DefaultAppGossipPeerSize, "Number of peers (which may be validators or non-validators) to gossip an AppGossip message to")
+	fs.Uint(ConsensusBlockAnnouncementValidatorSizeKey, constants.DefaultConsensusBlockAnnouncementValidatorSize, "Number of validators to announce each newly preferred block to")
+	fs.Uint(ConsensusBlockAnnouncementNonValidatorSizeKey, constants.DefaultConsensusBlockAnnouncementNonValidatorSize, "Number of non-validators to announce each newly preferred block to")
+	fs.Uint(ConsensusBlockAnnouncementPeerSizeKey, constants.DefaultConsensusBlockAnnouncementPeerSize, "Number of peers (which may be validators or non-validators) to announce each newly preferred block to")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -241,6 +248,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(KeystoreAPIEnabledKey, false, "If true, this node exposes the Keystore API")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
+	fs.Bool(EventsAPIEnabledKey, false, "If true, this node exposes consensus events over WebSocket")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
 
 	// Health Checks
@@ -256,6 +264,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Float64(RouterHealthMaxDropRateKey, 1, "Node reports unhealthy if the router drops more than this portion of messages")
 	fs.Uint(RouterHealthMaxOutstandingRequestsKey, 1024, "Node reports unhealthy if there are more than this many outstanding consensus requests (Get, PullQuery, etc.) over all chains")
 	fs.Duration(NetworkHealthMaxOutstandingDurationKey, 5*time.Minute, "Node reports unhealthy if there has been a request outstanding for this duration")
+	// Uptime Health
+	fs.Bool(UptimeHealthCheckEnabledKey, false, "If true, the P-chain reports unhealthy if the projected uptime of this node at the end of one of its staking periods is below the uptime requirement plus the margin")
+	fs.Float64(UptimeHealthCheckMarginKey, 0.05, "Fraction of the staking period added to the uptime requirement before the projected uptime of this node is reported as unhealthy")
+	fs.Float64(UptimeHealthCheckAssumedUptimeKey, 0, "Uptime (in [0, 1]) this node is assumed to have for the rest of its staking periods when projecting its uptime. If 0, the uptime observed so far is assumed")
 
 	// Staking
 	fs.Uint(StakingPortKey, DefaultStakingPort, "Port of the consensus server")
@@ -324,6 +336,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// ProposerVM
 	fs.Bool(ProposerVMUseCurrentHeightKey, false, "Have the ProposerVM always report the last accepted P-chain block height")
 
+	// Validator set checkpoints
+	fs.Uint64(ValidatorSetCheckpointIntervalKey, 16384, "Number of P-chain blocks between persisted validator set snapshots. If 0, no snapshots are written")
+	fs.Uint64(ValidatorSetCheckpointRetentionKey, 64, "Number of validator set snapshots to keep per subnet. If 0, all snapshots are kept")
+
 	// Metrics
 	fs.Bool(MeterVMsEnabledKey, true, "Enable Meter VMs to track VM performance with more granularity")
 	fs.Duration(UptimeMetricFreqKey, 30*time.Second, "Frequency of renewing this node's average uptime metric")
@@ -357,6 +373,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(ChainAliasesFileKey, defaultChainAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps blockchainIDs with custom aliases. Ignored if %s is specified", ChainConfigContentKey))
 	fs.String(ChainAliasesContentKey, "", "Specifies base64 encoded map from blockchainID to custom aliases")
 
+	// Remote VMs
+	fs.String(RemoteVMsFileKey, defaultRemoteVMsFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs to VM servers managed outside of the node. Ignored if %s is specified", RemoteVMsContentKey))
+	fs.String(RemoteVMsContentKey, "", "Specifies base64 encoded map from vmID to a VM server managed outside of the node")
+
 	// Delays
 	fs.Duration(NetworkInitialReconnectDelayKey, constants.DefaultNetworkInitialReconnectDelay, "Initial delay duration must be waited before attempting to reconnect a peer")
 	fs.Duration(NetworkMaxReconnectDelayKey, constants.DefaultNetworkMaxReconnectDelay, "Maximum delay duration must be waited before attempting to reconnect a peer")
@@ -143,6 +143,7 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	EventsAPIEnabledKey                                = "api-events-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
@@ -159,8 +160,16 @@ const (
 	AppGossipValidatorSizeKey                          = "consensus-app-gossip-validator-size"
 	AppGossipNonValidatorSizeKey                       = "consensus-app-gossip-non-validator-size"
 	AppGossipPeerSizeKey                               = "consensus-app-gossip-peer-size"
+	ConsensusBlockAnnouncementValidatorSizeKey         = "consensus-block-announcement-validator-size"
+	ConsensusBlockAnnouncementNonValidatorSizeKey      = "consensus-block-announcement-non-validator-size"
+	ConsensusBlockAnnouncementPeerSizeKey              = "consensus-block-announcement-peer-size"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
+	ConsensusCPUSchedulerMaxProcessingKey              = "consensus-cpu-scheduler-max-processing"
+	ConsensusCPUSchedulerPrimaryNetworkMinShareKey     = "consensus-cpu-scheduler-primary-network-min-share"
+	ConsensusCPUSchedulerUsageHalflifeKey              = "consensus-cpu-scheduler-usage-halflife"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
+	ValidatorSetCheckpointIntervalKey                  = "validator-set-checkpoint-interval"
+	ValidatorSetCheckpointRetentionKey                 = "validator-set-checkpoint-retention"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
@@ -168,6 +177,9 @@ const (
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
+	UptimeHealthCheckEnabledKey                        = "uptime-health-check-enabled"
+	UptimeHealthCheckMarginKey                         = "uptime-health-check-margin"
+	UptimeHealthCheckAssumedUptimeKey                  = "uptime-health-check-assumed-uptime"
 	RetryBootstrapKey                                  = "bootstrap-retry-enabled"
 	RetryBootstrapWarnFrequencyKey                     = "bootstrap-retry-warn-frequency"
 	PluginDirKey                                       = "plugin-dir"
@@ -210,6 +222,8 @@ const (
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
+	RemoteVMsFileKey                                   = "remote-vms-file"
+	RemoteVMsContentKey                                = "remote-vms-file-content"
 	ChainAliasesFileKey                                = "chain-aliases-file"
 	ChainAliasesContentKey                             = "chain-aliases-file-content"
 	TracingEnabledKey                                  = "tracing-enabled"
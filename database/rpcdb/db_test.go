@@ -24,23 +24,27 @@ type testDatabase struct {
 }
 
 func setupDB(t testing.TB) *testDatabase {
+	return setupDBWithTransport(t, nil)
+}
+
+func setupDBWithTransport(t testing.TB, transport *grpcutils.Transport) *testDatabase {
 	db := &testDatabase{
 		server: memdb.New(),
 	}
 
-	listener, err := grpcutils.NewListener()
+	listener, err := transport.NewListener()
 	if err != nil {
 		t.Fatalf("Failed to create listener: %s", err)
 	}
 	serverCloser := grpcutils.ServerCloser{}
 
-	server := grpcutils.NewServer()
+	server := transport.NewServer()
 	rpcdbpb.RegisterDatabaseServer(server, NewServer(db.server))
 	serverCloser.Add(server)
 
 	go grpcutils.Serve(listener, server)
 
-	conn, err := grpcutils.Dial(listener.Addr().String())
+	conn, err := transport.Dial(listener.Addr().String())
 	if err != nil {
 		t.Fatalf("Failed to dial: %s", err)
 	}
@@ -83,6 +87,20 @@ func BenchmarkInterface(b *testing.B) {
 	}
 }
 
+func BenchmarkInterfaceUnixSocket(b *testing.B) {
+	transport := &grpcutils.Transport{
+		UnixSocketDir: b.TempDir(),
+	}
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			db := setupDBWithTransport(b, transport)
+			bench(b, db.client, "rpcdb_unix", keys, values)
+			db.closeFn()
+		}
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	require := require.New(t)
 
@@ -314,6 +314,25 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		}
 		i.txIndices[chainID] = txIndex
 	case block.ChainVM:
+		if !ctx.TxsStreamed {
+			return
+		}
+
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup)
+		if err != nil {
+			i.log.Fatal("couldn't create index",
+				zap.String("chainName", chainName),
+				zap.String("endpoint", "tx"),
+				zap.Error(err),
+			)
+			if err := i.close(); err != nil {
+				i.log.Error("failed to close indexer",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		i.txIndices[chainID] = txIndex
 	default:
 		vmType := fmt.Sprintf("%T", vm)
 		i.log.Error("got unexpected vm type",
@@ -525,3 +525,47 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
 	require.Empty(idxr.blockIndices)
 }
+
+func TestIndexStreamedTxs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := &apiServerMock{}
+	config := Config{
+		IndexingEnabled:      true,
+		AllowIncompleteIndex: false,
+		Log:                  logging.NoLog{},
+		DB:                   versiondb.New(memdb.New()),
+		BlockAcceptorGroup:   snow.NewAcceptorGroup(logging.NoLog{}),
+		TxAcceptorGroup:      snow.NewAcceptorGroup(logging.NoLog{}),
+		VertexAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:            server,
+		ShutdownF:            func() {},
+	}
+
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	require.IsType(&indexer{}, idxrIntf)
+	idxr := idxrIntf.(*indexer)
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.ChainID = ids.GenerateTestID()
+	chainCtx.TxsStreamed = true
+
+	chainVM := mocks.NewMockChainVM(ctrl)
+	idxr.RegisterChain("chain", chainCtx, chainVM)
+	require.Equal([]string{"/block", "/tx"}, server.endpoints)
+	require.Len(idxr.blockIndices, 1)
+	require.Len(idxr.txIndices, 1)
+	require.Empty(idxr.vtxIndices)
+
+	txID, txBytes := ids.GenerateTestID(), utils.RandomBytes(32)
+	require.NoError(config.TxAcceptorGroup.Accept(chainCtx, txID, txBytes))
+
+	container, err := idxr.txIndices[chainCtx.ChainID].GetLastAccepted()
+	require.NoError(err)
+	require.Equal(txID, container.ID)
+	require.Equal(txBytes, container.Bytes)
+	require.NoError(idxr.Close())
+}
@@ -15,9 +15,15 @@ import (
 	"github.com/ava-labs/avalanchego/app"
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
 func main() {
+	// Sandboxed VM processes are started by re-executing the node.
+	if subprocess.IsSandboxInit() {
+		subprocess.RunSandboxInit()
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
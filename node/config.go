@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
@@ -25,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
 )
 
 type IPCConfig struct {
@@ -71,6 +73,7 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	EventsAPIEnabled   bool `json:"eventsAPIEnabled"`
 }
 
 type IPConfig struct {
@@ -182,6 +185,9 @@ type Config struct {
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
 	// handle App messages per chain.
 	ConsensusAppConcurrency int `json:"consensusAppConcurrency"`
+	// CPUSchedulerConfig configures how message processing is divided
+	// between the chains running on this node.
+	CPUSchedulerConfig scheduler.Config `json:"cpuSchedulerConfig"`
 
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 
@@ -192,6 +198,10 @@ type Config struct {
 
 	VMAliaser ids.Aliaser `json:"-"`
 
+	// RemoteVMs are VMs served by processes that are managed outside of the
+	// node.
+	RemoteVMs map[ids.ID]remote.VMConfig `json:"remoteVMs"`
+
 	// Halflife to use for the processing requests tracker.
 	// Larger halflife --> usage metrics change more slowly.
 	SystemTrackerProcessingHalflife time.Duration `json:"systemTrackerProcessingHalflife"`
@@ -221,6 +231,21 @@ type Config struct {
 	// See comment on [UseCurrentHeight] in platformvm.Config
 	UseCurrentHeight bool `json:"useCurrentHeight"`
 
+	// See comment on [ValidatorSetCheckpointInterval] in platformvm.Config
+	ValidatorSetCheckpointInterval uint64 `json:"validatorSetCheckpointInterval"`
+
+	// See comment on [ValidatorSetCheckpointRetention] in platformvm.Config
+	ValidatorSetCheckpointRetention uint64 `json:"validatorSetCheckpointRetention"`
+
+	// See comment on [UptimeHealthCheckEnabled] in platformvm.Config
+	UptimeHealthCheckEnabled bool `json:"uptimeHealthCheckEnabled"`
+
+	// See comment on [UptimeHealthCheckMargin] in platformvm.Config
+	UptimeHealthCheckMargin float64 `json:"uptimeHealthCheckMargin"`
+
+	// See comment on [UptimeHealthCheckAssumedUptime] in platformvm.Config
+	UptimeHealthCheckAssumedUptime float64 `json:"uptimeHealthCheckAssumedUptime"`
+
 	// ProvidedFlags contains all the flags set by the user
 	ProvidedFlags map[string]interface{} `json:"-"`
 
@@ -48,8 +48,10 @@ import (
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -72,13 +74,17 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/registry"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
 	avmconfig "github.com/ava-labs/avalanchego/vms/avm/config"
@@ -138,6 +144,9 @@ type Node struct {
 	TxAcceptorGroup     snow.AcceptorGroup
 	VertexAcceptorGroup snow.AcceptorGroup
 
+	// EventBus notifies subscribers as containers progress through consensus
+	EventBus eventbus.Bus
+
 	IPCs *ipcs.ChainIPCs
 
 	// Net runs the networking stack
@@ -193,6 +202,9 @@ type Node struct {
 	// messages of each peer.
 	resourceTracker tracker.ResourceTracker
 
+	// Divides the processing of consensus messages between chains.
+	cpuScheduler scheduler.Scheduler
+
 	// Specifies how much CPU usage each peer can cause before
 	// we rate-limit them.
 	cpuTargeter tracker.Targeter
@@ -496,10 +508,14 @@ func (n *Node) initBootstrappers() error {
 
 // Create the EventDispatcher used for hooking events
 // into the general process flow.
-func (n *Node) initEventDispatchers() {
+func (n *Node) initEventDispatchers() error {
 	n.BlockAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 	n.TxAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 	n.VertexAcceptorGroup = snow.NewAcceptorGroup(n.Log)
+
+	var err error
+	n.EventBus, err = eventbus.New("events", n.MetricsRegisterer)
+	return err
 }
 
 func (n *Node) initIPCs() error {
@@ -718,6 +734,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		BlockAcceptorGroup:                      n.BlockAcceptorGroup,
 		TxAcceptorGroup:                         n.TxAcceptorGroup,
 		VertexAcceptorGroup:                     n.VertexAcceptorGroup,
+		EventPublisher:                          n.EventBus,
 		DBManager:                               n.DBManager,
 		MsgCreator:                              n.msgCreator,
 		Router:                                  n.Config.ConsensusRouter,
@@ -749,6 +766,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		CPUScheduler:                            n.cpuScheduler,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
@@ -787,33 +805,41 @@ func (n *Node) initVMs() error {
 	errs.Add(
 		vmRegisterer.Register(context.TODO(), constants.PlatformVMID, &platformvm.Factory{
 			Config: platformconfig.Config{
-				Chains:                        n.chainManager,
-				Validators:                    vdrs,
-				UptimeLockedCalculator:        n.uptimeCalculator,
-				SybilProtectionEnabled:        n.Config.SybilProtectionEnabled,
-				TrackedSubnets:                n.Config.TrackedSubnets,
-				TxFee:                         n.Config.TxFee,
-				CreateAssetTxFee:              n.Config.CreateAssetTxFee,
-				CreateSubnetTxFee:             n.Config.CreateSubnetTxFee,
-				TransformSubnetTxFee:          n.Config.TransformSubnetTxFee,
-				CreateBlockchainTxFee:         n.Config.CreateBlockchainTxFee,
-				AddPrimaryNetworkValidatorFee: n.Config.AddPrimaryNetworkValidatorFee,
-				AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
-				AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
-				AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
-				UptimePercentage:              n.Config.UptimeRequirement,
-				MinValidatorStake:             n.Config.MinValidatorStake,
-				MaxValidatorStake:             n.Config.MaxValidatorStake,
-				MinDelegatorStake:             n.Config.MinDelegatorStake,
-				MinDelegationFee:              n.Config.MinDelegationFee,
-				MinStakeDuration:              n.Config.MinStakeDuration,
-				MaxStakeDuration:              n.Config.MaxStakeDuration,
-				RewardConfig:                  n.Config.RewardConfig,
-				ApricotPhase3Time:             version.GetApricotPhase3Time(n.Config.NetworkID),
-				ApricotPhase5Time:             version.GetApricotPhase5Time(n.Config.NetworkID),
-				BanffTime:                     version.GetBanffTime(n.Config.NetworkID),
-				CortinaTime:                   version.GetCortinaTime(n.Config.NetworkID),
-				UseCurrentHeight:              n.Config.UseCurrentHeight,
+				Chains:                          n.chainManager,
+				Validators:                      vdrs,
+				UptimeLockedCalculator:          n.uptimeCalculator,
+				ObservedUptimeCalculator:        &observedUptimeCalculator{net: n.Net},
+				SybilProtectionEnabled:          n.Config.SybilProtectionEnabled,
+				TrackedSubnets:                  n.Config.TrackedSubnets,
+				TxFee:                           n.Config.TxFee,
+				CreateAssetTxFee:                n.Config.CreateAssetTxFee,
+				CreateSubnetTxFee:               n.Config.CreateSubnetTxFee,
+				TransformSubnetTxFee:            n.Config.TransformSubnetTxFee,
+				CreateBlockchainTxFee:           n.Config.CreateBlockchainTxFee,
+				AddPrimaryNetworkValidatorFee:   n.Config.AddPrimaryNetworkValidatorFee,
+				AddPrimaryNetworkDelegatorFee:   n.Config.AddPrimaryNetworkDelegatorFee,
+				AddSubnetValidatorFee:           n.Config.AddSubnetValidatorFee,
+				AddSubnetDelegatorFee:           n.Config.AddSubnetDelegatorFee,
+				UptimePercentage:                n.Config.UptimeRequirement,
+				MinValidatorStake:               n.Config.MinValidatorStake,
+				MaxValidatorStake:               n.Config.MaxValidatorStake,
+				MinDelegatorStake:               n.Config.MinDelegatorStake,
+				MinDelegationFee:                n.Config.MinDelegationFee,
+				MinStakeDuration:                n.Config.MinStakeDuration,
+				MaxStakeDuration:                n.Config.MaxStakeDuration,
+				RewardConfig:                    n.Config.RewardConfig,
+				ApricotPhase3Time:               version.GetApricotPhase3Time(n.Config.NetworkID),
+				ApricotPhase5Time:               version.GetApricotPhase5Time(n.Config.NetworkID),
+				BanffTime:                       version.GetBanffTime(n.Config.NetworkID),
+				CortinaTime:                     version.GetCortinaTime(n.Config.NetworkID),
+				DurangoTime:                     version.GetDurangoTime(n.Config.NetworkID),
+				UseCurrentHeight:                n.Config.UseCurrentHeight,
+				ValidatorSetCheckpointInterval:  n.Config.ValidatorSetCheckpointInterval,
+				ValidatorSetCheckpointRetention: n.Config.ValidatorSetCheckpointRetention,
+				AdminAPIEnabled:                 n.Config.AdminAPIEnabled,
+				UptimeHealthCheckEnabled:        n.Config.UptimeHealthCheckEnabled,
+				UptimeHealthCheckMargin:         n.Config.UptimeHealthCheckMargin,
+				UptimeHealthCheckAssumedUptime:  n.Config.UptimeHealthCheckAssumedUptime,
 			},
 		}),
 		vmRegisterer.Register(context.TODO(), constants.AVMID, &avm.Factory{
@@ -826,11 +852,34 @@ func (n *Node) initVMs() error {
 		n.VMManager.RegisterFactory(context.TODO(), secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.VMManager.RegisterFactory(context.TODO(), nftfx.ID, &nftfx.Factory{}),
 		n.VMManager.RegisterFactory(context.TODO(), propertyfx.ID, &propertyfx.Factory{}),
+		n.VMManager.RegisterFactory(context.TODO(), secp256r1fx.ID, &secp256r1fx.Factory{
+			ActivationTime: version.GetDurangoTime(n.Config.NetworkID),
+		}),
+		n.VMManager.RegisterFactory(context.TODO(), htlcfx.ID, &htlcfx.Factory{
+			ActivationTime: version.GetDurangoTime(n.Config.NetworkID),
+		}),
 	)
 	if errs.Errored() {
 		return errs.Err
 	}
 
+	// Register the VMs that are served by processes managed outside of the
+	// node
+	for vmID, vmConfig := range n.Config.RemoteVMs {
+		transport, err := vmConfig.Transport()
+		if err != nil {
+			return fmt.Errorf("invalid config for remote vm %s: %w", vmID, err)
+		}
+		factory := rpcchainvm.NewRemoteFactory(remote.Config{
+			Addr:             vmConfig.Address,
+			Transport:        transport,
+			HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+		})
+		if err := vmRegisterer.Register(context.TODO(), vmID, factory); err != nil {
+			return err
+		}
+	}
+
 	// initialize vm runtime manager
 	n.runtimeManager = runtime.NewManager()
 
@@ -952,6 +1001,25 @@ func (n *Node) initAdminAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "")
 }
 
+// initEventsAPI exposes the consensus event bus over WebSocket
+// Assumes n.APIServer and n.EventBus are already initialized
+func (n *Node) initEventsAPI() error {
+	if !n.Config.EventsAPIEnabled {
+		n.Log.Info("skipping events API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing events API")
+	return n.APIServer.AddRoute(
+		&common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler:     eventbus.NewServer(n.Log, n.EventBus),
+		},
+		&sync.RWMutex{},
+		"events",
+		"",
+	)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -1212,6 +1280,11 @@ func (n *Node) initResourceManager(reg prometheus.Registerer) error {
 	n.resourceManager.TrackProcess(os.Getpid())
 
 	n.resourceTracker, err = tracker.NewResourceTracker(reg, n.resourceManager, &meter.ContinuousFactory{}, n.Config.SystemTrackerProcessingHalflife)
+	if err != nil {
+		return err
+	}
+
+	n.cpuScheduler, err = scheduler.New(n.Config.CPUSchedulerConfig, &meter.ContinuousFactory{}, "cpu_scheduler", reg)
 	return err
 }
 
@@ -1330,7 +1403,9 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing networking: %w", err)
 	}
 
-	n.initEventDispatchers()
+	if err := n.initEventDispatchers(); err != nil {
+		return fmt.Errorf("couldn't initialize event dispatchers: %w", err)
+	}
 
 	// Start the Health API
 	// Has to be initialized before chain manager
@@ -1359,6 +1434,9 @@ func (n *Node) Initialize(
 	if err := n.initIPCAPI(); err != nil { // Start the IPC API
 		return fmt.Errorf("couldn't initialize the IPC API: %w", err)
 	}
+	if err := n.initEventsAPI(); err != nil { // Start the Events API
+		return fmt.Errorf("couldn't initialize the events API: %w", err)
+	}
 	if err := n.initChainAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize chain aliases: %w", err)
 	}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+)
+
+var _ uptime.ObservedCalculator = (*observedUptimeCalculator)(nil)
+
+// observedUptimeCalculator reports the uptime of this node using the uptimes
+// that its peers report to the network layer.
+type observedUptimeCalculator struct {
+	net network.Network
+}
+
+func (c *observedUptimeCalculator) ObservedUptimePercent(subnetID ids.ID) (float64, error) {
+	result, err := c.net.NodeUptime(subnetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.WeightedAveragePercentage / 100, nil
+}
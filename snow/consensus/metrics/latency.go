@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
@@ -25,6 +26,9 @@ type Latency interface {
 	// Issued marks the item as having been issued.
 	Issued(id ids.ID, pollNumber uint64)
 
+	// Polled marks that a poll returned votes for the item.
+	Polled(id ids.ID, pollNumber uint64)
+
 	// Accepted marks the item as having been accepted.
 	// Pass the container size in bytes for metrics tracking.
 	Accepted(id ids.ID, pollNumber uint64, containerSize int)
@@ -44,6 +48,10 @@ type Latency interface {
 type opStart struct {
 	time       time.Time
 	pollNumber uint64
+
+	// firstPoll is the time of the first poll that returned votes for the
+	// item. It is zero if no such poll has happened.
+	firstPoll time.Time
 }
 
 // Latency reports commonly used consensus latency metrics.
@@ -51,11 +59,16 @@ type latency struct {
 	// ProcessingEntries keeps track of the [opStart] that each item was issued
 	// into the consensus instance. This is used to calculate the amount of time
 	// to accept or reject the item.
-	processingEntries linkedhashmap.LinkedHashmap[ids.ID, opStart]
+	processingEntries linkedhashmap.LinkedHashmap[ids.ID, *opStart]
 
 	// log reports anomalous events.
 	log logging.Logger
 
+	// chainID and publisher are used to report the progress of items to the
+	// event bus.
+	chainID   ids.ID
+	publisher eventbus.Publisher
+
 	// numProcessing keeps track of the number of items processing
 	numProcessing prometheus.Gauge
 
@@ -79,11 +92,21 @@ type latency struct {
 }
 
 // Initialize the metrics with the provided names.
-func NewLatency(metricName, descriptionName string, log logging.Logger, namespace string, reg prometheus.Registerer) (Latency, error) {
+func NewLatency(
+	metricName,
+	descriptionName string,
+	log logging.Logger,
+	chainID ids.ID,
+	publisher eventbus.Publisher,
+	namespace string,
+	reg prometheus.Registerer,
+) (Latency, error) {
 	errs := wrappers.Errs{}
 	l := &latency{
-		processingEntries: linkedhashmap.New[ids.ID, opStart](),
+		processingEntries: linkedhashmap.New[ids.ID, *opStart](),
 		log:               log,
+		chainID:           chainID,
+		publisher:         publisher,
 
 		// e.g.,
 		// "avalanche_7y7zwo7XatqnX4dtTakLo32o7jkMX4XuDa26WaxbCXoCT1qKK_blks_processing" to count how blocks are currently processing
@@ -155,13 +178,28 @@ func NewLatency(metricName, descriptionName string, log logging.Logger, namespac
 }
 
 func (l *latency) Issued(id ids.ID, pollNumber uint64) {
-	l.processingEntries.Put(id, opStart{
+	l.processingEntries.Put(id, &opStart{
 		time:       time.Now(),
 		pollNumber: pollNumber,
 	})
 	l.numProcessing.Inc()
 }
 
+func (l *latency) Polled(id ids.ID, _ uint64) {
+	start, ok := l.processingEntries.Get(id)
+	if !ok || !start.firstPoll.IsZero() {
+		return
+	}
+
+	start.firstPoll = time.Now()
+	l.publisher.Publish(eventbus.Event{
+		Type:        eventbus.Polled,
+		ChainID:     l.chainID,
+		ContainerID: id,
+		Time:        start.firstPoll,
+	})
+}
+
 func (l *latency) Accepted(id ids.ID, pollNumber uint64, containerSize int) {
 	start, ok := l.processingEntries.Get(id)
 	if !ok {
@@ -173,13 +211,17 @@ func (l *latency) Accepted(id ids.ID, pollNumber uint64, containerSize int) {
 	}
 	l.processingEntries.Delete(id)
 
-	l.pollsAccepted.Observe(float64(pollNumber - start.pollNumber))
+	polls := pollNumber - start.pollNumber
+	l.pollsAccepted.Observe(float64(polls))
 
-	duration := time.Since(start.time)
+	now := time.Now()
+	duration := now.Sub(start.time)
 	l.latAccepted.Observe(float64(duration))
 	l.numProcessing.Dec()
 
 	l.containerSizeAcceptedSum.Add(float64(containerSize))
+
+	l.publishDecision(eventbus.Accepted, id, start, now, polls)
 }
 
 func (l *latency) Rejected(id ids.ID, pollNumber uint64, containerSize int) {
@@ -193,13 +235,37 @@ func (l *latency) Rejected(id ids.ID, pollNumber uint64, containerSize int) {
 	}
 	l.processingEntries.Delete(id)
 
-	l.pollsRejected.Observe(float64(pollNumber - start.pollNumber))
+	polls := pollNumber - start.pollNumber
+	l.pollsRejected.Observe(float64(polls))
 
-	duration := time.Since(start.time)
+	now := time.Now()
+	duration := now.Sub(start.time)
 	l.latRejected.Observe(float64(duration))
 	l.numProcessing.Dec()
 
 	l.containerSizeRejectedSum.Add(float64(containerSize))
+
+	l.publishDecision(eventbus.Rejected, id, start, now, polls)
+}
+
+// publishDecision reports the decision of [id] along with the time spent in
+// each phase of consensus.
+func (l *latency) publishDecision(typ eventbus.Type, id ids.ID, start *opStart, now time.Time, polls uint64) {
+	breakdown := &eventbus.Latency{
+		Total: now.Sub(start.time),
+		Polls: polls,
+	}
+	if !start.firstPoll.IsZero() {
+		breakdown.FirstPoll = start.firstPoll.Sub(start.time)
+		breakdown.Polling = now.Sub(start.firstPoll)
+	}
+	l.publisher.Publish(eventbus.Event{
+		Type:        typ,
+		ChainID:     l.chainID,
+		ContainerID: id,
+		Time:        now,
+		Latency:     breakdown,
+	})
 }
 
 func (l *latency) MeasureAndGetOldestDuration() time.Duration {
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoValidators             = errors.New("no validators")
+	errZeroWeight               = errors.New("validator has zero weight")
+	errInsufficientWeight       = errors.New("total weight is less than k")
+	errInvalidByzantineFraction = errors.New("byzantine fraction must be in [0, 1)")
+	errInvalidDropRate          = errors.New("drop rate must be in [0, 1)")
+	errInvalidNumRuns           = errors.New("number of runs must be positive")
+	errInvalidMaxRounds         = errors.New("max rounds must be positive")
+)
+
+// Config describes a set of Monte-Carlo simulations of snowball consensus.
+type Config struct {
+	// Parameters are the consensus parameters being evaluated.
+	Parameters snowball.Parameters `json:"parameters"`
+
+	// Weights is the stake weight of each validator in the network. Queries
+	// are sampled proportionally to these weights.
+	Weights []uint64 `json:"weights"`
+
+	// ByzantineFraction is the maximum fraction of the total stake that is
+	// controlled by Byzantine validators. Byzantine validators always respond
+	// with the value that the fewest correct validators currently prefer.
+	ByzantineFraction float64 `json:"byzantineFraction"`
+
+	// DropRate is the probability that a query or its response is lost.
+	DropRate float64 `json:"dropRate"`
+
+	// NumRuns is the number of independent simulations to perform.
+	NumRuns int `json:"numRuns"`
+
+	// MaxRounds is the number of rounds after which a simulation is considered
+	// to have failed to finalize.
+	MaxRounds int `json:"maxRounds"`
+
+	// Seed is used to make the simulations reproducible.
+	Seed int64 `json:"seed"`
+}
+
+// Verify returns nil if the config describes a valid set of simulations.
+func (c *Config) Verify() error {
+	if err := c.Parameters.Verify(); err != nil {
+		return err
+	}
+	if len(c.Weights) == 0 {
+		return errNoValidators
+	}
+	totalWeight := uint64(0)
+	for i, weight := range c.Weights {
+		if weight == 0 {
+			return fmt.Errorf("%w: validator %d", errZeroWeight, i)
+		}
+		var err error
+		totalWeight, err = math.Add64(totalWeight, weight)
+		if err != nil {
+			return err
+		}
+	}
+	if totalWeight < uint64(c.Parameters.K) {
+		return fmt.Errorf("%w: %d < %d", errInsufficientWeight, totalWeight, c.Parameters.K)
+	}
+	switch {
+	case c.ByzantineFraction < 0 || c.ByzantineFraction >= 1:
+		return fmt.Errorf("%w: %f", errInvalidByzantineFraction, c.ByzantineFraction)
+	case c.DropRate < 0 || c.DropRate >= 1:
+		return fmt.Errorf("%w: %f", errInvalidDropRate, c.DropRate)
+	case c.NumRuns <= 0:
+		return fmt.Errorf("%w: %d", errInvalidNumRuns, c.NumRuns)
+	case c.MaxRounds <= 0:
+		return fmt.Errorf("%w: %d", errInvalidMaxRounds, c.MaxRounds)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball/simulator"
+)
+
+const (
+	kKey                 = "k"
+	alphaKey             = "alpha"
+	betaVirtuousKey      = "beta-virtuous"
+	betaRogueKey         = "beta-rogue"
+	weightsKey           = "weights"
+	numValidatorsKey     = "num-validators"
+	byzantineFractionKey = "byzantine-fraction"
+	dropRateKey          = "drop-rate"
+	numRunsKey           = "num-runs"
+	maxRoundsKey         = "max-rounds"
+	seedKey              = "seed"
+	jsonKey              = "json"
+)
+
+var errNoWeights = errors.New("either --weights or --num-validators must be provided")
+
+func main() {
+	fs := pflag.NewFlagSet("snowball-simulator", pflag.ContinueOnError)
+	fs.Int(kKey, snowball.DefaultParameters.K, "Number of validators sampled in each poll")
+	fs.Int(alphaKey, snowball.DefaultParameters.Alpha, "Number of votes required for a successful poll")
+	fs.Int(betaVirtuousKey, snowball.DefaultParameters.BetaVirtuous, "Number of consecutive successful polls required to finalize a virtuous value")
+	fs.Int(betaRogueKey, snowball.DefaultParameters.BetaRogue, "Number of consecutive successful polls required to finalize a rogue value")
+	fs.UintSlice(weightsKey, nil, "Comma separated stake weights of the validators")
+	fs.Int(numValidatorsKey, 0, "Number of equally weighted validators. Ignored if --weights is provided")
+	fs.Float64(byzantineFractionKey, 0, "Maximum fraction of stake controlled by Byzantine validators")
+	fs.Float64(dropRateKey, 0, "Probability that a query or its response is dropped")
+	fs.Int(numRunsKey, 1000, "Number of simulations to perform")
+	fs.Int(maxRoundsKey, 1000, "Number of rounds after which a simulation is considered to have failed to finalize")
+	fs.Int64(seedKey, 0, "Seed used to make the simulations reproducible")
+	fs.Bool(jsonKey, false, "If true, the result is printed as JSON")
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+
+	config, err := getConfig(fs)
+	if err != nil {
+		fmt.Printf("couldn't load config: %s\n", err)
+		os.Exit(1)
+	}
+
+	result, err := simulator.Run(config)
+	if err != nil {
+		fmt.Printf("couldn't run simulations: %s\n", err)
+		os.Exit(1)
+	}
+
+	printJSON, _ := fs.GetBool(jsonKey)
+	if !printJSON {
+		fmt.Println(result)
+		return
+	}
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Printf("couldn't marshal result: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(resultJSON))
+}
+
+func getConfig(fs *pflag.FlagSet) (simulator.Config, error) {
+	params := snowball.DefaultParameters
+	params.K, _ = fs.GetInt(kKey)
+	params.Alpha, _ = fs.GetInt(alphaKey)
+	params.BetaVirtuous, _ = fs.GetInt(betaVirtuousKey)
+	params.BetaRogue, _ = fs.GetInt(betaRogueKey)
+
+	var weights []uint64
+	if rawWeights, _ := fs.GetUintSlice(weightsKey); len(rawWeights) != 0 {
+		weights = make([]uint64, len(rawWeights))
+		for i, weight := range rawWeights {
+			weights[i] = uint64(weight)
+		}
+	} else {
+		numValidators, _ := fs.GetInt(numValidatorsKey)
+		if numValidators <= 0 {
+			return simulator.Config{}, errNoWeights
+		}
+		weights = make([]uint64, numValidators)
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	config := simulator.Config{
+		Parameters: params,
+		Weights:    weights,
+	}
+	config.ByzantineFraction, _ = fs.GetFloat64(byzantineFractionKey)
+	config.DropRate, _ = fs.GetFloat64(dropRateKey)
+	config.NumRuns, _ = fs.GetInt(numRunsKey)
+	config.MaxRounds, _ = fs.GetInt(maxRoundsKey)
+	config.Seed, _ = fs.GetInt64(seedKey)
+	return config, nil
+}
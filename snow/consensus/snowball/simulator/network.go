@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"math/rand"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+var colors = []ids.ID{
+	ids.Empty.Prefix(0),
+	ids.Empty.Prefix(1),
+}
+
+// outcome is the result of a single simulation.
+type outcome struct {
+	rounds    int
+	finalized bool
+	safe      bool
+	messages  uint64
+}
+
+// network simulates a set of validators deciding between two conflicting
+// values. Every round, each correct validator that hasn't finalized performs a
+// single weighted poll of the network.
+type network struct {
+	params    snowball.Parameters
+	dropRate  float64
+	maxRounds int
+
+	rng     *rand.Rand
+	sampler sampler.WeightedWithoutReplacement
+
+	// byzantine[i] is true iff validator i is Byzantine.
+	byzantine []bool
+	// nodes[i] is the consensus instance of validator i. It is nil if the
+	// validator is Byzantine.
+	nodes []snowball.Consensus
+	// running contains the indices of the correct validators that haven't
+	// finalized yet.
+	running []int
+
+	messages uint64
+}
+
+func newNetwork(config *Config, seed int64) (*network, error) {
+	n := &network{
+		params:    config.Parameters,
+		dropRate:  config.DropRate,
+		maxRounds: config.MaxRounds,
+		rng:       rand.New(rand.NewSource(seed)), // #nosec G404
+		sampler:   sampler.NewWeightedWithoutReplacement(),
+		byzantine: make([]bool, len(config.Weights)),
+		nodes:     make([]snowball.Consensus, len(config.Weights)),
+	}
+	if err := n.sampler.Initialize(config.Weights); err != nil {
+		return nil, err
+	}
+	n.sampler.Seed(n.rng.Int63())
+
+	// Assign validators to the adversary, in a random order, as long as the
+	// adversary's stake remains within the configured bound.
+	totalWeight := uint64(0)
+	for _, weight := range config.Weights {
+		totalWeight += weight
+	}
+	maxByzantineWeight := config.ByzantineFraction * float64(totalWeight)
+	byzantineWeight := uint64(0)
+	for _, i := range n.rng.Perm(len(config.Weights)) {
+		newByzantineWeight := byzantineWeight + config.Weights[i]
+		if float64(newByzantineWeight) > maxByzantineWeight {
+			continue
+		}
+		byzantineWeight = newByzantineWeight
+		n.byzantine[i] = true
+	}
+
+	for i, byzantine := range n.byzantine {
+		if byzantine {
+			continue
+		}
+
+		preference := n.rng.Intn(len(colors))
+		sb := &snowball.Tree{}
+		sb.Initialize(n.params, colors[preference])
+		sb.Add(colors[1-preference])
+
+		n.nodes[i] = sb
+		n.running = append(n.running, i)
+	}
+	return n, nil
+}
+
+func (n *network) run() (outcome, error) {
+	for round := 1; round <= n.maxRounds; round++ {
+		if err := n.round(); err != nil {
+			return outcome{}, err
+		}
+		if !n.safe() {
+			return outcome{
+				rounds:   round,
+				messages: n.messages,
+			}, nil
+		}
+		if len(n.running) == 0 {
+			return outcome{
+				rounds:    round,
+				finalized: true,
+				safe:      true,
+				messages:  n.messages,
+			}, nil
+		}
+	}
+	return outcome{
+		rounds:   n.maxRounds,
+		safe:     true,
+		messages: n.messages,
+	}, nil
+}
+
+// round performs a synchronous round of polls. All responses are based on the
+// preferences held at the start of the round.
+func (n *network) round() error {
+	preferences := make([]ids.ID, len(n.nodes))
+	counts := make([]int, len(colors))
+	for i, node := range n.nodes {
+		if node == nil {
+			continue
+		}
+		preference := node.Preference()
+		preferences[i] = preference
+		if preference == colors[1] {
+			counts[1]++
+		} else {
+			counts[0]++
+		}
+	}
+
+	// The adversary attempts to keep the network split by supporting the
+	// value that is currently the least preferred.
+	adversarial := colors[0]
+	if counts[1] < counts[0] {
+		adversarial = colors[1]
+	}
+
+	for _, i := range n.running {
+		indices, err := n.sampler.Sample(n.params.K)
+		if err != nil {
+			return err
+		}
+
+		// A validator sampled multiple times receives a single query, but its
+		// response counts once per time it was sampled. Peers are kept in
+		// sampling order so that simulations are reproducible.
+		var (
+			sampled = make(map[int]int, len(indices))
+			peers   = make([]int, 0, len(indices))
+		)
+		for _, peer := range indices {
+			if sampled[peer] == 0 {
+				peers = append(peers, peer)
+			}
+			sampled[peer]++
+		}
+
+		votes := bag.Bag[ids.ID]{}
+		for _, peer := range peers {
+			// The query is sent regardless of whether it is dropped.
+			n.messages++
+			if n.rng.Float64() < n.dropRate {
+				continue
+			}
+			n.messages++
+
+			vote := preferences[peer]
+			if n.byzantine[peer] {
+				vote = adversarial
+			}
+			votes.AddCount(vote, sampled[peer])
+		}
+		n.nodes[i].RecordPoll(votes)
+	}
+
+	running := n.running[:0]
+	for _, i := range n.running {
+		if !n.nodes[i].Finalized() {
+			running = append(running, i)
+		}
+	}
+	n.running = running
+	return nil
+}
+
+// safe returns false iff two correct validators have finalized different
+// values.
+func (n *network) safe() bool {
+	var (
+		finalized     bool
+		finalizedPref ids.ID
+	)
+	for _, node := range n.nodes {
+		if node == nil || !node.Finalized() {
+			continue
+		}
+		preference := node.Preference()
+		if !finalized {
+			finalized = true
+			finalizedPref = preference
+			continue
+		}
+		if preference != finalizedPref {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs Monte-Carlo simulations of snowball consensus to
+// evaluate a choice of consensus parameters.
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Result summarizes the outcome of a set of simulations.
+type Result struct {
+	// NumRuns is the number of simulations that were performed.
+	NumRuns int `json:"numRuns"`
+
+	// NumFinalized is the number of simulations where every correct validator
+	// finalized the same value within the maximum number of rounds.
+	NumFinalized int `json:"numFinalized"`
+
+	// NumSafetyFailures is the number of simulations where two correct
+	// validators finalized different values.
+	NumSafetyFailures int `json:"numSafetyFailures"`
+
+	// NumLivenessFailures is the number of simulations where some correct
+	// validator didn't finalize within the maximum number of rounds.
+	NumLivenessFailures int `json:"numLivenessFailures"`
+
+	// SafetyFailureProbability is the fraction of simulations that resulted in
+	// a safety failure.
+	SafetyFailureProbability float64 `json:"safetyFailureProbability"`
+
+	// MeanFinalityRounds is the average number of rounds it took for every
+	// correct validator to finalize, over the finalized simulations.
+	MeanFinalityRounds float64 `json:"meanFinalityRounds"`
+
+	// MaxFinalityRounds is the largest number of rounds it took for every
+	// correct validator to finalize, over the finalized simulations.
+	MaxFinalityRounds int `json:"maxFinalityRounds"`
+
+	// MeanMessages is the average number of queries and responses sent in a
+	// simulation.
+	MeanMessages float64 `json:"meanMessages"`
+
+	// MeanMessagesPerValidator is [MeanMessages] divided by the number of
+	// validators.
+	MeanMessagesPerValidator float64 `json:"meanMessagesPerValidator"`
+}
+
+// Run performs the simulations described by [config].
+func Run(config Config) (Result, error) {
+	if err := config.Verify(); err != nil {
+		return Result{}, err
+	}
+
+	var (
+		rng           = rand.New(rand.NewSource(config.Seed)) // #nosec G404
+		result        = Result{NumRuns: config.NumRuns}
+		totalRounds   uint64
+		totalMessages uint64
+	)
+	for i := 0; i < config.NumRuns; i++ {
+		n, err := newNetwork(&config, rng.Int63())
+		if err != nil {
+			return Result{}, err
+		}
+		o, err := n.run()
+		if err != nil {
+			return Result{}, err
+		}
+
+		totalMessages += o.messages
+		switch {
+		case !o.safe:
+			result.NumSafetyFailures++
+		case !o.finalized:
+			result.NumLivenessFailures++
+		default:
+			result.NumFinalized++
+			totalRounds += uint64(o.rounds)
+			if o.rounds > result.MaxFinalityRounds {
+				result.MaxFinalityRounds = o.rounds
+			}
+		}
+	}
+
+	result.SafetyFailureProbability = float64(result.NumSafetyFailures) / float64(result.NumRuns)
+	if result.NumFinalized > 0 {
+		result.MeanFinalityRounds = float64(totalRounds) / float64(result.NumFinalized)
+	}
+	result.MeanMessages = float64(totalMessages) / float64(result.NumRuns)
+	result.MeanMessagesPerValidator = result.MeanMessages / float64(len(config.Weights))
+	return result, nil
+}
+
+func (r Result) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("runs:                        %d\n", r.NumRuns))
+	sb.WriteString(fmt.Sprintf("finalized:                   %d\n", r.NumFinalized))
+	sb.WriteString(fmt.Sprintf("safety failures:             %d\n", r.NumSafetyFailures))
+	sb.WriteString(fmt.Sprintf("liveness failures:           %d\n", r.NumLivenessFailures))
+	sb.WriteString(fmt.Sprintf("safety failure probability:  %g\n", r.SafetyFailureProbability))
+	sb.WriteString(fmt.Sprintf("mean finality rounds:        %.2f\n", r.MeanFinalityRounds))
+	sb.WriteString(fmt.Sprintf("max finality rounds:         %d\n", r.MaxFinalityRounds))
+	sb.WriteString(fmt.Sprintf("mean messages:               %.2f\n", r.MeanMessages))
+	sb.WriteString(fmt.Sprintf("mean messages per validator: %.2f", r.MeanMessagesPerValidator))
+	return sb.String()
+}
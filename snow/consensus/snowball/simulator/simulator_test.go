@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+func testConfig() Config {
+	weights := make([]uint64, 50)
+	for i := range weights {
+		weights[i] = uint64(i + 1)
+	}
+	return Config{
+		Parameters: snowball.DefaultParameters,
+		Weights:    weights,
+		NumRuns:    10,
+		MaxRounds:  200,
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			modify:      func(*Config) {},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid parameters",
+			modify: func(c *Config) {
+				c.Parameters.Alpha = 1
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "no validators",
+			modify: func(c *Config) {
+				c.Weights = nil
+			},
+			expectedErr: errNoValidators,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *Config) {
+				c.Weights[3] = 0
+			},
+			expectedErr: errZeroWeight,
+		},
+		{
+			name: "total weight less than k",
+			modify: func(c *Config) {
+				c.Weights = []uint64{1}
+			},
+			expectedErr: errInsufficientWeight,
+		},
+		{
+			name: "byzantine fraction too large",
+			modify: func(c *Config) {
+				c.ByzantineFraction = 1
+			},
+			expectedErr: errInvalidByzantineFraction,
+		},
+		{
+			name: "negative drop rate",
+			modify: func(c *Config) {
+				c.DropRate = -.1
+			},
+			expectedErr: errInvalidDropRate,
+		},
+		{
+			name: "no runs",
+			modify: func(c *Config) {
+				c.NumRuns = 0
+			},
+			expectedErr: errInvalidNumRuns,
+		},
+		{
+			name: "no rounds",
+			modify: func(c *Config) {
+				c.MaxRounds = 0
+			},
+			expectedErr: errInvalidMaxRounds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			test.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRunHonestNetworkFinalizes(t *testing.T) {
+	require := require.New(t)
+
+	result, err := Run(testConfig())
+	require.NoError(err)
+	require.Equal(10, result.NumRuns)
+	require.Equal(10, result.NumFinalized)
+	require.Zero(result.NumSafetyFailures)
+	require.Zero(result.NumLivenessFailures)
+	require.Zero(result.SafetyFailureProbability)
+	require.GreaterOrEqual(result.MeanFinalityRounds, 15.0)
+	require.GreaterOrEqual(result.MaxFinalityRounds, 15)
+	require.Positive(result.MeanMessages)
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.ByzantineFraction = .1
+	config.DropRate = .05
+	config.Seed = 1
+
+	result0, err := Run(config)
+	require.NoError(err)
+
+	result1, err := Run(config)
+	require.NoError(err)
+	require.Equal(result0, result1)
+}
+
+func TestRunDroppedMessagesPreventFinality(t *testing.T) {
+	require := require.New(t)
+
+	// With half of the messages dropped, it is practically impossible to
+	// receive alpha votes in a poll.
+	config := testConfig()
+	config.DropRate = .5
+	config.MaxRounds = 20
+
+	result, err := Run(config)
+	require.NoError(err)
+	require.Zero(result.NumFinalized)
+	require.Equal(10, result.NumLivenessFailures)
+	require.Zero(result.MeanFinalityRounds)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 )
@@ -57,6 +58,7 @@ var (
 		RecordPollDivergedVotingTest,
 		RecordPollDivergedVotingWithNoConflictingBitTest,
 		RecordPollChangePreferredChainTest,
+		RecordPollEventsTest,
 		MetricsProcessingErrorTest,
 		MetricsAcceptedErrorTest,
 		MetricsRejectedErrorTest,
@@ -639,6 +641,83 @@ func RecordPollSplitVoteNoChangeTest(t *testing.T, factory Factory) {
 	require.Equal(float64(1), metrics["polls_successful"])
 }
 
+func RecordPollEventsTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	sm := factory.New()
+
+	bus, err := eventbus.New("", prometheus.NewRegistry())
+	require.NoError(err)
+	sub := bus.Subscribe(eventbus.Filter{}, 10)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.EventPublisher = bus
+
+	params := snowball.Parameters{
+		K:                     1,
+		Alpha:                 1,
+		BetaVirtuous:          1,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), firstBlock))
+	require.NoError(sm.Add(context.Background(), secondBlock))
+
+	votes := bag.Bag[ids.ID]{}
+	votes.Add(firstBlock.ID())
+
+	// The first poll only reports that the first block was polled
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Len(sub.Events(), 1)
+
+	event := <-sub.Events()
+	require.Equal(eventbus.Polled, event.Type)
+	require.Equal(ctx.ChainID, event.ChainID)
+	require.Equal(firstBlock.ID(), event.ContainerID)
+	require.Nil(event.Latency)
+
+	// The second poll decides both blocks
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.True(sm.Finalized())
+	require.Len(sub.Events(), 2)
+
+	event = <-sub.Events()
+	require.Equal(eventbus.Accepted, event.Type)
+	require.Equal(firstBlock.ID(), event.ContainerID)
+	require.NotNil(event.Latency)
+	require.Equal(uint64(2), event.Latency.Polls)
+	require.Equal(event.Latency.Total, event.Latency.FirstPoll+event.Latency.Polling)
+
+	event = <-sub.Events()
+	require.Equal(eventbus.Rejected, event.Type)
+	require.Equal(secondBlock.ID(), event.ContainerID)
+	require.NotNil(event.Latency)
+	require.Equal(uint64(2), event.Latency.Polls)
+	require.Zero(event.Latency.FirstPoll)
+	require.Zero(event.Latency.Polling)
+}
+
 func RecordPollWhenFinalizedTest(t *testing.T, factory Factory) {
 	sm := factory.New()
 
@@ -106,7 +106,7 @@ func (ts *Topological) Initialize(ctx *snow.ConsensusContext, params snowball.Pa
 		return err
 	}
 
-	latencyMetrics, err := metrics.NewLatency("blks", "block(s)", ctx.Log, "", ctx.Registerer)
+	latencyMetrics, err := metrics.NewLatency("blks", "block(s)", ctx.Log, ctx.ChainID, ctx.EventPublisher, "", ctx.Registerer)
 	if err != nil {
 		return err
 	}
@@ -257,6 +257,12 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	// Register a new poll call
 	ts.pollNumber++
 
+	for _, blkID := range voteBag.List() {
+		if ts.Processing(blkID) {
+			ts.Latency.Polled(blkID, ts.pollNumber)
+		}
+	}
+
 	var voteStack []votes
 	if voteBag.Len() >= ts.params.Alpha {
 		// Since we received at least alpha votes, it's possible that
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -83,10 +84,17 @@ type ConsensusContext struct {
 	// that their transaction was accepted.
 	TxAcceptor Acceptor
 
+	// TxsStreamed is true iff the VM of a Snowman chain passes the
+	// transactions of its accepted blocks to TxAcceptor.
+	TxsStreamed bool
+
 	// VertexAcceptor is the callback that will be fired whenever a vertex was
 	// accepted.
 	VertexAcceptor Acceptor
 
+	// EventPublisher is notified as containers progress through consensus.
+	EventPublisher eventbus.Publisher
+
 	// State indicates the current state of this consensus instance.
 	State utils.Atomic[EngineState]
 
@@ -124,5 +132,6 @@ func DefaultConsensusContextTest() *ConsensusContext {
 		BlockAcceptor:       noOpAcceptor{},
 		TxAcceptor:          noOpAcceptor{},
 		VertexAcceptor:      noOpAcceptor{},
+		EventPublisher:      eventbus.NoOpPublisher,
 	}
 }
@@ -270,6 +270,18 @@ func (mr *MockSenderMockRecorder) SendGetStateSummaryFrontier(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetStateSummaryFrontier", reflect.TypeOf((*MockSender)(nil).SendGetStateSummaryFrontier), arg0, arg1, arg2)
 }
 
+// SendAnnouncement mocks base method.
+func (m *MockSender) SendAnnouncement(arg0 context.Context, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendAnnouncement", arg0, arg1)
+}
+
+// SendAnnouncement indicates an expected call of SendAnnouncement.
+func (mr *MockSenderMockRecorder) SendAnnouncement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAnnouncement", reflect.TypeOf((*MockSender)(nil).SendAnnouncement), arg0, arg1)
+}
+
 // SendGossip mocks base method.
 func (m *MockSender) SendGossip(arg0 context.Context, arg1 []byte) {
 	m.ctrl.T.Helper()
@@ -125,6 +125,10 @@ type QuerySender interface {
 type Gossiper interface {
 	// Gossip the provided container throughout the network
 	SendGossip(ctx context.Context, container []byte)
+
+	// Announce the ID of the provided container so that peers can fetch it
+	// before being queried about it
+	SendAnnouncement(ctx context.Context, containerID ids.ID)
 }
 
 // NetworkAppSender sends VM-level messages to nodes in the network.
@@ -34,7 +34,7 @@ type SenderTest struct {
 	CantSendGetAccepted, CantSendAccepted,
 	CantSendGet, CantSendGetAncestors, CantSendPut, CantSendAncestors,
 	CantSendPullQuery, CantSendPushQuery, CantSendChits,
-	CantSendGossip,
+	CantSendGossip, CantSendAnnouncement,
 	CantSendAppRequest, CantSendAppResponse, CantSendAppGossip, CantSendAppGossipSpecific,
 	CantSendCrossChainAppRequest, CantSendCrossChainAppResponse bool
 
@@ -55,6 +55,7 @@ type SenderTest struct {
 	SendPullQueryF               func(context.Context, set.Set[ids.NodeID], uint32, ids.ID)
 	SendChitsF                   func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID)
 	SendGossipF                  func(context.Context, []byte)
+	SendAnnouncementF            func(context.Context, ids.ID)
 	SendAppRequestF              func(context.Context, set.Set[ids.NodeID], uint32, []byte) error
 	SendAppResponseF             func(context.Context, ids.NodeID, uint32, []byte) error
 	SendAppGossipF               func(context.Context, []byte) error
@@ -82,6 +83,7 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantSendPushQuery = cant
 	s.CantSendChits = cant
 	s.CantSendGossip = cant
+	s.CantSendAnnouncement = cant
 	s.CantSendAppRequest = cant
 	s.CantSendAppResponse = cant
 	s.CantSendAppGossip = cant
@@ -282,6 +284,17 @@ func (s *SenderTest) SendGossip(ctx context.Context, container []byte) {
 	}
 }
 
+// SendAnnouncement calls SendAnnouncementF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) SendAnnouncement(ctx context.Context, containerID ids.ID) {
+	if s.SendAnnouncementF != nil {
+		s.SendAnnouncementF(ctx, containerID)
+	} else if s.CantSendAnnouncement && s.T != nil {
+		s.T.Fatalf("Unexpectedly called SendAnnouncement")
+	}
+}
+
 // SendCrossChainAppRequest calls SendCrossChainAppRequestF if it was
 // initialized. If it wasn't initialized and this function shouldn't be called
 // and testing was initialized, then testing will fail.
@@ -0,0 +1,569 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	chitsOp messageOp = iota
+	putOp
+	pushQueryOp
+)
+
+const (
+	queryRequest requestKind = iota
+	getRequest
+)
+
+// defaultByzantineTimeoutRounds is the number of rounds after which an
+// unanswered request is failed by the network.
+const defaultByzantineTimeoutRounds = 3
+
+type (
+	messageOp   int
+	requestKind int
+)
+
+// byzantineParams are the consensus parameters used by the byzantine
+// scenarios. With at most 3 of 10 validators being byzantine, a byzantine
+// minority can never reach [Alpha] on its own.
+var byzantineParams = snowball.Parameters{
+	K:                     5,
+	Alpha:                 4,
+	BetaVirtuous:          3,
+	BetaRogue:             5,
+	ConcurrentRepolls:     3,
+	OptimalProcessing:     10,
+	MaxOutstandingItems:   1,
+	MaxItemProcessingTime: 1,
+}
+
+// peer scripts how a validator replies to the engine under test. Peers never
+// call into the engine directly. Instead they return the messages that should
+// be delivered to the engine, which allows peers to be wrapped to delay or
+// drop their replies.
+type peer interface {
+	// Query is called when the engine sends a PushQuery or a PullQuery for
+	// [blkID] to [nodeID].
+	Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message
+	// Get is called when the engine sends a Get for [blkID] to [nodeID].
+	Get(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message
+	// Round is called at the start of every round to allow [nodeID] to send
+	// unsolicited messages to the engine.
+	Round(n *byzantineNetwork, nodeID ids.NodeID) []*message
+}
+
+// message is a message sent from a peer to the engine under test.
+type message struct {
+	op         messageOp
+	nodeID     ids.NodeID
+	requestID  uint32
+	blkID      ids.ID
+	acceptedID ids.ID
+	blkBytes   []byte
+
+	// delay is the number of additional rounds the message is held before it
+	// is delivered.
+	delay int
+	// deliverAt is the round in which the message will be delivered.
+	deliverAt int
+}
+
+type request struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+type outstandingRequest struct {
+	kind     requestKind
+	deadline int
+}
+
+// byzantineNetwork runs a single Transitive engine against a set of scripted
+// peers.
+//
+// The network advances in rounds. Every request the engine sends is handed to
+// the addressed peer immediately, and the peer's replies are delivered no
+// earlier than the following round. Requests that are not answered within
+// [timeoutRounds] are failed, mirroring the behavior of the timeout manager.
+type byzantineNetwork struct {
+	t *testing.T
+
+	engine  *Transitive
+	vm      *block.TestVM
+	genesis *snowman.TestBlock
+
+	validators validators.Set
+	peers      map[ids.NodeID]peer
+	nodeIDs    []ids.NodeID
+
+	// blocks contains every block known to the network, including blocks
+	// that the engine has not been told about yet.
+	blocks  map[ids.ID]*snowman.TestBlock
+	byBytes map[string]*snowman.TestBlock
+	// parsed contains the blocks that the engine's VM knows about.
+	parsed set.Set[ids.ID]
+
+	// preference is the block honest peers vote for.
+	preference ids.ID
+
+	round         int
+	timeoutRounds int
+	nextRequestID uint32
+	inbox         []*message
+	outstanding   map[request]outstandingRequest
+}
+
+func newByzantineNetwork(t *testing.T, params snowball.Parameters) *byzantineNetwork {
+	require := require.New(t)
+
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte{0},
+	}
+
+	n := &byzantineNetwork{
+		t:             t,
+		genesis:       genesis,
+		peers:         make(map[ids.NodeID]peer),
+		blocks:        map[ids.ID]*snowman.TestBlock{genesis.ID(): genesis},
+		byBytes:       map[string]*snowman.TestBlock{string(genesis.Bytes()): genesis},
+		parsed:        set.Set[ids.ID]{genesis.ID(): struct{}{}},
+		preference:    genesis.ID(),
+		timeoutRounds: defaultByzantineTimeoutRounds,
+		outstanding:   make(map[request]outstandingRequest),
+	}
+
+	commonCfg := common.DefaultConfigTest()
+	engCfg := DefaultConfigs()
+	engCfg.Params = params
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	sender.CantSendAnnouncement = false
+	sender.SendGetF = n.sendGet
+	sender.SendPushQueryF = n.sendPushQuery
+	sender.SendPullQueryF = n.sendPullQuery
+	// Chits sent in reply to a peer's PushQuery are not tracked.
+	sender.SendChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID) {}
+	engCfg.Sender = sender
+	commonCfg.Sender = sender
+
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	vm.CantSetState = false
+	vm.CantSetPreference = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return genesis.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		if !n.parsed.Contains(blkID) {
+			return nil, errUnknownBlock
+		}
+		return n.blocks[blkID], nil
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		blk, ok := n.byBytes[string(blkBytes)]
+		if !ok {
+			return nil, errUnknownBytes
+		}
+		n.parsed.Add(blk.ID())
+		return blk, nil
+	}
+	engCfg.VM = vm
+	n.vm = vm
+
+	getHandler, err := getter.New(vm, commonCfg)
+	require.NoError(err)
+	engCfg.AllGetsServer = getHandler
+
+	n.validators = validators.NewSet()
+	engCfg.Validators = n.validators
+	n.engine, err = newTransitive(engCfg)
+	require.NoError(err)
+	return n
+}
+
+// addPeer registers [p] as a validator with weight 1 and returns its node ID.
+func (n *byzantineNetwork) addPeer(p peer) ids.NodeID {
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(n.t, n.validators.Add(nodeID, nil, ids.Empty, 1))
+	n.peers[nodeID] = p
+	n.nodeIDs = append(n.nodeIDs, nodeID)
+	return nodeID
+}
+
+// addPeers registers [count] peers created by [newPeer].
+func (n *byzantineNetwork) addPeers(count int, newPeer func() peer) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, count)
+	for i := range nodeIDs {
+		nodeIDs[i] = n.addPeer(newPeer())
+	}
+	return nodeIDs
+}
+
+// start starts the engine under test. All peers must have been added.
+func (n *byzantineNetwork) start() {
+	require.NoError(n.t, n.engine.Start(context.Background(), 0))
+}
+
+// newBlock creates a block on top of [parent] that is known to the network
+// but not yet to the engine. If [verifyErr] is non-nil, the block fails
+// verification.
+func (n *byzantineNetwork) newBlock(parent *snowman.TestBlock, verifyErr error) *snowman.TestBlock {
+	blkID := ids.GenerateTestID()
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID,
+			StatusV: choices.Processing,
+		},
+		ParentV: parent.ID(),
+		HeightV: parent.Height() + 1,
+		VerifyV: verifyErr,
+		BytesV:  blkID[:],
+	}
+	n.blocks[blkID] = blk
+	n.byBytes[string(blk.Bytes())] = blk
+	return blk
+}
+
+// newChain creates [length] blocks on top of [parent].
+func (n *byzantineNetwork) newChain(parent *snowman.TestBlock, length int) []*snowman.TestBlock {
+	chain := make([]*snowman.TestBlock, length)
+	for i := range chain {
+		parent = n.newBlock(parent, nil)
+		chain[i] = parent
+	}
+	return chain
+}
+
+// setPreference sets the block that honest peers vote for.
+func (n *byzantineNetwork) setPreference(blk snowman.Block) {
+	n.preference = blk.ID()
+}
+
+// push sends an unsolicited PushQuery for [blk] from [nodeID] to the engine.
+func (n *byzantineNetwork) push(nodeID ids.NodeID, blk snowman.Block) {
+	n.enqueue(n.pushQueryMsg(nodeID, blk))
+}
+
+func (n *byzantineNetwork) chitsMsg(nodeID ids.NodeID, requestID uint32, blkID ids.ID) *message {
+	return &message{
+		op:         chitsOp,
+		nodeID:     nodeID,
+		requestID:  requestID,
+		blkID:      blkID,
+		acceptedID: n.genesis.ID(),
+	}
+}
+
+func (*byzantineNetwork) putMsg(nodeID ids.NodeID, requestID uint32, blkBytes []byte) *message {
+	return &message{
+		op:        putOp,
+		nodeID:    nodeID,
+		requestID: requestID,
+		blkBytes:  blkBytes,
+	}
+}
+
+func (n *byzantineNetwork) pushQueryMsg(nodeID ids.NodeID, blk snowman.Block) *message {
+	n.nextRequestID++
+	return &message{
+		op:        pushQueryOp,
+		nodeID:    nodeID,
+		requestID: n.nextRequestID,
+		blkBytes:  blk.Bytes(),
+	}
+}
+
+func (n *byzantineNetwork) enqueue(msgs ...*message) {
+	for _, msg := range msgs {
+		msg.deliverAt = n.round + 1 + msg.delay
+		n.inbox = append(n.inbox, msg)
+	}
+}
+
+func (n *byzantineNetwork) sendGet(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+	n.outstanding[request{nodeID: nodeID, requestID: requestID}] = outstandingRequest{
+		kind:     getRequest,
+		deadline: n.round + n.timeoutRounds,
+	}
+	n.enqueue(n.peers[nodeID].Get(n, nodeID, requestID, blkID)...)
+}
+
+func (n *byzantineNetwork) sendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, blkBytes []byte) {
+	blk, ok := n.byBytes[string(blkBytes)]
+	require.True(n.t, ok, "engine pushed unknown block")
+	n.sendPullQuery(ctx, nodeIDs, requestID, blk.ID())
+}
+
+func (n *byzantineNetwork) sendPullQuery(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, blkID ids.ID) {
+	for nodeID := range nodeIDs {
+		n.outstanding[request{nodeID: nodeID, requestID: requestID}] = outstandingRequest{
+			kind:     queryRequest,
+			deadline: n.round + n.timeoutRounds,
+		}
+		n.enqueue(n.peers[nodeID].Query(n, nodeID, requestID, blkID)...)
+	}
+}
+
+// step advances the network by one round.
+func (n *byzantineNetwork) step() {
+	ctx := context.Background()
+	require := require.New(n.t)
+
+	n.round++
+	for _, nodeID := range n.nodeIDs {
+		n.enqueue(n.peers[nodeID].Round(n, nodeID)...)
+	}
+
+	// Messages enqueued while delivering are never due in this round, so it
+	// is safe to swap out the inbox.
+	inbox := n.inbox
+	n.inbox = nil
+	for _, msg := range inbox {
+		if msg.deliverAt > n.round {
+			n.inbox = append(n.inbox, msg)
+			continue
+		}
+		require.NoError(n.deliver(ctx, msg))
+	}
+
+	for req, outstanding := range n.outstanding {
+		if outstanding.deadline > n.round {
+			continue
+		}
+		delete(n.outstanding, req)
+		switch outstanding.kind {
+		case queryRequest:
+			require.NoError(n.engine.QueryFailed(ctx, req.nodeID, req.requestID))
+		case getRequest:
+			require.NoError(n.engine.GetFailed(ctx, req.nodeID, req.requestID))
+		}
+	}
+}
+
+func (n *byzantineNetwork) deliver(ctx context.Context, msg *message) error {
+	req := request{nodeID: msg.nodeID, requestID: msg.requestID}
+	switch msg.op {
+	case chitsOp:
+		if outstanding, ok := n.outstanding[req]; ok && outstanding.kind == queryRequest {
+			delete(n.outstanding, req)
+		}
+		return n.engine.Chits(ctx, msg.nodeID, msg.requestID, msg.blkID, msg.acceptedID)
+	case putOp:
+		if outstanding, ok := n.outstanding[req]; ok && outstanding.kind == getRequest {
+			delete(n.outstanding, req)
+		}
+		return n.engine.Put(ctx, msg.nodeID, msg.requestID, msg.blkBytes)
+	case pushQueryOp:
+		return n.engine.PushQuery(ctx, msg.nodeID, msg.requestID, msg.blkBytes)
+	default:
+		n.t.Fatalf("unknown message op %d", msg.op)
+		return nil
+	}
+}
+
+// runUntilAccepted advances the network until [blk] is accepted and returns
+// the number of rounds it took. The test fails if [blk] is not accepted
+// within [maxRounds] or if safety is violated at any point.
+func (n *byzantineNetwork) runUntilAccepted(blk snowman.Block, maxRounds int) int {
+	start := n.round
+	for blk.Status() != choices.Accepted {
+		if n.round-start >= maxRounds {
+			n.t.Fatalf("block %s not accepted within %d rounds", blk.ID(), maxRounds)
+		}
+		n.step()
+		n.requireSafety()
+	}
+	return n.round - start
+}
+
+// run advances the network by [rounds] rounds, checking safety after each
+// round.
+func (n *byzantineNetwork) run(rounds int) {
+	for i := 0; i < rounds; i++ {
+		n.step()
+		n.requireSafety()
+	}
+}
+
+// requireSafety fails the test if the engine accepted conflicting blocks, an
+// invalid block, or a block whose parent wasn't accepted.
+func (n *byzantineNetwork) requireSafety() {
+	accepted := make(map[uint64]ids.ID)
+	for blkID, blk := range n.blocks {
+		if blk.Status() != choices.Accepted || blkID == n.genesis.ID() {
+			continue
+		}
+		if conflictID, ok := accepted[blk.Height()]; ok {
+			n.t.Fatalf("accepted conflicting blocks %s and %s at height %d", conflictID, blkID, blk.Height())
+		}
+		accepted[blk.Height()] = blkID
+
+		if blk.VerifyV != nil {
+			n.t.Fatalf("accepted invalid block %s", blkID)
+		}
+		if parent := n.blocks[blk.Parent()]; parent.Status() != choices.Accepted {
+			n.t.Fatalf("accepted block %s with non-accepted parent %s", blkID, parent.ID())
+		}
+	}
+}
+
+// honestPeer votes for the network's preference and serves any block it
+// knows.
+type honestPeer struct{}
+
+func (honestPeer) Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, _ ids.ID) []*message {
+	return []*message{n.chitsMsg(nodeID, requestID, n.preference)}
+}
+
+func (honestPeer) Get(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message {
+	blk, ok := n.blocks[blkID]
+	if !ok {
+		return nil
+	}
+	return []*message{n.putMsg(nodeID, requestID, blk.Bytes())}
+}
+
+func (honestPeer) Round(*byzantineNetwork, ids.NodeID) []*message {
+	return nil
+}
+
+// equivocatingPeer replies to every query with one Chits message per block
+// in [votes], all for the same request. Blocks are served honestly so that the
+// engine is able to issue the conflicting votes.
+type equivocatingPeer struct {
+	honestPeer
+
+	votes []ids.ID
+}
+
+func (p *equivocatingPeer) Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, _ ids.ID) []*message {
+	msgs := make([]*message, len(p.votes))
+	for i, vote := range p.votes {
+		msgs[i] = n.chitsMsg(nodeID, requestID, vote)
+	}
+	return msgs
+}
+
+// invalidPutPeer votes for [vote] and replies to every Get with, in turn,
+// unparsable bytes, a block other than the one requested and the bytes of
+// [vote].
+type invalidPutPeer struct {
+	honestPeer
+
+	vote    *snowman.TestBlock
+	numPuts int
+}
+
+func (p *invalidPutPeer) Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, _ ids.ID) []*message {
+	return []*message{n.chitsMsg(nodeID, requestID, p.vote.ID())}
+}
+
+func (p *invalidPutPeer) Get(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message {
+	p.numPuts++
+	var blkBytes []byte
+	switch p.numPuts % 3 {
+	case 0:
+		blkBytes = []byte("not a block")
+	case 1:
+		// Reply with any known block other than the one requested.
+		for otherID, other := range n.blocks {
+			if otherID != blkID {
+				blkBytes = other.Bytes()
+				break
+			}
+		}
+	default:
+		blkBytes = p.vote.Bytes()
+	}
+	return []*message{n.putMsg(nodeID, requestID, blkBytes)}
+}
+
+// conflictingPusherPeer pushes a new block conflicting with the network's
+// preference every round and votes for the last block it pushed.
+type conflictingPusherPeer struct {
+	honestPeer
+
+	last *snowman.TestBlock
+}
+
+func (p *conflictingPusherPeer) Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, _ ids.ID) []*message {
+	if p.last == nil {
+		return nil
+	}
+	return []*message{n.chitsMsg(nodeID, requestID, p.last.ID())}
+}
+
+func (p *conflictingPusherPeer) Round(n *byzantineNetwork, nodeID ids.NodeID) []*message {
+	preference := n.blocks[n.preference]
+	parent := n.blocks[preference.Parent()]
+	if parent == nil {
+		return nil
+	}
+	p.last = n.newBlock(parent, nil)
+	return []*message{n.pushQueryMsg(nodeID, p.last)}
+}
+
+// delayedPeer delays every message sent by the wrapped peer by [delay]
+// rounds.
+type delayedPeer struct {
+	peer
+
+	delay int
+}
+
+func (p *delayedPeer) Query(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message {
+	return p.withDelay(p.peer.Query(n, nodeID, requestID, blkID))
+}
+
+func (p *delayedPeer) Get(n *byzantineNetwork, nodeID ids.NodeID, requestID uint32, blkID ids.ID) []*message {
+	return p.withDelay(p.peer.Get(n, nodeID, requestID, blkID))
+}
+
+func (p *delayedPeer) Round(n *byzantineNetwork, nodeID ids.NodeID) []*message {
+	return p.withDelay(p.peer.Round(n, nodeID))
+}
+
+func (p *delayedPeer) withDelay(msgs []*message) []*message {
+	for _, msg := range msgs {
+		msg.delay += p.delay
+	}
+	return msgs
+}
+
+// withholdingPeer never replies to any request.
+type withholdingPeer struct{}
+
+func (withholdingPeer) Query(*byzantineNetwork, ids.NodeID, uint32, ids.ID) []*message {
+	return nil
+}
+
+func (withholdingPeer) Get(*byzantineNetwork, ids.NodeID, uint32, ids.ID) []*message {
+	return nil
+}
+
+func (withholdingPeer) Round(*byzantineNetwork, ids.NodeID) []*message {
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+)
+
+const (
+	numHonestPeers    = 7
+	numByzantinePeers = 3
+
+	// maxRoundsPerBlock bounds the number of rounds a block proposed by an
+	// honest peer may take to be accepted in the presence of byzantine peers.
+	maxRoundsPerBlock = 500
+)
+
+// Byzantine peers vote for a block conflicting with the honest preference and
+// equivocate by sending multiple Chits for the same request.
+//
+//	  G
+//	 / \
+//	A   B
+func TestByzantineEquivocatingChits(t *testing.T) {
+	require := require.New(t)
+
+	n := newByzantineNetwork(t, byzantineParams)
+	a := n.newBlock(n.genesis, nil)
+	b := n.newBlock(n.genesis, nil)
+
+	honestIDs := n.addPeers(numHonestPeers, func() peer {
+		return honestPeer{}
+	})
+	byzantineIDs := n.addPeers(numByzantinePeers, func() peer {
+		return &equivocatingPeer{
+			votes: []ids.ID{b.ID(), a.ID(), b.ID()},
+		}
+	})
+	n.start()
+
+	n.setPreference(a)
+	n.push(honestIDs[0], a)
+	n.push(byzantineIDs[0], b)
+
+	n.runUntilAccepted(a, maxRoundsPerBlock)
+	require.Equal(choices.Rejected, b.Status())
+}
+
+// Byzantine peers vote for blocks that fail verification and reply to Get
+// requests with unparsable bytes, unrequested blocks and invalid blocks.
+//
+//	  G
+//	 / \
+//	A   X
+//	    |
+//	    Y
+func TestByzantineInvalidPuts(t *testing.T) {
+	require := require.New(t)
+
+	n := newByzantineNetwork(t, byzantineParams)
+	a := n.newBlock(n.genesis, nil)
+	x := n.newBlock(n.genesis, errInvalid)
+	y := n.newBlock(x, nil)
+
+	honestIDs := n.addPeers(numHonestPeers, func() peer {
+		return honestPeer{}
+	})
+	n.addPeers(numByzantinePeers, func() peer {
+		return &invalidPutPeer{
+			vote: y,
+		}
+	})
+	n.start()
+
+	n.setPreference(a)
+	n.push(honestIDs[0], a)
+
+	n.runUntilAccepted(a, maxRoundsPerBlock)
+	require.NotEqual(choices.Accepted, x.Status())
+	require.NotEqual(choices.Accepted, y.Status())
+
+	// Let any remaining requests to byzantine peers time out to ensure the
+	// engine doesn't leave dangling requests behind.
+	n.run(n.timeoutRounds + 1)
+	require.Zero(n.engine.blkReqs.Len())
+}
+
+// Byzantine peers either never reply or reply after the request has already
+// timed out.
+func TestByzantineWithheldAndDelayedResponses(t *testing.T) {
+	n := newByzantineNetwork(t, byzantineParams)
+	chain := n.newChain(n.genesis, 3)
+
+	honestIDs := n.addPeers(numHonestPeers, func() peer {
+		return honestPeer{}
+	})
+	n.addPeers(numByzantinePeers-1, func() peer {
+		return withholdingPeer{}
+	})
+	n.addPeer(&delayedPeer{
+		peer:  honestPeer{},
+		delay: 2 * n.timeoutRounds,
+	})
+	n.start()
+
+	for _, blk := range chain {
+		n.setPreference(blk)
+		n.push(honestIDs[0], blk)
+		n.runUntilAccepted(blk, maxRoundsPerBlock)
+	}
+}
+
+// Byzantine peers push a fresh block conflicting with the honest preference
+// every round and vote for the last block they pushed.
+func TestByzantineConflictingPushQueries(t *testing.T) {
+	n := newByzantineNetwork(t, byzantineParams)
+	chain := n.newChain(n.genesis, 3)
+
+	honestIDs := n.addPeers(numHonestPeers, func() peer {
+		return honestPeer{}
+	})
+	n.addPeers(numByzantinePeers, func() peer {
+		return &conflictingPusherPeer{}
+	})
+	n.start()
+
+	for _, blk := range chain {
+		n.setPreference(blk)
+		n.push(honestIDs[0], blk)
+		n.runUntilAccepted(blk, maxRoundsPerBlock)
+	}
+}
@@ -13,7 +13,8 @@ import (
 type metrics struct {
 	bootstrapFinished, numRequests, numBlocked, numBlockers, numNonVerifieds prometheus.Gauge
 	numBuilt, numBuildsFailed, numUselessPutBytes, numUselessPushQueryBytes  prometheus.Counter
-	getAncestorsBlks                                                         metric.Averager
+	numAnnouncements, numUselessAnnouncements                                prometheus.Counter
+	getAncestorsBlks, blkFirstSeen                                           metric.Averager
 }
 
 // Initialize the metrics
@@ -66,6 +67,23 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg,
 		&errs,
 	)
+	m.numAnnouncements = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blk_announcements_received",
+		Help:      "Number of block announcements received",
+	})
+	m.numUselessAnnouncements = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "useless_blk_announcements",
+		Help:      "Number of block announcements received for blocks that were already known",
+	})
+	m.blkFirstSeen = metric.NewAveragerWithErrs(
+		namespace,
+		"blks_first_seen",
+		"time (in ns) from a block's timestamp until it was first received from a peer",
+		reg,
+		&errs,
+	)
 	m.numNonVerifieds = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "non_verified_blks",
@@ -82,6 +100,8 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg.Register(m.numBuildsFailed),
 		reg.Register(m.numUselessPutBytes),
 		reg.Register(m.numUselessPushQueryBytes),
+		reg.Register(m.numAnnouncements),
+		reg.Register(m.numUselessAnnouncements),
 	)
 	return errs.Err
 }
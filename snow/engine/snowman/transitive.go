@@ -6,6 +6,7 @@ package snowman
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -19,9 +20,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	"github.com/ava-labs/avalanchego/snow/eventbus"
 	"github.com/ava-labs/avalanchego/snow/events"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -82,6 +85,10 @@ type Transitive struct {
 	// processing blocks has gone below the optimal number.
 	pendingBuildBlocks int
 
+	// the last block that was announced to the network, used to avoid
+	// announcing the same preference multiple times
+	lastAnnounced ids.ID
+
 	// errs tracks if an error has occurred in a callback
 	errs wrappers.Errs
 }
@@ -199,7 +206,18 @@ func (t *Transitive) GetFailed(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (t *Transitive) PullQuery(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
-	t.sendChits(ctx, nodeID, requestID)
+	// A gossiped PullQuery is an announcement of a block that the peer
+	// prefers. The peer isn't expecting a response, but the block should be
+	// fetched so that it is available once we are queried about it.
+	if requestID == constants.GossipMsgRequestID {
+		t.metrics.numAnnouncements.Inc()
+		if blk, err := t.GetBlock(ctx, blkID); err == nil && (t.wasIssued(blk) || t.Consensus.Decided(blk)) {
+			t.metrics.numUselessAnnouncements.Inc()
+			return nil
+		}
+	} else {
+		t.sendChits(ctx, nodeID, requestID)
+	}
 
 	// Try to issue [blkID] to consensus.
 	// If we're missing an ancestor, request it from [vdr]
@@ -537,6 +555,12 @@ func (t *Transitive) issueFrom(ctx context.Context, nodeID ids.NodeID, blk snowm
 	// issue [blk] and its ancestors to consensus.
 	blkID := blk.ID()
 	for !t.wasIssued(blk) {
+		firstSeen := time.Since(blk.Timestamp())
+		if firstSeen < 0 {
+			firstSeen = 0
+		}
+		t.metrics.blkFirstSeen.Observe(float64(firstSeen))
+
 		if err := t.issue(ctx, blk, false); err != nil {
 			return false, err
 		}
@@ -624,6 +648,12 @@ func (t *Transitive) issue(ctx context.Context, blk snowman.Block, push bool) er
 
 	// mark that the block is queued to be added to consensus once its ancestors have been
 	t.pending[blkID] = blk
+	t.Ctx.EventPublisher.Publish(eventbus.Event{
+		Type:        eventbus.Issued,
+		ChainID:     t.Ctx.ChainID,
+		ContainerID: blkID,
+		Time:        time.Now(),
+	})
 
 	// Remove any outstanding requests for this block
 	t.blkReqs.RemoveAny(blkID)
@@ -807,6 +837,7 @@ func (t *Transitive) deliver(ctx context.Context, blk snowman.Block, push bool)
 	if err := t.VM.SetPreference(ctx, t.Consensus.Preference()); err != nil {
 		return err
 	}
+	t.announcePreference(ctx)
 
 	// If the block is now preferred, query the network for its preferences
 	// with this new block.
@@ -887,6 +918,12 @@ func (t *Transitive) addUnverifiedBlockToConsensus(ctx context.Context, blk snow
 	t.nonVerifieds.Remove(blkID)
 	t.nonVerifiedCache.Evict(blkID)
 	t.metrics.numNonVerifieds.Set(float64(t.nonVerifieds.Len()))
+	t.Ctx.EventPublisher.Publish(eventbus.Event{
+		Type:        eventbus.Verified,
+		ChainID:     t.Ctx.ChainID,
+		ContainerID: blkID,
+		Time:        time.Now(),
+	})
 	t.Ctx.Log.Verbo("adding block to consensus",
 		zap.Stringer("blkID", blkID),
 	)
@@ -896,3 +933,15 @@ func (t *Transitive) addUnverifiedBlockToConsensus(ctx context.Context, blk snow
 		tree:    t.nonVerifieds,
 	})
 }
+
+// announcePreference gossips the ID of the current preference if it is
+// processing and hasn't already been announced. This allows peers to fetch the
+// block before they are queried about it.
+func (t *Transitive) announcePreference(ctx context.Context) {
+	prefID := t.Consensus.Preference()
+	if prefID == t.lastAnnounced || !t.Consensus.Processing(prefID) {
+		return
+	}
+	t.lastAnnounced = prefID
+	t.Sender.SendAnnouncement(ctx, prefID)
+}
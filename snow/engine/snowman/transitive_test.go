@@ -46,6 +46,7 @@ func setup(t *testing.T, commonCfg common.Config, engCfg Config) (ids.NodeID, va
 	engCfg.Sender = sender
 	commonCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -432,6 +433,7 @@ func TestEngineMultipleQuery(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -731,6 +733,7 @@ func TestEnginePushQuery(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	blk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -812,6 +815,7 @@ func TestEngineBuildBlock(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	blk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -865,6 +869,7 @@ func TestEngineRepoll(t *testing.T) {
 	vdr, _, sender, _, te, _ := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	queried := new(bool)
 	sender.SendPullQueryF = func(_ context.Context, inVdrs set.Set[ids.NodeID], _ uint32, blkID ids.ID) {
@@ -919,6 +924,7 @@ func TestVoteCanceling(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -1020,6 +1026,7 @@ func TestEngineNoQuery(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	gBlk := &snowman.TestBlock{TestDecidable: choices.TestDecidable{
 		IDV:     ids.GenerateTestID(),
@@ -1071,6 +1078,7 @@ func TestEngineNoRepollQuery(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	gBlk := &snowman.TestBlock{TestDecidable: choices.TestDecidable{
 		IDV:     ids.GenerateTestID(),
@@ -1108,6 +1116,7 @@ func TestEngineAbandonQuery(t *testing.T) {
 	vdr, _, sender, vm, te, _ := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	blkID := ids.GenerateTestID()
 
@@ -1151,6 +1160,7 @@ func TestEngineAbandonChit(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	blk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1206,6 +1216,7 @@ func TestEngineAbandonChitWithUnexpectedPutBlock(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	blk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1267,6 +1278,7 @@ func TestEngineBlockingChitRequest(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	missingBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1349,6 +1361,7 @@ func TestEngineBlockingChitResponse(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	issuedBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1430,6 +1443,7 @@ func TestEngineRetryFetch(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	missingBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1483,6 +1497,7 @@ func TestEngineUndeclaredDependencyDeadlock(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	validBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1581,6 +1596,7 @@ func TestEngineInvalidBlockIgnoredFromUnexpectedPeer(t *testing.T) {
 	}
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	missingBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1683,6 +1699,7 @@ func TestEnginePushQueryRequestIDConflict(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	missingBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -1797,6 +1814,7 @@ func TestEngineAggressivePolling(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -1910,6 +1928,7 @@ func TestEngineDoubleChit(t *testing.T) {
 	engCfg.Sender = sender
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -2035,6 +2054,7 @@ func TestEngineBuildBlockLimit(t *testing.T) {
 	sender := &common.SenderTest{T: t}
 	engCfg.Sender = sender
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -2990,6 +3010,7 @@ func TestEngineBuildBlockWithCachedNonVerifiedParent(t *testing.T) {
 	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	grandParentBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -3150,6 +3171,7 @@ func TestEngineApplyAcceptedFrontierInQueryFailed(t *testing.T) {
 	engCfg.Sender = sender
 
 	sender.Default(true)
+	sender.CantSendAnnouncement = false
 
 	vm := &block.TestVM{}
 	vm.T = t
@@ -3224,3 +3246,70 @@ func TestEngineApplyAcceptedFrontierInQueryFailed(t *testing.T) {
 
 	require.Equal(choices.Accepted, blk.Status())
 }
+
+func TestEngineBlockAnnouncement(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	// An announcement must not be responded to, but the block should be
+	// fetched from the announcer.
+	getRequestID := new(uint32)
+	sender.SendGetF = func(_ context.Context, inVdr ids.NodeID, requestID uint32, blkID ids.ID) {
+		require.Equal(vdr, inVdr)
+		require.Equal(blk.ID(), blkID)
+		*getRequestID = requestID
+	}
+	require.NoError(te.PullQuery(context.Background(), vdr, constants.GossipMsgRequestID, blk.ID()))
+	require.Equal(1, te.blkReqs.Len())
+
+	// Once the block is fetched and preferred, it should be announced exactly
+	// once.
+	announced := 0
+	sender.SendAnnouncementF = func(_ context.Context, blkID ids.ID) {
+		require.Equal(blk.ID(), blkID)
+		announced++
+	}
+	sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID) {}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		require.Equal(blk.Bytes(), b)
+		return blk, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	require.NoError(te.Put(context.Background(), vdr, *getRequestID, blk.Bytes()))
+	require.True(te.Consensus.Processing(blk.ID()))
+	require.Equal(1, announced)
+
+	// Announcing an already issued block is useless.
+	require.NoError(te.PullQuery(context.Background(), vdr, constants.GossipMsgRequestID, blk.ID()))
+	require.Equal(1, announced)
+	require.Zero(te.blkReqs.Len())
+}
@@ -78,6 +78,7 @@ func (v *voter) Update(ctx context.Context) {
 		v.t.errs.Add(err)
 		return
 	}
+	v.t.announcePreference(ctx)
 
 	if v.t.Consensus.Finalized() {
 		v.t.Ctx.Log.Debug("Snowman engine can quiesce")
@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package eventbus distributes notifications about the progress of containers
+// through consensus to interested subscribers.
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	_ Bus       = (*bus)(nil)
+	_ Publisher = noOpPublisher{}
+
+	// NoOpPublisher drops all published events.
+	NoOpPublisher Publisher = noOpPublisher{}
+)
+
+// Publisher is used by the consensus engines to report events.
+type Publisher interface {
+	// Publish must not block.
+	Publish(event Event)
+}
+
+// Bus delivers published events to all subscriptions whose filters match.
+type Bus interface {
+	Publisher
+
+	// Subscribe returns a new subscription that will be delivered all future
+	// events matching [filter]. At most [bufferSize] events are buffered for
+	// the subscription, if the subscriber falls further behind, events are
+	// dropped.
+	Subscribe(filter Filter, bufferSize int) *Subscription
+}
+
+type noOpPublisher struct{}
+
+func (noOpPublisher) Publish(Event) {}
+
+type bus struct {
+	published     *prometheus.CounterVec
+	dropped       prometheus.Counter
+	subscriptions prometheus.Gauge
+
+	lock sync.RWMutex
+	subs set.Set[*Subscription]
+}
+
+func New(namespace string, reg prometheus.Registerer) (Bus, error) {
+	b := &bus{
+		published: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "published",
+				Help:      "Number of events published",
+			},
+			[]string{"type"},
+		),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dropped",
+			Help:      "Number of events dropped due to slow subscribers",
+		}),
+		subscriptions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "subscriptions",
+			Help:      "Number of active subscriptions",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(b.published),
+		reg.Register(b.dropped),
+		reg.Register(b.subscriptions),
+	)
+	return b, errs.Err
+}
+
+func (b *bus) Publish(event Event) {
+	b.published.WithLabelValues(event.Type.String()).Inc()
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for sub := range b.subs {
+		if !sub.matches(&event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.dropped.Inc()
+		}
+	}
+}
+
+func (b *bus) Subscribe(filter Filter, bufferSize int) *Subscription {
+	sub := &Subscription{
+		bus:    b,
+		filter: filter,
+		events: make(chan Event, bufferSize),
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.subs.Add(sub)
+	b.subscriptions.Set(float64(b.subs.Len()))
+	return sub
+}
+
+func (b *bus) unsubscribe(sub *Subscription) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.subs.Contains(sub) {
+		return
+	}
+	b.subs.Remove(sub)
+	b.subscriptions.Set(float64(b.subs.Len()))
+	close(sub.events)
+}
+
+// Subscription receives the events published to a [Bus] that match its
+// filter.
+type Subscription struct {
+	bus *bus
+
+	filterLock sync.RWMutex
+	filter     Filter
+
+	events chan Event
+}
+
+// Events returns the channel that matching events are delivered on. The
+// channel is closed once the subscription is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// SetFilter replaces the filter of this subscription.
+func (s *Subscription) SetFilter(filter Filter) {
+	s.filterLock.Lock()
+	defer s.filterLock.Unlock()
+
+	s.filter = filter
+}
+
+// Close stops delivering events to this subscription. It is safe to call Close
+// multiple times.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+func (s *Subscription) matches(event *Event) bool {
+	s.filterLock.RLock()
+	defer s.filterLock.RUnlock()
+
+	return s.filter.Matches(event)
+}
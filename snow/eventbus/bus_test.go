@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestBusFilters(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	var (
+		chainID0     = ids.GenerateTestID()
+		chainID1     = ids.GenerateTestID()
+		containerID0 = ids.GenerateTestID()
+		containerID1 = ids.GenerateTestID()
+	)
+
+	all := b.Subscribe(Filter{}, 10)
+	chain := b.Subscribe(Filter{
+		ChainIDs: idSet(chainID0),
+	}, 10)
+	container := b.Subscribe(Filter{
+		ChainIDs:     idSet(chainID0),
+		ContainerIDs: idSet(containerID1),
+	}, 10)
+
+	events := []Event{
+		{
+			Type:        Issued,
+			ChainID:     chainID0,
+			ContainerID: containerID0,
+		},
+		{
+			Type:        Verified,
+			ChainID:     chainID1,
+			ContainerID: containerID1,
+		},
+		{
+			Type:        Accepted,
+			ChainID:     chainID0,
+			ContainerID: containerID1,
+		},
+	}
+	for _, event := range events {
+		b.Publish(event)
+	}
+
+	require.Len(all.Events(), 3)
+	require.Len(chain.Events(), 2)
+	require.Len(container.Events(), 1)
+	require.Equal(events[2], <-container.Events())
+
+	container.SetFilter(Filter{
+		ContainerIDs: idSet(containerID0),
+	})
+	b.Publish(events[0])
+	require.Equal(events[0], <-container.Events())
+}
+
+func TestBusDropsWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	sub := b.Subscribe(Filter{}, 1)
+
+	event := Event{
+		Type:        Polled,
+		ContainerID: ids.GenerateTestID(),
+	}
+	b.Publish(event)
+	b.Publish(Event{
+		Type:        Polled,
+		ContainerID: ids.GenerateTestID(),
+	})
+
+	require.Len(sub.Events(), 1)
+	require.Equal(event, <-sub.Events())
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	sub := b.Subscribe(Filter{}, 1)
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.Events()
+	require.False(ok)
+
+	// Publishing after the subscription was closed must not panic.
+	b.Publish(Event{
+		Type: Rejected,
+	})
+}
+
+func TestTypeJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, typ := range types {
+		typJSON, err := json.Marshal(typ)
+		require.NoError(err)
+
+		var parsedType Type
+		require.NoError(json.Unmarshal(typJSON, &parsedType))
+		require.Equal(typ, parsedType)
+	}
+
+	var typ Type
+	err := json.Unmarshal([]byte(`"finalized"`), &typ)
+	require.ErrorIs(err, errUnknownType)
+}
+
+func idSet(idList ...ids.ID) set.Set[ids.ID] {
+	s := set.NewSet[ids.ID](len(idList))
+	s.Add(idList...)
+	return s
+}
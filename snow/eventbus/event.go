@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	// Issued is emitted when the engine first learns of a container and starts
+	// working towards adding it to consensus.
+	Issued Type = iota + 1
+	// Verified is emitted once a container has passed verification and has
+	// been added to consensus.
+	Verified
+	// Polled is emitted the first time that a poll returns votes for a
+	// container.
+	Polled
+	// Accepted is emitted when a container is accepted.
+	Accepted
+	// Rejected is emitted when a container is rejected.
+	Rejected
+)
+
+var (
+	errUnknownType = errors.New("unknown event type")
+
+	types = []Type{
+		Issued,
+		Verified,
+		Polled,
+		Accepted,
+		Rejected,
+	}
+)
+
+// Type identifies the phase of consensus that an event reports.
+type Type uint8
+
+func (t Type) String() string {
+	switch t {
+	case Issued:
+		return "issued"
+	case Verified:
+		return "verified"
+	case Polled:
+		return "polled"
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Type) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	for _, typ := range types {
+		if typ.String() == str {
+			*t = typ
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", errUnknownType, str)
+}
+
+// Event reports that a container progressed through a phase of consensus.
+type Event struct {
+	Type        Type      `json:"type"`
+	ChainID     ids.ID    `json:"chainID"`
+	ContainerID ids.ID    `json:"containerID"`
+	Time        time.Time `json:"time"`
+
+	// Latency is only populated for [Accepted] and [Rejected] events.
+	Latency *Latency `json:"latency,omitempty"`
+}
+
+// Latency breaks down how long a container spent in each phase of consensus
+// after it was verified.
+type Latency struct {
+	// FirstPoll is the time from verification until the first poll that
+	// returned votes for the container. It is zero if the container was
+	// decided without ever being polled.
+	FirstPoll time.Duration `json:"firstPoll"`
+	// Polling is the time from the first poll that returned votes for the
+	// container until it was decided.
+	Polling time.Duration `json:"polling"`
+	// Total is the time from verification until the container was decided.
+	Total time.Duration `json:"total"`
+	// Polls is the number of polls that were performed while the container
+	// was processing.
+	Polls uint64 `json:"polls"`
+}
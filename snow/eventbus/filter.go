@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// Filter restricts the events delivered to a subscription. An empty set
+// matches every value.
+type Filter struct {
+	ChainIDs     set.Set[ids.ID] `json:"chainIDs"`
+	ContainerIDs set.Set[ids.ID] `json:"containerIDs"`
+}
+
+// Matches returns true if [event] should be delivered to a subscription using
+// this filter.
+func (f *Filter) Matches(event *Event) bool {
+	if f.ChainIDs.Len() != 0 && !f.ChainIDs.Contains(event.ChainID) {
+		return false
+	}
+	return f.ContainerIDs.Len() == 0 || f.ContainerIDs.Contains(event.ContainerID)
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Size of the ws read buffer
+	readBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	writeBufferSize = units.KiB
+
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the peer.
+	pongWait = 60 * time.Second
+
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+
+	// Maximum message size allowed from peer.
+	maxMessageSize = 128 * units.KiB
+
+	// Maximum number of events buffered for a peer.
+	maxPendingEvents = 1024
+
+	// MaxFilterIDs is the maximum number of IDs a peer may include in a filter.
+	MaxFilterIDs = 1024
+)
+
+var (
+	_ http.Handler = (*Server)(nil)
+
+	errTooManyFilterIDs = errors.New("too many filter IDs")
+
+	upgrader = websocket.Upgrader{
+		ReadBufferSize:  readBufferSize,
+		WriteBufferSize: writeBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+type errorMsg struct {
+	Error string `json:"error"`
+}
+
+// Server exposes a [Bus] over WebSocket.
+//
+// After connecting, a client sends a JSON encoded [Filter]. Every event
+// matching the filter is then written to the client as a JSON encoded [Event].
+// The client may send a new filter at any time to replace the current one.
+type Server struct {
+	log logging.Logger
+	bus Bus
+}
+
+func NewServer(log logging.Logger, bus Bus) *Server {
+	return &Server{
+		log: log,
+		bus: bus,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+
+	c := &connection{
+		log:  s.log,
+		conn: wsConn,
+	}
+	go c.run(s.bus)
+}
+
+type connection struct {
+	log  logging.Logger
+	conn *websocket.Conn
+}
+
+// run waits for the initial filter before subscribing to the bus.
+func (c *connection) run(bus Bus) {
+	defer func() {
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := c.conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	filter, err := c.readFilter()
+	if err != nil {
+		c.log.Debug("failed to read initial filter",
+			zap.Error(err),
+		)
+		// The error is reported on a best effort basis.
+		_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+		_ = c.conn.WriteJSON(&errorMsg{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	sub := bus.Subscribe(filter, maxPendingEvents)
+	defer sub.Close()
+
+	go c.readPump(sub)
+	c.writePump(sub)
+}
+
+// readPump applies filter updates from the peer. Closing the subscription
+// causes the writePump to exit.
+//
+// Because the writePump is the only writer to the connection, errors after the
+// initial filter aren't reported to the peer. The connection is closed instead.
+func (c *connection) readPump(sub *Subscription) {
+	defer sub.Close()
+
+	for {
+		filter, err := c.readFilter()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.log.Debug("unexpected close in websockets",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		sub.SetFilter(filter)
+	}
+}
+
+func (c *connection) writePump(sub *Subscription) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if !ok {
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *connection) readFilter() (Filter, error) {
+	var filter Filter
+	if err := c.conn.ReadJSON(&filter); err != nil {
+		return Filter{}, err
+	}
+	if numIDs := filter.ChainIDs.Len() + filter.ContainerIDs.Len(); numIDs > MaxFilterIDs {
+		return Filter{}, fmt.Errorf("%w: %d > %d", errTooManyFilterIDs, numIDs, MaxFilterIDs)
+	}
+	return filter, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestServer(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	httpServer := httptest.NewServer(NewServer(logging.NoLog{}, b))
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	containerID := ids.GenerateTestID()
+	require.NoError(conn.WriteJSON(&Filter{
+		ContainerIDs: idSet(containerID),
+	}))
+
+	// Wait for the subscription to be registered.
+	require.Eventually(func() bool {
+		b.(*bus).lock.RLock()
+		defer b.(*bus).lock.RUnlock()
+		return b.(*bus).subs.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	expected := Event{
+		Type:        Accepted,
+		ChainID:     ids.GenerateTestID(),
+		ContainerID: containerID,
+		Time:        time.Unix(1, 0).UTC(),
+		Latency: &Latency{
+			FirstPoll: time.Millisecond,
+			Polling:   2 * time.Millisecond,
+			Total:     3 * time.Millisecond,
+			Polls:     4,
+		},
+	}
+	b.Publish(Event{
+		Type:        Accepted,
+		ContainerID: ids.GenerateTestID(),
+	})
+	b.Publish(expected)
+
+	var event Event
+	require.NoError(conn.ReadJSON(&event))
+	require.Equal(expected, event)
+
+	require.NoError(conn.Close())
+	require.Eventually(func() bool {
+		b.(*bus).lock.RLock()
+		defer b.(*bus).lock.RUnlock()
+		return b.(*bus).subs.Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServerRejectsLargeFilter(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	httpServer := httptest.NewServer(NewServer(logging.NoLog{}, b))
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	filter := Filter{
+		ContainerIDs: idSet(),
+	}
+	for i := 0; i <= MaxFilterIDs; i++ {
+		filter.ContainerIDs.Add(ids.GenerateTestID())
+	}
+	require.NoError(conn.WriteJSON(&filter))
+
+	var msg errorMsg
+	require.NoError(conn.ReadJSON(&msg))
+	require.Contains(msg.Error, errTooManyFilterIDs.Error())
+}
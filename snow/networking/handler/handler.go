@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/networking/worker"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -100,6 +101,9 @@ type handler struct {
 
 	// Tracks cpu/disk usage caused by each peer.
 	resourceTracker tracker.ResourceTracker
+	// Schedules the processing of this chain's messages with the other chains
+	// running on this node.
+	cpuScheduler scheduler.ChainScheduler
 
 	// Holds messages that [engine] hasn't processed yet.
 	// [unprocessedMsgsCond.L] must be held while accessing [syncMessageQueue].
@@ -116,6 +120,9 @@ type handler struct {
 	totalClosingTime     time.Duration
 	closingChan          chan struct{}
 	numDispatchersClosed int
+	// Cancelled when [closingChan] is closed
+	closingCtx    context.Context
+	cancelClosing context.CancelFunc
 	// Closed when this handler and [engine] are done shutting down
 	closed chan struct{}
 
@@ -136,6 +143,7 @@ func New(
 	gossipFrequency time.Duration,
 	threadPoolSize int,
 	resourceTracker tracker.ResourceTracker,
+	cpuScheduler scheduler.ChainScheduler,
 	subnetConnector validators.SubnetConnector,
 	subnet subnets.Subnet,
 	peerTracker commontracker.Peers,
@@ -151,13 +159,15 @@ func New(
 		closingChan:      make(chan struct{}),
 		closed:           make(chan struct{}),
 		resourceTracker:  resourceTracker,
+		cpuScheduler:     cpuScheduler,
 		subnetConnector:  subnetConnector,
 		subnet:           subnet,
 		peerTracker:      peerTracker,
 	}
 
-	var err error
+	h.closingCtx, h.cancelClosing = context.WithCancel(context.Background())
 
+	var err error
 	h.metrics, err = newMetrics("handler", h.ctx.Registerer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing handler metrics errored with: %w", err)
@@ -267,6 +277,7 @@ func (h *handler) Start(ctx context.Context, recoverPanic bool) {
 
 // Push the message onto the handler's queue
 func (h *handler) Push(ctx context.Context, msg Message) {
+	msg.received = h.clock.Time()
 	switch msg.Op() {
 	case message.AppRequestOp, message.AppRequestFailedOp, message.AppResponseOp, message.AppGossipOp,
 		message.CrossChainAppRequestOp, message.CrossChainAppRequestFailedOp, message.CrossChainAppResponseOp:
@@ -310,6 +321,7 @@ func (h *handler) Stop(ctx context.Context) {
 		h.syncMessageQueue.Shutdown()
 		h.asyncMessageQueue.Shutdown()
 		close(h.closingChan)
+		h.cancelClosing()
 
 		// TODO: switch this to use a [context.Context] with a cancel function.
 		//
@@ -367,8 +379,17 @@ func (h *handler) dispatchSync(ctx context.Context) {
 			return
 		}
 
+		// Wait until this chain is scheduled to process the message. If the
+		// handler is shutting down, the message is dropped.
+		if err := h.cpuScheduler.Acquire(h.closingCtx); err != nil {
+			msg.OnFinishedHandling()
+			return
+		}
+
 		// If there is an error handling the message, shut down the chain
-		if err := h.handleSyncMsg(ctx, msg); err != nil {
+		err := h.handleSyncMsg(ctx, msg)
+		h.cpuScheduler.Release()
+		if err != nil {
 			h.StopWithError(ctx, fmt.Errorf(
 				"%w while processing sync message: %s",
 				err,
@@ -456,6 +477,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("messageOp", op),
 		)
 	}
+	h.observeQueueDelay(msg, startTime)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
 	h.ctx.Lock.Lock()
 	lockAcquiredTime := h.clock.Time()
@@ -469,6 +491,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			msgHandlingTime   = endTime.Sub(lockAcquiredTime)
 		)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.metrics.cpuTime.Add(float64(processingTime))
 		messageHistograms.processingTime.Observe(float64(processingTime))
 		messageHistograms.msgHandlingTime.Observe(float64(msgHandlingTime))
 		msg.OnFinishedHandling()
@@ -761,7 +784,14 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 
 func (h *handler) handleAsyncMsg(ctx context.Context, msg Message) {
 	h.asyncMessagePool.Send(func() {
-		if err := h.executeAsyncMsg(ctx, msg); err != nil {
+		if err := h.cpuScheduler.Acquire(h.closingCtx); err != nil {
+			msg.OnFinishedHandling()
+			return
+		}
+
+		err := h.executeAsyncMsg(ctx, msg)
+		h.cpuScheduler.Release()
+		if err != nil {
 			h.StopWithError(ctx, fmt.Errorf(
 				"%w while processing async message: %s",
 				err,
@@ -791,6 +821,7 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("messageOp", op),
 		)
 	}
+	h.observeQueueDelay(msg, startTime)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
 	defer func() {
 		var (
@@ -799,6 +830,7 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 			processingTime    = endTime.Sub(startTime)
 		)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.metrics.cpuTime.Add(float64(processingTime))
 		// There is no lock grabbed here, so both metrics are identical
 		messageHistograms.processingTime.Observe(float64(processingTime))
 		messageHistograms.msgHandlingTime.Observe(float64(processingTime))
@@ -978,6 +1010,14 @@ func (h *handler) popUnexpiredMsg(
 	}
 }
 
+func (h *handler) observeQueueDelay(msg Message, startTime time.Time) {
+	// Messages that were never queued don't have a queue delay.
+	if msg.received.IsZero() {
+		return
+	}
+	h.metrics.queueDelay.Observe(float64(startTime.Sub(msg.received)))
+}
+
 func (h *handler) closeDispatcher(ctx context.Context) {
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
@@ -1001,6 +1041,8 @@ func (h *handler) shutdown(ctx context.Context) {
 		close(h.closed)
 	}()
 
+	h.cpuScheduler.Deregister()
+
 	// shutdown may be called during Start, so we populate the start closing
 	// time here in case Stop was never called.
 	if h.startClosingTime.IsZero() {
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -57,6 +58,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -152,6 +154,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -243,6 +246,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		1,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -323,6 +327,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -401,6 +406,7 @@ func TestHandlerSubnetConnector(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		connector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -571,6 +577,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				time.Second,
 				testThreadPoolSize,
 				resourceTracker,
+				scheduler.NoOpChain,
 				validators.UnhandledSubnetConnector,
 				subnets.New(ids.EmptyNodeID, subnets.Config{}),
 				commontracker.NewPeers(),
@@ -624,3 +631,106 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerWaitsForScheduler(t *testing.T) {
+	require := require.New(t)
+
+	called := make(chan struct{}, 1)
+
+	ctx := snow.DefaultConsensusContextTest()
+
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	cpuScheduler, err := scheduler.New(
+		scheduler.Config{
+			MaxProcessing:          1,
+			PrimaryNetworkMinShare: .5,
+			UsageHalflife:          time.Second,
+		},
+		meter.ContinuousFactory{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	chainScheduler, err := cpuScheduler.Register(ctx.ChainID, ctx.SubnetID, 1, 1)
+	require.NoError(err)
+	otherChainScheduler, err := cpuScheduler.Register(ids.GenerateTestID(), ids.GenerateTestID(), 1, 1)
+	require.NoError(err)
+
+	handlerIntf, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		chainScheduler,
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+	)
+	require.NoError(err)
+	handler := handlerIntf.(*handler)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	bootstrapper.GetAcceptedFrontierF = func(context.Context, ids.NodeID, uint32) error {
+		called <- struct{}{}
+		return nil
+	}
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping, // assumed bootstrap is ongoing
+	})
+
+	// Another chain is using the only processing slot.
+	require.NoError(otherChainScheduler.Acquire(context.Background()))
+
+	handler.Start(context.Background(), false)
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InboundGetAcceptedFrontier(ids.Empty, 1, time.Minute, ids.EmptyNodeID, p2p.EngineType_ENGINE_TYPE_SNOWMAN),
+		EngineType:     p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+
+	select {
+	case <-called:
+		require.FailNow("message handled while another chain was processing")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	otherChainScheduler.Release()
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		require.FailNow("message wasn't handled after being scheduled")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -75,6 +76,7 @@ func TestHealthCheckSubnet(t *testing.T) {
 				time.Second,
 				testThreadPoolSize,
 				resourceTracker,
+				scheduler.NoOpChain,
 				validators.UnhandledSubnetConnector,
 				sb,
 				peerTracker,
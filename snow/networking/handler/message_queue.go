@@ -6,6 +6,7 @@ package handler
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -30,6 +31,9 @@ type Message struct {
 	// The desired engine type to execute this message. If not specified,
 	// the current executing engine type is used.
 	EngineType p2p.EngineType
+
+	// The time this message was pushed to the handler
+	received time.Time
 }
 
 type MessageQueue interface {
@@ -17,6 +17,12 @@ type metrics struct {
 	expired      prometheus.Counter
 	asyncExpired prometheus.Counter
 	messages     map[message.Op]*messageProcessing
+	// cpuTime is the total time (in ns) spent processing messages after being
+	// scheduled by the node-wide scheduler.
+	cpuTime prometheus.Counter
+	// queueDelay is the time a message spent between being queued and being
+	// processed, including the time spent waiting to be scheduled.
+	queueDelay metric.Averager
 }
 
 type messageProcessing struct {
@@ -37,9 +43,22 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 		Name:      "async_expired",
 		Help:      "Incoming async messages dropped because the message deadline expired",
 	})
+	cpuTime := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cpu_time",
+		Help:      "time (in ns) spent processing messages after being scheduled",
+	})
 	errs.Add(
 		reg.Register(expired),
 		reg.Register(asyncExpired),
+		reg.Register(cpuTime),
+	)
+	queueDelay := metric.NewAveragerWithErrs(
+		namespace,
+		"queue_delay",
+		"time (in ns) a message waited between being queued and being processed",
+		reg,
+		&errs,
 	)
 
 	messages := make(map[message.Op]*messageProcessing, len(message.ConsensusOps))
@@ -68,5 +87,7 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 		expired:      expired,
 		asyncExpired: asyncExpired,
 		messages:     messages,
+		cpuTime:      cpuTime,
+		queueDelay:   queueDelay,
 	}, errs.Err
 }
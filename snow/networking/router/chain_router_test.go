@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -97,6 +98,7 @@ func TestShutdown(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(chainCtx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -233,6 +235,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -388,6 +391,7 @@ func TestRouterTimeout(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -857,6 +861,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -1147,6 +1152,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		sb,
 		commontracker.NewPeers(),
@@ -1297,6 +1303,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(requester.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -1316,6 +1323,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(responder.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -1563,6 +1571,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		sb,
 		commontracker.NewPeers(),
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	numChains     prometheus.Gauge
+	numProcessing prometheus.Gauge
+	numWaiting    prometheus.Gauge
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		numChains: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "chains",
+			Help:      "Number of chains registered with the scheduler",
+		}),
+		numProcessing: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "processing",
+			Help:      "Number of messages currently being processed across all chains",
+		}),
+		numWaiting: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "waiting",
+			Help:      "Number of chains currently waiting for a processing slot",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.numChains),
+		reg.Register(m.numProcessing),
+		reg.Register(m.numWaiting),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	// NoOp is a Scheduler that never limits message processing.
+	NoOp Scheduler = noOpScheduler{}
+	// NoOpChain is a ChainScheduler that never limits message processing.
+	NoOpChain ChainScheduler = noOpChainScheduler{}
+)
+
+type noOpScheduler struct{}
+
+func (noOpScheduler) Register(ids.ID, ids.ID, uint64, uint64) (ChainScheduler, error) {
+	return NoOpChain, nil
+}
+
+type noOpChainScheduler struct{}
+
+func (noOpChainScheduler) Acquire(context.Context) error {
+	return nil
+}
+
+func (noOpChainScheduler) Release() {}
+
+func (noOpChainScheduler) Deregister() {}
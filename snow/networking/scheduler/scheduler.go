@@ -0,0 +1,358 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// DefaultShare is the share used for a subnet or chain that doesn't specify
+// one.
+const DefaultShare = 1
+
+var (
+	_ Scheduler      = (*scheduler)(nil)
+	_ ChainScheduler = (*chainScheduler)(nil)
+
+	errDuplicateChain           = errors.New("duplicate chain")
+	errInvalidMaxProcessing     = errors.New("max processing must not be negative")
+	errInvalidPrimaryNetworkMin = errors.New("primary network min share must be in [0, 1]")
+	errInvalidUsageHalflife     = errors.New("usage halflife must be positive")
+)
+
+type Config struct {
+	// MaxProcessing is the number of messages, across all chains, that may be
+	// processed concurrently. Once this many messages are being processed,
+	// chains wait for a slot and slots are handed out according to each
+	// chain's share. If 0, message processing isn't limited and the
+	// scheduler is disabled.
+	MaxProcessing int `json:"maxProcessing"`
+
+	// PrimaryNetworkMinShare is the minimum portion, in [0, 1], of the
+	// processing slots that is reserved for the primary network's chains when
+	// they are contending with other subnets.
+	PrimaryNetworkMinShare float64 `json:"primaryNetworkMinShare"`
+
+	// UsageHalflife is the halflife of the per-chain processing usage meters.
+	// Larger halflife --> past usage is taken into account for longer.
+	UsageHalflife time.Duration `json:"usageHalflife"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.MaxProcessing < 0:
+		return fmt.Errorf("%w: %d", errInvalidMaxProcessing, c.MaxProcessing)
+	case c.PrimaryNetworkMinShare < 0 || c.PrimaryNetworkMinShare > 1:
+		return fmt.Errorf("%w: %f", errInvalidPrimaryNetworkMin, c.PrimaryNetworkMinShare)
+	case c.UsageHalflife <= 0:
+		return fmt.Errorf("%w: %s", errInvalidUsageHalflife, c.UsageHalflife)
+	default:
+		return nil
+	}
+}
+
+// Scheduler divides the node's message processing capacity between chains.
+//
+// Each subnet is assigned a share of the capacity, which is further divided
+// between the subnet's chains by their own shares. When more chains want to
+// process a message than there are processing slots, the next slot is given
+// to the waiting chain with the lowest recent usage relative to its share.
+type Scheduler interface {
+	// Register [chainID], which is validated by [subnetID], with the provided
+	// shares. A share of 0 is treated as [DefaultShare].
+	//
+	// If [subnetID] was already registered, [subnetShare] replaces the
+	// subnet's previous share.
+	Register(chainID, subnetID ids.ID, subnetShare, chainShare uint64) (ChainScheduler, error)
+}
+
+// ChainScheduler schedules the message processing of a single chain.
+type ChainScheduler interface {
+	// Acquire blocks until the chain is allowed to process a message or
+	// [ctx] is done. If nil is returned, Release must be called once the
+	// message has been processed.
+	Acquire(ctx context.Context) error
+
+	// Release marks that the chain finished processing a message.
+	Release()
+
+	// Deregister removes the chain from the scheduler. Acquire must not be
+	// called after Deregister.
+	Deregister()
+}
+
+type subnet struct {
+	share uint64
+	// Chain ID --> Chain
+	chains map[ids.ID]*chainScheduler
+	// Sum of the shares of [chains]
+	totalChainShares uint64
+}
+
+type waiter struct {
+	chain *chainScheduler
+	ready chan struct{}
+}
+
+type scheduler struct {
+	// Useful for faking time in tests
+	clock   mockable.Clock
+	metrics *metrics
+	config  Config
+	factory meter.Factory
+
+	lock sync.Mutex
+	// Subnet ID --> Subnet
+	subnets map[ids.ID]*subnet
+	// Sum of the shares of [subnets]
+	totalSubnetShares uint64
+	numProcessing     int
+	// Chains waiting for a processing slot, in the order they started waiting
+	waiting []*waiter
+}
+
+func New(
+	config Config,
+	factory meter.Factory,
+	namespace string,
+	reg prometheus.Registerer,
+) (Scheduler, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if config.MaxProcessing == 0 {
+		return NoOp, nil
+	}
+	metrics, err := newMetrics(namespace, reg)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduler{
+		metrics: metrics,
+		config:  config,
+		factory: factory,
+		subnets: make(map[ids.ID]*subnet),
+	}, nil
+}
+
+func (s *scheduler) Register(chainID, subnetID ids.ID, subnetShare, chainShare uint64) (ChainScheduler, error) {
+	if subnetShare == 0 {
+		subnetShare = DefaultShare
+	}
+	if chainShare == 0 {
+		chainShare = DefaultShare
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sb, ok := s.subnets[subnetID]
+	if !ok {
+		sb = &subnet{
+			chains: make(map[ids.ID]*chainScheduler),
+		}
+		s.subnets[subnetID] = sb
+	}
+	if _, ok := sb.chains[chainID]; ok {
+		return nil, fmt.Errorf("%w: %s", errDuplicateChain, chainID)
+	}
+
+	s.totalSubnetShares -= sb.share
+	sb.share = subnetShare
+	s.totalSubnetShares += sb.share
+
+	c := &chainScheduler{
+		s:        s,
+		chainID:  chainID,
+		subnetID: subnetID,
+		share:    chainShare,
+		usage:    s.factory.New(s.config.UsageHalflife),
+	}
+	sb.chains[chainID] = c
+	sb.totalChainShares += chainShare
+	s.metrics.numChains.Inc()
+	return c, nil
+}
+
+// portion returns the portion, in (0, 1], of the processing capacity that is
+// allocated to [c].
+//
+// Assumes [s.lock] is held.
+func (s *scheduler) portion(c *chainScheduler) float64 {
+	sb := s.subnets[c.subnetID]
+	subnetPortion := float64(sb.share) / float64(s.totalSubnetShares)
+
+	primaryNetwork, ok := s.subnets[constants.PrimaryNetworkID]
+	if ok && len(s.subnets) > 1 {
+		primaryPortion := float64(primaryNetwork.share) / float64(s.totalSubnetShares)
+		if primaryPortion < s.config.PrimaryNetworkMinShare {
+			// Scale the portions so that the primary network is given its
+			// reserved minimum and the remaining subnets keep their relative
+			// portions.
+			if c.subnetID == constants.PrimaryNetworkID {
+				subnetPortion = s.config.PrimaryNetworkMinShare
+			} else {
+				subnetPortion *= (1 - s.config.PrimaryNetworkMinShare) / (1 - primaryPortion)
+			}
+		}
+	}
+	return subnetPortion * float64(c.share) / float64(sb.totalChainShares)
+}
+
+// priority returns the usage of [c] relative to its portion of the processing
+// capacity. Lower values are scheduled first.
+//
+// Assumes [s.lock] is held.
+func (s *scheduler) priority(c *chainScheduler, now time.Time) float64 {
+	portion := s.portion(c)
+	if portion <= 0 {
+		return math.Inf(1)
+	}
+	return c.usage.Read(now) / portion
+}
+
+// schedule hands out free processing slots to the waiting chains with the
+// lowest usage relative to their portion of the capacity.
+//
+// Assumes [s.lock] is held.
+func (s *scheduler) schedule(now time.Time) {
+	for s.numProcessing < s.config.MaxProcessing && len(s.waiting) > 0 {
+		var (
+			next         = 0
+			nextPriority float64
+		)
+		for i, w := range s.waiting {
+			priority := s.priority(w.chain, now)
+			if i == 0 || priority < nextPriority {
+				next = i
+				nextPriority = priority
+			}
+		}
+
+		w := s.waiting[next]
+		s.waiting = append(s.waiting[:next], s.waiting[next+1:]...)
+		s.start(w.chain, now)
+		close(w.ready)
+	}
+	s.metrics.numWaiting.Set(float64(len(s.waiting)))
+}
+
+// start marks that [c] was given a processing slot.
+//
+// Assumes [s.lock] is held.
+func (s *scheduler) start(c *chainScheduler, now time.Time) {
+	s.numProcessing++
+	c.usage.Inc(now, 1)
+	s.metrics.numProcessing.Set(float64(s.numProcessing))
+}
+
+// remove [w] from the waiting chains. Returns false if [w] was already given
+// a processing slot.
+//
+// Assumes [s.lock] is held.
+func (s *scheduler) remove(w *waiter) bool {
+	for i, other := range s.waiting {
+		if other == w {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			s.metrics.numWaiting.Set(float64(len(s.waiting)))
+			return true
+		}
+	}
+	return false
+}
+
+type chainScheduler struct {
+	s        *scheduler
+	chainID  ids.ID
+	subnetID ids.ID
+	share    uint64
+	// Tracks the number of messages of this chain being processed
+	usage meter.Meter
+}
+
+func (c *chainScheduler) Acquire(ctx context.Context) error {
+	s := c.s
+	s.lock.Lock()
+	now := s.clock.Time()
+	if s.numProcessing < s.config.MaxProcessing && len(s.waiting) == 0 {
+		s.start(c, now)
+		s.lock.Unlock()
+		return nil
+	}
+
+	w := &waiter{
+		chain: c,
+		ready: make(chan struct{}),
+	}
+	s.waiting = append(s.waiting, w)
+	s.metrics.numWaiting.Set(float64(len(s.waiting)))
+	s.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.remove(w) {
+		// The slot was handed out concurrently with [ctx] being cancelled, so
+		// it must be handed back.
+		c.release(s.clock.Time())
+	}
+	return ctx.Err()
+}
+
+func (c *chainScheduler) Release() {
+	s := c.s
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c.release(s.clock.Time())
+}
+
+// Assumes [c.s.lock] is held.
+func (c *chainScheduler) release(now time.Time) {
+	s := c.s
+	s.numProcessing--
+	c.usage.Dec(now, 1)
+	s.metrics.numProcessing.Set(float64(s.numProcessing))
+	s.schedule(now)
+}
+
+func (c *chainScheduler) Deregister() {
+	s := c.s
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sb, ok := s.subnets[c.subnetID]
+	if !ok {
+		return
+	}
+	if _, ok := sb.chains[c.chainID]; !ok {
+		return
+	}
+
+	delete(sb.chains, c.chainID)
+	sb.totalChainShares -= c.share
+	if len(sb.chains) == 0 {
+		delete(s.subnets, c.subnetID)
+		s.totalSubnetShares -= sb.share
+	}
+	s.metrics.numChains.Dec()
+}
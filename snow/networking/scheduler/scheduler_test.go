@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+)
+
+func newTestScheduler(t *testing.T, config Config) *scheduler {
+	s, err := New(config, meter.ContinuousFactory{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	return s.(*scheduler)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: Config{
+				MaxProcessing:          1,
+				PrimaryNetworkMinShare: .5,
+				UsageHalflife:          time.Second,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "disabled",
+			config: Config{
+				MaxProcessing:          0,
+				PrimaryNetworkMinShare: .5,
+				UsageHalflife:          time.Second,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative max processing",
+			config: Config{
+				MaxProcessing:          -1,
+				PrimaryNetworkMinShare: .5,
+				UsageHalflife:          time.Second,
+			},
+			expectedErr: errInvalidMaxProcessing,
+		},
+		{
+			name: "primary network min share too large",
+			config: Config{
+				MaxProcessing:          1,
+				PrimaryNetworkMinShare: 1.5,
+				UsageHalflife:          time.Second,
+			},
+			expectedErr: errInvalidPrimaryNetworkMin,
+		},
+		{
+			name: "zero usage halflife",
+			config: Config{
+				MaxProcessing:          1,
+				PrimaryNetworkMinShare: .5,
+				UsageHalflife:          0,
+			},
+			expectedErr: errInvalidUsageHalflife,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewDisabled(t *testing.T) {
+	s, err := New(Config{
+		MaxProcessing:          0,
+		PrimaryNetworkMinShare: .5,
+		UsageHalflife:          time.Second,
+	}, meter.ContinuousFactory{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.Equal(t, NoOp, s)
+}
+
+func TestSchedulerPortion(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestScheduler(t, Config{
+		MaxProcessing:          1,
+		PrimaryNetworkMinShare: .5,
+		UsageHalflife:          time.Second,
+	})
+
+	pChain, err := s.Register(ids.GenerateTestID(), constants.PrimaryNetworkID, 1, 1)
+	require.NoError(err)
+	xChain, err := s.Register(ids.GenerateTestID(), constants.PrimaryNetworkID, 1, 3)
+	require.NoError(err)
+
+	// The primary network is the only subnet, so it gets everything.
+	require.InDelta(.25, s.portion(pChain.(*chainScheduler)), 1e-9)
+	require.InDelta(.75, s.portion(xChain.(*chainScheduler)), 1e-9)
+
+	subnetID := ids.GenerateTestID()
+	subnetChain, err := s.Register(ids.GenerateTestID(), subnetID, 9, 0)
+	require.NoError(err)
+	otherSubnetChain, err := s.Register(ids.GenerateTestID(), ids.GenerateTestID(), 6, 0)
+	require.NoError(err)
+
+	// The primary network's configured portion is 1/16, which is raised to
+	// the reserved minimum. The other subnets split the remainder by share.
+	require.InDelta(.125, s.portion(pChain.(*chainScheduler)), 1e-9)
+	require.InDelta(.375, s.portion(xChain.(*chainScheduler)), 1e-9)
+	require.InDelta(.3, s.portion(subnetChain.(*chainScheduler)), 1e-9)
+	require.InDelta(.2, s.portion(otherSubnetChain.(*chainScheduler)), 1e-9)
+
+	subnetChain.Deregister()
+	otherSubnetChain.Deregister()
+	require.InDelta(.25, s.portion(pChain.(*chainScheduler)), 1e-9)
+	require.Len(s.subnets, 1)
+}
+
+func TestSchedulerDuplicateChain(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestScheduler(t, Config{
+		MaxProcessing:          1,
+		PrimaryNetworkMinShare: .5,
+		UsageHalflife:          time.Second,
+	})
+
+	chainID := ids.GenerateTestID()
+	_, err := s.Register(chainID, constants.PrimaryNetworkID, 1, 1)
+	require.NoError(err)
+	_, err = s.Register(chainID, constants.PrimaryNetworkID, 1, 1)
+	require.ErrorIs(err, errDuplicateChain)
+}
+
+func TestSchedulerPrefersLowerRelativeUsage(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestScheduler(t, Config{
+		MaxProcessing:          1,
+		PrimaryNetworkMinShare: .5,
+		UsageHalflife:          time.Second,
+	})
+	now := time.Now()
+	s.clock.Set(now)
+
+	busyChain, err := s.Register(ids.GenerateTestID(), ids.GenerateTestID(), 1, 1)
+	require.NoError(err)
+	pChain, err := s.Register(ids.GenerateTestID(), constants.PrimaryNetworkID, 1, 1)
+	require.NoError(err)
+
+	// The busy chain uses the only slot for a while.
+	require.NoError(busyChain.Acquire(context.Background()))
+	now = now.Add(time.Second)
+	s.clock.Set(now)
+
+	busyAcquired := make(chan struct{})
+	go func() {
+		require.NoError(busyChain.Acquire(context.Background()))
+		close(busyAcquired)
+	}()
+	require.Eventually(func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return len(s.waiting) == 1
+	}, time.Second, time.Millisecond)
+
+	pAcquired := make(chan struct{})
+	go func() {
+		require.NoError(pChain.Acquire(context.Background()))
+		close(pAcquired)
+	}()
+	require.Eventually(func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return len(s.waiting) == 2
+	}, time.Second, time.Millisecond)
+
+	// Even though the busy chain started waiting first, the idle chain is
+	// scheduled next.
+	busyChain.Release()
+	<-pAcquired
+	select {
+	case <-busyAcquired:
+		require.FailNow("busy chain was scheduled concurrently")
+	default:
+	}
+
+	pChain.Release()
+	<-busyAcquired
+	busyChain.Release()
+	require.Zero(s.numProcessing)
+}
+
+func TestSchedulerAcquireCancelled(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestScheduler(t, Config{
+		MaxProcessing:          1,
+		PrimaryNetworkMinShare: .5,
+		UsageHalflife:          time.Second,
+	})
+
+	chain, err := s.Register(ids.GenerateTestID(), constants.PrimaryNetworkID, 1, 1)
+	require.NoError(err)
+	require.NoError(chain.Acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = chain.Acquire(ctx)
+	require.ErrorIs(err, context.Canceled)
+	require.Empty(s.waiting)
+
+	chain.Release()
+	require.Zero(s.numProcessing)
+	require.NoError(chain.Acquire(context.Background()))
+	chain.Release()
+}
@@ -1529,6 +1529,53 @@ func (s *sender) SendAppGossip(_ context.Context, appGossipBytes []byte) error {
 	return nil
 }
 
+// SendAnnouncement gossips the ID of the provided container so that peers can
+// fetch it before they are queried about it.
+func (s *sender) SendAnnouncement(_ context.Context, containerID ids.ID) {
+	gossipConfig := s.subnet.Config().GossipConfig
+	if gossipConfig.BlockAnnouncementValidatorSize == 0 &&
+		gossipConfig.BlockAnnouncementNonValidatorSize == 0 &&
+		gossipConfig.BlockAnnouncementPeerSize == 0 {
+		return
+	}
+
+	// Create the outbound message.
+	deadline := s.timeouts.TimeoutDuration()
+	outMsg, err := s.msgCreator.PullQuery(
+		s.ctx.ChainID,
+		constants.GossipMsgRequestID,
+		deadline,
+		containerID,
+		s.engineType,
+	)
+	if err != nil {
+		s.ctx.Log.Error("failed to build message",
+			zap.Stringer("messageOp", message.PullQueryOp),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Duration("deadline", deadline),
+			zap.Stringer("containerID", containerID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	sentTo := s.sender.Gossip(
+		outMsg,
+		s.ctx.SubnetID,
+		int(gossipConfig.BlockAnnouncementValidatorSize),
+		int(gossipConfig.BlockAnnouncementNonValidatorSize),
+		int(gossipConfig.BlockAnnouncementPeerSize),
+		s.subnet,
+	)
+	if sentTo.Len() == 0 {
+		s.ctx.Log.Debug("failed to send message",
+			zap.Stringer("messageOp", message.PullQueryOp),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Stringer("containerID", containerID),
+		)
+	}
+}
+
 // SendGossip gossips the provided container
 func (s *sender) SendGossip(_ context.Context, container []byte) {
 	// Create the outbound message.
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/scheduler"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -126,6 +127,7 @@ func TestTimeout(t *testing.T) {
 		time.Hour,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -397,6 +399,7 @@ func TestReliableMessages(t *testing.T) {
 		1,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -545,6 +548,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		time.Second,
 		testThreadPoolSize,
 		resourceTracker,
+		scheduler.NoOpChain,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -1370,3 +1374,94 @@ func TestSender_Single_Request(t *testing.T) {
 		})
 	}
 }
+
+func TestSender_Announcement(t *testing.T) {
+	var (
+		chainID     = ids.GenerateTestID()
+		subnetID    = ids.GenerateTestID()
+		deadline    = time.Second
+		ctx         = snow.DefaultContextTest()
+		containerID = ids.GenerateTestID()
+		engineType  = p2p.EngineType_ENGINE_TYPE_SNOWMAN
+	)
+	ctx.ChainID = chainID
+	ctx.SubnetID = subnetID
+	snowCtx := &snow.ConsensusContext{
+		Context:             ctx,
+		Registerer:          prometheus.NewRegistry(),
+		AvalancheRegisterer: prometheus.NewRegistry(),
+	}
+
+	tests := []struct {
+		name         string
+		gossipConfig subnets.GossipConfig
+		expectSend   bool
+	}{
+		{
+			name:         "disabled",
+			gossipConfig: defaultSubnetConfig.GossipConfig,
+			expectSend:   false,
+		},
+		{
+			name: "enabled",
+			gossipConfig: subnets.GossipConfig{
+				BlockAnnouncementValidatorSize:    1,
+				BlockAnnouncementNonValidatorSize: 2,
+				BlockAnnouncementPeerSize:         3,
+			},
+			expectSend: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				msgCreator     = message.NewMockOutboundMsgBuilder(ctrl)
+				externalSender = NewMockExternalSender(ctrl)
+				timeoutManager = timeout.NewMockManager(ctrl)
+				router         = router.NewMockRouter(ctrl)
+				subnet         = subnets.New(ctx.NodeID, subnets.Config{
+					GossipConfig: tt.gossipConfig,
+				})
+			)
+			snowCtx.Registerer = prometheus.NewRegistry()
+
+			sender, err := New(
+				snowCtx,
+				msgCreator,
+				externalSender,
+				router,
+				timeoutManager,
+				engineType,
+				subnet,
+			)
+			require.NoError(err)
+
+			if tt.expectSend {
+				timeoutManager.EXPECT().TimeoutDuration().Return(deadline)
+				msgCreator.EXPECT().PullQuery(
+					chainID,
+					constants.GossipMsgRequestID,
+					deadline,
+					containerID,
+					engineType,
+				).Return(nil, nil)
+				externalSender.EXPECT().Gossip(
+					gomock.Any(), // Outbound message
+					subnetID,
+					int(tt.gossipConfig.BlockAnnouncementValidatorSize),
+					int(tt.gossipConfig.BlockAnnouncementNonValidatorSize),
+					int(tt.gossipConfig.BlockAnnouncementPeerSize),
+					subnet,
+				).Return(set.Set[ids.NodeID]{
+					ids.GenerateTestNodeID(): struct{}{},
+				})
+			}
+
+			sender.SendAnnouncement(context.Background(), containerID)
+		})
+	}
+}
@@ -250,6 +250,15 @@ func (s *tracedSender) SendAppGossip(ctx context.Context, appGossipBytes []byte)
 	return s.sender.SendAppGossip(ctx, appGossipBytes)
 }
 
+func (s *tracedSender) SendAnnouncement(ctx context.Context, containerID ids.ID) {
+	_, span := s.tracer.Start(ctx, "tracedSender.SendAnnouncement", oteltrace.WithAttributes(
+		attribute.Stringer("containerID", containerID),
+	))
+	defer span.End()
+
+	s.sender.SendAnnouncement(ctx, containerID)
+}
+
 func (s *tracedSender) SendGossip(ctx context.Context, container []byte) {
 	_, span := s.tracer.Start(ctx, "tracedSender.SendGossip", oteltrace.WithAttributes(
 		attribute.Int("containerLen", len(container)),
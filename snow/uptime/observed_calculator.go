@@ -0,0 +1,15 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// ObservedCalculator reports the uptime of this node as observed by the other
+// validators of a subnet.
+type ObservedCalculator interface {
+	// ObservedUptimePercent returns the stake weighted average of the uptimes
+	// of this node reported by the validators of [subnetID], over the current
+	// staking period of this node. The result is in the range [0, 1].
+	ObservedUptimePercent(subnetID ids.ID) (float64, error)
+}
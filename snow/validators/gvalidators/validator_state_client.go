@@ -23,12 +23,23 @@ var (
 
 type Client struct {
 	client pb.ValidatorStateClient
+	// true if the server sends compressed public keys
+	compressedPublicKeys bool
 }
 
 func NewClient(client pb.ValidatorStateClient) *Client {
 	return &Client{client: client}
 }
 
+// NewCompressedClient returns a client for a server created with
+// NewCompressedServer.
+func NewCompressedClient(client pb.ValidatorStateClient) *Client {
+	return &Client{
+		client:               client,
+		compressedPublicKeys: true,
+	}
+}
+
 func (c *Client) GetMinimumHeight(ctx context.Context) (uint64, error) {
 	resp, err := c.client.GetMinimumHeight(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -75,7 +86,14 @@ func (c *Client) GetValidatorSet(
 			return nil, err
 		}
 		var publicKey *bls.PublicKey
-		if len(validator.PublicKey) > 0 {
+		switch {
+		case len(validator.PublicKey) == 0:
+		case c.compressedPublicKeys:
+			publicKey, err = bls.PublicKeyFromBytes(validator.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+		default:
 			// This is a performance optimization to avoid the cost of compression
 			// and key re-verification with PublicKeyFromBytes. We can safely
 			// assume that the BLS Public Keys are verified before being added
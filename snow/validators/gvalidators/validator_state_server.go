@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 
 	pb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
 )
@@ -19,12 +20,24 @@ var _ pb.ValidatorStateServer = (*Server)(nil)
 type Server struct {
 	pb.UnsafeValidatorStateServer
 	state validators.State
+	// true if public keys should be sent compressed
+	compressedPublicKeys bool
 }
 
 func NewServer(state validators.State) *Server {
 	return &Server{state: state}
 }
 
+// NewCompressedServer returns a server that sends compressed public keys. This
+// is more expensive than sending uncompressed public keys, but is required by
+// clients that predate uncompressed public keys.
+func NewCompressedServer(state validators.State) *Server {
+	return &Server{
+		state:                state,
+		compressedPublicKeys: true,
+	}
+}
+
 func (s *Server) GetMinimumHeight(ctx context.Context, _ *emptypb.Empty) (*pb.GetMinimumHeightResponse, error) {
 	height, err := s.state.GetMinimumHeight(ctx)
 	return &pb.GetMinimumHeightResponse{Height: height}, err
@@ -68,7 +81,11 @@ func (s *Server) GetValidatorSet(ctx context.Context, req *pb.GetValidatorSetReq
 			NodeId: vdr.NodeID[:],
 			Weight: vdr.Weight,
 		}
-		if vdr.PublicKey != nil {
+		switch {
+		case vdr.PublicKey == nil:
+		case s.compressedPublicKeys:
+			vdrPB.PublicKey = bls.PublicKeyToBytes(vdr.PublicKey)
+		default:
 			// This is a performance optimization to avoid the cost of compression
 			// from PublicKeyToBytes.
 			vdrPB.PublicKey = vdr.PublicKey.Serialize()
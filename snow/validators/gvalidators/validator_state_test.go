@@ -32,6 +32,17 @@ type testState struct {
 func setupState(t testing.TB, ctrl *gomock.Controller) *testState {
 	t.Helper()
 
+	return setupStateWith(t, ctrl, NewServer, NewClient)
+}
+
+func setupStateWith(
+	t testing.TB,
+	ctrl *gomock.Controller,
+	newServer func(validators.State) *Server,
+	newClient func(pb.ValidatorStateClient) *Client,
+) *testState {
+	t.Helper()
+
 	state := &testState{
 		server: validators.NewMockState(ctrl),
 	}
@@ -43,7 +54,7 @@ func setupState(t testing.TB, ctrl *gomock.Controller) *testState {
 	serverCloser := grpcutils.ServerCloser{}
 
 	server := grpcutils.NewServer()
-	pb.RegisterValidatorStateServer(server, NewServer(state.server))
+	pb.RegisterValidatorStateServer(server, newServer(state.server))
 	serverCloser.Add(server)
 
 	go grpcutils.Serve(listener, server)
@@ -53,7 +64,7 @@ func setupState(t testing.TB, ctrl *gomock.Controller) *testState {
 		t.Fatalf("Failed to dial: %s", err)
 	}
 
-	state.client = NewClient(pb.NewValidatorStateClient(conn))
+	state.client = newClient(pb.NewValidatorStateClient(conn))
 	state.closeFn = func() {
 		serverCloser.Stop()
 		_ = conn.Close()
@@ -187,6 +198,40 @@ func TestGetValidatorSet(t *testing.T) {
 	require.Error(err) //nolint:forbidigo // currently returns grpc error
 }
 
+func TestGetValidatorSetCompressed(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	state := setupStateWith(t, ctrl, NewCompressedServer, NewCompressedClient)
+	defer state.closeFn()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	vdr0 := &validators.GetValidatorOutput{
+		NodeID:    ids.GenerateTestNodeID(),
+		PublicKey: bls.PublicFromSecretKey(sk),
+		Weight:    1,
+	}
+	vdr1 := &validators.GetValidatorOutput{
+		NodeID:    ids.GenerateTestNodeID(),
+		PublicKey: nil,
+		Weight:    2,
+	}
+
+	expectedVdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		vdr0.NodeID: vdr0,
+		vdr1.NodeID: vdr1,
+	}
+	height := uint64(1337)
+	subnetID := ids.GenerateTestID()
+	state.server.EXPECT().GetValidatorSet(gomock.Any(), height, subnetID).Return(expectedVdrs, nil)
+
+	vdrs, err := state.client.GetValidatorSet(context.Background(), height, subnetID)
+	require.NoError(err)
+	require.Equal(expectedVdrs, vdrs)
+}
+
 func TestPublicKeyDeserialize(t *testing.T) {
 	require := require.New(t)
 
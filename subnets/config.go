@@ -25,6 +25,12 @@ type GossipConfig struct {
 	AppGossipValidatorSize           uint `json:"appGossipValidatorSize" yaml:"appGossipValidatorSize"`
 	AppGossipNonValidatorSize        uint `json:"appGossipNonValidatorSize" yaml:"appGossipNonValidatorSize"`
 	AppGossipPeerSize                uint `json:"appGossipPeerSize" yaml:"appGossipPeerSize"`
+	// Block announcements notify peers of newly preferred blocks so that they
+	// can fetch them before being queried. Announcements are disabled if all
+	// of the sizes are 0.
+	BlockAnnouncementValidatorSize    uint `json:"gossipBlockAnnouncementValidatorSize" yaml:"gossipBlockAnnouncementValidatorSize"`
+	BlockAnnouncementNonValidatorSize uint `json:"gossipBlockAnnouncementNonValidatorSize" yaml:"gossipBlockAnnouncementNonValidatorSize"`
+	BlockAnnouncementPeerSize         uint `json:"gossipBlockAnnouncementPeerSize" yaml:"gossipBlockAnnouncementPeerSize"`
 }
 
 type Config struct {
@@ -44,6 +50,15 @@ type Config struct {
 	// building a snowman++ block.
 	// TODO: Remove this flag once all VMs throttle their own block production.
 	ProposerMinBlockDelay time.Duration `json:"proposerMinBlockDelay" yaml:"proposerMinBlockDelay"`
+
+	// CPUShare is this Subnet's share of the node's message processing,
+	// relative to the shares of the other Subnets this node is running. 0 is
+	// treated as the default share of 1.
+	CPUShare uint64 `json:"cpuShare" yaml:"cpuShare"`
+	// ChainCPUShares divides this Subnet's share of the node's message
+	// processing between its chains. Chains that aren't specified are given
+	// the default share of 1.
+	ChainCPUShares map[ids.ID]uint64 `json:"chainCPUShares" yaml:"chainCPUShares"`
 }
 
 func (c *Config) Valid() error {
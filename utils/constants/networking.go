@@ -76,6 +76,8 @@ const (
 	DefaultAcceptedFrontierGossipFrequency                 = 10 * time.Second
 	DefaultConsensusAppConcurrency                         = 2
 	DefaultConsensusShutdownTimeout                        = time.Minute
+	DefaultConsensusCPUSchedulerPrimaryNetworkMinShare     = .5
+	DefaultConsensusCPUSchedulerUsageHalflife              = 15 * time.Second
 	DefaultConsensusGossipAcceptedFrontierValidatorSize    = 0
 	DefaultConsensusGossipAcceptedFrontierNonValidatorSize = 0
 	DefaultConsensusGossipAcceptedFrontierPeerSize         = 15
@@ -85,6 +87,9 @@ const (
 	DefaultAppGossipValidatorSize                          = 10
 	DefaultAppGossipNonValidatorSize                       = 0
 	DefaultAppGossipPeerSize                               = 0
+	DefaultConsensusBlockAnnouncementValidatorSize         = 0
+	DefaultConsensusBlockAnnouncementNonValidatorSize      = 0
+	DefaultConsensusBlockAnnouncementPeerSize              = 0
 
 	// Inbound Throttling
 	DefaultInboundThrottlerAtLargeAllocSize         = 6 * units.MiB
@@ -41,6 +41,20 @@ func PublicKeyFromBytes(pkBytes []byte) (*PublicKey, error) {
 	return pk, nil
 }
 
+// PublicKeyToUncompressedBytes returns the uncompressed big-endian format of
+// the public key.
+func PublicKeyToUncompressedBytes(pk *PublicKey) []byte {
+	return pk.Serialize()
+}
+
+// PublicKeyFromValidUncompressedBytes parses the uncompressed big-endian format
+// of the public key into a public key. It is assumed that the provided bytes
+// are valid, so the expensive validation of the key is skipped. Returns nil if
+// the bytes aren't an uncompressed public key.
+func PublicKeyFromValidUncompressedBytes(pkBytes []byte) *PublicKey {
+	return new(PublicKey).Deserialize(pkBytes)
+}
+
 // AggregatePublicKeys aggregates a non-zero number of public keys into a single
 // aggregated public key.
 // Invariant: all [pks] have been validated.
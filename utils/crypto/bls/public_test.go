@@ -36,6 +36,21 @@ func TestPublicKeyBytes(t *testing.T) {
 	require.Equal(pkBytes, pk2Bytes)
 }
 
+func TestPublicKeyUncompressedBytes(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSecretKey()
+	require.NoError(err)
+
+	pk := PublicFromSecretKey(sk)
+	pkBytes := PublicKeyToUncompressedBytes(pk)
+
+	pk2 := PublicKeyFromValidUncompressedBytes(pkBytes)
+	require.NotNil(pk2)
+	require.Equal(pk, pk2)
+	require.Equal(pkBytes, PublicKeyToUncompressedBytes(pk2))
+}
+
 func TestAggregatePublicKeysNoop(t *testing.T) {
 	require := require.New(t)
 
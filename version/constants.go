@@ -12,9 +12,18 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
-// RPCChainVMProtocol should be bumped anytime changes are made which require
-// the plugin vm to upgrade to latest avalanchego release to be compatible.
-const RPCChainVMProtocol uint = 26
+const (
+	// RPCChainVMProtocol should be bumped anytime changes are made which
+	// require the plugin vm to upgrade to latest avalanchego release to be
+	// compatible.
+	RPCChainVMProtocol uint = 26
+
+	// RPCChainVMProtocolMin is the oldest protocol version that is still
+	// supported. Plugins and avalanchego negotiate the newest protocol version
+	// they both support, so plugins don't need to be released in lockstep with
+	// avalanchego.
+	RPCChainVMProtocolMin uint = 25
+)
 
 // These are globals that describe network upgrades and node versions
 var (
@@ -100,6 +109,12 @@ var (
 		constants.FujiID:    time.Date(2023, time.April, 6, 15, 0, 0, 0, time.UTC),
 	}
 	CortinaDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	DurangoTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	DurangoDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
 )
 
 func init() {
@@ -172,6 +187,13 @@ func GetCortinaTime(networkID uint32) time.Time {
 	return CortinaDefaultTime
 }
 
+func GetDurangoTime(networkID uint32) time.Time {
+	if upgradeTime, exists := DurangoTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DurangoDefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
@@ -16,7 +16,12 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 var (
@@ -114,3 +119,44 @@ func createTestTxs(cm codec.Manager) ([]*txs.Tx, error) {
 	}
 	return testTxs, nil
 }
+
+func TestUpgradeFxTypeIDs(t *testing.T) {
+	require := require.New(t)
+
+	baseFxs := []fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	}
+	parser, err := NewParser(baseFxs)
+	require.NoError(err)
+	upgradedParser, err := NewParser(append(baseFxs, &secp256r1fx.Fx{}, &htlcfx.Fx{}))
+	require.NoError(err)
+
+	// Appending an upgrade fx must not change the type IDs of the existing
+	// types.
+	blk := &StandardBlock{}
+	blkBytes, err := parser.Codec().Marshal(CodecVersion, blk)
+	require.NoError(err)
+	upgradedBlkBytes, err := upgradedParser.Codec().Marshal(CodecVersion, blk)
+	require.NoError(err)
+	require.Equal(blkBytes, upgradedBlkBytes)
+
+	// The types of the upgrade fx start at its type ID offset, so that they
+	// match the type IDs of the P-chain.
+	var out verify.State = &secp256r1fx.TransferOutput{}
+	outBytes, err := upgradedParser.Codec().Marshal(CodecVersion, &out)
+	require.NoError(err)
+	require.Equal(
+		[]byte{0x00, 0x00, 0x00, 0x00, 0x00, secp256r1fx.TypeIDOffset + 2},
+		outBytes[:6],
+	)
+
+	out = &htlcfx.TransferOutput{}
+	outBytes, err = upgradedParser.Codec().Marshal(CodecVersion, &out)
+	require.NoError(err)
+	require.Equal(
+		[]byte{0x00, 0x00, 0x00, 0x00, 0x00, htlcfx.TypeIDOffset + 1},
+		outBytes[:6],
+	)
+}
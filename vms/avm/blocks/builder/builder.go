@@ -86,6 +86,9 @@ func (b *builder) BuildBlock(context.Context) (snowman.Block, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Txs are verified against the timestamp of the new block, as they will be
+	// when the block is verified.
+	stateDiff.SetTimestamp(nextTimestamp)
 
 	var (
 		blockTxs      []*txs.Tx
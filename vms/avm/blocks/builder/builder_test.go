@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blkexecutor "github.com/ava-labs/avalanchego/vms/avm/blocks/executor"
@@ -483,6 +484,73 @@ func TestBuilderBuildBlock(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "htlc claim times out at block timestamp",
+			builderFunc: func(ctrl *gomock.Controller) Builder {
+				preferredID := ids.GenerateTestID()
+				preferredHeight := uint64(1337)
+				now := time.Now()
+				preferredTimestamp := now.Add(-2 * time.Second)
+				preferredBlock := blocks.NewMockBlock(ctrl)
+				preferredBlock.EXPECT().Height().Return(preferredHeight)
+				preferredBlock.EXPECT().Timestamp().Return(preferredTimestamp)
+
+				clock := &mockable.Clock{}
+				clock.Set(now)
+
+				preferredState := states.NewMockChain(ctrl)
+				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
+				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+
+				manager := blkexecutor.NewMockManager(ctrl)
+				manager.EXPECT().Preferred().Return(preferredID)
+				manager.EXPECT().GetStatelessBlock(preferredID).Return(preferredBlock, nil)
+				manager.EXPECT().GetState(preferredID).Return(preferredState, true)
+
+				// The claim is still valid at the preferred timestamp, but not
+				// at the timestamp of the new block.
+				utxo := &htlcfx.TransferOutput{
+					Timeout: uint64(now.Unix()),
+				}
+				in := &htlcfx.TransferInput{
+					Preimage: []byte{1},
+				}
+				unsignedTx := txs.NewMockUnsignedTx(ctrl)
+				unsignedTx.EXPECT().Visit(gomock.Any()).DoAndReturn( // Fail semantic verification
+					func(visitor txs.Visitor) error {
+						require.IsType(t, &txexecutor.SemanticVerifier{}, visitor)
+						verifier := visitor.(*txexecutor.SemanticVerifier)
+						chainTime := uint64(verifier.State.GetTimestamp().Unix())
+						return htlcfx.VerifyTimeout(in, utxo, chainTime)
+					},
+				)
+				tx := &txs.Tx{Unsigned: unsignedTx}
+
+				mempool := mempool.NewMockMempool(ctrl)
+				mempool.EXPECT().Peek(gomock.Any()).Return(tx)
+				mempool.EXPECT().Remove([]*txs.Tx{tx})
+				mempool.EXPECT().MarkDropped(tx.ID(), gomock.Any()).Do(
+					func(_ ids.ID, err error) {
+						require.ErrorIs(t, err, htlcfx.ErrExpired)
+					},
+				)
+				// Second loop iteration
+				mempool.EXPECT().Peek(gomock.Any()).Return(nil)
+				mempool.EXPECT().RequestBuildBlock()
+
+				return New(
+					&txexecutor.Backend{
+						Ctx: &snow.Context{
+							Log: logging.NoLog{},
+						},
+					},
+					manager,
+					clock,
+					mempool,
+				)
+			},
+			expectedErr: ErrNoTransactions, // The only tx was expired
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,7 +10,6 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
-	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 )
@@ -35,21 +34,12 @@ type parser struct {
 }
 
 func NewParser(fxs []fxs.Fx) (Parser, error) {
-	p, err := txs.NewParser(fxs)
-	if err != nil {
-		return nil, err
-	}
-	c := p.CodecRegistry()
-	gc := p.GenesisCodecRegistry()
-
-	errs := wrappers.Errs{}
-	errs.Add(
-		c.RegisterType(&StandardBlock{}),
-		gc.RegisterType(&StandardBlock{}),
+	return NewCustomParser(
+		make(map[reflect.Type]int),
+		&mockable.Clock{},
+		logging.NoLog{},
+		fxs,
 	)
-	return &parser{
-		Parser: p,
-	}, errs.Err
 }
 
 func NewCustomParser(
@@ -58,21 +48,23 @@ func NewCustomParser(
 	log logging.Logger,
 	fxs []fxs.Fx,
 ) (Parser, error) {
-	p, err := txs.NewCustomParser(typeToFxIndex, clock, log, fxs)
+	// The block types are registered before the types of any upgrade fxs, so
+	// that adding an upgrade fx doesn't change their type IDs.
+	p, err := txs.NewCustomParserWithTypes(
+		typeToFxIndex,
+		clock,
+		log,
+		fxs,
+		[]interface{}{
+			&StandardBlock{},
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
-	c := p.CodecRegistry()
-	gc := p.GenesisCodecRegistry()
-
-	errs := wrappers.Errs{}
-	errs.Add(
-		c.RegisterType(&StandardBlock{}),
-		gc.RegisterType(&StandardBlock{}),
-	)
 	return &parser{
 		Parser: p,
-	}, errs.Err
+	}, nil
 }
 
 func (p *parser) ParseBlock(bytes []byte) (Block, error) {
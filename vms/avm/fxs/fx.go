@@ -4,19 +4,25 @@
 package fxs
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 var (
-	_ Fx = (*secp256k1fx.Fx)(nil)
-	_ Fx = (*nftfx.Fx)(nil)
-	_ Fx = (*propertyfx.Fx)(nil)
+	_ Fx        = (*secp256k1fx.Fx)(nil)
+	_ Fx        = (*nftfx.Fx)(nil)
+	_ Fx        = (*propertyfx.Fx)(nil)
+	_ UpgradeFx = (*secp256r1fx.Fx)(nil)
+	_ UpgradeFx = (*htlcfx.Fx)(nil)
 )
 
 type ParsedFx struct {
@@ -49,6 +55,22 @@ type Fx interface {
 	VerifyOperation(tx, op, cred interface{}, utxos []interface{}) error
 }
 
+// UpgradeFx is an Fx that a network upgrade adds to chains that already exist.
+//
+// Its types are registered after all of the other types of the chain, so that
+// the type IDs of the chain's existing blocks, txs and fxs don't change. It may
+// be used with any asset, as assets created before it was added can't list it
+// in their initial states.
+type UpgradeFx interface {
+	Fx
+
+	// ActivationTime returns the time after which the fx may be used
+	ActivationTime() time.Time
+
+	// TypeIDOffset returns the codec type ID of the first type of the fx
+	TypeIDOffset() uint32
+}
+
 type FxOperation interface {
 	verify.Verifiable
 	snow.ContextInitializable
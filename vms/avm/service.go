@@ -22,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -201,6 +202,57 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSO
 	return nil
 }
 
+// MempoolTx describes a transaction in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type of the transaction, e.g. "BaseTx"
+	Type string      `json:"type"`
+	Size json.Uint64 `json:"size"`
+	// Unix time the transaction was added to the mempool
+	AddedTime json.Uint64 `json:"addedTime"`
+}
+
+// GetMempoolReply defines the GetMempool replies returned from the API
+type GetMempoolReply struct {
+	// Transactions in the mempool, ordered by the time they were added
+	Txs      []MempoolTx `json:"txs"`
+	NumTxs   json.Uint64 `json:"numTxs"`
+	NumBytes json.Uint64 `json:"numBytes"`
+	// Number of transactions in the mempool by type
+	NumTxsByType map[string]json.Uint64 `json:"numTxsByType"`
+}
+
+// GetMempool returns the transactions that are waiting to be included in a
+// block.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+	)
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	entries := s.vm.mempool.List()
+	reply.Txs = make([]MempoolTx, len(entries))
+	reply.NumTxsByType = make(map[string]json.Uint64)
+	for i, entry := range entries {
+		txType := mempool.TxType(entry.Tx.Unsigned)
+		size := len(entry.Tx.Bytes())
+		reply.Txs[i] = MempoolTx{
+			TxID:      entry.Tx.ID(),
+			Type:      txType,
+			Size:      json.Uint64(size),
+			AddedTime: json.Uint64(entry.Added.Unix()),
+		}
+		reply.NumBytes += json.Uint64(size)
+		reply.NumTxsByType[txType]++
+	}
+	reply.NumTxs = json.Uint64(len(entries))
+	return nil
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
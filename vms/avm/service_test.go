@@ -40,6 +40,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/blocks/executor"
 	"github.com/ava-labs/avalanchego/vms/avm/states"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
@@ -3030,3 +3031,95 @@ func TestServiceGetHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceGetMempool(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().Truncate(time.Second)
+	baseTx := &txs.Tx{Unsigned: &txs.BaseTx{}}
+	baseTx.SetBytes([]byte{1}, []byte{1, 2, 3})
+	exportTx := &txs.Tx{Unsigned: &txs.ExportTx{}}
+	exportTx.SetBytes([]byte{2}, []byte{4, 5})
+
+	tests := []struct {
+		name          string
+		serviceFunc   func(ctrl *gomock.Controller) *Service
+		expectedErr   error
+		expectedReply *GetMempoolReply
+	}{
+		{
+			name: "chain not linearized",
+			serviceFunc: func(*gomock.Controller) *Service {
+				return &Service{
+					vm: &VM{
+						ctx: &snow.Context{
+							Log: logging.NoLog{},
+						},
+					},
+				}
+			},
+			expectedErr: errNotLinearized,
+		},
+		{
+			name: "happy path",
+			serviceFunc: func(ctrl *gomock.Controller) *Service {
+				pool := mempool.NewMockMempool(ctrl)
+				pool.EXPECT().List().Return([]mempool.Entry{
+					{
+						Tx:    baseTx,
+						Added: now,
+					},
+					{
+						Tx:    exportTx,
+						Added: now.Add(time.Second),
+					},
+				})
+				return &Service{
+					vm: &VM{
+						mempool: pool,
+						ctx: &snow.Context{
+							Log: logging.NoLog{},
+						},
+					},
+				}
+			},
+			expectedReply: &GetMempoolReply{
+				Txs: []MempoolTx{
+					{
+						TxID:      baseTx.ID(),
+						Type:      "BaseTx",
+						Size:      3,
+						AddedTime: json.Uint64(now.Unix()),
+					},
+					{
+						TxID:      exportTx.ID(),
+						Type:      "ExportTx",
+						Size:      2,
+						AddedTime: json.Uint64(now.Add(time.Second).Unix()),
+					},
+				},
+				NumTxs:   2,
+				NumBytes: 5,
+				NumTxsByType: map[string]json.Uint64{
+					"BaseTx":   1,
+					"ExportTx": 1,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := tt.serviceFunc(ctrl)
+
+			reply := &GetMempoolReply{}
+			err := service.GetMempool(nil, nil, reply)
+			require.ErrorIs(err, tt.expectedErr)
+			if err == nil {
+				require.Equal(tt.expectedReply, reply)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type codecRegistry struct {
 	codecs      []codec.Registry
 	index       int
 	typeToIndex map[reflect.Type]int
+	numTypes    *uint32
 }
 
 func (cr *codecRegistry) RegisterType(val interface{}) error {
@@ -32,6 +33,7 @@ func (cr *codecRegistry) RegisterType(val interface{}) error {
 	for _, c := range cr.codecs {
 		errs.Add(c.RegisterType(val))
 	}
+	*cr.numTypes++
 	return errs.Err
 }
 
@@ -6,13 +6,16 @@ package executor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/states"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 )
 
 var (
@@ -22,6 +25,8 @@ var (
 	errNotAnAsset      = errors.New("not an asset")
 	errIncompatibleFx  = errors.New("incompatible feature extension")
 	errUnknownFx       = errors.New("unknown feature extension")
+	errFxNotActive     = errors.New("feature extension is not active")
+	errCantExportHTLC  = errors.New("hash time locked outputs can't be exported")
 )
 
 type SemanticVerifier struct {
@@ -56,6 +61,13 @@ func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
 }
 
 func (v *SemanticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	for _, state := range tx.States {
+		// Note: The fx index is verified to be in range during syntactic
+		// verification.
+		if err := v.verifyFxActive(int(state.FxIndex)); err != nil {
+			return err
+		}
+	}
 	return v.BaseTx(&tx.BaseTx)
 }
 
@@ -133,6 +145,11 @@ func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	for _, out := range tx.ExportedOuts {
+		if _, ok := out.Out.(*htlcfx.TransferOutput); ok {
+			// Other chains don't know how to enforce the hash time lock.
+			return errCantExportHTLC
+		}
+
 		fxIndex, err := v.getFx(out.Out)
 		if err != nil {
 			return err
@@ -180,7 +197,25 @@ func (v *SemanticVerifier) verifyTransferOfUTXO(
 	}
 
 	fx := v.Fxs[fxIndex].Fx
-	return fx.VerifyTransfer(tx, in.In, cred, utxo.Out)
+	if err := fx.VerifyTransfer(tx, in.In, cred, utxo.Out); err != nil {
+		return err
+	}
+	return v.verifyTimeout(in.In, utxo.Out)
+}
+
+// verifyTimeout enforces the timeout of hash time locked UTXOs against the
+// chain time, which isn't available to the fx.
+func (v *SemanticVerifier) verifyTimeout(in avax.TransferableIn, utxoOut verify.State) error {
+	out, ok := utxoOut.(*htlcfx.TransferOutput)
+	if !ok {
+		return nil
+	}
+	htlcIn, ok := in.(*htlcfx.TransferInput)
+	if !ok {
+		return errIncompatibleFx
+	}
+	chainTime := uint64(v.State.GetTimestamp().Unix())
+	return htlcfx.VerifyTimeout(htlcIn, out, chainTime)
 }
 
 func (v *SemanticVerifier) verifyOperation(
@@ -223,6 +258,15 @@ func (v *SemanticVerifier) verifyFxUsage(
 	fxID int,
 	assetID ids.ID,
 ) error {
+	if err := v.verifyFxActive(fxID); err != nil {
+		return err
+	}
+	if _, ok := v.Fxs[fxID].Fx.(fxs.UpgradeFx); ok {
+		// Assets created before the fx was added can't list it in their
+		// initial states, so any asset may be used with it.
+		return nil
+	}
+
 	tx, err := v.State.GetTx(assetID)
 	if err != nil {
 		return err
@@ -242,6 +286,23 @@ func (v *SemanticVerifier) verifyFxUsage(
 	return errIncompatibleFx
 }
 
+// verifyFxActive returns an error if the fx at [fxID] is an upgrade fx that
+// isn't activated at the current chain time.
+func (v *SemanticVerifier) verifyFxActive(fxID int) error {
+	fx, ok := v.Fxs[fxID].Fx.(fxs.UpgradeFx)
+	if !ok {
+		return nil
+	}
+	if activationTime := fx.ActivationTime(); v.State.GetTimestamp().Before(activationTime) {
+		return fmt.Errorf("%w: fx %s activates at %s",
+			errFxNotActive,
+			v.Fxs[fxID].ID,
+			activationTime,
+		)
+	}
+	return nil
+}
+
 func (v *SemanticVerifier) getFx(val interface{}) (int, error) {
 	valType := reflect.TypeOf(val)
 	fx, exists := v.TypeToFxIndex[valType]
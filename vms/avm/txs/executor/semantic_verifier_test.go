@@ -6,6 +6,7 @@ package executor
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
@@ -27,7 +29,9 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 func TestSemanticVerifierBaseTx(t *testing.T) {
@@ -388,6 +392,324 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 	}
 }
 
+func TestSemanticVerifierBaseTxUpgradeFx(t *testing.T) {
+	ctx := newContext(t)
+
+	activationTime := time.Unix(1_700_000_000, 0)
+	r1FxIntf, err := (&secp256r1fx.Factory{ActivationTime: activationTime}).New(logging.NoLog{})
+	require.NoError(t, err)
+	r1Fx := r1FxIntf.(*secp256r1fx.Fx)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	secpFx := &secp256k1fx.Fx{}
+	parser, err := txs.NewCustomParser(
+		typeToFxIndex,
+		new(mockable.Clock),
+		logging.NoWarn{},
+		[]fxs.Fx{
+			secpFx,
+			r1Fx,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, typeToFxIndex[reflect.TypeOf(&secp256r1fx.TransferOutput{})])
+
+	key, err := secp256r1fx.NewPrivateKey()
+	require.NoError(t, err)
+
+	codec := parser.Codec()
+	utxoID := avax.UTXOID{
+		TxID:        ids.GenerateTestID(),
+		OutputIndex: 2,
+	}
+	asset := avax.Asset{
+		ID: ids.GenerateTestID(),
+	}
+	baseTx := txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxoID,
+				Asset:  asset,
+				In: &secp256r1fx.TransferInput{
+					TransferInput: secp256k1fx.TransferInput{
+						Amt: 12345,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				},
+			}},
+		},
+	}
+	tx := &txs.Tx{
+		Unsigned: &baseTx,
+	}
+	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &tx.Unsigned)
+	require.NoError(t, err)
+	sig, err := key.Sign(unsignedBytes)
+	require.NoError(t, err)
+	tx.Creds = []*fxs.FxCredential{{
+		Verifiable: &secp256r1fx.Credential{
+			Sigs: []secp256r1fx.Signature{*sig},
+		},
+	}}
+	require.NoError(t, tx.Initialize(codec))
+
+	utxo := avax.UTXO{
+		UTXOID: utxoID,
+		Asset:  asset,
+		Out: &secp256r1fx.TransferOutput{
+			Amt: 12345,
+			OutputOwners: secp256r1fx.OutputOwners{
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs: []ids.ShortID{
+						key.Address(),
+					},
+				},
+			},
+		},
+	}
+
+	backend := &Backend{
+		Ctx:    ctx,
+		Config: &feeConfig,
+		Fxs: []*fxs.ParsedFx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: secpFx,
+			},
+			{
+				ID: secp256r1fx.ID,
+				Fx: r1Fx,
+			},
+		},
+		TypeToFxIndex: typeToFxIndex,
+		Codec:         codec,
+		FeeAssetID:    ids.GenerateTestID(),
+		Bootstrapped:  true,
+	}
+	require.NoError(t, r1Fx.Bootstrapped())
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "before activation",
+			timestamp: activationTime.Add(-time.Second),
+			err:       errFxNotActive,
+		},
+		{
+			// The asset doesn't list the upgrade fx in its initial states
+			name:      "after activation",
+			timestamp: activationTime,
+			err:       nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			state := states.NewMockChain(ctrl)
+			state.EXPECT().GetUTXOFromID(&utxoID).Return(&utxo, nil)
+			state.EXPECT().GetTimestamp().Return(test.timestamp)
+
+			err := tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: backend,
+				State:   state,
+				Tx:      tx,
+			})
+			require.ErrorIs(err, test.err)
+		})
+	}
+}
+
+func TestSemanticVerifierBaseTxHTLC(t *testing.T) {
+	ctx := newContext(t)
+
+	activationTime := time.Unix(1_700_000_000, 0)
+	htlcFxIntf, err := (&htlcfx.Factory{ActivationTime: activationTime}).New(logging.NoLog{})
+	require.NoError(t, err)
+	htlcFx := htlcFxIntf.(*htlcfx.Fx)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	secpFx := &secp256k1fx.Fx{}
+	parser, err := txs.NewCustomParser(
+		typeToFxIndex,
+		new(mockable.Clock),
+		logging.NoWarn{},
+		[]fxs.Fx{
+			secpFx,
+			htlcFx,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, htlcFx.Bootstrapped())
+
+	codec := parser.Codec()
+	utxoID := avax.UTXOID{
+		TxID:        ids.GenerateTestID(),
+		OutputIndex: 2,
+	}
+	asset := avax.Asset{
+		ID: ids.GenerateTestID(),
+	}
+	preimage := []byte("swap secret")
+	timeout := activationTime.Add(time.Hour)
+	utxo := avax.UTXO{
+		UTXOID: utxoID,
+		Asset:  asset,
+		Out: &htlcfx.TransferOutput{
+			Amt:      12345,
+			HashLock: hashing.ComputeHash256Array(preimage),
+			Timeout:  uint64(timeout.Unix()),
+			Receiver: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					keys[0].Address(),
+				},
+			},
+			Refund: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					keys[1].Address(),
+				},
+			},
+		},
+	}
+
+	backend := &Backend{
+		Ctx:    ctx,
+		Config: &feeConfig,
+		Fxs: []*fxs.ParsedFx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: secpFx,
+			},
+			{
+				ID: htlcfx.ID,
+				Fx: htlcFx,
+			},
+		},
+		TypeToFxIndex: typeToFxIndex,
+		Codec:         codec,
+		FeeAssetID:    ids.GenerateTestID(),
+		Bootstrapped:  true,
+	}
+
+	tests := []struct {
+		name      string
+		preimage  []byte
+		key       *secp256k1.PrivateKey
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "claim before timeout",
+			preimage:  preimage,
+			key:       keys[0],
+			timestamp: timeout.Add(-time.Second),
+			err:       nil,
+		},
+		{
+			name:      "claim at timeout",
+			preimage:  preimage,
+			key:       keys[0],
+			timestamp: timeout,
+			err:       htlcfx.ErrExpired,
+		},
+		{
+			name:      "claim with wrong preimage",
+			preimage:  []byte("wrong secret"),
+			key:       keys[0],
+			timestamp: timeout.Add(-time.Second),
+			err:       htlcfx.ErrWrongPreimage,
+		},
+		{
+			name:      "claim signed by refund owner",
+			preimage:  preimage,
+			key:       keys[1],
+			timestamp: timeout.Add(-time.Second),
+			err:       secp256k1fx.ErrWrongSig,
+		},
+		{
+			name:      "refund before timeout",
+			key:       keys[1],
+			timestamp: timeout.Add(-time.Second),
+			err:       htlcfx.ErrNotExpired,
+		},
+		{
+			name:      "refund at timeout",
+			key:       keys[1],
+			timestamp: timeout,
+			err:       nil,
+		},
+		{
+			name:      "before activation",
+			preimage:  preimage,
+			key:       keys[0],
+			timestamp: activationTime.Add(-time.Second),
+			err:       errFxNotActive,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := &txs.Tx{
+				Unsigned: &txs.BaseTx{
+					BaseTx: avax.BaseTx{
+						Ins: []*avax.TransferableInput{{
+							UTXOID: utxoID,
+							Asset:  asset,
+							In: &htlcfx.TransferInput{
+								TransferInput: secp256k1fx.TransferInput{
+									Amt: 12345,
+									Input: secp256k1fx.Input{
+										SigIndices: []uint32{0},
+									},
+								},
+								Preimage: test.preimage,
+							},
+						}},
+					},
+				},
+			}
+			unsignedBytes, err := codec.Marshal(txs.CodecVersion, &tx.Unsigned)
+			require.NoError(err)
+			sig, err := test.key.Sign(unsignedBytes)
+			require.NoError(err)
+			cred := &htlcfx.Credential{
+				Credential: secp256k1fx.Credential{
+					Sigs: make([][secp256k1.SignatureLen]byte, 1),
+				},
+			}
+			copy(cred.Sigs[0][:], sig)
+			tx.Creds = []*fxs.FxCredential{{
+				Verifiable: cred,
+			}}
+			require.NoError(tx.Initialize(codec))
+
+			state := states.NewMockChain(ctrl)
+			state.EXPECT().GetUTXOFromID(&utxoID).Return(&utxo, nil)
+			state.EXPECT().GetTimestamp().Return(test.timestamp).AnyTimes()
+
+			err = tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: backend,
+				State:   state,
+				Tx:      tx,
+			})
+			require.ErrorIs(err, test.err)
+		})
+	}
+}
+
 func TestSemanticVerifierExportTx(t *testing.T) {
 	ctx := newContext(t)
 
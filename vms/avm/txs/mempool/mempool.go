@@ -6,6 +6,8 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 )
@@ -30,6 +33,8 @@ const (
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	txTypeLabel = "tx_type"
 )
 
 var (
@@ -41,12 +46,21 @@ var (
 	errConflictsWithOtherTx = errors.New("tx conflicts with other tx")
 )
 
+// Entry is a transaction in the mempool along with the time it was added.
+type Entry struct {
+	Tx    *txs.Tx
+	Added time.Time
+}
+
 // Mempool contains transactions that have not yet been put into a block.
 type Mempool interface {
 	Add(tx *txs.Tx) error
 	Has(txID ids.ID) bool
 	Get(txID ids.ID) *txs.Tx
 	Remove(txs []*txs.Tx)
+	// List returns all the transactions in the mempool, ordered by the time
+	// they were added.
+	List() []Entry
 
 	// Peek returns the next first tx that was added to the mempool whose size
 	// is less than or equal to maxTxSize.
@@ -66,8 +80,15 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
-	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
-	numTxs      prometheus.Gauge
+	unissuedTxs    linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
+	numTxs         prometheus.Gauge
+	numTxsByType   *prometheus.GaugeVec
+	numBytesByType *prometheus.GaugeVec
+
+	// Key: Tx ID
+	// Value: Time the tx was added to the mempool
+	addedTimes map[ids.ID]time.Time
+	clock      mockable.Clock
 
 	toEngine chan<- common.Message
 
@@ -101,12 +122,33 @@ func New(
 		return nil, err
 	}
 
+	numTxsByType := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "txs",
+		Help:      "Number of transactions in the mempool by tx type",
+	}, []string{txTypeLabel})
+	if err := registerer.Register(numTxsByType); err != nil {
+		return nil, err
+	}
+
+	numBytesByType := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tx_bytes",
+		Help:      "Number of bytes of transactions in the mempool by tx type",
+	}, []string{txTypeLabel})
+	if err := registerer.Register(numBytesByType); err != nil {
+		return nil, err
+	}
+
 	bytesAvailableMetric.Set(maxMempoolSize)
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
 		unissuedTxs:          linkedhashmap.New[ids.ID, *txs.Tx](),
 		numTxs:               numTxsMetric,
+		numTxsByType:         numTxsByType,
+		numBytesByType:       numBytesByType,
+		addedTimes:           make(map[ids.ID]time.Time),
 		toEngine:             toEngine,
 		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
@@ -148,6 +190,11 @@ func (m *mempool) Add(tx *txs.Tx) error {
 
 	m.unissuedTxs.Put(txID, tx)
 	m.numTxs.Inc()
+	m.addedTimes[txID] = m.clock.Time()
+
+	txType := TxType(tx.Unsigned)
+	m.numTxsByType.WithLabelValues(txType).Inc()
+	m.numBytesByType.WithLabelValues(txType).Add(float64(txSize))
 
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Union(inputs)
@@ -180,12 +227,30 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 
 		m.unissuedTxs.Delete(txID)
 		m.numTxs.Dec()
+		delete(m.addedTimes, txID)
+
+		txType := TxType(tx.Unsigned)
+		m.numTxsByType.WithLabelValues(txType).Dec()
+		m.numBytesByType.WithLabelValues(txType).Sub(float64(len(txBytes)))
 
 		inputs := tx.Unsigned.InputIDs()
 		m.consumedUTXOs.Difference(inputs)
 	}
 }
 
+func (m *mempool) List() []Entry {
+	entries := make([]Entry, 0, m.unissuedTxs.Len())
+	txIter := m.unissuedTxs.NewIterator()
+	for txIter.Next() {
+		txID := txIter.Key()
+		entries = append(entries, Entry{
+			Tx:    txIter.Value(),
+			Added: m.addedTimes[txID],
+		})
+	}
+	return entries
+}
+
 func (m *mempool) Peek(maxTxSize int) *txs.Tx {
 	txIter := m.unissuedTxs.NewIterator()
 	for txIter.Next() {
@@ -217,3 +282,8 @@ func (m *mempool) GetDropReason(txID ids.ID) error {
 	err, _ := m.droppedTxIDs.Get(txID)
 	return err
 }
+
+// TxType returns the name of the type of [utx], e.g. "BaseTx".
+func TxType(utx txs.UnsignedTx) string {
+	return reflect.TypeOf(utx).Elem().Name()
+}
@@ -5,11 +5,14 @@ package mempool
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
@@ -115,6 +118,62 @@ func TestTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestMempoolList(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempoolIntf, err := New("mempool", registerer, nil)
+	require.NoError(err)
+
+	mempool := mempoolIntf.(*mempool)
+
+	testTxs := createTestTxs(2)
+	now := time.Now().Truncate(time.Second)
+	for i, tx := range testTxs {
+		mempool.clock.Set(now.Add(time.Duration(i) * time.Second))
+		require.NoError(mempool.Add(tx))
+	}
+
+	require.Equal(
+		[]Entry{
+			{
+				Tx:    testTxs[0],
+				Added: now,
+			},
+			{
+				Tx:    testTxs[1],
+				Added: now.Add(time.Second),
+			},
+		},
+		mempool.List(),
+	)
+
+	txType := TxType(testTxs[0].Unsigned)
+	require.Equal("CreateAssetTx", txType)
+	expectedBytes := len(testTxs[0].Bytes()) + len(testTxs[1].Bytes())
+	require.Equal(float64(2), gaugeValue(t, mempool.numTxsByType.WithLabelValues(txType)))
+	require.Equal(float64(expectedBytes), gaugeValue(t, mempool.numBytesByType.WithLabelValues(txType)))
+
+	mempool.Remove(testTxs[:1])
+	require.Equal(
+		[]Entry{
+			{
+				Tx:    testTxs[1],
+				Added: now.Add(time.Second),
+			},
+		},
+		mempool.List(),
+	)
+	require.Equal(float64(1), gaugeValue(t, mempool.numTxsByType.WithLabelValues(txType)))
+	require.Equal(float64(len(testTxs[1].Bytes())), gaugeValue(t, mempool.numBytesByType.WithLabelValues(txType)))
+}
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	metric := &dto.Metric{}
+	require.NoError(t, gauge.Write(metric))
+	return metric.GetGauge().GetValue()
+}
+
 func createTestTxs(count int) []*txs.Tx {
 	testTxs := make([]*txs.Tx, 0, count)
 	addr := keys[0].PublicKey().Address()
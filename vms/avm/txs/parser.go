@@ -4,6 +4,7 @@
 package txs
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -17,10 +18,18 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 )
 
-// CodecVersion is the current default codec version
-const CodecVersion = 0
+const (
+	// CodecVersion is the current default codec version
+	CodecVersion = 0
 
-var _ Parser = (*parser)(nil)
+	numTxTypes = 5
+)
+
+var (
+	_ Parser = (*parser)(nil)
+
+	errTypeIDOffsetTooLow = errors.New("type ID offset is lower than the number of registered types")
+)
 
 type Parser interface {
 	Codec() codec.Manager
@@ -57,6 +66,19 @@ func NewCustomParser(
 	clock *mockable.Clock,
 	log logging.Logger,
 	fxs []fxs.Fx,
+) (Parser, error) {
+	return NewCustomParserWithTypes(typeToFxIndex, clock, log, fxs, nil)
+}
+
+// NewCustomParserWithTypes returns a parser that additionally registers
+// [types] after the types of [fxs], but before the types of any
+// [fxs.UpgradeFx].
+func NewCustomParserWithTypes(
+	typeToFxIndex map[reflect.Type]int,
+	clock *mockable.Clock,
+	log logging.Logger,
+	fxList []fxs.Fx,
+	types []interface{},
 ) (Parser, error) {
 	gc := linearcodec.New([]string{reflectcodec.DefaultTagName}, 1<<20)
 	c := linearcodec.NewDefault()
@@ -84,18 +106,60 @@ func NewCustomParser(
 		return nil, errs.Err
 	}
 
+	numTypes := uint32(numTxTypes)
 	vm := &fxVM{
 		typeToFxIndex: typeToFxIndex,
 		clock:         clock,
 		log:           log,
 	}
-	for i, fx := range fxs {
+	initializeFx := func(i int, fx fxs.Fx) error {
 		vm.codecRegistry = &codecRegistry{
 			codecs:      []codec.Registry{gc, c},
 			index:       i,
 			typeToIndex: vm.typeToFxIndex,
+			numTypes:    &numTypes,
+		}
+		return fx.Initialize(vm)
+	}
+
+	var upgradeFxs []int
+	for i, fx := range fxList {
+		if _, ok := fx.(fxs.UpgradeFx); ok {
+			upgradeFxs = append(upgradeFxs, i)
+			continue
+		}
+		if err := initializeFx(i, fx); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, t := range types {
+		errs.Add(
+			c.RegisterType(t),
+			gc.RegisterType(t),
+		)
+		numTypes++
+	}
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	for _, i := range upgradeFxs {
+		fx := fxList[i].(fxs.UpgradeFx)
+		offset := fx.TypeIDOffset()
+		if offset < numTypes {
+			return nil, fmt.Errorf("%w: fx %d requires offset %d but %d types are registered",
+				errTypeIDOffsetTooLow,
+				i,
+				offset,
+				numTypes,
+			)
 		}
-		if err := fx.Initialize(vm); err != nil {
+		c.SkipRegistrations(int(offset - numTypes))
+		gc.SkipRegistrations(int(offset - numTypes))
+		numTypes = offset
+
+		if err := initializeFx(i, fx); err != nil {
 			return nil, err
 		}
 	}
@@ -134,6 +134,7 @@ type VM struct {
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
+	mempool      mempool.Mempool
 	network      network.Network
 }
 
@@ -451,6 +452,7 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	vm.mempool = mempool
 
 	vm.chainManager = blockexecutor.NewManager(
 		mempool,
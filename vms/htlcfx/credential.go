@@ -0,0 +1,10 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import "github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+type Credential struct {
+	secp256k1fx.Credential `serialize:"true"`
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var (
+	_ vms.Factory = (*Factory)(nil)
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'h', 't', 'l', 'c', 'f', 'x'}
+)
+
+type Factory struct {
+	// ActivationTime is the time after which the fx may be used
+	ActivationTime time.Time
+}
+
+func (f *Factory) New(logging.Logger) (interface{}, error) {
+	return &Fx{activationTime: f.ActivationTime}, nil
+}
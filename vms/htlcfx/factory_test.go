@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestFactory(t *testing.T) {
+	require := require.New(t)
+	activationTime := time.Unix(1_700_000_000, 0)
+	factory := Factory{ActivationTime: activationTime}
+	fxIntf, err := factory.New(logging.NoLog{})
+	require.NoError(err)
+	require.IsType(&Fx{}, fxIntf)
+	fx := fxIntf.(*Fx)
+	require.Equal(activationTime, fx.ActivationTime())
+	require.Equal(uint32(TypeIDOffset), fx.TypeIDOffset())
+}
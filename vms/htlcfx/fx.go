@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// TypeIDOffset is the codec type ID of the first type of this fx when it is
+// added to the X-chain. It follows the types of the secp256r1fx.
+const TypeIDOffset = 42
+
+var (
+	ErrWrongPreimage = errors.New("preimage doesn't match the hash lock")
+	ErrExpired       = errors.New("hash time lock has expired")
+	ErrNotExpired    = errors.New("hash time lock hasn't expired")
+
+	errCantOperate          = errors.New("cant perform operations with this fx")
+	errCantVerifyPermission = errors.New("cant verify permissions with this fx")
+	errCantCreateOutput     = errors.New("cant create outputs with this fx")
+)
+
+// Fx describes the hash time lock feature extension. Its outputs can be
+// claimed by a receiver that reveals the preimage of a hash before a timeout,
+// and refunded to the sender afterwards.
+//
+// The fx doesn't enforce its activation time or the timeout of its outputs, as
+// it doesn't have access to the chain time. The VM must reject its use before
+// [Fx.ActivationTime] and must call [VerifyTimeout] when its outputs are
+// spent.
+type Fx struct {
+	secp256k1fx.Fx
+	activationTime time.Time
+}
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing hash time lock fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+// ActivationTime returns the time after which the fx may be used
+func (fx *Fx) ActivationTime() time.Time {
+	return fx.activationTime
+}
+
+// TypeIDOffset returns the codec type ID of the first type of this fx
+func (*Fx) TypeIDOffset() uint32 {
+	return TypeIDOffset
+}
+
+func (*Fx) VerifyOperation(interface{}, interface{}, interface{}, []interface{}) error {
+	return errCantOperate
+}
+
+// VerifyPermission isn't supported, as hash time locked outputs don't
+// describe the owner of a permission.
+func (*Fx) VerifyPermission(interface{}, interface{}, interface{}, interface{}) error {
+	return errCantVerifyPermission
+}
+
+// CreateOutput isn't supported, as a hash time locked output can't be created
+// from an amount and an owner alone.
+func (*Fx) CreateOutput(uint64, interface{}) (interface{}, error) {
+	return nil, errCantCreateOutput
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return secp256k1fx.ErrWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return secp256k1fx.ErrWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return secp256k1fx.ErrWrongUTXOType
+	}
+	return fx.VerifySpend(tx, in, cred, out)
+}
+
+// VerifySpend ensures that [utxo] can be claimed or refunded by [in]. It
+// doesn't verify the timeout of [utxo].
+func (fx *Fx) VerifySpend(utx secp256k1fx.UnsignedTx, in *TransferInput, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(utxo, in, cred); err != nil {
+		return err
+	} else if utxo.Amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", secp256k1fx.ErrMismatchedAmounts, utxo.Amt, in.Amt)
+	}
+
+	if in.IsClaim() && hashing.ComputeHash256Array(in.Preimage) != utxo.HashLock {
+		return ErrWrongPreimage
+	}
+	return fx.VerifyCredentials(utx, &in.Input, &cred.Credential, utxo.Owners(in))
+}
+
+// VerifyTimeout ensures that [in] spends [utxo] along the path that is allowed
+// at [chainTime]. Outputs can only be claimed before their timeout and can only
+// be refunded at or after it.
+func VerifyTimeout(in *TransferInput, utxo *TransferOutput, chainTime uint64) error {
+	claim := in.IsClaim()
+	switch {
+	case claim && chainTime >= utxo.Timeout:
+		return fmt.Errorf("%w: timeout %d <= chain time %d", ErrExpired, utxo.Timeout, chainTime)
+	case !claim && chainTime < utxo.Timeout:
+		return fmt.Errorf("%w: timeout %d > chain time %d", ErrNotExpired, utxo.Timeout, chainTime)
+	default:
+		return nil
+	}
+}
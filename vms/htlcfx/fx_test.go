@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	preimage = []byte("swap secret")
+)
+
+func newTestFx(t *testing.T) *Fx {
+	require := require.New(t)
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	vm.Clk.Set(time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC))
+	fx := &Fx{}
+	require.NoError(fx.Initialize(vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+	return fx
+}
+
+func newTestKey(t *testing.T) *secp256k1.PrivateKey {
+	key, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newTestOutput(receiver, refund *secp256k1.PrivateKey) *TransferOutput {
+	return &TransferOutput{
+		Amt:      1,
+		HashLock: hashing.ComputeHash256Array(preimage),
+		Timeout:  100,
+		Receiver: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{receiver.Address()},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{refund.Address()},
+		},
+	}
+}
+
+func newTestInput(preimage []byte) *TransferInput {
+	return &TransferInput{
+		TransferInput: secp256k1fx.TransferInput{
+			Amt: 1,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+		Preimage: preimage,
+	}
+}
+
+func newTestCredential(t *testing.T, key *secp256k1.PrivateKey) *Credential {
+	sig, err := key.Sign(txBytes)
+	require.NoError(t, err)
+	cred := &Credential{
+		Credential: secp256k1fx.Credential{
+			Sigs: make([][secp256k1.SignatureLen]byte, 1),
+		},
+	}
+	copy(cred.Sigs[0][:], sig)
+	return cred
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	receiver := newTestKey(t)
+	refund := newTestKey(t)
+
+	tests := []struct {
+		name string
+		in   *TransferInput
+		key  *secp256k1.PrivateKey
+		err  error
+	}{
+		{
+			name: "claim",
+			in:   newTestInput(preimage),
+			key:  receiver,
+			err:  nil,
+		},
+		{
+			name: "claim with wrong preimage",
+			in:   newTestInput([]byte("wrong secret")),
+			key:  receiver,
+			err:  ErrWrongPreimage,
+		},
+		{
+			name: "claim signed by refund owner",
+			in:   newTestInput(preimage),
+			key:  refund,
+			err:  secp256k1fx.ErrWrongSig,
+		},
+		{
+			name: "refund",
+			in:   newTestInput(nil),
+			key:  refund,
+			err:  nil,
+		},
+		{
+			name: "refund signed by receiver",
+			in:   newTestInput(nil),
+			key:  receiver,
+			err:  secp256k1fx.ErrWrongSig,
+		},
+		{
+			name: "preimage too long",
+			in:   newTestInput(make([]byte, MaxPreimageLen+1)),
+			key:  receiver,
+			err:  ErrPreimageTooLong,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fx := newTestFx(t)
+			tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+			out := newTestOutput(receiver, refund)
+			cred := newTestCredential(t, test.key)
+			err := fx.VerifyTransfer(tx, test.in, cred, out)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestFxVerifyTransferMismatchedAmounts(t *testing.T) {
+	fx := newTestFx(t)
+	receiver := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in := newTestInput(preimage)
+	in.Amt = 2
+	cred := newTestCredential(t, receiver)
+	out := newTestOutput(receiver, newTestKey(t))
+	err := fx.VerifyTransfer(tx, in, cred, out)
+	require.ErrorIs(t, err, secp256k1fx.ErrMismatchedAmounts)
+}
+
+func TestFxVerifyTransferWrongTypes(t *testing.T) {
+	fx := newTestFx(t)
+	receiver := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in := newTestInput(preimage)
+	cred := newTestCredential(t, receiver)
+	out := newTestOutput(receiver, newTestKey(t))
+
+	err := fx.VerifyTransfer(nil, in, cred, out)
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongTxType)
+
+	err = fx.VerifyTransfer(tx, &in.TransferInput, cred, out)
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongInputType)
+
+	err = fx.VerifyTransfer(tx, in, &cred.Credential, out)
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongCredentialType)
+
+	err = fx.VerifyTransfer(tx, in, cred, &secp256k1fx.TransferOutput{})
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongUTXOType)
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	fx := newTestFx(t)
+	err := fx.VerifyOperation(nil, nil, nil, nil)
+	require.ErrorIs(t, err, errCantOperate)
+}
+
+func TestFxVerifyPermission(t *testing.T) {
+	fx := newTestFx(t)
+	err := fx.VerifyPermission(nil, nil, nil, nil)
+	require.ErrorIs(t, err, errCantVerifyPermission)
+}
+
+func TestFxCreateOutput(t *testing.T) {
+	fx := newTestFx(t)
+	_, err := fx.CreateOutput(1, &secp256k1fx.OutputOwners{})
+	require.ErrorIs(t, err, errCantCreateOutput)
+}
+
+func TestVerifyTimeout(t *testing.T) {
+	out := &TransferOutput{Timeout: 100}
+	tests := []struct {
+		name      string
+		in        *TransferInput
+		chainTime uint64
+		err       error
+	}{
+		{
+			name:      "claim before timeout",
+			in:        newTestInput(preimage),
+			chainTime: 99,
+			err:       nil,
+		},
+		{
+			name:      "claim at timeout",
+			in:        newTestInput(preimage),
+			chainTime: 100,
+			err:       ErrExpired,
+		},
+		{
+			name:      "refund before timeout",
+			in:        newTestInput(nil),
+			chainTime: 99,
+			err:       ErrNotExpired,
+		},
+		{
+			name:      "refund at timeout",
+			in:        newTestInput(nil),
+			chainTime: 100,
+			err:       nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyTimeout(test.in, out, test.chainTime)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
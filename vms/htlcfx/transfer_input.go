@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// MaxPreimageLen is the maximum number of bytes a preimage may have
+const MaxPreimageLen = 256
+
+var ErrPreimageTooLong = errors.New("preimage is too long")
+
+// TransferInput spends a hash time locked output. If Preimage is provided, the
+// output is claimed by its receiver. Otherwise, the output is refunded to its
+// sender.
+type TransferInput struct {
+	secp256k1fx.TransferInput `serialize:"true"`
+
+	Preimage []byte `serialize:"true" json:"preimage"`
+}
+
+// MarshalJSON marshals the embedded TransferInput along with the preimage,
+// which is hex encoded
+func (in *TransferInput) MarshalJSON() ([]byte, error) {
+	preimage, err := formatting.Encode(formatting.HexNC, in.Preimage)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't convert preimage to string: %w", err)
+	}
+	return json.Marshal(map[string]interface{}{
+		"amount":           in.Amt,
+		"signatureIndices": in.SigIndices,
+		"preimage":         preimage,
+	})
+}
+
+// IsClaim returns true if this input claims the output with the preimage of
+// its hash lock
+func (in *TransferInput) IsClaim() bool {
+	return len(in.Preimage) != 0
+}
+
+// Verify this input is syntactically valid
+func (in *TransferInput) Verify() error {
+	switch {
+	case in == nil:
+		return secp256k1fx.ErrNilInput
+	case len(in.Preimage) > MaxPreimageLen:
+		return fmt.Errorf("%w: %d > %d", ErrPreimageTooLong, len(in.Preimage), MaxPreimageLen)
+	default:
+		return in.TransferInput.Verify()
+	}
+}
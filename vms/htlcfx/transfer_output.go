@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ avax.TransferableOut = (*TransferOutput)(nil)
+	_ avax.Addressable     = (*TransferOutput)(nil)
+
+	ErrNoTimeout         = errors.New("output has no timeout")
+	ErrEmptyPreimageLock = errors.New("hash lock is the hash of an empty preimage")
+
+	// emptyPreimageHashLock can't be claimed, as inputs with an empty
+	// preimage refund the output.
+	emptyPreimageHashLock = hashing.ComputeHash256Array(nil)
+)
+
+// TransferOutput is locked by the sha256 hash of a secret. Before Timeout, it
+// can be claimed by Receiver with the preimage of HashLock. At and after
+// Timeout, it can be refunded to Refund.
+type TransferOutput struct {
+	verify.IsState `json:"-"`
+
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	HashLock [hashing.HashLen]byte `serialize:"true" json:"hashLock"`
+	// Timeout is the unix time, compared against the chain time, at which the
+	// output stops being claimable and becomes refundable.
+	Timeout uint64 `serialize:"true" json:"timeout"`
+
+	Receiver secp256k1fx.OutputOwners `serialize:"true" json:"receiver"`
+	Refund   secp256k1fx.OutputOwners `serialize:"true" json:"refund"`
+}
+
+// InitCtx allows addresses to be formatted into their human readable format
+// during JSON marshalling.
+func (out *TransferOutput) InitCtx(ctx *snow.Context) {
+	out.Receiver.InitCtx(ctx)
+	out.Refund.InitCtx(ctx)
+}
+
+// MarshalJSON marshals the output into a JSON readable format. The hash lock is
+// hex encoded.
+func (out *TransferOutput) MarshalJSON() ([]byte, error) {
+	hashLock, err := formatting.Encode(formatting.HexNC, out.HashLock[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't convert hash lock to string: %w", err)
+	}
+	receiver, err := out.Receiver.Fields()
+	if err != nil {
+		return nil, err
+	}
+	refund, err := out.Refund.Fields()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]interface{}{
+		"amount":   out.Amt,
+		"hashLock": hashLock,
+		"timeout":  out.Timeout,
+		"receiver": receiver,
+		"refund":   refund,
+	})
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *TransferOutput) Amount() uint64 {
+	return out.Amt
+}
+
+// Addresses returns the addresses of the receiver followed by the addresses of
+// the refund owners that aren't receivers, so that the output is indexed for
+// both parties of the swap.
+func (out *TransferOutput) Addresses() [][]byte {
+	numAddrs := len(out.Receiver.Addrs) + len(out.Refund.Addrs)
+	addrs := set.NewSet[ids.ShortID](numAddrs)
+	addrBytes := make([][]byte, 0, numAddrs)
+	for _, owners := range []*secp256k1fx.OutputOwners{&out.Receiver, &out.Refund} {
+		for _, addr := range owners.Addrs {
+			if addrs.Contains(addr) {
+				continue
+			}
+			addrs.Add(addr)
+			addrBytes = append(addrBytes, addr.Bytes())
+		}
+	}
+	return addrBytes
+}
+
+// Owners returns the owners that can spend this output with [in]
+func (out *TransferOutput) Owners(in *TransferInput) *secp256k1fx.OutputOwners {
+	if in.IsClaim() {
+		return &out.Receiver
+	}
+	return &out.Refund
+}
+
+func (out *TransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return secp256k1fx.ErrNilOutput
+	case out.Amt == 0:
+		return secp256k1fx.ErrNoValueOutput
+	case out.Timeout == 0:
+		return ErrNoTimeout
+	case out.HashLock == emptyPreimageHashLock:
+		return ErrEmptyPreimageLock
+	}
+	if err := out.Receiver.Verify(); err != nil {
+		return fmt.Errorf("invalid receiver: %w", err)
+	}
+	if err := out.Refund.Verify(); err != nil {
+		return fmt.Errorf("invalid refund: %w", err)
+	}
+	return nil
+}
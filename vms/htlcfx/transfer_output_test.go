@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestOutputVerify(t *testing.T) {
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.ShortEmpty},
+	}
+	tests := []struct {
+		name string
+		out  *TransferOutput
+		err  error
+	}{
+		{
+			name: "nil",
+			out:  nil,
+			err:  secp256k1fx.ErrNilOutput,
+		},
+		{
+			name: "no value",
+			out: &TransferOutput{
+				Timeout:  1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			err: secp256k1fx.ErrNoValueOutput,
+		},
+		{
+			name: "no timeout",
+			out: &TransferOutput{
+				Amt:      1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			err: ErrNoTimeout,
+		},
+		{
+			name: "unspendable receiver",
+			out: &TransferOutput{
+				Amt:     1,
+				Timeout: 1,
+				Receiver: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     []ids.ShortID{ids.ShortEmpty},
+				},
+				Refund: owners,
+			},
+			err: secp256k1fx.ErrOutputUnspendable,
+		},
+		{
+			name: "unspendable refund",
+			out: &TransferOutput{
+				Amt:      1,
+				Timeout:  1,
+				Receiver: owners,
+				Refund: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     []ids.ShortID{ids.ShortEmpty},
+				},
+			},
+			err: secp256k1fx.ErrOutputUnspendable,
+		},
+		{
+			name: "hash lock of empty preimage",
+			out: &TransferOutput{
+				Amt:      1,
+				HashLock: hashing.ComputeHash256Array(nil),
+				Timeout:  1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			err: ErrEmptyPreimageLock,
+		},
+		{
+			name: "valid",
+			out: &TransferOutput{
+				Amt:      1,
+				Timeout:  1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			err: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.out.Verify(), test.err)
+		})
+	}
+}
+
+func TestOutputAddresses(t *testing.T) {
+	require := require.New(t)
+	receiver0 := ids.GenerateTestShortID()
+	receiver1 := ids.GenerateTestShortID()
+	refund := ids.GenerateTestShortID()
+	out := &TransferOutput{
+		Receiver: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{receiver0, receiver1},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{refund, receiver1},
+		},
+	}
+	// The receiver addresses are returned first, without duplicates
+	for i := 0; i < 10; i++ {
+		require.Equal(
+			[][]byte{receiver0.Bytes(), receiver1.Bytes(), refund.Bytes()},
+			out.Addresses(),
+		)
+	}
+}
+
+func TestOutputOwners(t *testing.T) {
+	require := require.New(t)
+	out := &TransferOutput{
+		Receiver: secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	}
+	require.Equal(&out.Receiver, out.Owners(&TransferInput{Preimage: []byte{1}}))
+	require.Equal(&out.Refund, out.Owners(&TransferInput{}))
+}
@@ -11,9 +11,12 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
 var (
@@ -27,6 +30,37 @@ type Factory interface {
 	New(logging.Logger) (interface{}, error)
 }
 
+// SandboxedFactory is implemented by factories of VMs whose processes can be
+// started in a sandbox.
+type SandboxedFactory interface {
+	Factory
+
+	// NewSandboxed returns a new instance of the VM whose processes are
+	// started in [sandbox].
+	NewSandboxed(log logging.Logger, sandbox *subprocess.SandboxConfig) (interface{}, error)
+}
+
+// Upgrader is implemented by VMs whose implementation can be replaced while
+// their chain is running.
+type Upgrader interface {
+	// Upgrade replaces the implementation of the VM with the plugin the VM
+	// was registered with in the plugin directory. If the new implementation
+	// can't resume the chain, an error is returned.
+	Upgrade(ctx context.Context) error
+}
+
+// TxStreamer is implemented by VMs that may stream the transactions of their
+// accepted blocks.
+type TxStreamer interface {
+	// StreamTxs causes the transactions of the blocks accepted by the VM to be
+	// passed to the TxAcceptor of [ctx]. The height of the last block whose
+	// transactions were passed is persisted in [db], so that the transactions
+	// of the blocks accepted since then are passed after a restart. Returns
+	// false if the VM doesn't stream its transactions. Must be called after the
+	// VM is initialized.
+	StreamTxs(ctx *snow.ConsensusContext, db database.Database) (bool, error)
+}
+
 // Manager tracks a collection of VM factories, their aliases, and their
 // versions.
 // It has the following functionality:
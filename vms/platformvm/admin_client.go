@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ AdminClient = (*adminClient)(nil)
+
+// AdminClient for interacting with the admin API of the P-chain
+type AdminClient interface {
+	// ExportState exports the last accepted state of the P-chain. If [name] is
+	// not empty, the export is written to a new file named [name] in the
+	// exports directory of the P-chain data directory on the node. If [height]
+	// is not zero, the state is only exported if [height] is the last accepted
+	// height.
+	ExportState(ctx context.Context, name string, height uint64, options ...rpc.Option) (*ExportStateReply, error)
+}
+
+// adminClient implementation for interacting with the admin API of the
+// P-chain
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns a client for interacting with the admin API of the
+// P-chain
+func NewAdminClient(uri string) AdminClient {
+	path := fmt.Sprintf(
+		"%s/ext/%s/P/admin",
+		uri,
+		constants.ChainAliasPrefix,
+	)
+	return &adminClient{
+		requester: rpc.NewEndpointRequester(path),
+	}
+}
+
+func (c *adminClient) ExportState(ctx context.Context, name string, height uint64, options ...rpc.Option) (*ExportStateReply, error) {
+	res := &ExportStateReply{}
+	err := c.requester.SendRequest(ctx, "admin.exportState", &ExportStateArgs{
+		Name:   name,
+		Height: json.Uint64(height),
+	}, res, options...)
+	return res, err
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+// exportDirName is the directory, in the data directory of the chain, that
+// exports are written to.
+const exportDirName = "exports"
+
+var (
+	errInvalidExportName     = errors.New("invalid export name")
+	errHeightNotLastAccepted = errors.New("height isn't the last accepted height")
+)
+
+// AdminService is the API service for node operators. It is only available if
+// the admin API of the node is enabled.
+type AdminService struct {
+	vm *VM
+}
+
+// ExportStateArgs are the arguments for calling ExportState
+type ExportStateArgs struct {
+	// Name of the file the export is written to, in the exports directory of
+	// the chain data directory. The file must not exist. If empty, only the
+	// hash of the export is returned.
+	Name string `json:"name"`
+	// Height the state is expected to be exported at. Only the last accepted
+	// state is stored, so the state can't be exported at any other height.
+	// If non-zero and the last accepted height differs, nothing is exported.
+	Height json.Uint64 `json:"height"`
+}
+
+// ExportStateReply is the response from calling ExportState
+type ExportStateReply struct {
+	Height  json.Uint64 `json:"height"`
+	BlockID ids.ID      `json:"blockID"`
+	Hash    ids.ID      `json:"hash"`
+}
+
+// ExportState exports the last accepted state of the P-chain. Nodes that
+// accepted the same blocks report the same hash. To compare the states of
+// several nodes, each node must be exported at the same height.
+func (s *AdminService) ExportState(_ *http.Request, args *ExportStateArgs, reply *ExportStateReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "exportState"),
+		zap.String("name", args.Name),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	var path string
+	if args.Name != "" {
+		if args.Name != filepath.Base(args.Name) || args.Name == "." || args.Name == ".." {
+			return fmt.Errorf("%w: %q", errInvalidExportName, args.Name)
+		}
+		exportDir := filepath.Join(s.vm.ctx.ChainDataDir, exportDirName)
+		if err := os.MkdirAll(exportDir, perms.ReadWriteExecute); err != nil {
+			return fmt.Errorf("couldn't create export directory: %w", err)
+		}
+		path = filepath.Join(exportDir, args.Name)
+	}
+
+	export, err := s.vm.state.Export()
+	if err != nil {
+		return fmt.Errorf("couldn't export state: %w", err)
+	}
+	if args.Height != 0 && args.Height != export.Height {
+		return fmt.Errorf("%w: %d != %d", errHeightNotLastAccepted, args.Height, export.Height)
+	}
+	if path != "" {
+		if err := state.WriteExport(path, export); err != nil {
+			return fmt.Errorf("couldn't write export: %w", err)
+		}
+	}
+
+	reply.Height = export.Height
+	reply.BlockID = export.BlockID
+	reply.Hash = export.Hash
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+func TestAdminServiceExportState(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	adminService := &AdminService{vm: service.vm}
+
+	// Only return the hash
+	hashReply := ExportStateReply{}
+	require.NoError(adminService.ExportState(nil, &ExportStateArgs{}, &hashReply))
+	require.Equal(service.vm.state.GetLastAccepted(), hashReply.BlockID)
+
+	// Write the export
+	service.vm.ctx.ChainDataDir = t.TempDir()
+	reply := ExportStateReply{}
+	require.NoError(adminService.ExportState(nil, &ExportStateArgs{Name: "export.json"}, &reply))
+	require.Equal(hashReply, reply)
+
+	path := filepath.Join(service.vm.ctx.ChainDataDir, exportDirName, "export.json")
+	export, err := state.ReadExport(path)
+	require.NoError(err)
+	require.Equal(reply.Hash, export.Hash)
+	require.Equal(reply.Height, export.Height)
+	require.NotEmpty(export.CurrentStakers)
+	require.NotEmpty(export.UTXOs)
+
+	// The state is only exported at the last accepted height
+	err = adminService.ExportState(nil, &ExportStateArgs{Name: "other.json", Height: reply.Height + 1}, &ExportStateReply{})
+	require.ErrorIs(err, errHeightNotLastAccepted)
+	_, err = os.Stat(filepath.Join(service.vm.ctx.ChainDataDir, exportDirName, "other.json"))
+	require.ErrorIs(err, fs.ErrNotExist)
+
+	heightReply := ExportStateReply{}
+	require.NoError(adminService.ExportState(nil, &ExportStateArgs{Height: reply.Height}, &heightReply))
+	require.Equal(reply, heightReply)
+
+	// Existing files aren't overwritten
+	err = adminService.ExportState(nil, &ExportStateArgs{Name: "export.json"}, &ExportStateReply{})
+	require.ErrorIs(err, fs.ErrExist)
+
+	// Exports can't be written outside of the export directory
+	for _, name := range []string{".", "..", "../export.json", "/tmp/export.json"} {
+		err := adminService.ExportState(nil, &ExportStateArgs{Name: name}, &ExportStateReply{})
+		require.ErrorIs(err, errInvalidExportName)
+	}
+}
+
+func TestAdminServiceEnabled(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	handlers, err := vm.CreateHandlers(context.Background())
+	require.NoError(err)
+	require.NotContains(handlers, "/admin")
+
+	vm.AdminAPIEnabled = true
+	handlers, err = vm.CreateHandlers(context.Background())
+	require.NoError(err)
+	require.Contains(handlers, "/admin")
+}
@@ -188,6 +188,12 @@ func (v *verifier) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 		)
 	}
 
+	if err := executor.VerifyFxActivation(v.txExecutorBackend, currentTimestamp, b.Tx); err != nil {
+		txID := b.Tx.ID()
+		v.MarkDropped(txID, err) // cache tx as dropped
+		return fmt.Errorf("tx %s failed semantic verification: %w", txID, err)
+	}
+
 	atomicExecutor := executor.AtomicTxExecutor{
 		Backend:       v.txExecutorBackend,
 		ParentID:      parentID,
@@ -357,6 +363,11 @@ func (v *verifier) proposalBlock(
 	onCommitState state.Diff,
 	onAbortState state.Diff,
 ) error {
+	if err := executor.VerifyFxActivation(v.txExecutorBackend, onAbortState.GetTimestamp(), b.Tx); err != nil {
+		v.MarkDropped(b.Tx.ID(), err) // cache tx as dropped
+		return err
+	}
+
 	txExecutor := executor.ProposalTxExecutor{
 		OnCommitState: onCommitState,
 		OnAbortState:  onAbortState,
@@ -406,6 +417,11 @@ func (v *verifier) standardBlock(
 	// Finally we process the transactions
 	funcs := make([]func(), 0, len(b.Transactions))
 	for _, tx := range b.Transactions {
+		if err := executor.VerifyFxActivation(v.txExecutorBackend, blkState.timestamp, tx); err != nil {
+			v.MarkDropped(tx.ID(), err) // cache tx as dropped
+			return err
+		}
+
 		txExecutor := executor.StandardTxExecutor{
 			Backend: v.txExecutorBackend,
 			State:   onAcceptState,
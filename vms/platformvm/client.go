@@ -70,6 +70,9 @@ type Client interface {
 	//
 	// Deprecated: Subnets should be fetched from a dedicated indexer.
 	GetSubnets(ctx context.Context, subnetIDs []ids.ID, options ...rpc.Option) ([]ClientSubnet, error)
+	// GetSubnetOwner returns the current owner of the specified subnet and
+	// every owner it has had, in the order they became the owner
+	GetSubnetOwner(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ClientSubnetOwner, []ClientSubnetOwner, error)
 	// GetStakingAssetID returns the assetID of the asset used for staking on
 	// subnet corresponding to [subnetID]
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
@@ -79,6 +82,19 @@ type Client interface {
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, error)
+	// EstimateReward returns the reward of staking [amount] on the subnet with
+	// ID [subnetID] for [duration]. If [delegator] is true, the stake is
+	// delegated to a validator charging [delegationFeeRate] percent.
+	// Otherwise, the stake is a validator's stake charging [delegationFeeRate].
+	EstimateReward(
+		ctx context.Context,
+		subnetID ids.ID,
+		amount uint64,
+		duration time.Duration,
+		delegationFeeRate float32,
+		delegator bool,
+		options ...rpc.Option,
+	) (*EstimateRewardReply, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -201,6 +217,13 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// GetMempool returns the transactions that are waiting to be included in
+	// a block.
+	GetMempool(ctx context.Context, options ...rpc.Option) (*GetMempoolReply, error)
+	// SimulateTx executes the signed or unsigned transaction on top of the
+	// preferred block without issuing it. If the transaction is invalid, the
+	// returned reply contains the verification error.
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -408,6 +431,48 @@ func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Op
 	return subnets, nil
 }
 
+// ClientSubnetOwner is a representation of a subnet owner used in client
+// methods
+type ClientSubnetOwner struct {
+	// ID of the CreateSubnetTx or TransferSubnetOwnershipTx that set the owner
+	TxID        ids.ID
+	ControlKeys []ids.ShortID
+	Threshold   uint32
+	Locktime    uint64
+}
+
+func (c *client) GetSubnetOwner(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ClientSubnetOwner, []ClientSubnetOwner, error) {
+	res := &GetSubnetOwnerResponse{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetOwner", &GetSubnetOwnerArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	if err != nil {
+		return ClientSubnetOwner{}, nil, err
+	}
+	owner, err := getClientSubnetOwner(res.Owner)
+	if err != nil {
+		return ClientSubnetOwner{}, nil, err
+	}
+	history := make([]ClientSubnetOwner, len(res.History))
+	for i, apiOwner := range res.History {
+		history[i], err = getClientSubnetOwner(apiOwner)
+		if err != nil {
+			return ClientSubnetOwner{}, nil, err
+		}
+	}
+	return owner, history, nil
+}
+
+func getClientSubnetOwner(apiOwner APISubnetOwner) (ClientSubnetOwner, error) {
+	controlKeys, err := address.ParseToIDs(apiOwner.ControlKeys)
+	return ClientSubnetOwner{
+		TxID:        apiOwner.TxID,
+		ControlKeys: controlKeys,
+		Threshold:   uint32(apiOwner.Threshold),
+		Locktime:    uint64(apiOwner.Locktime),
+	}, err
+}
+
 func (c *client) GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error) {
 	res := &GetStakingAssetIDResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getStakingAssetID", &GetStakingAssetIDArgs{
@@ -455,6 +520,26 @@ func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options
 	return uint64(res.Supply), err
 }
 
+func (c *client) EstimateReward(
+	ctx context.Context,
+	subnetID ids.ID,
+	amount uint64,
+	duration time.Duration,
+	delegationFeeRate float32,
+	delegator bool,
+	options ...rpc.Option,
+) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateReward", &EstimateRewardArgs{
+		SubnetID:          subnetID,
+		Amount:            json.Uint64(amount),
+		Duration:          json.Uint64(duration / time.Second),
+		DelegationFeeRate: json.Float32(delegationFeeRate),
+		Delegator:         delegator,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
@@ -710,6 +795,26 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -32,6 +32,10 @@ type Config struct {
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
+	// Reports the uptime of this node as observed by its peers. If nil, the
+	// projected uptime of this node isn't reported.
+	ObservedUptimeCalculator uptime.ObservedCalculator
+
 	// True if the node is being run with staking enabled
 	SybilProtectionEnabled bool
 
@@ -101,6 +105,9 @@ type Config struct {
 	// Time of the Cortina network upgrade
 	CortinaTime time.Time
 
+	// Time of the Durango network upgrade
+	DurangoTime time.Time
+
 	// UseCurrentHeight forces [GetMinimumHeight] to return the current height
 	// of the P-Chain instead of the oldest block in the [recentlyAccepted]
 	// window.
@@ -109,6 +116,34 @@ type Config struct {
 	// on recently created subnets (without this, users need to wait for
 	// [recentlyAcceptedWindowTTL] to pass for activation to occur).
 	UseCurrentHeight bool
+
+	// ValidatorSetCheckpointInterval is the number of blocks between persisted
+	// snapshots of every subnet's validator set. Historical validator sets are
+	// rebuilt starting from the nearest snapshot. If 0, no snapshots are
+	// written.
+	ValidatorSetCheckpointInterval uint64
+
+	// ValidatorSetCheckpointRetention is the number of validator set snapshots
+	// kept per subnet. If 0, all snapshots are kept.
+	ValidatorSetCheckpointRetention uint64
+
+	// AdminAPIEnabled enables the P-chain admin API, which exports the chain
+	// state.
+	AdminAPIEnabled bool
+
+	// UptimeHealthCheckEnabled causes the health check to fail if the uptime
+	// of this node at the end of one of its staking periods is projected to
+	// be below the uptime requirement plus [UptimeHealthCheckMargin].
+	UptimeHealthCheckEnabled bool
+
+	// UptimeHealthCheckMargin is the fraction of the staking period added to
+	// the uptime requirement when checking the projected uptime of this node.
+	UptimeHealthCheckMargin float64
+
+	// UptimeHealthCheckAssumedUptime is the uptime this node is assumed to
+	// have for the rest of its staking periods when projecting its uptime. If
+	// 0, the uptime observed so far is assumed.
+	UptimeHealthCheckAssumedUptime float64
 }
 
 func (c *Config) IsApricotPhase3Activated(timestamp time.Time) bool {
@@ -123,6 +158,10 @@ func (c *Config) IsBanffActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.BanffTime)
 }
 
+func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.DurangoTime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package exporter exports the P-chain state from the database of a node that
+// isn't running.
+package exporter
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+var (
+	// vmDBPrefix must match the prefix the chain manager gives the database
+	// of every VM.
+	vmDBPrefix = []byte("vm")
+
+	errNoState = errors.New("the database doesn't contain a P-chain state")
+)
+
+// Export returns the export of the last accepted P-chain state stored in [db],
+// the current database of a node.
+//
+// [db] isn't modified.
+func Export(db database.Database) (*state.Export, error) {
+	chainDB := prefixdb.New(constants.PlatformChainID[:], db)
+	vmDB := prefixdb.New(vmDBPrefix, chainDB)
+	isEmpty, err := database.IsEmpty(vmDB)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return nil, errNoState
+	}
+
+	vdrs := validators.NewManager()
+	_ = vdrs.Add(constants.PrimaryNetworkID, validators.NewSet())
+	cfg := &config.Config{
+		Validators: vdrs,
+	}
+
+	// Loading the state may write to the database, so all the writes are
+	// buffered in memory and dropped.
+	s, err := state.New(
+		versiondb.New(vmDB),
+		nil,
+		prometheus.NewRegistry(),
+		cfg,
+		&snow.Context{
+			Log: logging.NoLog{},
+		},
+		metrics.Noop,
+		reward.NewCalculator(cfg.RewardConfig),
+		&utils.Atomic[bool]{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.Export()
+	errs := wrappers.Errs{}
+	errs.Add(err, s.Close())
+	return export, errs.Err
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+func TestExport(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	_, err := Export(db)
+	require.ErrorIs(err, errNoState)
+
+	genesisBytes, _, err := genesis.FromConfig(&genesis.LocalConfig)
+	require.NoError(err)
+
+	vdrs := validators.NewManager()
+	_ = vdrs.Add(constants.PrimaryNetworkID, validators.NewSet())
+	cfg := &config.Config{
+		Validators:   vdrs,
+		RewardConfig: genesis.LocalParams.RewardConfig,
+	}
+	vmDB := prefixdb.New(vmDBPrefix, prefixdb.New(constants.PlatformChainID[:], db))
+	s, err := state.New(
+		vmDB,
+		genesisBytes,
+		prometheus.NewRegistry(),
+		cfg,
+		&snow.Context{
+			NetworkID: constants.LocalID,
+			Log:       logging.NoLog{},
+		},
+		metrics.Noop,
+		reward.NewCalculator(cfg.RewardConfig),
+		&utils.Atomic[bool]{},
+	)
+	require.NoError(err)
+
+	expectedExport, err := s.Export()
+	require.NoError(err)
+	require.NoError(s.Close())
+
+	dbSize, err := database.Size(db)
+	require.NoError(err)
+
+	export, err := Export(db)
+	require.NoError(err)
+	require.Equal(expectedExport, export)
+
+	// The database must not be modified
+	newDBSize, err := database.Size(db)
+	require.NoError(err)
+	require.Equal(dbSize, newDBSize)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/exporter"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+const (
+	dbDirKey      = "db-dir"
+	heightKey     = "height"
+	outputFileKey = "output-file"
+	verifyFileKey = "verify-file"
+)
+
+var (
+	errNoDBDir               = errors.New("--db-dir must be provided")
+	errHeightNotLastAccepted = errors.New("height isn't the last accepted height of the database")
+	errHeightMismatch        = errors.New("exports are at different heights")
+	errHashMismatch          = errors.New("exports have different hashes")
+)
+
+func main() {
+	fs := pflag.NewFlagSet("platformvm-exporter", pflag.ContinueOnError)
+	fs.String(dbDirKey, "", "Database directory of the network of the node, e.g. ~/.avalanchego/db/mainnet. The node must not be running")
+	fs.Uint64(heightKey, 0, "Height the state is expected to be exported at. Only the last accepted state is stored, so a database can only be exported at the height the node stopped at. If non-zero and the last accepted height differs, nothing is exported")
+	fs.String(outputFileKey, "", "Path of the file the export is written to. If empty, the export isn't written")
+	fs.String(verifyFileKey, "", "Path of an export, possibly created by another node, to compare against")
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := run(fs); err != nil {
+		fmt.Printf("%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(fs *pflag.FlagSet) error {
+	dbDir, _ := fs.GetString(dbDirKey)
+	if dbDir == "" {
+		return errNoDBDir
+	}
+
+	dbPath := filepath.Join(dbDir, version.CurrentDatabase.String())
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("couldn't find database: %w", err)
+	}
+	db, err := leveldb.New(dbPath, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open database at %s: %w", dbPath, err)
+	}
+	defer db.Close()
+
+	export, err := exporter.Export(db)
+	if err != nil {
+		return fmt.Errorf("couldn't export state: %w", err)
+	}
+	if height, _ := fs.GetUint64(heightKey); height != 0 && height != uint64(export.Height) {
+		return fmt.Errorf("%w: %d != %d", errHeightNotLastAccepted, height, export.Height)
+	}
+	fmt.Printf("exported state at height %d, block %s, with hash %s\n", export.Height, export.BlockID, export.Hash)
+
+	if outputFile, _ := fs.GetString(outputFileKey); outputFile != "" {
+		if err := state.WriteExport(outputFile, export); err != nil {
+			return fmt.Errorf("couldn't write export: %w", err)
+		}
+		fmt.Printf("wrote export to %s\n", outputFile)
+	}
+
+	verifyFile, _ := fs.GetString(verifyFileKey)
+	if verifyFile == "" {
+		return nil
+	}
+
+	expectedExport, err := state.ReadExport(verifyFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read export to verify: %w", err)
+	}
+	// The exports of nodes that stopped at different heights can't be
+	// compared, as neither state can be exported at the other's height.
+	switch {
+	case expectedExport.Height != export.Height:
+		return fmt.Errorf("%w: %d != %d", errHeightMismatch, expectedExport.Height, export.Height)
+	case expectedExport.Hash != export.Hash:
+		return fmt.Errorf("%w: %s != %s", errHashMismatch, expectedExport.Hash, export.Hash)
+	}
+	fmt.Printf("verified export %s\n", verifyFile)
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fx
+
+import (
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+)
+
+var (
+	_ Fx    = (*SECPFx)(nil)
+	_ Owner = (*secp256r1fx.OutputOwners)(nil)
+	_ Owned = (*secp256r1fx.TransferOutput)(nil)
+)
+
+// SECPFx routes verification to the secp256k1fx or the secp256r1fx, based on
+// the type of the output or owner being verified.
+type SECPFx struct {
+	Secp256k1Fx secp256k1fx.Fx
+	Secp256r1Fx secp256r1fx.Fx
+}
+
+func (fx *SECPFx) Initialize(vm interface{}) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		fx.Secp256k1Fx.Initialize(vm),
+		fx.Secp256r1Fx.Initialize(vm),
+	)
+	return errs.Err
+}
+
+func (fx *SECPFx) Bootstrapping() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		fx.Secp256k1Fx.Bootstrapping(),
+		fx.Secp256r1Fx.Bootstrapping(),
+	)
+	return errs.Err
+}
+
+func (fx *SECPFx) Bootstrapped() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		fx.Secp256k1Fx.Bootstrapped(),
+		fx.Secp256r1Fx.Bootstrapped(),
+	)
+	return errs.Err
+}
+
+func (fx *SECPFx) VerifyTransfer(tx, in, cred, utxo interface{}) error {
+	if _, ok := utxo.(*secp256r1fx.TransferOutput); ok {
+		return fx.Secp256r1Fx.VerifyTransfer(tx, in, cred, utxo)
+	}
+	return fx.Secp256k1Fx.VerifyTransfer(tx, in, cred, utxo)
+}
+
+func (fx *SECPFx) VerifyPermission(tx, in, cred, controlGroup interface{}) error {
+	if _, ok := controlGroup.(*secp256r1fx.OutputOwners); ok {
+		return fx.Secp256r1Fx.VerifyPermission(tx, in, cred, controlGroup)
+	}
+	return fx.Secp256k1Fx.VerifyPermission(tx, in, cred, controlGroup)
+}
+
+func (fx *SECPFx) CreateOutput(amount uint64, controlGroup interface{}) (interface{}, error) {
+	if _, ok := controlGroup.(*secp256r1fx.OutputOwners); ok {
+		return fx.Secp256r1Fx.CreateOutput(amount, controlGroup)
+	}
+	return fx.Secp256k1Fx.CreateOutput(amount, controlGroup)
+}
@@ -5,14 +5,39 @@ package platformvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
+var errLowProjectedUptime = errors.New("projected uptime is below the uptime requirement")
+
+// uptimeHealth reports the uptime this node is projected to have at the end of
+// its current staking periods.
+type uptimeHealth struct {
+	// ProjectedUptimes maps the subnets validated by this node to the uptime
+	// this node is projected to have at the end of its staking period.
+	ProjectedUptimes map[ids.ID]float64 `json:"projectedUptimes"`
+	// RequiredUptimes maps the subnets validated by this node to the uptime
+	// this node is required to have to be rewarded.
+	RequiredUptimes map[ids.ID]float64 `json:"requiredUptimes"`
+}
+
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
+	health := &uptimeHealth{
+		ProjectedUptimes: make(map[ids.ID]float64),
+		RequiredUptimes:  make(map[ids.ID]float64),
+	}
+
 	localPrimaryValidator, err := vm.state.GetCurrentValidator(
 		constants.PrimaryNetworkID,
 		vm.ctx.NodeID,
@@ -20,6 +45,9 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 	switch err {
 	case nil:
 		vm.metrics.SetTimeUntilUnstake(time.Until(localPrimaryValidator.EndTime))
+		if err := vm.projectLocalUptime(localPrimaryValidator, health); err != nil {
+			return nil, err
+		}
 	case database.ErrNotFound:
 		vm.metrics.SetTimeUntilUnstake(0)
 	default:
@@ -34,11 +62,106 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		switch err {
 		case nil:
 			vm.metrics.SetTimeUntilSubnetUnstake(subnetID, time.Until(localSubnetValidator.EndTime))
+			if err := vm.projectLocalUptime(localSubnetValidator, health); err != nil {
+				return nil, err
+			}
 		case database.ErrNotFound:
-			vm.metrics.SetTimeUntilSubnetUnstake(subnetID, 0)
+			vm.metrics.DeleteSubnetUptime(subnetID)
 		default:
 			return nil, fmt.Errorf("couldn't get current subnet validator of %q: %w", subnetID, err)
 		}
 	}
-	return nil, nil
+
+	if !vm.UptimeHealthCheckEnabled {
+		return health, nil
+	}
+
+	var lowUptimeSubnetIDs []ids.ID
+	for subnetID, projectedUptime := range health.ProjectedUptimes {
+		if projectedUptime < health.RequiredUptimes[subnetID]+vm.UptimeHealthCheckMargin {
+			lowUptimeSubnetIDs = append(lowUptimeSubnetIDs, subnetID)
+		}
+	}
+	if len(lowUptimeSubnetIDs) > 0 {
+		return health, fmt.Errorf("%w on subnets %v", errLowProjectedUptime, lowUptimeSubnetIDs)
+	}
+	return health, nil
+}
+
+// projectLocalUptime adds to [health] the uptime this node is projected to
+// have at the end of the staking period of [localValidator], if this node can
+// be rewarded for it.
+func (vm *VM) projectLocalUptime(localValidator *state.Staker, health *uptimeHealth) error {
+	if vm.ObservedUptimeCalculator == nil {
+		return nil
+	}
+
+	subnetID := localValidator.SubnetID
+	requiredUptime := vm.UptimePercentage
+	if subnetID != constants.PrimaryNetworkID {
+		transformSubnetIntf, err := vm.state.GetSubnetTransformation(subnetID)
+		if err == database.ErrNotFound {
+			// Validators of permissioned subnets aren't rewarded.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get transformation of subnet %q: %w", subnetID, err)
+		}
+		transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+		if !ok {
+			return fmt.Errorf("expected tx type *txs.TransformSubnetTx but got %T", transformSubnetIntf.Unsigned)
+		}
+		requiredUptime = float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator
+	}
+
+	observedUptime, err := vm.ObservedUptimeCalculator.ObservedUptimePercent(subnetID)
+	if err != nil {
+		// The uptime of this node may not be observed yet, for example if the
+		// validator set hasn't been updated.
+		vm.ctx.Log.Debug("couldn't get observed uptime",
+			zap.Stringer("subnetID", subnetID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	assumedUptime := observedUptime
+	if vm.UptimeHealthCheckAssumedUptime > 0 {
+		assumedUptime = vm.UptimeHealthCheckAssumedUptime
+	}
+	projectedUptime := projectUptime(
+		observedUptime,
+		assumedUptime,
+		localValidator.StartTime,
+		vm.clock.Time(),
+		localValidator.EndTime,
+	)
+	if subnetID == constants.PrimaryNetworkID {
+		vm.metrics.SetProjectedUptime(projectedUptime)
+	} else {
+		vm.metrics.SetProjectedSubnetUptime(subnetID, projectedUptime)
+	}
+
+	health.ProjectedUptimes[subnetID] = projectedUptime
+	health.RequiredUptimes[subnetID] = requiredUptime
+	return nil
+}
+
+// projectUptime returns the uptime at [end] of a staking period that started
+// at [start], if the uptime at [now] is [observedUptime] and the uptime from
+// [now] until [end] is [assumedUptime].
+func projectUptime(observedUptime, assumedUptime float64, start, now, end time.Time) float64 {
+	if !end.After(start) {
+		return observedUptime
+	}
+	if now.Before(start) {
+		now = start
+	}
+	if now.After(end) {
+		now = end
+	}
+
+	elapsed := float64(now.Sub(start))
+	remaining := float64(end.Sub(now))
+	return (observedUptime*elapsed + assumedUptime*remaining) / (elapsed + remaining)
 }
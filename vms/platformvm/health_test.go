@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+type testObservedUptimeCalculator map[ids.ID]float64
+
+func (c testObservedUptimeCalculator) ObservedUptimePercent(subnetID ids.ID) (float64, error) {
+	return c[subnetID], nil
+}
+
+func TestProjectUptime(t *testing.T) {
+	start := time.Unix(1_000, 0)
+	end := start.Add(100 * time.Second)
+
+	tests := []struct {
+		name           string
+		observedUptime float64
+		assumedUptime  float64
+		now            time.Time
+		expected       float64
+	}{
+		{
+			name:           "start of period",
+			observedUptime: 0,
+			assumedUptime:  1,
+			now:            start,
+			expected:       1,
+		},
+		{
+			name:           "middle of period",
+			observedUptime: .5,
+			assumedUptime:  1,
+			now:            start.Add(50 * time.Second),
+			expected:       .75,
+		},
+		{
+			name:           "middle of period at observed uptime",
+			observedUptime: .5,
+			assumedUptime:  .5,
+			now:            start.Add(50 * time.Second),
+			expected:       .5,
+		},
+		{
+			name:           "end of period",
+			observedUptime: .5,
+			assumedUptime:  1,
+			now:            end,
+			expected:       .5,
+		},
+		{
+			name:           "before period",
+			observedUptime: 0,
+			assumedUptime:  1,
+			now:            start.Add(-time.Second),
+			expected:       1,
+		},
+		{
+			name:           "after period",
+			observedUptime: .5,
+			assumedUptime:  1,
+			now:            end.Add(time.Second),
+			expected:       .5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.InDelta(
+				t,
+				test.expected,
+				projectUptime(test.observedUptime, test.assumedUptime, start, test.now, end),
+				1e-9,
+			)
+		})
+	}
+}
+
+func TestHealthCheckProjectedUptime(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vm.ctx.NodeID = ids.NodeID(keys[0].PublicKey().Address())
+	vm.UptimePercentage = .8
+	vm.UptimeHealthCheckEnabled = true
+	vm.UptimeHealthCheckMargin = .05
+
+	// Halfway through the staking period of the genesis validators
+	vm.clock.Set(defaultValidateStartTime.Add(defaultValidateEndTime.Sub(defaultValidateStartTime) / 2))
+
+	// Keeping the observed uptime for the rest of the staking period would
+	// result in an uptime of 90%.
+	vm.ObservedUptimeCalculator = testObservedUptimeCalculator{
+		constants.PrimaryNetworkID: .9,
+	}
+	healthIntf, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	health := healthIntf.(*uptimeHealth)
+	require.InDelta(.9, health.ProjectedUptimes[constants.PrimaryNetworkID], 1e-9)
+	require.InDelta(.8, health.RequiredUptimes[constants.PrimaryNetworkID], 1e-9)
+
+	// Keeping the observed uptime for the rest of the staking period would
+	// result in an uptime of 84%, which is within the margin of the
+	// requirement.
+	vm.ObservedUptimeCalculator = testObservedUptimeCalculator{
+		constants.PrimaryNetworkID: .84,
+	}
+	_, err = vm.HealthCheck(context.Background())
+	require.ErrorIs(err, errLowProjectedUptime)
+
+	// Staying online for the rest of the staking period would result in an
+	// uptime of 92%.
+	vm.UptimeHealthCheckAssumedUptime = 1
+	healthIntf, err = vm.HealthCheck(context.Background())
+	require.NoError(err)
+	health = healthIntf.(*uptimeHealth)
+	require.InDelta(.92, health.ProjectedUptimes[constants.PrimaryNetworkID], 1e-9)
+
+	// Staying online for the rest of the staking period would result in an
+	// uptime of 84%.
+	vm.ObservedUptimeCalculator = testObservedUptimeCalculator{
+		constants.PrimaryNetworkID: .68,
+	}
+	_, err = vm.HealthCheck(context.Background())
+	require.ErrorIs(err, errLowProjectedUptime)
+
+	// The projected uptime is only reported if the health check is disabled.
+	vm.UptimeHealthCheckEnabled = false
+	_, err = vm.HealthCheck(context.Background())
+	require.NoError(err)
+}
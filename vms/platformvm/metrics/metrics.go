@@ -42,6 +42,14 @@ type Metrics interface {
 	SetTimeUntilUnstake(time.Duration)
 	// Mark when this node will unstake from a subnet.
 	SetTimeUntilSubnetUnstake(subnetID ids.ID, timeUntilUnstake time.Duration)
+	// Mark the uptime this node is projected to have at the end of its
+	// staking period on the Primary Network.
+	SetProjectedUptime(float64)
+	// Mark the uptime this node is projected to have at the end of its
+	// staking period on a subnet.
+	SetProjectedSubnetUptime(subnetID ids.ID, projectedUptime float64)
+	// Mark that this node doesn't validate a subnet anymore.
+	DeleteSubnetUptime(subnetID ids.ID)
 }
 
 func New(
@@ -64,6 +72,19 @@ func New(
 			},
 			[]string{"subnetID"},
 		),
+		projectedUptime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "projected_uptime",
+			Help:      "Uptime (in [0, 1]) this node is projected to have at the end of its staking period on the Primary Network",
+		}),
+		projectedSubnetUptime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "projected_uptime_subnet",
+				Help:      "Uptime (in [0, 1]) this node is projected to have at the end of its staking period on the subnet",
+			},
+			[]string{"subnetID"},
+		),
 		localStake: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "local_staked",
@@ -115,6 +136,8 @@ func New(
 		err,
 		registerer.Register(m.timeUntilUnstake),
 		registerer.Register(m.timeUntilSubnetUnstake),
+		registerer.Register(m.projectedUptime),
+		registerer.Register(m.projectedSubnetUptime),
 		registerer.Register(m.localStake),
 		registerer.Register(m.totalStake),
 
@@ -137,6 +160,8 @@ type metrics struct {
 
 	timeUntilUnstake       prometheus.Gauge
 	timeUntilSubnetUnstake *prometheus.GaugeVec
+	projectedUptime        prometheus.Gauge
+	projectedSubnetUptime  *prometheus.GaugeVec
 	localStake             prometheus.Gauge
 	totalStake             prometheus.Gauge
 
@@ -191,3 +216,17 @@ func (m *metrics) SetTimeUntilUnstake(timeUntilUnstake time.Duration) {
 func (m *metrics) SetTimeUntilSubnetUnstake(subnetID ids.ID, timeUntilUnstake time.Duration) {
 	m.timeUntilSubnetUnstake.WithLabelValues(subnetID.String()).Set(float64(timeUntilUnstake))
 }
+
+func (m *metrics) SetProjectedUptime(projectedUptime float64) {
+	m.projectedUptime.Set(projectedUptime)
+}
+
+func (m *metrics) SetProjectedSubnetUptime(subnetID ids.ID, projectedUptime float64) {
+	m.projectedSubnetUptime.WithLabelValues(subnetID.String()).Set(projectedUptime)
+}
+
+func (m *metrics) DeleteSubnetUptime(subnetID ids.ID) {
+	subnetIDStr := subnetID.String()
+	m.timeUntilSubnetUnstake.DeleteLabelValues(subnetIDStr)
+	m.projectedSubnetUptime.DeleteLabelValues(subnetIDStr)
+}
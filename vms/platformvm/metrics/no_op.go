@@ -47,6 +47,12 @@ func (noopMetrics) SetTimeUntilUnstake(time.Duration) {}
 
 func (noopMetrics) SetTimeUntilSubnetUnstake(ids.ID, time.Duration) {}
 
+func (noopMetrics) SetProjectedUptime(float64) {}
+
+func (noopMetrics) SetProjectedSubnetUptime(ids.ID, float64) {}
+
+func (noopMetrics) DeleteSubnetUptime(ids.ID) {}
+
 func (noopMetrics) SetSubnetPercentConnected(ids.ID, float64) {}
 
 func (noopMetrics) SetPercentConnected(float64) {}
@@ -27,7 +27,10 @@ type txMetrics struct {
 	numRemoveSubnetValidatorTxs,
 	numTransformSubnetTxs,
 	numAddPermissionlessValidatorTxs,
-	numAddPermissionlessDelegatorTxs prometheus.Counter
+	numAddPermissionlessDelegatorTxs,
+	numTransferSubnetOwnershipTxs,
+	numSetAutoRenewTxs,
+	numSetSubnetValidatorWeightTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -49,6 +52,9 @@ func newTxMetrics(
 		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
 		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
 		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
+		numSetAutoRenewTxs:               newTxMetric(namespace, "set_auto_renew", registerer, &errs),
+		numSetSubnetValidatorWeightTxs:   newTxMetric(namespace, "set_subnet_validator_weight", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -132,3 +138,18 @@ func (m *txMetrics) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegator
 	m.numAddPermissionlessDelegatorTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	m.numTransferSubnetOwnershipTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) SetAutoRenewTx(*txs.SetAutoRenewTx) error {
+	m.numSetAutoRenewTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	m.numSetSubnetValidatorWeightTxs.Inc()
+	return nil
+}
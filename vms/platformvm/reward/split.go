@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import "github.com/ava-labs/avalanchego/utils/math"
+
+// Split [totalAmount] into [totalAmount * shares percentage] and the remainder.
+//
+// Invariant: [shares] <= [PercentDenominator]
+func Split(totalAmount uint64, shares uint32) (uint64, uint64) {
+	remainderShares := PercentDenominator - uint64(shares)                  // shares <= PercentDenominator so no underflow
+	remainderAmount := remainderShares * (totalAmount / PercentDenominator) // remainderShares <= PercentDenominator so no overflow
+
+	// Delay rounding as long as possible for small numbers
+	if optimisticReward, err := math.Mul64(remainderShares, totalAmount); err == nil {
+		remainderAmount = optimisticReward / PercentDenominator
+	}
+
+	amountFromShares := totalAmount - remainderAmount // remainderAmount <= totalAmount so no underflow
+	return amountFromShares, remainderAmount
+}
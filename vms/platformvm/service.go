@@ -31,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -40,9 +41,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/blocks/builder"
 )
 
 const (
@@ -59,25 +62,68 @@ const (
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
+
+	// Period used to annualize estimated staking rewards
+	year = 365 * 24 * time.Hour
+
+	// Max number of blocks that can be returned by GetBlocksByHeightRange
+	maxGetBlocksPageSize = 100
+
+	// Max number of blocks that are scanned by a single call to
+	// GetTxsByFilter
+	maxGetTxsScannedBlocks = 16 * builder.MaxPageSize
 )
 
 var (
-	errMissingDecisionBlock     = errors.New("should have a decision block within the past two blocks")
-	errNoSubnetID               = errors.New("argument 'subnetID' not provided")
-	errNoRewardAddress          = errors.New("argument 'rewardAddress' not provided")
-	errInvalidDelegationRate    = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
-	errNoAddresses              = errors.New("no addresses provided")
-	errNoKeys                   = errors.New("user has no keys or funds")
-	errStartTimeTooSoon         = fmt.Errorf("start time must be at least %s in the future", minAddStakerDelay)
-	errStartTimeTooLate         = errors.New("start time is too far in the future")
-	errNamedSubnetCantBePrimary = errors.New("subnet validator attempts to validate primary network")
-	errNoAmount                 = errors.New("argument 'amount' must be > 0")
-	errMissingName              = errors.New("argument 'name' not given")
-	errMissingVMID              = errors.New("argument 'vmID' not given")
-	errMissingBlockchainID      = errors.New("argument 'blockchainID' not given")
-	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
-	errStartAfterEndTime        = errors.New("start time must be before end time")
-	errStartTimeInThePast       = errors.New("start time in the past")
+	errMissingDecisionBlock       = errors.New("should have a decision block within the past two blocks")
+	errNoSubnetID                 = errors.New("argument 'subnetID' not provided")
+	errNoRewardAddress            = errors.New("argument 'rewardAddress' not provided")
+	errInvalidDelegationRate      = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
+	errNoAddresses                = errors.New("no addresses provided")
+	errNoKeys                     = errors.New("user has no keys or funds")
+	errStartTimeTooSoon           = fmt.Errorf("start time must be at least %s in the future", minAddStakerDelay)
+	errStartTimeTooLate           = errors.New("start time is too far in the future")
+	errNamedSubnetCantBePrimary   = errors.New("subnet validator attempts to validate primary network")
+	errNoAmount                   = errors.New("argument 'amount' must be > 0")
+	errNoDuration                 = errors.New("argument 'duration' must be > 0")
+	errMissingName                = errors.New("argument 'name' not given")
+	errMissingVMID                = errors.New("argument 'vmID' not given")
+	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errMissingPrivateKey          = errors.New("argument 'privateKey' not given")
+	errStartAfterEndTime          = errors.New("start time must be before end time")
+	errStartTimeInThePast         = errors.New("start time in the past")
+	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
+	errStartHeightAfterEndHeight  = errors.New("start height must not be after end height")
+	errStartHeightNotAccepted     = errors.New("start height is above the last accepted height")
+	errUnknownTxType              = errors.New("unknown tx type")
+
+	// filterableTxTypes are the names of the tx types that txs can be filtered
+	// by.
+	filterableTxTypes = func() set.Set[string] {
+		utxs := []txs.UnsignedTx{
+			&txs.AddValidatorTx{},
+			&txs.AddSubnetValidatorTx{},
+			&txs.AddDelegatorTx{},
+			&txs.CreateChainTx{},
+			&txs.CreateSubnetTx{},
+			&txs.ImportTx{},
+			&txs.ExportTx{},
+			&txs.AdvanceTimeTx{},
+			&txs.RewardValidatorTx{},
+			&txs.RemoveSubnetValidatorTx{},
+			&txs.TransformSubnetTx{},
+			&txs.AddPermissionlessValidatorTx{},
+			&txs.AddPermissionlessDelegatorTx{},
+			&txs.TransferSubnetOwnershipTx{},
+			&txs.SetAutoRenewTx{},
+			&txs.SetSubnetValidatorWeightTx{},
+		}
+		names := set.NewSet[string](len(utxs))
+		for _, utx := range utxs {
+			names.Add(mempool.TxType(utx))
+		}
+		return names
+	}()
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -554,8 +600,11 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 				continue
 			}
 
-			unsignedTx := subnet.Unsigned.(*txs.CreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			ownerIntf, err := s.vm.state.GetSubnetOwner(subnetID)
+			if err != nil {
+				return err
+			}
+			owner := ownerIntf.(*secp256k1fx.OutputOwners)
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := s.addrManager.FormatLocalAddress(controlKeyID)
@@ -614,13 +663,16 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 			return err
 		}
 
-		subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-		if !ok {
+		if _, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx); !ok {
 			return fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
 		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+		ownerIntf, err := s.vm.state.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 		if !ok {
-			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnet.Owner)
+			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", ownerIntf)
 		}
 
 		controlAddrs := make([]string, len(owner.Addrs))
@@ -641,6 +693,98 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 	return nil
 }
 
+// GetSubnetOwnerArgs are the arguments to GetSubnetOwner
+type GetSubnetOwnerArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// APISubnetOwner is an owner of a subnet and the tx that made it the owner
+type APISubnetOwner struct {
+	// ID of the CreateSubnetTx or TransferSubnetOwnershipTx that set the owner
+	TxID ids.ID `json:"txID"`
+
+	ControlKeys []string    `json:"controlKeys"`
+	Threshold   json.Uint32 `json:"threshold"`
+	Locktime    json.Uint64 `json:"locktime"`
+}
+
+// GetSubnetOwnerResponse is the response from calling GetSubnetOwner
+type GetSubnetOwnerResponse struct {
+	// Current owner of the subnet
+	Owner APISubnetOwner `json:"owner"`
+	// Every owner the subnet has had, in the order they became the owner. The
+	// first owner was set by the CreateSubnetTx of the subnet and the last
+	// owner is the current owner.
+	History []APISubnetOwner `json:"history"`
+}
+
+// GetSubnetOwner returns the current owner of a subnet and the history of its
+// ownership.
+func (s *Service) GetSubnetOwner(_ *http.Request, args *GetSubnetOwnerArgs, response *GetSubnetOwnerResponse) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetOwner"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkIsNotASubnet
+	}
+
+	subnetTx, _, err := s.vm.state.GetTx(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get subnet %q: %w", args.SubnetID, err)
+	}
+	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
+	}
+
+	transfers, err := s.vm.state.GetSubnetOwnershipTransfers(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get ownership transfers of subnet %q: %w", args.SubnetID, err)
+	}
+
+	response.History = make([]APISubnetOwner, 0, len(transfers)+1)
+	owner, err := s.getAPISubnetOwner(args.SubnetID, subnet.Owner)
+	if err != nil {
+		return err
+	}
+	response.History = append(response.History, owner)
+	for _, transferTx := range transfers {
+		transfer := transferTx.Unsigned.(*txs.TransferSubnetOwnershipTx)
+		owner, err := s.getAPISubnetOwner(transferTx.ID(), transfer.Owner)
+		if err != nil {
+			return err
+		}
+		response.History = append(response.History, owner)
+	}
+	response.Owner = response.History[len(response.History)-1]
+	return nil
+}
+
+func (s *Service) getAPISubnetOwner(txID ids.ID, ownerIntf fx.Owner) (APISubnetOwner, error) {
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return APISubnetOwner{}, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", ownerIntf)
+	}
+
+	controlAddrs := make([]string, len(owner.Addrs))
+	for i, controlKeyID := range owner.Addrs {
+		addr, err := s.addrManager.FormatLocalAddress(controlKeyID)
+		if err != nil {
+			return APISubnetOwner{}, fmt.Errorf("problem formatting address: %w", err)
+		}
+		controlAddrs[i] = addr
+	}
+	return APISubnetOwner{
+		TxID:        txID,
+		ControlKeys: controlAddrs,
+		Threshold:   json.Uint32(owner.Threshold),
+		Locktime:    json.Uint64(owner.Locktime),
+	}, nil
+}
+
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
 type GetStakingAssetIDArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1093,6 +1237,81 @@ func (s *Service) GetCurrentSupply(_ *http.Request, args *GetCurrentSupplyArgs,
 	return err
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// ID of the subnet the stake would be added to.
+	// If omitted, defaults to the primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// Amount of the staking asset being staked
+	Amount json.Uint64 `json:"amount"`
+	// Staking duration, in seconds
+	Duration json.Uint64 `json:"duration"`
+	// Delegation fee charged by the validator, as a percentage
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+	// If true, the stake is delegated to a validator charging
+	// [DelegationFeeRate]. Otherwise, the stake is a validator's stake.
+	Delegator bool `json:"delegator"`
+}
+
+// EstimateRewardReply is the response from calling EstimateReward
+type EstimateRewardReply struct {
+	// Supply of the staking asset the reward was calculated against
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Total reward generated by the stake
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Part of the potential reward paid to the staker
+	Reward json.Uint64 `json:"reward"`
+	// Part of the potential reward paid to the validator as a delegation fee.
+	// Always 0 for validators.
+	DelegationFee json.Uint64 `json:"delegationFee"`
+	// Annualized return of [Reward] on the stake, as a percentage
+	APR json.Float64 `json:"apr"`
+}
+
+// EstimateReward returns the reward a validator or delegator would receive for
+// staking the given amount for the given duration, assuming it meets its
+// uptime requirement. The reward is calculated against the last accepted
+// state.
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateReward"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	switch {
+	case args.Amount == 0:
+		return errNoAmount
+	case args.Duration == 0:
+		return errNoDuration
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	duration := time.Duration(args.Duration) * time.Second
+	estimate, err := executor.EstimateReward(
+		s.vm.txExecutorBackend,
+		s.vm.state,
+		args.SubnetID,
+		uint64(args.Amount),
+		duration,
+		uint32(10000*args.DelegationFeeRate), // Shares
+		args.Delegator,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't estimate reward: %w", err)
+	}
+
+	reply.CurrentSupply = json.Uint64(estimate.CurrentSupply)
+	reply.PotentialReward = json.Uint64(estimate.PotentialReward)
+	reply.Reward = json.Uint64(estimate.StakerReward)
+	reply.DelegationFee = json.Uint64(estimate.DelegationFee)
+
+	rewardRate := float64(estimate.StakerReward) / float64(args.Amount)
+	reply.APR = json.Float64(100 * rewardRate * float64(year) / float64(duration))
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -2138,6 +2357,177 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+// MempoolTx describes a transaction in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type of the transaction, e.g. "AddValidatorTx"
+	Type string      `json:"type"`
+	Size json.Uint64 `json:"size"`
+	// Unix time the transaction was added to the mempool
+	AddedTime json.Uint64 `json:"addedTime"`
+	// Unix time the staker starts staking. Only set for staker transactions.
+	StartTime *json.Uint64 `json:"startTime,omitempty"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	// Transactions in the mempool, ordered by the time they were added
+	Txs            []MempoolTx `json:"txs"`
+	NumTxs         json.Uint64 `json:"numTxs"`
+	NumBytes       json.Uint64 `json:"numBytes"`
+	NumStakerTxs   json.Uint64 `json:"numStakerTxs"`
+	NumStakerBytes json.Uint64 `json:"numStakerBytes"`
+	// Number of transactions in the mempool by type
+	NumTxsByType map[string]json.Uint64 `json:"numTxsByType"`
+}
+
+// GetMempool returns the transactions that are waiting to be included in a
+// block.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempool"),
+	)
+
+	entries := s.vm.Builder.List()
+	reply.Txs = make([]MempoolTx, len(entries))
+	reply.NumTxsByType = make(map[string]json.Uint64)
+	for i, entry := range entries {
+		txType := mempool.TxType(entry.Tx.Unsigned)
+		size := json.Uint64(len(entry.Tx.Bytes()))
+		reply.Txs[i] = MempoolTx{
+			TxID:      entry.Tx.ID(),
+			Type:      txType,
+			Size:      size,
+			AddedTime: json.Uint64(entry.Added.Unix()),
+		}
+		reply.NumBytes += size
+		reply.NumTxsByType[txType]++
+
+		staker, ok := entry.Tx.Unsigned.(txs.Staker)
+		if !ok {
+			continue
+		}
+		startTime := json.Uint64(staker.StartTime().Unix())
+		reply.Txs[i].StartTime = &startTime
+		reply.NumStakerTxs++
+		reply.NumStakerBytes += size
+	}
+	reply.NumTxs = json.Uint64(len(entries))
+	return nil
+}
+
+// SimulatedStaker is a validator or delegator that would be added by a
+// simulated transaction.
+type SimulatedStaker struct {
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Weight    json.Uint64 `json:"weight"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	Pending   bool        `json:"pending"`
+}
+
+// SimulateTxReply is the response from SimulateTx
+type SimulateTxReply struct {
+	// TxID of the simulated tx. If the tx was unsigned, this isn't the ID the
+	// tx will have once it is signed.
+	TxID ids.ID `json:"txID"`
+	// Signed is true if the signatures of the tx were verified
+	Signed bool `json:"signed"`
+	// Error is the reason the tx would be dropped. Empty if the tx is valid.
+	Error string `json:"error,omitempty"`
+	// The UTXOs that would be consumed, including imported UTXOs
+	ConsumedUTXOs []ids.ID `json:"consumedUTXOs"`
+	// The UTXOs that would be produced
+	ProducedUTXOs []string `json:"producedUTXOs"`
+	// The stakers that would be added
+	Stakers []SimulatedStaker `json:"stakers"`
+	// Amount of AVAX that would be burned
+	Burned json.Uint64 `json:"burned"`
+	// Encoding specifies the encoding format the UTXOs are returned in
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx executes a signed or unsigned tx on top of the preferred block
+// without issuing it. If the tx is unsigned, its signatures aren't verified.
+// Nothing is committed to the chain state or gossiped.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	if !s.vm.bootstrapped.Get() {
+		return blockbuilder.ErrChainNotSynced
+	}
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+
+	backend := s.vm.txExecutorBackend
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		var utx txs.UnsignedTx
+		if _, unsignedErr := txs.Codec.Unmarshal(txBytes, &utx); unsignedErr != nil {
+			return fmt.Errorf("couldn't parse tx: %w", err)
+		}
+		tx = &txs.Tx{
+			Unsigned: utx,
+			Creds:    executor.PlaceholderCredentials(utx),
+		}
+		if err := tx.Initialize(txs.Codec); err != nil {
+			return fmt.Errorf("couldn't initialize tx: %w", err)
+		}
+		backend = executor.WithoutSignatureChecks(backend)
+	} else {
+		reply.Signed = true
+	}
+	reply.TxID = tx.ID()
+	reply.Encoding = args.Encoding
+
+	preferred, err := s.vm.Preferred()
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	simulation, err := executor.Simulate(backend, preferred.ID(), s.vm.manager, tx)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.ConsumedUTXOs = simulation.ConsumedUTXOs
+	reply.ProducedUTXOs = make([]string, len(simulation.ProducedUTXOs))
+	for i, utxo := range simulation.ProducedUTXOs {
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return fmt.Errorf("failed to encode UTXO to bytes: %w", err)
+		}
+
+		reply.ProducedUTXOs[i], err = formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode utxo as %s: %w", args.Encoding, err)
+		}
+	}
+	reply.Stakers = make([]SimulatedStaker, len(simulation.Stakers))
+	for i, staker := range simulation.Stakers {
+		reply.Stakers[i] = SimulatedStaker{
+			TxID:      staker.TxID,
+			NodeID:    staker.NodeID,
+			SubnetID:  staker.SubnetID,
+			Weight:    json.Uint64(staker.Weight),
+			StartTime: json.Uint64(staker.StartTime.Unix()),
+			EndTime:   json.Uint64(staker.EndTime.Unix()),
+			Pending:   staker.Priority.IsPending(),
+		}
+	}
+	reply.Burned = json.Uint64(simulation.Burned)
+	return nil
+}
+
 // GetTx gets a tx
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -2595,6 +2985,330 @@ func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *ap
 	return nil
 }
 
+// GetBlocksByHeightRangeArgs are the arguments for GetBlocksByHeightRange
+type GetBlocksByHeightRangeArgs struct {
+	// First height of the range
+	StartHeight json.Uint64 `json:"startHeight"`
+	// Last height of the range, inclusive. Heights above the last accepted
+	// height are ignored.
+	EndHeight json.Uint64 `json:"endHeight"`
+	// Max number of blocks to return
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetBlocksByHeightRangeReply is the response from GetBlocksByHeightRange
+type GetBlocksByHeightRangeReply struct {
+	// Accepted blocks, ordered by height
+	Blocks []blocks.Block `json:"blocks"`
+	// Height to start the next page from. Omitted if there are no more blocks
+	// in the range.
+	NextHeight *json.Uint64 `json:"nextHeight,omitempty"`
+}
+
+// GetBlocksByHeightRange returns the accepted blocks with heights in
+// [args.StartHeight, args.EndHeight], decoded as JSON. While the blocks
+// accepted before the block ID index existed are still being indexed after an
+// upgrade, [state.ErrBlockIDIndexNotReady] is returned.
+func (s *Service) GetBlocksByHeightRange(_ *http.Request, args *GetBlocksByHeightRangeArgs, reply *GetBlocksByHeightRangeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlocksByHeightRange"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	startHeight, endHeight, err := s.acceptedHeightRange(args.StartHeight, args.EndHeight)
+	if err != nil {
+		return err
+	}
+
+	limit := uint64(args.Limit)
+	if limit == 0 || maxGetBlocksPageSize < limit {
+		limit = maxGetBlocksPageSize
+	}
+
+	reply.Blocks = []blocks.Block{}
+	height := startHeight
+	for ; height <= endHeight && uint64(len(reply.Blocks)) < limit; height++ {
+		block, err := s.getAcceptedBlock(height)
+		if err != nil {
+			return err
+		}
+		block.InitCtx(s.vm.ctx)
+		reply.Blocks = append(reply.Blocks, block)
+	}
+
+	if height <= endHeight {
+		nextHeight := json.Uint64(height)
+		reply.NextHeight = &nextHeight
+	}
+	return nil
+}
+
+// GetTxsByFilterArgs are the arguments for GetTxsByFilter. A tx is returned
+// only if it matches all of the provided filters.
+type GetTxsByFilterArgs struct {
+	// First height of the range
+	StartHeight json.Uint64 `json:"startHeight"`
+	// Last height of the range, inclusive. Heights above the last accepted
+	// height are ignored.
+	EndHeight json.Uint64 `json:"endHeight"`
+	// If provided, only txs of one of these types are returned, for example
+	// "AddPermissionlessDelegatorTx". Unknown types are rejected.
+	TxTypes []string `json:"txTypes"`
+	// If provided, only txs that reference this node are returned
+	NodeID *ids.NodeID `json:"nodeID"`
+	// If provided, only txs that reference this subnet are returned
+	SubnetID *ids.ID `json:"subnetID"`
+	// If provided, only txs that send funds or rewards to, or give ownership
+	// to, one of these addresses are returned
+	Addresses []string `json:"addresses"`
+	// Max number of txs to return
+	Limit json.Uint32 `json:"limit"`
+}
+
+// FilteredTx is an accepted tx returned by GetTxsByFilter
+type FilteredTx struct {
+	Tx      *txs.Tx     `json:"tx"`
+	BlockID ids.ID      `json:"blockID"`
+	Height  json.Uint64 `json:"height"`
+}
+
+// GetTxsByFilterReply is the response from GetTxsByFilter
+type GetTxsByFilterReply struct {
+	// Matching txs, ordered by the height of the block that accepted them
+	Txs []FilteredTx `json:"txs"`
+	// Height to start the next page from. Omitted if there are no more blocks
+	// in the range.
+	NextHeight *json.Uint64 `json:"nextHeight,omitempty"`
+}
+
+// GetTxsByFilter returns the accepted txs in the blocks with heights in
+// [args.StartHeight, args.EndHeight] that match the provided filters, decoded
+// as JSON.
+//
+// Pages end at block boundaries, so a page only contains more than
+// [args.Limit] txs if a single block contains more matching txs than that. A
+// page may contain fewer txs than [args.Limit], even if [NextHeight] is
+// provided, as the number of blocks scanned per call is bounded.
+func (s *Service) GetTxsByFilter(_ *http.Request, args *GetTxsByFilterArgs, reply *GetTxsByFilterReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTxsByFilter"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	startHeight, endHeight, err := s.acceptedHeightRange(args.StartHeight, args.EndHeight)
+	if err != nil {
+		return err
+	}
+
+	filter := &txFilter{
+		nodeID:   args.NodeID,
+		subnetID: args.SubnetID,
+	}
+	for _, txType := range args.TxTypes {
+		if !filterableTxTypes.Contains(txType) {
+			return fmt.Errorf("%w: %q", errUnknownTxType, txType)
+		}
+		filter.txTypes.Add(txType)
+	}
+	filter.addresses, err = avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	reply.Txs = []FilteredTx{}
+	var (
+		height        = startHeight
+		scannedBlocks int
+	)
+	for ; height <= endHeight && scannedBlocks < maxGetTxsScannedBlocks; height++ {
+		block, err := s.getAcceptedBlock(height)
+		if err != nil {
+			return err
+		}
+
+		var blockTxs []FilteredTx
+		for _, tx := range block.Txs() {
+			if !filter.matches(tx) {
+				continue
+			}
+			tx.Unsigned.InitCtx(s.vm.ctx)
+			blockTxs = append(blockTxs, FilteredTx{
+				Tx:      tx,
+				BlockID: block.ID(),
+				Height:  json.Uint64(height),
+			})
+		}
+		if len(reply.Txs) > 0 && len(reply.Txs)+len(blockTxs) > limit {
+			break
+		}
+		reply.Txs = append(reply.Txs, blockTxs...)
+		scannedBlocks++
+		if len(reply.Txs) >= limit {
+			height++
+			break
+		}
+	}
+
+	if height <= endHeight {
+		nextHeight := json.Uint64(height)
+		reply.NextHeight = &nextHeight
+	}
+	return nil
+}
+
+// acceptedHeightRange returns the range of accepted heights in
+// [startHeight, endHeight].
+func (s *Service) acceptedHeightRange(startHeight, endHeight json.Uint64) (uint64, uint64, error) {
+	if startHeight > endHeight {
+		return 0, 0, errStartHeightAfterEndHeight
+	}
+
+	lastAcceptedID := s.vm.state.GetLastAccepted()
+	lastAccepted, err := s.vm.manager.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	lastAcceptedHeight := lastAccepted.Height()
+	if uint64(startHeight) > lastAcceptedHeight {
+		return 0, 0, fmt.Errorf("%w: %d > %d", errStartHeightNotAccepted, startHeight, lastAcceptedHeight)
+	}
+	return uint64(startHeight), math.Min(uint64(endHeight), lastAcceptedHeight), nil
+}
+
+// getAcceptedBlock returns the accepted block at [height].
+func (s *Service) getAcceptedBlock(height uint64) (blocks.Block, error) {
+	blockID, err := s.vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+	}
+	block, err := s.vm.manager.GetStatelessBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blockID, err)
+	}
+	return block, nil
+}
+
+// txFilter matches the txs that satisfy all of its provided criteria.
+type txFilter struct {
+	txTypes   set.Set[string]
+	nodeID    *ids.NodeID
+	subnetID  *ids.ID
+	addresses set.Set[ids.ShortID]
+}
+
+func (f *txFilter) matches(tx *txs.Tx) bool {
+	utx := tx.Unsigned
+	if f.txTypes.Len() > 0 && !f.txTypes.Contains(mempool.TxType(utx)) {
+		return false
+	}
+	if f.nodeID != nil {
+		nodeID, ok := txNodeID(utx)
+		if !ok || nodeID != *f.nodeID {
+			return false
+		}
+	}
+	if f.subnetID != nil {
+		subnetID, ok := txSubnetID(tx)
+		if !ok || subnetID != *f.subnetID {
+			return false
+		}
+	}
+	if f.addresses.Len() == 0 {
+		return true
+	}
+	addrs := txAddresses(utx)
+	return addrs.Overlaps(f.addresses)
+}
+
+// txNodeID returns the node referenced by [utx], if any.
+func txNodeID(utx txs.UnsignedTx) (ids.NodeID, bool) {
+	switch utx := utx.(type) {
+	case txs.Staker:
+		return utx.NodeID(), true
+	case *txs.RemoveSubnetValidatorTx:
+		return utx.NodeID, true
+	case *txs.SetSubnetValidatorWeightTx:
+		return utx.NodeID, true
+	default:
+		return ids.EmptyNodeID, false
+	}
+}
+
+// txSubnetID returns the subnet referenced by [tx], if any.
+func txSubnetID(tx *txs.Tx) (ids.ID, bool) {
+	switch utx := tx.Unsigned.(type) {
+	case txs.Staker:
+		return utx.SubnetID(), true
+	case *txs.CreateSubnetTx:
+		return tx.ID(), true
+	case *txs.CreateChainTx:
+		return utx.SubnetID, true
+	case *txs.RemoveSubnetValidatorTx:
+		return utx.Subnet, true
+	case *txs.SetSubnetValidatorWeightTx:
+		return utx.Subnet, true
+	case *txs.TransformSubnetTx:
+		return utx.Subnet, true
+	case *txs.TransferSubnetOwnershipTx:
+		return utx.Subnet, true
+	default:
+		return ids.Empty, false
+	}
+}
+
+// txAddresses returns the addresses that [utx] sends funds or rewards to, or
+// gives ownership to.
+func txAddresses(utx txs.UnsignedTx) set.Set[ids.ShortID] {
+	// [outs] must not share the backing array of the outputs of [utx], so
+	// that appending to it can't modify [utx].
+	outs := append([]*avax.TransferableOutput(nil), utx.Outputs()...)
+	var owners []fx.Owner
+	switch utx := utx.(type) {
+	case txs.ValidatorTx:
+		outs = append(outs, utx.Stake()...)
+		owners = append(owners, utx.ValidationRewardsOwner(), utx.DelegationRewardsOwner())
+	case txs.DelegatorTx:
+		outs = append(outs, utx.Stake()...)
+		owners = append(owners, utx.RewardsOwner())
+	case *txs.ExportTx:
+		outs = append(outs, utx.ExportedOutputs...)
+	case *txs.CreateSubnetTx:
+		owners = append(owners, utx.Owner)
+	case *txs.TransferSubnetOwnershipTx:
+		owners = append(owners, utx.Owner)
+	}
+
+	var addrs set.Set[ids.ShortID]
+	addAddresses := func(addressable interface{}) {
+		a, ok := addressable.(avax.Addressable)
+		if !ok {
+			return
+		}
+		for _, addrBytes := range a.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err == nil {
+				addrs.Add(addr)
+			}
+		}
+	}
+	for _, out := range outs {
+		addAddresses(out.Out)
+	}
+	for _, owner := range owners {
+		addAddresses(owner)
+	}
+	return addrs
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*json.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
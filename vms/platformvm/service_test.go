@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -32,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -700,6 +702,307 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetSubnetOwner(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	formatOwner := func(owner *secp256k1fx.OutputOwners) []string {
+		controlKeys := make([]string, len(owner.Addrs))
+		for i, addr := range owner.Addrs {
+			controlKey, err := service.addrManager.FormatLocalAddress(addr)
+			require.NoError(err)
+			controlKeys[i] = controlKey
+		}
+		return controlKeys
+	}
+
+	createOwner := testSubnet1.Unsigned.(*txs.CreateSubnetTx).Owner.(*secp256k1fx.OutputOwners)
+	expectedCreateOwner := APISubnetOwner{
+		TxID:        subnetID,
+		ControlKeys: formatOwner(createOwner),
+		Threshold:   json.Uint32(createOwner.Threshold),
+	}
+
+	reply := GetSubnetOwnerResponse{}
+	require.NoError(service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{SubnetID: subnetID}, &reply))
+	require.Equal(expectedCreateOwner, reply.Owner)
+	require.Equal([]APISubnetOwner{expectedCreateOwner}, reply.History)
+
+	newOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[3].PublicKey().Address()},
+	}
+	tx, err := service.vm.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		newOwner,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	service.vm.state.AddTx(tx, status.Committed)
+	service.vm.state.AddSubnetOwnershipTransfer(tx)
+
+	expectedNewOwner := APISubnetOwner{
+		TxID:        tx.ID(),
+		ControlKeys: formatOwner(newOwner),
+		Threshold:   json.Uint32(newOwner.Threshold),
+	}
+
+	reply = GetSubnetOwnerResponse{}
+	require.NoError(service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{SubnetID: subnetID}, &reply))
+	require.Equal(expectedNewOwner, reply.Owner)
+	require.Equal([]APISubnetOwner{expectedCreateOwner, expectedNewOwner}, reply.History)
+
+	err = service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{SubnetID: constants.PrimaryNetworkID}, &reply)
+	require.ErrorIs(err, errPrimaryNetworkIsNotASubnet)
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Empty(reply.Txs)
+	require.Zero(reply.NumTxs)
+
+	decisionTx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.Add(decisionTx))
+
+	startTime := service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+	stakerTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestShortID(),
+		0,
+		[]*secp256k1.PrivateKey{keys[1]},
+		keys[1].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.Add(stakerTx))
+
+	reply = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Len(reply.Txs, 2)
+
+	txsByID := make(map[ids.ID]MempoolTx)
+	for _, tx := range reply.Txs {
+		txsByID[tx.TxID] = tx
+	}
+
+	decisionReply := txsByID[decisionTx.ID()]
+	require.Equal("CreateChainTx", decisionReply.Type)
+	require.Equal(json.Uint64(len(decisionTx.Bytes())), decisionReply.Size)
+	require.Nil(decisionReply.StartTime)
+
+	stakerReply := txsByID[stakerTx.ID()]
+	require.Equal("AddValidatorTx", stakerReply.Type)
+	require.Equal(json.Uint64(len(stakerTx.Bytes())), stakerReply.Size)
+	require.NotNil(stakerReply.StartTime)
+	require.Equal(json.Uint64(startTime.Unix()), *stakerReply.StartTime)
+
+	require.Equal(json.Uint64(2), reply.NumTxs)
+	require.Equal(json.Uint64(len(decisionTx.Bytes())+len(stakerTx.Bytes())), reply.NumBytes)
+	require.Equal(json.Uint64(1), reply.NumStakerTxs)
+	require.Equal(json.Uint64(len(stakerTx.Bytes())), reply.NumStakerBytes)
+	require.Equal(
+		map[string]json.Uint64{
+			"CreateChainTx":  1,
+			"AddValidatorTx": 1,
+		},
+		reply.NumTxsByType,
+	)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	calculator := reward.NewCalculator(defaultRewardConfig)
+
+	// Estimate the reward of a validator
+	reply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &EstimateRewardArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		Amount:            json.Uint64(defaultMinValidatorStake),
+		Duration:          json.Uint64(defaultMaxStakingDuration / time.Second),
+		DelegationFeeRate: 10,
+	}, &reply))
+
+	expectedReward := calculator.Calculate(defaultMaxStakingDuration, defaultMinValidatorStake, currentSupply)
+	require.NotZero(expectedReward)
+	require.Equal(json.Uint64(currentSupply), reply.CurrentSupply)
+	require.Equal(json.Uint64(expectedReward), reply.PotentialReward)
+	require.Equal(json.Uint64(expectedReward), reply.Reward)
+	require.Zero(reply.DelegationFee)
+	require.Positive(float64(reply.APR))
+
+	// Estimate the reward of a delegator
+	reply = EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &EstimateRewardArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		Amount:            json.Uint64(defaultMinDelegatorStake),
+		Duration:          json.Uint64(defaultMaxStakingDuration / time.Second),
+		DelegationFeeRate: 10,
+		Delegator:         true,
+	}, &reply))
+
+	expectedReward = calculator.Calculate(defaultMaxStakingDuration, defaultMinDelegatorStake, currentSupply)
+	expectedFee, expectedDelegatorReward := reward.Split(expectedReward, reward.PercentDenominator/10)
+	require.NotZero(expectedFee)
+	require.Equal(json.Uint64(expectedReward), reply.PotentialReward)
+	require.Equal(json.Uint64(expectedDelegatorReward), reply.Reward)
+	require.Equal(json.Uint64(expectedFee), reply.DelegationFee)
+
+	// The stake must follow the staking rules
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		Amount:            json.Uint64(defaultMinValidatorStake),
+		Duration:          json.Uint64((defaultMaxStakingDuration + time.Second) / time.Second),
+		DelegationFeeRate: 10,
+	}, &reply)
+	require.ErrorIs(err, txexecutor.ErrStakeTooLong)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		Amount:            json.Uint64(defaultMinDelegatorStake - 1),
+		Duration:          json.Uint64(defaultMaxStakingDuration / time.Second),
+		DelegationFeeRate: 10,
+		Delegator:         true,
+	}, &reply)
+	require.ErrorIs(err, txexecutor.ErrWeightTooSmall)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		Amount:            json.Uint64(defaultMinValidatorStake),
+		Duration:          json.Uint64(defaultMaxStakingDuration / time.Second),
+		DelegationFeeRate: 101,
+	}, &reply)
+	require.ErrorIs(err, errInvalidDelegationRate)
+}
+
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+
+	expectedConsumed := tx.Unsigned.InputIDs().List()
+	require.NotEmpty(expectedConsumed)
+	utils.Sort(expectedConsumed)
+	expectedBurned := service.vm.TxFee
+
+	// Simulate the signed tx
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	reply := SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &reply))
+	require.Empty(reply.Error)
+	require.True(reply.Signed)
+	require.Equal(tx.ID(), reply.TxID)
+	require.Equal(expectedConsumed, reply.ConsumedUTXOs)
+	require.Len(reply.ProducedUTXOs, len(tx.UTXOs()))
+	require.Empty(reply.Stakers)
+	require.Equal(expectedBurned, uint64(reply.Burned))
+
+	// Nothing should have been issued or committed
+	require.False(service.vm.Builder.Has(tx.ID()))
+	for _, utxoID := range expectedConsumed {
+		_, err := service.vm.state.GetUTXO(utxoID)
+		require.NoError(err)
+	}
+
+	// Simulate the unsigned tx
+	txStr, err = formatting.Encode(formatting.Hex, tx.Unsigned.Bytes())
+	require.NoError(err)
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &reply))
+	require.Empty(reply.Error)
+	require.False(reply.Signed)
+	require.Equal(expectedConsumed, reply.ConsumedUTXOs)
+	require.Equal(expectedBurned, uint64(reply.Burned))
+
+	// Simulate the tx signed by the wrong key
+	badTx := &txs.Tx{Unsigned: tx.Unsigned}
+	signers := make([][]*secp256k1.PrivateKey, len(tx.Creds))
+	for i := range signers {
+		signers[i] = []*secp256k1.PrivateKey{keys[1]}
+	}
+	require.NoError(badTx.Sign(txs.Codec, signers))
+	txStr, err = formatting.Encode(formatting.Hex, badTx.Bytes())
+	require.NoError(err)
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &reply))
+	require.Contains(reply.Error, secp256k1fx.ErrWrongSig.Error())
+	require.Empty(reply.ConsumedUTXOs)
+
+	// Simulate a staker tx
+	nodeID := ids.GenerateTestNodeID()
+	startTime := service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+	stakerTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		0,
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	txStr, err = formatting.Encode(formatting.Hex, stakerTx.Bytes())
+	require.NoError(err)
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &reply))
+	require.Empty(reply.Error)
+	require.Len(reply.Stakers, 1)
+	require.Equal(stakerTx.ID(), reply.Stakers[0].TxID)
+	require.Equal(nodeID, reply.Stakers[0].NodeID)
+	require.Equal(service.vm.MinValidatorStake, uint64(reply.Stakers[0].Weight))
+	require.Equal(service.vm.AddPrimaryNetworkValidatorFee, uint64(reply.Burned))
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -776,3 +1079,215 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlocksAndTxsByHeightRange(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	service.vm.Config.CreateAssetTxFee = 100 * defaultTxFee
+
+	lastAccepted, err := service.vm.manager.GetStatelessBlock(service.vm.state.GetLastAccepted())
+	require.NoError(err)
+	startHeight := lastAccepted.Height() + 1
+
+	createChainTx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	exportAddr := ids.GenerateTestShortID()
+	exportTx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		exportAddr,
+		[]*secp256k1.PrivateKey{keys[4]},
+		keys[4].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	var acceptedBlkIDs []ids.ID
+	for _, tx := range []*txs.Tx{createChainTx, exportTx} {
+		require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+
+		blk, err := service.vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(service.vm.SetPreference(context.Background(), blk.ID()))
+		acceptedBlkIDs = append(acceptedBlkIDs, blk.ID())
+	}
+
+	// Blocks are paginated
+	blocksReply := GetBlocksByHeightRangeReply{}
+	require.NoError(service.GetBlocksByHeightRange(nil, &GetBlocksByHeightRangeArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(startHeight + 100),
+		Limit:       1,
+	}, &blocksReply))
+	require.Len(blocksReply.Blocks, 1)
+	require.Equal(acceptedBlkIDs[0], blocksReply.Blocks[0].ID())
+	require.NotNil(blocksReply.NextHeight)
+	require.Equal(json.Uint64(startHeight+1), *blocksReply.NextHeight)
+
+	blocksReply = GetBlocksByHeightRangeReply{}
+	require.NoError(service.GetBlocksByHeightRange(nil, &GetBlocksByHeightRangeArgs{
+		StartHeight: json.Uint64(startHeight + 1),
+		EndHeight:   json.Uint64(startHeight + 100),
+	}, &blocksReply))
+	require.Len(blocksReply.Blocks, 1)
+	require.Equal(acceptedBlkIDs[1], blocksReply.Blocks[0].ID())
+	require.Nil(blocksReply.NextHeight)
+
+	_, err = stdjson.Marshal(blocksReply)
+	require.NoError(err)
+
+	err = service.GetBlocksByHeightRange(nil, &GetBlocksByHeightRangeArgs{
+		StartHeight: json.Uint64(startHeight + 1),
+		EndHeight:   json.Uint64(startHeight),
+	}, &blocksReply)
+	require.ErrorIs(err, errStartHeightAfterEndHeight)
+
+	err = service.GetBlocksByHeightRange(nil, &GetBlocksByHeightRangeArgs{
+		StartHeight: json.Uint64(startHeight + 2),
+		EndHeight:   json.Uint64(startHeight + 2),
+	}, &blocksReply)
+	require.ErrorIs(err, errStartHeightNotAccepted)
+
+	formattedExportAddr, err := service.addrManager.FormatLocalAddress(exportAddr)
+	require.NoError(err)
+	subnetID := testSubnet1.ID()
+
+	tests := []struct {
+		name          string
+		args          GetTxsByFilterArgs
+		expectedTxIDs []ids.ID
+	}{
+		{
+			name:          "no filter",
+			args:          GetTxsByFilterArgs{},
+			expectedTxIDs: []ids.ID{createChainTx.ID(), exportTx.ID()},
+		},
+		{
+			name: "tx type",
+			args: GetTxsByFilterArgs{
+				TxTypes: []string{"ExportTx"},
+			},
+			expectedTxIDs: []ids.ID{exportTx.ID()},
+		},
+		{
+			name: "subnet",
+			args: GetTxsByFilterArgs{
+				SubnetID: &subnetID,
+			},
+			expectedTxIDs: []ids.ID{createChainTx.ID()},
+		},
+		{
+			name: "address",
+			args: GetTxsByFilterArgs{
+				Addresses: []string{formattedExportAddr},
+			},
+			expectedTxIDs: []ids.ID{exportTx.ID()},
+		},
+		{
+			name: "node",
+			args: GetTxsByFilterArgs{
+				NodeID: &ids.EmptyNodeID,
+			},
+			expectedTxIDs: nil,
+		},
+		{
+			name: "mismatched filters",
+			args: GetTxsByFilterArgs{
+				TxTypes:  []string{"ExportTx"},
+				SubnetID: &subnetID,
+			},
+			expectedTxIDs: nil,
+		},
+	}
+	for _, test := range tests {
+		args := test.args
+		args.StartHeight = json.Uint64(startHeight)
+		args.EndHeight = json.Uint64(startHeight + 100)
+
+		reply := GetTxsByFilterReply{}
+		require.NoError(service.GetTxsByFilter(nil, &args, &reply), test.name)
+		require.Nil(reply.NextHeight, test.name)
+
+		txIDs := make([]ids.ID, 0, len(reply.Txs))
+		for _, tx := range reply.Txs {
+			txIDs = append(txIDs, tx.Tx.ID())
+		}
+		require.ElementsMatch(test.expectedTxIDs, txIDs, test.name)
+
+		_, err := stdjson.Marshal(reply)
+		require.NoError(err, test.name)
+	}
+
+	err = service.GetTxsByFilter(nil, &GetTxsByFilterArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(startHeight + 100),
+		TxTypes:     []string{"ExportTx", "UnknownTx"},
+	}, &GetTxsByFilterReply{})
+	require.ErrorIs(err, errUnknownTxType)
+
+	// Txs are paginated at block boundaries
+	txsReply := GetTxsByFilterReply{}
+	require.NoError(service.GetTxsByFilter(nil, &GetTxsByFilterArgs{
+		StartHeight: 0,
+		EndHeight:   json.Uint64(startHeight + 100),
+		TxTypes:     []string{"CreateChainTx", "ExportTx"},
+		Limit:       1,
+	}, &txsReply))
+	require.Len(txsReply.Txs, 1)
+	require.Equal(createChainTx.ID(), txsReply.Txs[0].Tx.ID())
+	require.Equal(acceptedBlkIDs[0], txsReply.Txs[0].BlockID)
+	require.Equal(json.Uint64(startHeight), txsReply.Txs[0].Height)
+	require.NotNil(txsReply.NextHeight)
+	require.Equal(json.Uint64(startHeight+1), *txsReply.NextHeight)
+}
+
+// Ensure looking up the addresses of a tx doesn't modify its outputs
+func TestTxAddressesDoesNotModifyTx(t *testing.T) {
+	require := require.New(t)
+
+	newOut := func(addr ids.ShortID) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+
+	var (
+		outAddr   = ids.GenerateTestShortID()
+		stakeAddr = ids.GenerateTestShortID()
+		owner     = &secp256k1fx.OutputOwners{}
+	)
+	outs := make([]*avax.TransferableOutput, 1, 2)
+	outs[0] = newOut(outAddr)
+	utx := &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: outs,
+		}},
+		StakeOuts:             []*avax.TransferableOutput{newOut(stakeAddr)},
+		ValidatorRewardsOwner: owner,
+		DelegatorRewardsOwner: owner,
+	}
+
+	addrs := txAddresses(utx)
+	require.True(addrs.Contains(outAddr))
+	require.True(addrs.Contains(stakeAddr))
+	require.Nil(outs[:2][1])
+}
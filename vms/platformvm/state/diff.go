@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -49,6 +50,12 @@ type diff struct {
 	addedChains  map[ids.ID][]*txs.Tx
 	cachedChains map[ids.ID][]*txs.Tx
 
+	// Subnet ID --> Txs that transfer the ownership of the subnet
+	addedSubnetOwnershipTransfers map[ids.ID][]*txs.Tx
+
+	// Staker tx ID --> SetAutoRenewTx configuring the staker
+	addedStakerAutoRenewals map[ids.ID]*txs.Tx
+
 	addedRewardUTXOs map[ids.ID][]*avax.UTXO
 
 	addedTxs map[ids.ID]*txAndStatus
@@ -109,7 +116,7 @@ func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	// validator.
 	newValidator, status := d.currentStakerDiffs.GetValidator(subnetID, nodeID)
 	switch status {
-	case added:
+	case added, updated:
 		return newValidator, nil
 	case deleted:
 		return nil, database.ErrNotFound
@@ -156,6 +163,10 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) UpdateCurrentValidator(staker *Staker) {
+	d.currentStakerDiffs.UpdateValidator(staker)
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -376,6 +387,67 @@ func (d *diff) AddChain(createChainTx *txs.Tx) {
 	d.cachedChains[tx.SubnetID] = append(cachedChains, createChainTx)
 }
 
+func (d *diff) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	transfers, err := parentState.GetSubnetOwnershipTransfers(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	addedTransfers := d.addedSubnetOwnershipTransfers[subnetID]
+	if len(addedTransfers) == 0 {
+		return transfers, nil
+	}
+
+	newTransfers := make([]*txs.Tx, 0, len(transfers)+len(addedTransfers))
+	newTransfers = append(newTransfers, transfers...)
+	newTransfers = append(newTransfers, addedTransfers...)
+	return newTransfers, nil
+}
+
+func (d *diff) AddSubnetOwnershipTransfer(transferSubnetOwnershipTxIntf *txs.Tx) {
+	tx := transferSubnetOwnershipTxIntf.Unsigned.(*txs.TransferSubnetOwnershipTx)
+	if d.addedSubnetOwnershipTransfers == nil {
+		d.addedSubnetOwnershipTransfers = make(map[ids.ID][]*txs.Tx)
+	}
+	d.addedSubnetOwnershipTransfers[tx.Subnet] = append(d.addedSubnetOwnershipTransfers[tx.Subnet], transferSubnetOwnershipTxIntf)
+}
+
+func (d *diff) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	if transfers := d.addedSubnetOwnershipTransfers[subnetID]; len(transfers) > 0 {
+		lastTransfer := transfers[len(transfers)-1].Unsigned.(*txs.TransferSubnetOwnershipTx)
+		return lastTransfer.Owner, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetOwner(subnetID)
+}
+
+func (d *diff) GetStakerAutoRenewal(stakerTxID ids.ID) (*txs.Tx, error) {
+	if tx, exists := d.addedStakerAutoRenewals[stakerTxID]; exists {
+		return tx, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetStakerAutoRenewal(stakerTxID)
+}
+
+func (d *diff) SetStakerAutoRenewal(stakerTxID ids.ID, setAutoRenewTx *txs.Tx) {
+	if d.addedStakerAutoRenewals == nil {
+		d.addedStakerAutoRenewals = make(map[ids.ID]*txs.Tx)
+	}
+	d.addedStakerAutoRenewals[stakerTxID] = setAutoRenewTx
+}
+
 func (d *diff) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
 	if tx, exists := d.addedTxs[txID]; exists {
 		return tx.tx, tx.status, nil
@@ -466,9 +538,17 @@ func (d *diff) Apply(baseState State) error {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			switch validatorDiff.validatorStatus {
 			case added:
+				if validatorDiff.replacedValidator != nil {
+					baseState.DeleteCurrentValidator(validatorDiff.replacedValidator)
+				}
 				baseState.PutCurrentValidator(validatorDiff.validator)
+				if validatorDiff.weightUpdated {
+					baseState.UpdateCurrentValidator(validatorDiff.validator)
+				}
 			case deleted:
 				baseState.DeleteCurrentValidator(validatorDiff.validator)
+			case updated:
+				baseState.UpdateCurrentValidator(validatorDiff.validator)
 			}
 
 			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
@@ -520,6 +600,14 @@ func (d *diff) Apply(baseState State) error {
 			baseState.AddChain(chain)
 		}
 	}
+	for _, transfers := range d.addedSubnetOwnershipTransfers {
+		for _, transfer := range transfers {
+			baseState.AddSubnetOwnershipTransfer(transfer)
+		}
+	}
+	for stakerTxID, tx := range d.addedStakerAutoRenewals {
+		baseState.SetStakerAutoRenewal(stakerTxID, tx)
+	}
 	for _, tx := range d.addedTxs {
 		baseState.AddTx(tx.tx, tx.status)
 	}
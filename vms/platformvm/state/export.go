@@ -0,0 +1,347 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var (
+	// utxoStateUTXOPrefix is the prefix the UTXO state stores UTXOs under in
+	// the [utxoDB].
+	utxoStateUTXOPrefix = []byte("utxo")
+
+	errWrongExportHash = errors.New("export hash doesn't match its contents")
+
+	errIsNotCreateChainTx = errors.New("is not a create chain tx")
+)
+
+// Export is a canonical snapshot of the last accepted state. Two nodes that
+// accepted the same blocks produce the same [Hash].
+type Export struct {
+	// Hash of the export, excluding [Hash] and [Uptimes]
+	Hash      ids.ID           `json:"hash"`
+	Height    avajson.Uint64   `json:"height"`
+	BlockID   ids.ID           `json:"blockID"`
+	Timestamp avajson.Uint64   `json:"timestamp"`
+	Subnets   []ExportedSubnet `json:"subnets"`
+	// Stakers are sorted in the order they are removed from the staker set
+	CurrentStakers []ExportedStaker `json:"currentStakers"`
+	PendingStakers []ExportedStaker `json:"pendingStakers"`
+	// UTXOs are sorted by ID
+	UTXOs []ExportedUTXO `json:"utxos"`
+	// Uptimes are measured locally by every node, so they aren't included in
+	// [Hash]
+	Uptimes []ExportedUptime `json:"uptimes"`
+}
+
+// ExportedSubnet is a subnet in an [Export]. The primary network is exported
+// as the first subnet.
+type ExportedSubnet struct {
+	ID ids.ID `json:"id"`
+	// Hex encoding of the current owner of the subnet. Empty for the primary
+	// network.
+	Owner string `json:"owner"`
+	// ID of the TransformSubnetTx of the subnet. Empty if the subnet wasn't
+	// transformed.
+	TransformSubnetTxID ids.ID `json:"transformSubnetTxID"`
+	// Current supply of the staking asset of the subnet. Zero if the subnet
+	// wasn't transformed.
+	CurrentSupply avajson.Uint64 `json:"currentSupply"`
+	// Chains are sorted in the order they were created
+	Chains []ExportedChain `json:"chains"`
+}
+
+// ExportedChain is a chain in an [Export].
+type ExportedChain struct {
+	ID   ids.ID `json:"id"`
+	Name string `json:"name"`
+	VMID ids.ID `json:"vmID"`
+}
+
+// ExportedStaker is a validator or delegator in an [Export].
+type ExportedStaker struct {
+	TxID     ids.ID     `json:"txID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	SubnetID ids.ID     `json:"subnetID"`
+	// Hex encoding of the BLS public key of the staker, if it registered one
+	PublicKey       string         `json:"publicKey,omitempty"`
+	Weight          avajson.Uint64 `json:"weight"`
+	StartTime       avajson.Uint64 `json:"startTime"`
+	EndTime         avajson.Uint64 `json:"endTime"`
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	Priority        avajson.Uint8  `json:"priority"`
+}
+
+// ExportedUTXO is a UTXO in an [Export].
+type ExportedUTXO struct {
+	ID ids.ID `json:"id"`
+	// Hex encoding of the UTXO
+	UTXO string `json:"utxo"`
+}
+
+// ExportedUptime is the uptime of a current validator measured by the node
+// that created the [Export].
+type ExportedUptime struct {
+	NodeID      ids.NodeID     `json:"nodeID"`
+	SubnetID    ids.ID         `json:"subnetID"`
+	UpDuration  avajson.Uint64 `json:"upDuration"`
+	LastUpdated avajson.Uint64 `json:"lastUpdated"`
+}
+
+// ComputeHash returns the hash of [e], excluding [e.Hash] and [e.Uptimes].
+func (e *Export) ComputeHash() (ids.ID, error) {
+	hashed := *e
+	hashed.Hash = ids.Empty
+	hashed.Uptimes = nil
+
+	exportBytes, err := json.Marshal(&hashed)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return hashing.ComputeHash256Array(exportBytes), nil
+}
+
+// Verify returns an error if [e.Hash] doesn't match the contents of [e].
+func (e *Export) Verify() error {
+	hash, err := e.ComputeHash()
+	if err != nil {
+		return err
+	}
+	if hash != e.Hash {
+		return fmt.Errorf("%w: expected %s but got %s", errWrongExportHash, hash, e.Hash)
+	}
+	return nil
+}
+
+// WriteExport writes [export] as JSON to a new file at [path]. It fails if the
+// file already exists.
+func WriteExport(path string, export *Export) error {
+	exportBytes, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(exportBytes); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadExport reads the export written to the file at [path] and verifies its
+// hash.
+func ReadExport(path string) (*Export, error) {
+	exportBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{}
+	if err := json.Unmarshal(exportBytes, export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	return export, export.Verify()
+}
+
+func (s *state) Export() (*Export, error) {
+	lastAcceptedID := s.GetLastAccepted()
+	lastAccepted, _, err := s.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block %s: %w", lastAcceptedID, err)
+	}
+
+	export := &Export{
+		Height:    avajson.Uint64(lastAccepted.Height()),
+		BlockID:   lastAcceptedID,
+		Timestamp: avajson.Uint64(s.GetTimestamp().Unix()),
+	}
+
+	primaryNetwork, err := s.exportSubnet(constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return nil, err
+	}
+	export.Subnets = append(export.Subnets, primaryNetwork)
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return nil, err
+	}
+	for _, createSubnetTx := range subnets {
+		subnet, err := s.exportSubnet(createSubnetTx.ID(), createSubnetTx)
+		if err != nil {
+			return nil, err
+		}
+		export.Subnets = append(export.Subnets, subnet)
+	}
+
+	currentStakers, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	export.CurrentStakers, err = exportStakers(currentStakers)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingStakers, err := s.GetPendingStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	export.PendingStakers, err = exportStakers(pendingStakers)
+	if err != nil {
+		return nil, err
+	}
+
+	export.UTXOs, err = s.exportUTXOs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, staker := range export.CurrentStakers {
+		if !txs.Priority(staker.Priority).IsValidator() {
+			continue
+		}
+		upDuration, lastUpdated, err := s.GetUptime(staker.NodeID, staker.SubnetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get uptime of %s on %s: %w", staker.NodeID, staker.SubnetID, err)
+		}
+		export.Uptimes = append(export.Uptimes, ExportedUptime{
+			NodeID:      staker.NodeID,
+			SubnetID:    staker.SubnetID,
+			UpDuration:  avajson.Uint64(upDuration.Seconds()),
+			LastUpdated: avajson.Uint64(lastUpdated.Unix()),
+		})
+	}
+
+	export.Hash, err = export.ComputeHash()
+	return export, err
+}
+
+// exportSubnet exports the subnet created by [createSubnetTx]. If
+// [createSubnetTx] is nil, the primary network is exported.
+func (s *state) exportSubnet(subnetID ids.ID, createSubnetTx *txs.Tx) (ExportedSubnet, error) {
+	subnet := ExportedSubnet{
+		ID: subnetID,
+	}
+	if createSubnetTx != nil {
+		owner, err := s.GetSubnetOwner(subnetID)
+		if err != nil {
+			return ExportedSubnet{}, err
+		}
+		ownerBytes, err := txs.Codec.Marshal(txs.Version, &owner)
+		if err != nil {
+			return ExportedSubnet{}, fmt.Errorf("failed to serialize owner of %s: %w", subnetID, err)
+		}
+		subnet.Owner, err = formatting.Encode(formatting.Hex, ownerBytes)
+		if err != nil {
+			return ExportedSubnet{}, err
+		}
+
+		transformSubnetTx, err := s.GetSubnetTransformation(subnetID)
+		switch err {
+		case nil:
+			subnet.TransformSubnetTxID = transformSubnetTx.ID()
+		case database.ErrNotFound:
+		default:
+			return ExportedSubnet{}, err
+		}
+	}
+
+	if createSubnetTx == nil || subnet.TransformSubnetTxID != ids.Empty {
+		currentSupply, err := s.GetCurrentSupply(subnetID)
+		if err != nil {
+			return ExportedSubnet{}, err
+		}
+		subnet.CurrentSupply = avajson.Uint64(currentSupply)
+	}
+
+	chains, err := s.GetChains(subnetID)
+	if err != nil {
+		return ExportedSubnet{}, err
+	}
+	subnet.Chains = make([]ExportedChain, len(chains))
+	for i, chainTx := range chains {
+		createChainTx, ok := chainTx.Unsigned.(*txs.CreateChainTx)
+		if !ok {
+			return ExportedSubnet{}, fmt.Errorf("%s %w", chainTx.ID(), errIsNotCreateChainTx)
+		}
+		subnet.Chains[i] = ExportedChain{
+			ID:   chainTx.ID(),
+			Name: createChainTx.ChainName,
+			VMID: createChainTx.VMID,
+		}
+	}
+	return subnet, nil
+}
+
+func (s *state) exportUTXOs() ([]ExportedUTXO, error) {
+	utxoDB := prefixdb.New(utxoStateUTXOPrefix, s.utxoDB)
+	utxoIter := utxoDB.NewIterator()
+	defer utxoIter.Release()
+
+	var utxos []ExportedUTXO
+	for utxoIter.Next() {
+		utxoID, err := ids.ToID(utxoIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		utxoStr, err := formatting.Encode(formatting.Hex, utxoIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, ExportedUTXO{
+			ID:   utxoID,
+			UTXO: utxoStr,
+		})
+	}
+	return utxos, utxoIter.Error()
+}
+
+func exportStakers(it StakerIterator) ([]ExportedStaker, error) {
+	defer it.Release()
+
+	var stakers []ExportedStaker
+	for it.Next() {
+		staker := it.Value()
+		exported := ExportedStaker{
+			TxID:            staker.TxID,
+			NodeID:          staker.NodeID,
+			SubnetID:        staker.SubnetID,
+			Weight:          avajson.Uint64(staker.Weight),
+			StartTime:       avajson.Uint64(staker.StartTime.Unix()),
+			EndTime:         avajson.Uint64(staker.EndTime.Unix()),
+			PotentialReward: avajson.Uint64(staker.PotentialReward),
+			Priority:        avajson.Uint8(staker.Priority),
+		}
+		if staker.PublicKey != nil {
+			var err error
+			exported.PublicKey, err = formatting.Encode(formatting.Hex, bls.PublicKeyToBytes(staker.PublicKey))
+			if err != nil {
+				return nil, err
+			}
+		}
+		stakers = append(stakers, exported)
+	}
+	return stakers, nil
+}
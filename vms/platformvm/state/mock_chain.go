@@ -13,6 +13,7 @@ import (
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
+	fx "github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	status "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -77,6 +78,18 @@ func (mr *MockChainMockRecorder) AddSubnet(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnet", reflect.TypeOf((*MockChain)(nil).AddSubnet), arg0)
 }
 
+// AddSubnetOwnershipTransfer mocks base method.
+func (m *MockChain) AddSubnetOwnershipTransfer(arg0 *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubnetOwnershipTransfer", arg0)
+}
+
+// AddSubnetOwnershipTransfer indicates an expected call of AddSubnetOwnershipTransfer.
+func (mr *MockChainMockRecorder) AddSubnetOwnershipTransfer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubnetOwnershipTransfer", reflect.TypeOf((*MockChain)(nil).AddSubnetOwnershipTransfer), arg0)
+}
+
 // AddSubnetTransformation mocks base method.
 func (m *MockChain) AddSubnetTransformation(arg0 *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -323,6 +336,51 @@ func (mr *MockChainMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockChain)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStakerAutoRenewal mocks base method.
+func (m *MockChain) GetStakerAutoRenewal(arg0 ids.ID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerAutoRenewal", arg0)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerAutoRenewal indicates an expected call of GetStakerAutoRenewal.
+func (mr *MockChainMockRecorder) GetStakerAutoRenewal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerAutoRenewal", reflect.TypeOf((*MockChain)(nil).GetStakerAutoRenewal), arg0)
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", arg0)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockChainMockRecorder) GetSubnetOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockChain)(nil).GetSubnetOwner), arg0)
+}
+
+// GetSubnetOwnershipTransfers mocks base method.
+func (m *MockChain) GetSubnetOwnershipTransfers(arg0 ids.ID) ([]*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwnershipTransfers", arg0)
+	ret0, _ := ret[0].([]*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwnershipTransfers indicates an expected call of GetSubnetOwnershipTransfers.
+func (mr *MockChainMockRecorder) GetSubnetOwnershipTransfers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwnershipTransfers", reflect.TypeOf((*MockChain)(nil).GetSubnetOwnershipTransfers), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockChain) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -472,6 +530,18 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetStakerAutoRenewal mocks base method.
+func (m *MockChain) SetStakerAutoRenewal(arg0 ids.ID, arg1 *txs.Tx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStakerAutoRenewal", arg0, arg1)
+}
+
+// SetStakerAutoRenewal indicates an expected call of SetStakerAutoRenewal.
+func (mr *MockChainMockRecorder) SetStakerAutoRenewal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStakerAutoRenewal", reflect.TypeOf((*MockChain)(nil).SetStakerAutoRenewal), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -483,3 +553,15 @@ func (mr *MockChainMockRecorder) SetTimestamp(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockChain)(nil).SetTimestamp), arg0)
 }
+
+// UpdateCurrentValidator mocks base method.
+func (m *MockChain) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockChainMockRecorder) UpdateCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockChain)(nil).UpdateCurrentValidator), arg0)
+}
@@ -7,6 +7,7 @@ const (
 	unmodified diffValidatorStatus = iota
 	added
 	deleted
+	updated
 )
 
 type diffValidatorStatus uint8
@@ -24,6 +24,9 @@ type CurrentStakers interface {
 	// PutCurrentValidator adds the [staker] describing a validator to the
 	// staker set.
 	//
+	// If the current validator was deleted before [staker] was put, [staker]
+	// replaces it.
+	//
 	// Invariant: [staker] is not currently a CurrentValidator
 	PutCurrentValidator(staker *Staker)
 
@@ -33,6 +36,14 @@ type CurrentStakers interface {
 	// Invariant: [staker] is currently a CurrentValidator
 	DeleteCurrentValidator(staker *Staker)
 
+	// UpdateCurrentValidator replaces the current validator that has the same
+	// TxID as [staker] with [staker]. Only the weight of the validator may be
+	// updated.
+	//
+	// Invariant: A staker with the TxID of [staker] is currently a
+	//            CurrentValidator
+	UpdateCurrentValidator(staker *Staker)
+
 	// SetDelegateeReward sets the accrued delegation rewards for [nodeID] on
 	// [subnetID] to [amount].
 	SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error
@@ -136,6 +147,9 @@ func (v *baseStakers) PutValidator(staker *Staker) {
 	validator.validator = staker
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == deleted {
+		validatorDiff.replacedValidator = validatorDiff.validator
+	}
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
@@ -150,10 +164,39 @@ func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	validatorDiff.validatorStatus = deleted
 	validatorDiff.validator = staker
+	validatorDiff.weightUpdated = false
+	if validatorDiff.replacedValidator != nil {
+		// The validator that replaced or updated [replacedValidator] is being
+		// removed, so only the removal of [replacedValidator] remains.
+		validatorDiff.validator = validatorDiff.replacedValidator
+		validatorDiff.replacedValidator = nil
+	}
 
 	v.stakers.Delete(staker)
 }
 
+func (v *baseStakers) UpdateValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	previousStaker := validator.validator
+	validator.validator = staker
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	switch validatorDiff.validatorStatus {
+	case added:
+		// The validator hasn't been written yet, so it is written with its
+		// updated weight.
+		validatorDiff.weightUpdated = true
+	case unmodified:
+		validatorDiff.validatorStatus = updated
+		validatorDiff.replacedValidator = previousStaker
+	}
+	validatorDiff.validator = staker
+
+	// The order of the stakers doesn't depend on their weight, so [staker]
+	// replaces [previousStaker].
+	v.stakers.ReplaceOrInsert(staker)
+}
+
 func (v *baseStakers) GetDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) StakerIterator {
 	subnetValidators, ok := v.validators[subnetID]
 	if !ok {
@@ -254,15 +297,28 @@ type diffStakers struct {
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 	addedStakers   *btree.BTreeG[*Staker]
 	deletedStakers map[ids.ID]*Staker
+	// updatedStakers are the validators in [addedStakers] that replace a
+	// validator of the parent state with the same TxID.
+	updatedStakers map[ids.ID]*Staker
 }
 
 type diffValidator struct {
-	// validatorStatus describes whether a validator has been added or removed.
+	// validatorStatus describes whether a validator has been added, removed
+	// or had its weight updated.
 	//
 	// validatorStatus is not affected by delegators ops so unmodified does not
 	// mean that diffValidator hasn't change, since delegators may have changed.
 	validatorStatus diffValidatorStatus
 	validator       *Staker
+	// replacedValidator is the validator that was removed before [validator]
+	// was added. It is only set if [validatorStatus] is added.
+	//
+	// In the base state, it is also the validator whose weight was updated to
+	// the weight of [validator] if [validatorStatus] is updated.
+	replacedValidator *Staker
+	// weightUpdated is true if the weight of [validator] was updated after it
+	// was added. It is only set if [validatorStatus] is added.
+	weightUpdated bool
 
 	addedDelegators   *btree.BTreeG[*Staker]
 	deletedDelegators map[ids.ID]*Staker
@@ -270,7 +326,6 @@ type diffValidator struct {
 
 // GetValidator attempts to fetch the validator with the given subnetID and
 // nodeID.
-// Invariant: Assumes that the validator will never be removed and then added.
 func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, diffValidatorStatus) {
 	subnetValidatorDiffs, ok := s.validatorDiffs[subnetID]
 	if !ok {
@@ -282,14 +337,21 @@ func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 		return nil, unmodified
 	}
 
-	if validatorDiff.validatorStatus == added {
-		return validatorDiff.validator, added
+	switch validatorDiff.validatorStatus {
+	case added, updated:
+		return validatorDiff.validator, validatorDiff.validatorStatus
+	default:
+		return nil, validatorDiff.validatorStatus
 	}
-	return nil, validatorDiff.validatorStatus
 }
 
 func (s *diffStakers) PutValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == deleted {
+		// The removed validator is being replaced. It remains in
+		// [deletedStakers] so that it is masked from the parent state.
+		validatorDiff.replacedValidator = validatorDiff.validator
+	}
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
@@ -301,13 +363,30 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 
 func (s *diffStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
-	if validatorDiff.validatorStatus == added {
+	validatorDiff.weightUpdated = false
+	switch {
+	case validatorDiff.replacedValidator != nil:
+		// This validator replaced another validator and was immediately
+		// removed in this diff. We treat it as if only the replaced validator
+		// was removed.
+		s.addedStakers.Delete(validatorDiff.validator)
+		validatorDiff.validatorStatus = deleted
+		validatorDiff.validator = validatorDiff.replacedValidator
+		validatorDiff.replacedValidator = nil
+	case validatorDiff.validatorStatus == added:
 		// This validator was added and immediately removed in this diff. We
 		// treat it as if it was never added.
 		validatorDiff.validatorStatus = unmodified
 		s.addedStakers.Delete(validatorDiff.validator)
 		validatorDiff.validator = nil
-	} else {
+	default:
+		if validatorDiff.validatorStatus == updated {
+			// The weight of this validator was updated in this diff. We
+			// treat it as if only the validator of the parent state was
+			// removed.
+			s.addedStakers.Delete(validatorDiff.validator)
+			delete(s.updatedStakers, staker.TxID)
+		}
 		validatorDiff.validatorStatus = deleted
 		validatorDiff.validator = staker
 		if s.deletedStakers == nil {
@@ -317,6 +396,28 @@ func (s *diffStakers) DeleteValidator(staker *Staker) {
 	}
 }
 
+func (s *diffStakers) UpdateValidator(staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	switch validatorDiff.validatorStatus {
+	case added:
+		validatorDiff.weightUpdated = true
+	default:
+		validatorDiff.validatorStatus = updated
+		if s.updatedStakers == nil {
+			s.updatedStakers = make(map[ids.ID]*Staker)
+		}
+		s.updatedStakers[staker.TxID] = staker
+	}
+	validatorDiff.validator = staker
+
+	if s.addedStakers == nil {
+		s.addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+	}
+	// The order of the stakers doesn't depend on their weight, so [staker]
+	// replaces any previous update of the validator.
+	s.addedStakers.ReplaceOrInsert(staker)
+}
+
 func (s *diffStakers) GetDelegatorIterator(
 	parentIterator StakerIterator,
 	subnetID ids.ID,
@@ -369,6 +470,11 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 }
 
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
+	if len(s.updatedStakers) > 0 {
+		// The updated validators are in [addedStakers], so their previous
+		// versions are skipped.
+		parentIterator = NewMaskedIterator(parentIterator, s.updatedStakers)
+	}
 	return NewMaskedIterator(
 		NewMergedIterator(
 			parentIterator,
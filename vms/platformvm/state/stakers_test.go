@@ -137,6 +137,42 @@ func TestBaseStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestBaseStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 2 * staker.Weight
+
+	v := newBaseStakers()
+	v.PutValidator(staker)
+	// Drop the addition of the validator, as if it was written.
+	v.validatorDiffs = make(map[ids.ID]map[ids.NodeID]*diffValidator)
+
+	v.UpdateValidator(&updatedStaker)
+
+	returnedValidator, err := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.NoError(err)
+	require.Equal(&updatedStaker, returnedValidator)
+
+	stakerIterator := v.GetStakerIterator()
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	// The previous validator is kept so that the change in weight can be
+	// recorded.
+	validatorDiff := v.validatorDiffs[staker.SubnetID][staker.NodeID]
+	require.Equal(updated, validatorDiff.validatorStatus)
+	require.Equal(&updatedStaker, validatorDiff.validator)
+	require.Equal(staker, validatorDiff.replacedValidator)
+
+	v.DeleteValidator(&updatedStaker)
+
+	// Deleting the updated validator deletes the previous validator.
+	require.Equal(deleted, validatorDiff.validatorStatus)
+	require.Equal(staker, validatorDiff.validator)
+	require.Nil(validatorDiff.replacedValidator)
+}
+
 func TestDiffStakersValidator(t *testing.T) {
 	require := require.New(t)
 	staker := newTestStaker()
@@ -195,6 +231,97 @@ func TestDiffStakersDeleteValidator(t *testing.T) {
 	require.Nil(returnedStaker)
 }
 
+func TestDiffStakersReplaceValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	replacement := newTestStaker()
+	replacement.NodeID = staker.NodeID
+	replacement.SubnetID = staker.SubnetID
+
+	v := diffStakers{}
+
+	v.DeleteValidator(staker)
+	v.PutValidator(replacement)
+
+	// The replaced validator is removed when the replacement is added.
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(added, status)
+	require.Equal(replacement, returnedStaker)
+
+	validatorDiff := v.validatorDiffs[staker.SubnetID][staker.NodeID]
+	require.Equal(staker, validatorDiff.replacedValidator)
+
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(replacement), stakerIterator)
+
+	v.DeleteValidator(replacement)
+
+	// Deleting the replacement reverts to the deletion of the replaced
+	// validator.
+	returnedStaker, status = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(deleted, status)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
+func TestDiffStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 2 * staker.Weight
+
+	v := diffStakers{}
+
+	v.UpdateValidator(&updatedStaker)
+
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(updated, status)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	// The updated validator replaces the validator of the parent state.
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	v.DeleteValidator(&updatedStaker)
+
+	// Deleting the updated validator deletes the validator of the parent
+	// state.
+	returnedStaker, status = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(deleted, status)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
+func TestDiffStakersUpdateAddedValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 2 * staker.Weight
+
+	v := diffStakers{}
+
+	v.PutValidator(staker)
+	v.UpdateValidator(&updatedStaker)
+
+	// The validator is still added, but with the updated weight.
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(added, status)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	validatorDiff := v.validatorDiffs[staker.SubnetID][staker.NodeID]
+	require.True(validatorDiff.weightUpdated)
+
+	stakerIterator := v.GetStakerIterator(EmptyIterator)
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
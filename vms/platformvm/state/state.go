@@ -6,12 +6,15 @@ package state
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/btree"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
@@ -33,6 +36,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -49,6 +53,13 @@ const (
 	rewardUTXOsCacheSize    = 2048
 	chainCacheSize          = 2048
 	chainDBCacheSize        = 2048
+
+	subnetOwnershipTransferCacheSize = 2048
+	subnetOwnerCacheSize             = 2048
+
+	// blockIDIndexBatchSize is the number of blocks indexed by height before
+	// the progress of the backfill of the index is committed.
+	blockIDIndexBatchSize = 10_000
 )
 
 var (
@@ -56,12 +67,17 @@ var (
 	_ cache.SizedElement = (*stateBlk)(nil)
 	_ cache.SizedElement = (*txAndStatus)(nil)
 
-	ErrDelegatorSubset              = errors.New("delegator's time range must be a subset of the validator's time range")
-	errMissingValidatorSet          = errors.New("missing validator set")
-	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
-	errDuplicateValidatorSet        = errors.New("duplicate validator set")
+	ErrBlockIDIndexNotReady           = errors.New("block ID index not ready")
+	ErrDelegatorSubset                = errors.New("delegator's time range must be a subset of the validator's time range")
+	errMissingValidatorSet            = errors.New("missing validator set")
+	errValidatorSetAlreadyPopulated   = errors.New("validator set already populated")
+	errDuplicateValidatorSet          = errors.New("duplicate validator set")
+	errInvalidCheckpointPublicKey     = errors.New("invalid public key in validator set checkpoint")
+	errIsNotCreateSubnetTx            = errors.New("is not a create subnet tx")
+	errIsNotTransferSubnetOwnershipTx = errors.New("is not a transfer subnet ownership tx")
 
 	blockPrefix                   = []byte("block")
+	blockIDPrefix                 = []byte("blockID")
 	validatorsPrefix              = []byte("validators")
 	currentPrefix                 = []byte("current")
 	pendingPrefix                 = []byte("pending")
@@ -71,6 +87,7 @@ var (
 	subnetDelegatorPrefix         = []byte("subnetDelegator")
 	validatorWeightDiffsPrefix    = []byte("validatorDiffs")
 	validatorPublicKeyDiffsPrefix = []byte("publicKeyDiffs")
+	validatorSetCheckpointsPrefix = []byte("checkpoints")
 	txPrefix                      = []byte("tx")
 	rewardUTXOsPrefix             = []byte("rewardUTXOs")
 	utxoPrefix                    = []byte("utxo")
@@ -78,12 +95,17 @@ var (
 	transformedSubnetPrefix       = []byte("transformedSubnet")
 	supplyPrefix                  = []byte("supply")
 	chainPrefix                   = []byte("chain")
+	subnetOwnershipPrefix         = []byte("subnetOwnership")
+	subnetOwnershipCountPrefix    = []byte("subnetOwnershipCount")
+	stakerAutoRenewalPrefix       = []byte("stakerAutoRenewal")
 	singletonPrefix               = []byte("singleton")
 
-	timestampKey     = []byte("timestamp")
-	currentSupplyKey = []byte("current supply")
-	lastAcceptedKey  = []byte("last accepted")
-	initializedKey   = []byte("initialized")
+	timestampKey          = []byte("timestamp")
+	currentSupplyKey      = []byte("current supply")
+	lastAcceptedKey       = []byte("last accepted")
+	initializedKey        = []byte("initialized")
+	blockIDsIndexedKey    = []byte("block IDs indexed")
+	nextBlockIDToIndexKey = []byte("next block ID to index")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -112,6 +134,23 @@ type Chain interface {
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 	AddChain(createChainTx *txs.Tx)
 
+	// GetSubnetOwnershipTransfers returns the TransferSubnetOwnershipTxs of
+	// [subnetID] in the order they were accepted.
+	GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error)
+	AddSubnetOwnershipTransfer(transferSubnetOwnershipTx *txs.Tx)
+
+	// GetSubnetOwner returns the current owner of [subnetID]. This is the
+	// owner set by the most recent TransferSubnetOwnershipTx of the subnet or,
+	// if its ownership was never transferred, the owner set by its
+	// CreateSubnetTx.
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+
+	// GetStakerAutoRenewal returns the SetAutoRenewTx that configures the
+	// renewal of the staker added by [stakerTxID]. If the staker was never
+	// configured, [database.ErrNotFound] is returned.
+	GetStakerAutoRenewal(stakerTxID ids.ID) (*txs.Tx, error)
+	SetStakerAutoRenewal(stakerTxID ids.ID, setAutoRenewTx *txs.Tx)
+
 	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
 	AddTx(tx *txs.Tx, status status.Status)
 }
@@ -127,6 +166,25 @@ type State interface {
 	GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status, error)
 	AddStatelessBlock(block blocks.Block, status choices.Status)
 
+	// GetBlockIDAtHeight returns the ID of the accepted block at [height]. If
+	// no block was accepted at [height], database.ErrNotFound is returned.
+	// Until the blocks accepted before the index existed are indexed,
+	// ErrBlockIDIndexNotReady is returned.
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+
+	// IndexBlockIDs indexes by height the blocks that were accepted before
+	// the index existed. The index is filled in from the last accepted block
+	// down to genesis, in batches of [blockIDIndexBatchSize] blocks. [lock] is
+	// held while a batch is indexed and released between batches, so this is
+	// expected to be run in the background. The next block to index is
+	// committed with every batch, so an interrupted backfill resumes where it
+	// stopped.
+	IndexBlockIDs(lock sync.Locker) error
+
+	// Export returns a canonical snapshot of the last accepted state. The
+	// state isn't stored at earlier heights, so it can't be exported at them.
+	Export() (*Export, error)
+
 	// ValidatorSet adds all the validators and delegators of [subnetID] into
 	// [vdrs].
 	ValidatorSet(subnetID ids.ID, vdrs validators.Set) error
@@ -137,6 +195,13 @@ type State interface {
 	// that left the Primary Network validator set.
 	GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error)
 
+	// GetValidatorSetCheckpoint returns the validator set of [subnetID] at the
+	// lowest checkpointed height that is >= [height], along with that height.
+	// Only the primary network validator set includes BLS public keys.
+	//
+	// If there is no such checkpoint, database.ErrNotFound is returned.
+	GetValidatorSetCheckpoint(subnetID ids.ID, height uint64) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -198,12 +263,17 @@ func (b *stateBlk) Size() int {
  * | | '-. height+subnet
  * | |   '-. list
  * | |     '-- nodeID -> weightChange
- * | '-. pub key diffs
- * |   '-. height
- * |     '-. list
- * |       '-- nodeID -> public key
+ * | |-. pub key diffs
+ * | | '-. height
+ * | |   '-. list
+ * | |     '-- nodeID -> public key
+ * | '-. checkpoints
+ * |   '-. subnetID
+ * |     '-- height -> validator set
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. block IDs
+ * | '-- height -> blockID
  * |-. txs
  * | '-- txID -> tx bytes + tx status
  * |- rewardUTXOs
@@ -219,8 +289,15 @@ func (b *stateBlk) Size() int {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. subnet ownership
+ * | '-. subnetID
+ * |   '-- index -> txID
+ * |-. staker auto renewals
+ * | '-- stakerTxID -> txID
  * '-. singletons
  *   |-- initializedKey -> nil
+ *   |-- blockIDsIndexedKey -> nil
+ *   |-- nextBlockIDToIndexKey -> blockID
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   '-- lastAcceptedKey -> lastAccepted
@@ -234,6 +311,9 @@ type state struct {
 	rewards      reward.Calculator
 	bootstrapped *utils.Atomic[bool]
 
+	// blockIDsIndexed is true once every accepted block is indexed by height.
+	blockIDsIndexed utils.Atomic[bool]
+
 	baseDB *versiondb.Database
 
 	currentStakers *baseStakers
@@ -246,6 +326,7 @@ type state struct {
 	// If the block isn't known, nil is cached.
 	blockCache cache.Cacher[ids.ID, *stateBlk]
 	blockDB    database.Database
+	blockIDDB  database.Database
 
 	validatorsDB                 database.Database
 	currentValidatorsDB          database.Database
@@ -273,6 +354,8 @@ type state struct {
 	validatorPublicKeyDiffsCache cache.Cacher[uint64, map[ids.NodeID]*bls.PublicKey] // cache of height -> map[ids.NodeID]*bls.PublicKey
 	validatorPublicKeyDiffsDB    database.Database
 
+	validatorSetCheckpointsDB database.Database
+
 	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher[ids.ID, *txAndStatus] // txID -> {*txs.Tx, Status}. If the entry is nil, it isn't in the database
 	txDB     database.Database
@@ -303,6 +386,15 @@ type state struct {
 	chainDBCache cache.Cacher[ids.ID, linkeddb.LinkedDB] // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
+	addedSubnetOwnershipTransfers map[ids.ID][]*txs.Tx            // maps subnetID -> the newly added ownership transfers of the subnet
+	subnetOwnershipTransferCache  cache.Cacher[ids.ID, []*txs.Tx] // cache of subnetID -> the ownership transfers after all local modifications
+	subnetOwnershipDB             database.Database
+	subnetOwnershipCountDB        database.Database              // maps subnetID -> the number of persisted ownership transfers of the subnet
+	subnetOwnerCache              cache.Cacher[ids.ID, fx.Owner] // cache of subnetID -> the owner of the subnet after all local modifications
+
+	addedStakerAutoRenewals map[ids.ID]*txs.Tx // maps stakerTxID -> the SetAutoRenewTx configuring the staker
+	stakerAutoRenewalDB     database.Database
+
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
@@ -332,6 +424,23 @@ func (v *ValidatorWeightDiff) Add(negative bool, amount uint64) error {
 	return nil
 }
 
+// validatorSetCheckpoint is a snapshot of a subnet's validator set.
+type validatorSetCheckpoint struct {
+	Validators []checkpointValidator `serialize:"true"`
+}
+
+type checkpointValidator struct {
+	NodeID ids.NodeID `serialize:"true"`
+	// Uncompressed BLS key of the validator, so that it can be parsed without
+	// being validated again. Empty if the validator didn't register a BLS key.
+	PublicKey []byte `serialize:"true"`
+	Weight    uint64 `serialize:"true"`
+}
+
+func (v checkpointValidator) Less(o checkpointValidator) bool {
+	return v.NodeID.Less(o.NodeID)
+}
+
 type heightWithSubnet struct {
 	Height   uint64 `serialize:"true"`
 	SubnetID ids.ID `serialize:"true"`
@@ -504,6 +613,24 @@ func new(
 		return nil, err
 	}
 
+	subnetOwnershipTransferCache, err := metercacher.New[ids.ID, []*txs.Tx](
+		"subnet_ownership_transfer_cache",
+		metricsReg,
+		&cache.LRU[ids.ID, []*txs.Tx]{Size: subnetOwnershipTransferCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetOwnerCache, err := metercacher.New[ids.ID, fx.Owner](
+		"subnet_owner_cache",
+		metricsReg,
+		&cache.LRU[ids.ID, fx.Owner]{Size: subnetOwnerCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &state{
 		validatorState: newValidatorState(),
 
@@ -517,6 +644,7 @@ func new(
 		addedBlocks: make(map[ids.ID]stateBlk),
 		blockCache:  blockCache,
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
+		blockIDDB:   prefixdb.New(blockIDPrefix, baseDB),
 
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
@@ -544,6 +672,7 @@ func new(
 		validatorWeightDiffsCache:    validatorWeightDiffsCache,
 		validatorPublicKeyDiffsCache: validatorPublicKeyDiffsCache,
 		validatorPublicKeyDiffsDB:    validatorPublicKeyDiffsDB,
+		validatorSetCheckpointsDB:    prefixdb.New(validatorSetCheckpointsPrefix, validatorsDB),
 
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(txPrefix, baseDB),
@@ -573,6 +702,15 @@ func new(
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
+		addedSubnetOwnershipTransfers: make(map[ids.ID][]*txs.Tx),
+		subnetOwnershipTransferCache:  subnetOwnershipTransferCache,
+		subnetOwnershipDB:             prefixdb.New(subnetOwnershipPrefix, baseDB),
+		subnetOwnershipCountDB:        prefixdb.New(subnetOwnershipCountPrefix, baseDB),
+		subnetOwnerCache:              subnetOwnerCache,
+
+		addedStakerAutoRenewals: make(map[ids.ID]*txs.Tx),
+		stakerAutoRenewalDB:     prefixdb.New(stakerAutoRenewalPrefix, baseDB),
+
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
 	}, nil
 }
@@ -589,6 +727,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) UpdateCurrentValidator(staker *Staker) {
+	s.currentStakers.UpdateValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -751,6 +893,131 @@ func (s *state) AddChain(createChainTxIntf *txs.Tx) {
 	}
 }
 
+func (s *state) GetSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	if transfers, cached := s.subnetOwnershipTransferCache.Get(subnetID); cached {
+		return transfers, nil
+	}
+
+	transfers, err := s.getPersistedSubnetOwnershipTransfers(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	transfers = append(transfers, s.addedSubnetOwnershipTransfers[subnetID]...)
+	s.subnetOwnershipTransferCache.Put(subnetID, transfers)
+	return transfers, nil
+}
+
+func (s *state) getPersistedSubnetOwnershipTransfers(subnetID ids.ID) ([]*txs.Tx, error) {
+	transferDB := prefixdb.New(subnetID[:], s.subnetOwnershipDB)
+	transferDBIt := transferDB.NewIterator()
+	defer transferDBIt.Release()
+
+	// Keys are the big endian index of the transfer, so the transfers are
+	// iterated in the order they were accepted.
+	var transfers []*txs.Tx
+	for transferDBIt.Next() {
+		txID, err := ids.ToID(transferDBIt.Value())
+		if err != nil {
+			return nil, err
+		}
+		tx, _, err := s.GetTx(txID)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, tx)
+	}
+	return transfers, transferDBIt.Error()
+}
+
+func (s *state) AddSubnetOwnershipTransfer(transferSubnetOwnershipTxIntf *txs.Tx) {
+	transferSubnetOwnershipTx := transferSubnetOwnershipTxIntf.Unsigned.(*txs.TransferSubnetOwnershipTx)
+	subnetID := transferSubnetOwnershipTx.Subnet
+	s.addedSubnetOwnershipTransfers[subnetID] = append(s.addedSubnetOwnershipTransfers[subnetID], transferSubnetOwnershipTxIntf)
+	if transfers, cached := s.subnetOwnershipTransferCache.Get(subnetID); cached {
+		transfers = append(transfers, transferSubnetOwnershipTxIntf)
+		s.subnetOwnershipTransferCache.Put(subnetID, transfers)
+	}
+	s.subnetOwnerCache.Put(subnetID, transferSubnetOwnershipTx.Owner)
+}
+
+func (s *state) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	if owner, cached := s.subnetOwnerCache.Get(subnetID); cached {
+		return owner, nil
+	}
+
+	owner, err := s.getPersistedSubnetOwner(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if transfers := s.addedSubnetOwnershipTransfers[subnetID]; len(transfers) > 0 {
+		lastTransfer := transfers[len(transfers)-1].Unsigned.(*txs.TransferSubnetOwnershipTx)
+		owner = lastTransfer.Owner
+	}
+	s.subnetOwnerCache.Put(subnetID, owner)
+	return owner, nil
+}
+
+// getPersistedSubnetOwner only reads the most recent persisted ownership
+// transfer of [subnetID], rather than every transfer.
+func (s *state) getPersistedSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	createSubnetTx, _, err := s.GetTx(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	subnet, ok := createSubnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, fmt.Errorf("%s %w", subnetID, errIsNotCreateSubnetTx)
+	}
+
+	numTransfers, err := database.GetUInt64(s.subnetOwnershipCountDB, subnetID[:])
+	if errors.Is(err, database.ErrNotFound) {
+		return subnet.Owner, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transferDB := prefixdb.New(subnetID[:], s.subnetOwnershipDB)
+	txIDBytes, err := transferDB.Get(database.PackUInt64(numTransfers - 1))
+	if err != nil {
+		return nil, err
+	}
+	txID, err := ids.ToID(txIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	transferTx, _, err := s.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	transfer, ok := transferTx.Unsigned.(*txs.TransferSubnetOwnershipTx)
+	if !ok {
+		return nil, fmt.Errorf("%s %w", txID, errIsNotTransferSubnetOwnershipTx)
+	}
+	return transfer.Owner, nil
+}
+
+func (s *state) GetStakerAutoRenewal(stakerTxID ids.ID) (*txs.Tx, error) {
+	if tx, exists := s.addedStakerAutoRenewals[stakerTxID]; exists {
+		return tx, nil
+	}
+
+	txIDBytes, err := s.stakerAutoRenewalDB.Get(stakerTxID[:])
+	if err != nil {
+		return nil, err
+	}
+	txID, err := ids.ToID(txIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	tx, _, err := s.GetTx(txID)
+	return tx, err
+}
+
+func (s *state) SetStakerAutoRenewal(stakerTxID ids.ID, setAutoRenewTx *txs.Tx) {
+	s.addedStakerAutoRenewals[stakerTxID] = setAutoRenewTx
+}
+
 func (s *state) getChainDB(subnetID ids.ID) linkeddb.LinkedDB {
 	if chainDB, cached := s.chainDBCache.Get(subnetID); cached {
 		return chainDB
@@ -1013,6 +1280,47 @@ func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.P
 	return pkDiffs, diffIter.Error()
 }
 
+func (s *state) GetValidatorSetCheckpoint(subnetID ids.ID, height uint64) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	checkpointDB := prefixdb.New(subnetID[:], s.validatorSetCheckpointsDB)
+	checkpointIter := checkpointDB.NewIteratorWithStart(database.PackUInt64(height))
+	defer checkpointIter.Release()
+
+	if !checkpointIter.Next() {
+		if err := checkpointIter.Error(); err != nil {
+			return 0, nil, err
+		}
+		return 0, nil, database.ErrNotFound
+	}
+
+	checkpointHeight, err := database.ParseUInt64(checkpointIter.Key())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	checkpoint := validatorSetCheckpoint{}
+	if _, err := blocks.GenesisCodec.Unmarshal(checkpointIter.Value(), &checkpoint); err != nil {
+		return 0, nil, err
+	}
+
+	validatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(checkpoint.Validators))
+	for _, vdr := range checkpoint.Validators {
+		var pk *bls.PublicKey
+		if len(vdr.PublicKey) != 0 {
+			// The key was validated before it was written.
+			pk = bls.PublicKeyFromValidUncompressedBytes(vdr.PublicKey)
+			if pk == nil {
+				return 0, nil, fmt.Errorf("%w: %s", errInvalidCheckpointPublicKey, vdr.NodeID)
+			}
+		}
+		validatorSet[vdr.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.NodeID,
+			PublicKey: pk,
+			Weight:    vdr.Weight,
+		}
+	}
+	return checkpointHeight, validatorSet, nil
+}
+
 func (s *state) syncGenesis(genesisBlk blocks.Block, genesis *genesis.State) error {
 	genesisBlkID := genesisBlk.ID()
 	s.SetLastAccepted(genesisBlkID)
@@ -1152,6 +1460,9 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if metadata.weight != 0 {
+			staker.Weight = metadata.weight
+		}
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
@@ -1194,6 +1505,9 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if metadata.weight != 0 {
+			staker.Weight = metadata.weight
+		}
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
@@ -1386,6 +1700,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	errs.Add(
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height),
+		s.writeValidatorSetCheckpoints(height),
 		s.writePendingStakers(),
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList), // Must be called after writeCurrentStakers
 		s.writeTXs(),
@@ -1395,6 +1710,8 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
+		s.writeSubnetOwnershipTransfers(),
+		s.writeStakerAutoRenewals(),
 		s.writeMetadata(),
 	)
 	return errs.Err
@@ -1421,8 +1738,13 @@ func (s *state) Close() error {
 		s.transformedSubnetDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
+		s.subnetOwnershipDB.Close(),
+		s.subnetOwnershipCountDB.Close(),
+		s.stakerAutoRenewalDB.Close(),
+		s.validatorSetCheckpointsDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
+		s.blockIDDB.Close(),
 	)
 	return errs.Err
 }
@@ -1453,6 +1775,96 @@ func (s *state) sync(genesis []byte) error {
 			err,
 		)
 	}
+
+	indexed, err := s.singletonDB.Has(blockIDsIndexedKey)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check if the accepted blocks are indexed: %w",
+			err,
+		)
+	}
+	s.blockIDsIndexed.Set(indexed)
+	return nil
+}
+
+func (s *state) IndexBlockIDs(lock sync.Locker) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	indexed, err := s.singletonDB.Has(blockIDsIndexedKey)
+	if err != nil {
+		return err
+	}
+	if indexed {
+		s.blockIDsIndexed.Set(true)
+		return nil
+	}
+
+	blkID, err := database.GetID(s.singletonDB, nextBlockIDToIndexKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		// Blocks accepted from now on are indexed when they are written.
+		blkID = s.lastAccepted
+	case err != nil:
+		return err
+	}
+
+	var (
+		startTime  = time.Now()
+		numIndexed int
+	)
+	for {
+		blk, _, err := s.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+
+		heightKey := database.PackUInt64(blk.Height())
+		if err := s.blockIDDB.Put(heightKey, blkID[:]); err != nil {
+			return fmt.Errorf("failed to index block %s: %w", blkID, err)
+		}
+		numIndexed++
+
+		if blk.Height() == 0 {
+			break
+		}
+		blkID = blk.Parent()
+
+		if numIndexed%blockIDIndexBatchSize != 0 {
+			continue
+		}
+		if err := database.PutID(s.singletonDB, nextBlockIDToIndexKey, blkID); err != nil {
+			return err
+		}
+		if err := s.baseDB.Commit(); err != nil {
+			return err
+		}
+		s.ctx.Log.Info("indexing accepted blocks by height",
+			zap.Int("numIndexed", numIndexed),
+			zap.Uint64("height", blk.Height()),
+		)
+
+		// Release the lock between batches so that the chain keeps making
+		// progress while the index is filled in.
+		lock.Unlock()
+		lock.Lock()
+	}
+
+	if err := s.singletonDB.Delete(nextBlockIDToIndexKey); err != nil {
+		return err
+	}
+	if err := s.singletonDB.Put(blockIDsIndexedKey, nil); err != nil {
+		return err
+	}
+	if err := s.baseDB.Commit(); err != nil {
+		return err
+	}
+	s.blockIDsIndexed.Set(true)
+
+	s.ctx.Log.Info("indexed accepted blocks by height",
+		zap.Int("numIndexed", numIndexed),
+		zap.Duration("duration", time.Since(startTime)),
+	)
 	return nil
 }
 
@@ -1478,6 +1890,12 @@ func (s *state) init(genesisBytes []byte) error {
 		return err
 	}
 
+	// Every accepted block is indexed when it is written.
+	if err := s.singletonDB.Put(blockIDsIndexedKey, nil); err != nil {
+		return err
+	}
+	s.blockIDsIndexed.Set(true)
+
 	return s.Commit()
 }
 
@@ -1536,10 +1954,25 @@ func (s *state) writeBlocks() error {
 		if err := s.blockDB.Put(blkID[:], blockBytes); err != nil {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
+
+		if stBlk.Status != choices.Accepted {
+			continue
+		}
+		heightKey := database.PackUInt64(stBlk.Blk.Height())
+		if err := s.blockIDDB.Put(heightKey, blkID[:]); err != nil {
+			return fmt.Errorf("failed to index block %s: %w", blkID, err)
+		}
 	}
 	return nil
 }
 
+func (s *state) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	if !s.blockIDsIndexed.Get() {
+		return ids.Empty, ErrBlockIDIndexNotReady
+	}
+	return database.GetID(s.blockIDDB, database.PackUInt64(height))
+}
+
 func (s *state) GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status, error) {
 	if blk, ok := s.addedBlocks[blockID]; ok {
 		return blk.Blk, blk.Status, nil
@@ -1631,11 +2064,49 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 					PotentialDelegateeReward: 0,
 				}
 
-				metadataBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, metadata)
+				if replacedStaker := validatorDiff.replacedValidator; replacedStaker != nil {
+					// The validator is replacing a validator of the same node,
+					// so only the difference in weight is recorded.
+					if err := weightDiff.Add(true, replacedStaker.Weight); err != nil {
+						return fmt.Errorf("failed to decrease node weight diff: %w", err)
+					}
+
+					if replacedStaker.StartTime.Equal(staker.StartTime) {
+						// The validator continues the staking period of the
+						// replaced validator, so its uptime is carried over.
+						upDuration, lastUpdated, err := s.validatorState.GetUptime(nodeID, subnetID)
+						if err != nil {
+							return fmt.Errorf("failed to get replaced validator uptime: %w", err)
+						}
+						metadata.UpDuration = upDuration
+						metadata.LastUpdated = uint64(lastUpdated.Unix())
+						metadata.lastUpdated = lastUpdated
+					}
+
+					if replacedStaker.PublicKey != nil {
+						pkDiffs[nodeID] = replacedStaker.PublicKey
+
+						pkBytes := bls.PublicKeyToBytes(replacedStaker.PublicKey)
+						if err := pkDiffDB.Put(nodeID[:], pkBytes); err != nil {
+							return err
+						}
+					}
+
+					if err := validatorDB.Delete(replacedStaker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete replaced current validator: %w", err)
+					}
+
+					s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+				}
+
+				if validatorDiff.weightUpdated {
+					metadata.weight = staker.Weight
+				}
+
+				metadataBytes, err := marshalValidatorMetadata(metadata)
 				if err != nil {
 					return fmt.Errorf("failed to serialize current validator: %w", err)
 				}
-
 				if err = validatorDB.Put(staker.TxID[:], metadataBytes); err != nil {
 					return fmt.Errorf("failed to write current validator to list: %w", err)
 				}
@@ -1662,6 +2133,20 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 				}
 
 				s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+			case updated:
+				// The weight of the validator is being updated, so only the
+				// difference in weight is recorded.
+				staker := validatorDiff.validator
+				weightDiff.Amount = staker.Weight
+				if err := weightDiff.Add(true, validatorDiff.replacedValidator.Weight); err != nil {
+					return fmt.Errorf("failed to decrease node weight diff: %w", err)
+				}
+
+				// The updated weight is written with the rest of the
+				// validator's metadata.
+				if err := s.validatorState.SetWeight(nodeID, subnetID, staker.Weight); err != nil {
+					return fmt.Errorf("failed to update validator weight: %w", err)
+				}
 			}
 
 			err := writeCurrentDelegatorDiff(
@@ -1701,7 +2186,7 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 			if weightDiff.Decrease {
 				err = validators.RemoveWeight(s.cfg.Validators, subnetID, nodeID, weightDiff.Amount)
 			} else {
-				if validatorDiff.validatorStatus == added {
+				if validatorDiff.validatorStatus == added && validatorDiff.replacedValidator == nil {
 					staker := validatorDiff.validator
 					err = validators.Add(
 						s.cfg.Validators,
@@ -1769,6 +2254,108 @@ func writeCurrentDelegatorDiff(
 	return nil
 }
 
+// writeValidatorSetCheckpoints persists the validator set of every subnet if
+// [height] is a multiple of the configured checkpoint interval. Checkpoints
+// older than the configured retention are removed.
+func (s *state) writeValidatorSetCheckpoints(height uint64) error {
+	interval := s.cfg.ValidatorSetCheckpointInterval
+	if interval == 0 || height%interval != 0 {
+		return nil
+	}
+
+	heightBytes := database.PackUInt64(height)
+	for subnetID, subnetValidators := range s.currentStakers.validators {
+		checkpoint := validatorSetCheckpoint{
+			Validators: make([]checkpointValidator, 0, len(subnetValidators)),
+		}
+		for nodeID, validator := range subnetValidators {
+			staker := validator.validator
+			if staker == nil {
+				continue
+			}
+
+			weight := staker.Weight
+			delegatorIterator := NewTreeIterator(validator.delegators)
+			for delegatorIterator.Next() {
+				var err error
+				weight, err = math.Add64(weight, delegatorIterator.Value().Weight)
+				if err != nil {
+					delegatorIterator.Release()
+					return err
+				}
+			}
+			delegatorIterator.Release()
+
+			var pkBytes []byte
+			if staker.PublicKey != nil {
+				pkBytes = bls.PublicKeyToUncompressedBytes(staker.PublicKey)
+			}
+			checkpoint.Validators = append(checkpoint.Validators, checkpointValidator{
+				NodeID:    nodeID,
+				PublicKey: pkBytes,
+				Weight:    weight,
+			})
+		}
+		utils.Sort(checkpoint.Validators)
+
+		checkpointBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &checkpoint)
+		if err != nil {
+			return fmt.Errorf("failed to serialize validator set checkpoint: %w", err)
+		}
+
+		checkpointDB := prefixdb.New(subnetID[:], s.validatorSetCheckpointsDB)
+		if err := checkpointDB.Put(heightBytes, checkpointBytes); err != nil {
+			return err
+		}
+		if err := s.pruneValidatorSetCheckpoints(checkpointDB, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneValidatorSetCheckpoints removes the checkpoints in [checkpointDB] that
+// are outside of the configured retention, given that the most recent
+// checkpoint is at [height].
+func (s *state) pruneValidatorSetCheckpoints(checkpointDB database.Database, height uint64) error {
+	retention := s.cfg.ValidatorSetCheckpointRetention
+	if retention == 0 {
+		return nil
+	}
+
+	retainedHeights, err := math.Mul64(retention-1, s.cfg.ValidatorSetCheckpointInterval)
+	if err != nil || retainedHeights >= height {
+		// All the checkpoints are within the retention.
+		return nil
+	}
+	minHeight := height - retainedHeights
+
+	var expiredKeys [][]byte
+	checkpointIter := checkpointDB.NewIterator()
+	defer checkpointIter.Release()
+	for checkpointIter.Next() {
+		key := checkpointIter.Key()
+		checkpointHeight, err := database.ParseUInt64(key)
+		if err != nil {
+			return err
+		}
+		if checkpointHeight >= minHeight {
+			break
+		}
+		expiredKeys = append(expiredKeys, key)
+	}
+	if err := checkpointIter.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range expiredKeys {
+		if err := checkpointDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *state) writePendingStakers() error {
 	for subnetID, subnetValidatorDiffs := range s.pendingStakers.validatorDiffs {
 		delete(s.pendingStakers.validatorDiffs, subnetID)
@@ -1951,6 +2538,44 @@ func (s *state) writeChains() error {
 	return nil
 }
 
+func (s *state) writeSubnetOwnershipTransfers() error {
+	for subnetID, transfers := range s.addedSubnetOwnershipTransfers {
+		// The number of persisted transfers is the index of the next
+		// transfer.
+		index, err := database.GetUInt64(s.subnetOwnershipCountDB, subnetID[:])
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("failed to read subnet ownership transfer count: %w", err)
+		}
+
+		transferDB := prefixdb.New(subnetID[:], s.subnetOwnershipDB)
+		for _, transfer := range transfers {
+			txID := transfer.ID()
+			if err := transferDB.Put(database.PackUInt64(index), txID[:]); err != nil {
+				return fmt.Errorf("failed to write subnet ownership transfer: %w", err)
+			}
+			index++
+		}
+		if err := database.PutUInt64(s.subnetOwnershipCountDB, subnetID[:], index); err != nil {
+			return fmt.Errorf("failed to write subnet ownership transfer count: %w", err)
+		}
+		delete(s.addedSubnetOwnershipTransfers, subnetID)
+	}
+	return nil
+}
+
+func (s *state) writeStakerAutoRenewals() error {
+	for stakerTxID, tx := range s.addedStakerAutoRenewals {
+		stakerTxID := stakerTxID
+		txID := tx.ID()
+
+		delete(s.addedStakerAutoRenewals, stakerTxID)
+		if err := s.stakerAutoRenewalDB.Put(stakerTxID[:], txID[:]); err != nil {
+			return fmt.Errorf("failed to write staker auto renewal: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, timestampKey, s.timestamp); err != nil {
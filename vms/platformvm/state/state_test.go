@@ -4,23 +4,29 @@
 package state
 
 import (
+	"sync"
 	"testing"
 	"time"
 
 	stdmath "math"
 
+	"github.com/golang/mock/gomock"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -30,8 +36,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
 )
 
 var (
@@ -423,6 +432,291 @@ func TestGetValidatorPublicKeyDiffs(t *testing.T) {
 	}
 }
 
+func TestValidatorSetCheckpoints(t *testing.T) {
+	require := require.New(t)
+	stateIntf, _ := newInitializedState(require)
+	state := stateIntf.(*state)
+	state.cfg.ValidatorSetCheckpointInterval = 2
+	state.cfg.ValidatorSetCheckpointRetention = 2
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		nodeID0   = ids.GenerateTestNodeID()
+		nodeID1   = ids.GenerateTestNodeID()
+		validator = &Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    nodeID0,
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    10,
+			PublicKey: pk,
+		}
+		delegator = &Staker{
+			TxID:     ids.GenerateTestID(),
+			NodeID:   nodeID0,
+			SubnetID: constants.PrimaryNetworkID,
+			Weight:   5,
+		}
+		noPKValidator = &Staker{
+			TxID:     ids.GenerateTestID(),
+			NodeID:   nodeID1,
+			SubnetID: constants.PrimaryNetworkID,
+			Weight:   3,
+		}
+		subnetValidator = &Staker{
+			TxID:     ids.GenerateTestID(),
+			NodeID:   nodeID0,
+			SubnetID: subnetID,
+			Weight:   7,
+		}
+	)
+
+	// height 1
+	state.PutCurrentValidator(validator)
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	// height 2
+	state.PutCurrentDelegator(delegator)
+	state.PutCurrentValidator(noPKValidator)
+	state.PutCurrentValidator(subnetValidator)
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	// height 3
+	state.DeleteCurrentValidator(noPKValidator)
+	state.SetHeight(3)
+	require.NoError(state.Commit())
+
+	// height 4
+	state.DeleteCurrentDelegator(delegator)
+	state.SetHeight(4)
+	require.NoError(state.Commit())
+
+	checkpointHeight, vdrs, err := state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 1)
+	require.NoError(err)
+	require.Equal(uint64(2), checkpointHeight)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		initialNodeID: {
+			NodeID: initialNodeID,
+			Weight: units.Avax,
+		},
+		nodeID0: {
+			NodeID:    nodeID0,
+			PublicKey: pk,
+			Weight:    15,
+		},
+		nodeID1: {
+			NodeID: nodeID1,
+			Weight: 3,
+		},
+	}, vdrs)
+
+	checkpointHeight, vdrs, err = state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 3)
+	require.NoError(err)
+	require.Equal(uint64(4), checkpointHeight)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		initialNodeID: {
+			NodeID: initialNodeID,
+			Weight: units.Avax,
+		},
+		nodeID0: {
+			NodeID:    nodeID0,
+			PublicKey: pk,
+			Weight:    10,
+		},
+	}, vdrs)
+
+	checkpointHeight, vdrs, err = state.GetValidatorSetCheckpoint(subnetID, 2)
+	require.NoError(err)
+	require.Equal(uint64(2), checkpointHeight)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID: nodeID0,
+			Weight: 7,
+		},
+	}, vdrs)
+
+	_, _, err = state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 5)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// heights 5 and 6
+	state.SetHeight(5)
+	require.NoError(state.Commit())
+	state.SetHeight(6)
+	require.NoError(state.Commit())
+
+	// Only the two most recent checkpoints are retained.
+	checkpointHeight, _, err = state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 1)
+	require.NoError(err)
+	require.Equal(uint64(4), checkpointHeight)
+
+	checkpointHeight, _, err = state.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 5)
+	require.NoError(err)
+	require.Equal(uint64(6), checkpointHeight)
+}
+
+func TestStateExport(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+	require.NoError(s.Commit())
+
+	export, err := s.Export()
+	require.NoError(err)
+	require.NoError(export.Verify())
+
+	require.Zero(export.Height)
+	require.Equal(s.GetLastAccepted(), export.BlockID)
+	require.Equal(avajson.Uint64(initialTime.Unix()), export.Timestamp)
+	require.Len(export.Subnets, 1)
+	require.Equal(constants.PrimaryNetworkID, export.Subnets[0].ID)
+	require.NotZero(export.Subnets[0].CurrentSupply)
+	require.Len(export.Subnets[0].Chains, 1)
+	require.Equal("x", export.Subnets[0].Chains[0].Name)
+	require.Len(export.CurrentStakers, 1)
+	require.Equal(initialNodeID, export.CurrentStakers[0].NodeID)
+	require.Empty(export.PendingStakers)
+	require.Len(export.UTXOs, 1)
+	require.Len(export.Uptimes, 1)
+
+	// The same state loaded from the database must have the same export
+	reloadedState := newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+	reloadedExport, err := reloadedState.Export()
+	require.NoError(err)
+	require.Equal(export, reloadedExport)
+
+	// Uptimes aren't part of the hash
+	export.Uptimes = nil
+	require.NoError(export.Verify())
+
+	// The rest of the export is
+	export.Height++
+	err = export.Verify()
+	require.ErrorIs(err, errWrongExportHash)
+
+	// Modifying the state modifies the hash
+	s.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Avax,
+		},
+	})
+	require.NoError(s.Commit())
+
+	modifiedExport, err := s.Export()
+	require.NoError(err)
+	require.NoError(modifiedExport.Verify())
+	require.Len(modifiedExport.UTXOs, 2)
+	require.NotEqual(reloadedExport.Hash, modifiedExport.Hash)
+}
+
+func TestGetBlockIDAtHeight(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+	require.NoError(s.IndexBlockIDs(&sync.Mutex{}))
+	require.NoError(s.Commit())
+
+	genesisBlkID := s.GetLastAccepted()
+	blkID, err := s.GetBlockIDAtHeight(0)
+	require.NoError(err)
+	require.Equal(genesisBlkID, blkID)
+
+	_, err = s.GetBlockIDAtHeight(1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Only accepted blocks are indexed
+	processingBlk, err := blocks.NewBanffStandardBlock(initialTime, genesisBlkID, 1, nil)
+	require.NoError(err)
+	s.AddStatelessBlock(processingBlk, choices.Processing)
+	require.NoError(s.Commit())
+
+	_, err = s.GetBlockIDAtHeight(1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	parentID := genesisBlkID
+	blkIDs := []ids.ID{genesisBlkID}
+	for height := uint64(1); height <= 3; height++ {
+		blk, err := blocks.NewBanffStandardBlock(initialTime, parentID, height, nil)
+		require.NoError(err)
+		s.AddStatelessBlock(blk, choices.Accepted)
+		s.SetLastAccepted(blk.ID())
+		require.NoError(s.Commit())
+
+		parentID = blk.ID()
+		blkIDs = append(blkIDs, parentID)
+	}
+
+	for height, expectedBlkID := range blkIDs {
+		blkID, err := s.GetBlockIDAtHeight(uint64(height))
+		require.NoError(err)
+		require.Equal(expectedBlkID, blkID)
+	}
+
+	// Blocks accepted before the index existed are indexed by the backfill,
+	// and the index reports that it isn't ready until then
+	blockIDDB := prefixdb.New(blockIDPrefix, db)
+	singletonDB := prefixdb.New(singletonPrefix, db)
+	for height := range blkIDs {
+		require.NoError(blockIDDB.Delete(database.PackUInt64(uint64(height))))
+	}
+	require.NoError(singletonDB.Delete(blockIDsIndexedKey))
+
+	reloadedState := newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+	_, err = reloadedState.GetBlockIDAtHeight(0)
+	require.ErrorIs(err, ErrBlockIDIndexNotReady)
+
+	require.NoError(reloadedState.IndexBlockIDs(&sync.Mutex{}))
+
+	for height, expectedBlkID := range blkIDs {
+		blkID, err := reloadedState.GetBlockIDAtHeight(uint64(height))
+		require.NoError(err)
+		require.Equal(expectedBlkID, blkID)
+	}
+
+	// The index isn't backfilled again once it is complete
+	require.NoError(blockIDDB.Delete(database.PackUInt64(0)))
+
+	reloadedState = newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+	require.NoError(reloadedState.IndexBlockIDs(&sync.Mutex{}))
+
+	_, err = reloadedState.GetBlockIDAtHeight(0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// An interrupted backfill resumes from the next block to index
+	require.NoError(singletonDB.Delete(blockIDsIndexedKey))
+	require.NoError(database.PutID(singletonDB, nextBlockIDToIndexKey, blkIDs[1]))
+	require.NoError(blockIDDB.Delete(database.PackUInt64(1)))
+	require.NoError(blockIDDB.Delete(database.PackUInt64(3)))
+
+	reloadedState = newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+	require.NoError(reloadedState.IndexBlockIDs(&sync.Mutex{}))
+
+	for height, expectedBlkID := range blkIDs[:3] {
+		blkID, err := reloadedState.GetBlockIDAtHeight(uint64(height))
+		require.NoError(err)
+		require.Equal(expectedBlkID, blkID)
+	}
+	_, err = reloadedState.GetBlockIDAtHeight(3)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	has, err := singletonDB.Has(nextBlockIDToIndexKey)
+	require.NoError(err)
+	require.False(has)
+	has, err = singletonDB.Has(blockIDsIndexedKey)
+	require.NoError(err)
+	require.True(has)
+}
+
 func newInitializedState(require *require.Assertions) (State, database.Database) {
 	s, db := newUninitializedState(require)
 
@@ -502,7 +796,9 @@ func newStateFromDB(require *require.Assertions, db database.Database) State {
 		&config.Config{
 			Validators: vdrs,
 		},
-		&snow.Context{},
+		&snow.Context{
+			Log: logging.NoLog{},
+		},
 		prometheus.NewRegistry(),
 		reward.NewCalculator(reward.Config{
 			MaxConsumptionRate: .12 * reward.PercentDenominator,
@@ -834,3 +1130,241 @@ func TestStateAddRemoveValidator(t *testing.T) {
 		require.Equal(diff.expectedPublicKeyDiff, gotPublicKeyDiffs)
 	}
 }
+
+func TestStateUpdateValidatorWeight(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, db := newInitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = initialTime.Add(time.Second)
+		endTime   = initialValidatorEndTime
+		weight    = uint64(1000)
+	)
+	addTx := &txs.Tx{
+		Unsigned: &txs.AddSubnetValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				BlockchainID: constants.PlatformChainID,
+			}},
+			SubnetValidator: txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					Start:  uint64(startTime.Unix()),
+					End:    uint64(endTime.Unix()),
+					Wght:   weight,
+				},
+				Subnet: subnetID,
+			},
+			SubnetAuth: &secp256k1fx.Input{},
+		},
+	}
+	require.NoError(addTx.Sign(txs.Codec, nil))
+
+	staker, err := NewCurrentStaker(addTx.ID(), addTx.Unsigned.(*txs.AddSubnetValidatorTx), 0)
+	require.NoError(err)
+
+	s.AddTx(addTx, status.Committed)
+	s.PutCurrentValidator(staker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	upDuration := time.Hour
+	lastUpdated := startTime.Add(2 * time.Hour)
+	require.NoError(s.SetUptime(nodeID, subnetID, upDuration, lastUpdated))
+
+	// Update the weight of the validator through a diff
+	lastAcceptedID := s.GetLastAccepted()
+	versions := NewMockVersions(ctrl)
+	versions.EXPECT().GetState(lastAcceptedID).Return(s, true).AnyTimes()
+
+	d, err := NewDiff(lastAcceptedID, versions)
+	require.NoError(err)
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 3 * weight
+	d.UpdateCurrentValidator(&updatedStaker)
+
+	gotValidator, err := d.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(&updatedStaker, gotValidator)
+
+	require.NoError(d.Apply(s))
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	// Only the change in weight is recorded
+	weightDiffs, err := s.GetValidatorWeightDiffs(2, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: false,
+				Amount:   2 * weight,
+			},
+		},
+		weightDiffs,
+	)
+
+	// The updated weight and the uptime of the validator are persisted
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+
+	gotValidator, err = s.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(addTx.ID(), gotValidator.TxID)
+	require.Equal(3*weight, gotValidator.Weight)
+
+	gotUpDuration, gotLastUpdated, err := s.GetUptime(nodeID, subnetID)
+	require.NoError(err)
+	require.Equal(upDuration, gotUpDuration)
+	require.Equal(lastUpdated.Unix(), gotLastUpdated.Unix())
+
+	// Removing the validator removes its updated weight
+	s.DeleteCurrentValidator(gotValidator)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	weightDiffs, err = s.GetValidatorWeightDiffs(3, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: true,
+				Amount:   3 * weight,
+			},
+		},
+		weightDiffs,
+	)
+}
+
+func TestSubnetOwnershipTransfers(t *testing.T) {
+	require := require.New(t)
+
+	state, db := newInitializedState(require)
+
+	subnetID := ids.GenerateTestID()
+	newTransfer := func() *txs.Tx {
+		tx := &txs.Tx{
+			Unsigned: &txs.TransferSubnetOwnershipTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					BlockchainID: constants.PlatformChainID,
+				}},
+				Subnet:     subnetID,
+				SubnetAuth: &secp256k1fx.Input{},
+				Owner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		return tx
+	}
+
+	transfers, err := state.GetSubnetOwnershipTransfers(subnetID)
+	require.NoError(err)
+	require.Empty(transfers)
+
+	// Transfers are returned in the order they were added, both before and
+	// after they are committed.
+	var expectedTransfers []*txs.Tx
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			transfer := newTransfer()
+			state.AddTx(transfer, status.Committed)
+			state.AddSubnetOwnershipTransfer(transfer)
+			expectedTransfers = append(expectedTransfers, transfer)
+
+			transfers, err := state.GetSubnetOwnershipTransfers(subnetID)
+			require.NoError(err)
+			require.Equal(expectedTransfers, transfers)
+		}
+		require.NoError(state.Commit())
+	}
+
+	transfers, err = state.GetSubnetOwnershipTransfers(ids.GenerateTestID())
+	require.NoError(err)
+	require.Empty(transfers)
+
+	// The transfers are persisted in the order they were added.
+	state = newStateFromDB(require, db)
+	transfers, err = state.GetSubnetOwnershipTransfers(subnetID)
+	require.NoError(err)
+	require.Len(transfers, len(expectedTransfers))
+	for i, expectedTransfer := range expectedTransfers {
+		require.Equal(expectedTransfer.ID(), transfers[i].ID())
+	}
+}
+
+func TestGetSubnetOwner(t *testing.T) {
+	require := require.New(t)
+
+	state, db := newInitializedState(require)
+
+	newOwner := func() *secp256k1fx.OutputOwners {
+		return &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	}
+
+	createSubnetTx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				BlockchainID: constants.PlatformChainID,
+			}},
+			Owner: newOwner(),
+		},
+	}
+	require.NoError(createSubnetTx.Sign(txs.Codec, nil))
+	subnetID := createSubnetTx.ID()
+
+	state.AddTx(createSubnetTx, status.Committed)
+	state.AddSubnet(createSubnetTx)
+
+	// The subnet is owned by the owner set by its CreateSubnetTx until its
+	// ownership is transferred.
+	owner, err := state.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(createSubnetTx.Unsigned.(*txs.CreateSubnetTx).Owner, owner)
+
+	require.NoError(state.Commit())
+
+	var expectedOwner *secp256k1fx.OutputOwners
+	for i := 0; i < 3; i++ {
+		expectedOwner = newOwner()
+		transfer := &txs.Tx{
+			Unsigned: &txs.TransferSubnetOwnershipTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					BlockchainID: constants.PlatformChainID,
+				}},
+				Subnet:     subnetID,
+				SubnetAuth: &secp256k1fx.Input{},
+				Owner:      expectedOwner,
+			},
+		}
+		require.NoError(transfer.Sign(txs.Codec, nil))
+		state.AddTx(transfer, status.Committed)
+		state.AddSubnetOwnershipTransfer(transfer)
+
+		owner, err := state.GetSubnetOwner(subnetID)
+		require.NoError(err)
+		require.Equal(expectedOwner, owner)
+
+		require.NoError(state.Commit())
+	}
+
+	// The owner is read from the most recent persisted transfer.
+	state = newStateFromDB(require, db)
+	owner, err = state.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(expectedOwner, owner)
+
+	_, err = state.GetSubnetOwner(ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
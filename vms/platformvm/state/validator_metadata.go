@@ -21,6 +21,13 @@ import (
 // CodecVersionLen + UpDurationLen + LastUpdatedLen + PotentialRewardLen
 const preDelegateeRewardSize = wrappers.ShortLen + 3*wrappers.LongLen
 
+// preWeightSize is the size of codec marshalling [validatorMetadata] without
+// an updated weight.
+//
+// CodecVersionLen + UpDurationLen + LastUpdatedLen + PotentialRewardLen +
+// PotentialDelegateeRewardLen
+const preWeightSize = preDelegateeRewardSize + wrappers.LongLen
+
 var _ validatorState = (*metadata)(nil)
 
 type preDelegateeRewardMetadata struct {
@@ -37,12 +44,25 @@ type validatorMetadata struct {
 
 	txID        ids.ID
 	lastUpdated time.Time
+	// weight is the weight of the validator if it was updated after the
+	// validator was added. If zero, the weight of the validator is the weight
+	// in the tx that added it.
+	weight uint64
+}
+
+type weightedValidatorMetadata struct {
+	UpDuration               time.Duration `serialize:"true"`
+	LastUpdated              uint64        `serialize:"true"` // Unix time in seconds
+	PotentialReward          uint64        `serialize:"true"`
+	PotentialDelegateeReward uint64        `serialize:"true"`
+	Weight                   uint64        `serialize:"true"`
 }
 
 // Permissioned validators originally wrote their values as nil.
 // With Banff we wrote the potential reward.
 // With Cortina we wrote the potential reward with the potential delegatee reward.
 // We now write the uptime, reward, and delegatee reward together.
+// Validators whose weight was updated also write their weight.
 func parseValidatorMetadata(bytes []byte, metadata *validatorMetadata) error {
 	switch len(bytes) {
 	case 0:
@@ -67,16 +87,43 @@ func parseValidatorMetadata(bytes []byte, metadata *validatorMetadata) error {
 		metadata.UpDuration = tmp.UpDuration
 		metadata.LastUpdated = tmp.LastUpdated
 		metadata.PotentialReward = tmp.PotentialReward
+	case preWeightSize:
+		// everything but the weight was stored
+		if _, err := txs.Codec.Unmarshal(bytes, metadata); err != nil {
+			return err
+		}
 	default:
 		// everything was stored
-		if _, err := txs.Codec.Unmarshal(bytes, metadata); err != nil {
+		tmp := weightedValidatorMetadata{}
+		if _, err := txs.Codec.Unmarshal(bytes, &tmp); err != nil {
 			return err
 		}
+
+		metadata.UpDuration = tmp.UpDuration
+		metadata.LastUpdated = tmp.LastUpdated
+		metadata.PotentialReward = tmp.PotentialReward
+		metadata.PotentialDelegateeReward = tmp.PotentialDelegateeReward
+		metadata.weight = tmp.Weight
 	}
 	metadata.lastUpdated = time.Unix(int64(metadata.LastUpdated), 0)
 	return nil
 }
 
+// marshalValidatorMetadata only writes the weight of the validator if it was
+// updated, so that the metadata of other validators keeps its format.
+func marshalValidatorMetadata(metadata *validatorMetadata) ([]byte, error) {
+	if metadata.weight == 0 {
+		return genesis.Codec.Marshal(txs.Version, metadata)
+	}
+	return genesis.Codec.Marshal(txs.Version, &weightedValidatorMetadata{
+		UpDuration:               metadata.UpDuration,
+		LastUpdated:              metadata.LastUpdated,
+		PotentialReward:          metadata.PotentialReward,
+		PotentialDelegateeReward: metadata.PotentialDelegateeReward,
+		Weight:                   metadata.weight,
+	})
+}
+
 type validatorState interface {
 	// LoadValidatorMetadata sets the [metadata] of [vdrID] on [subnetID].
 	// GetUptime and SetUptime will return an error if the [vdrID] and
@@ -121,14 +168,23 @@ type validatorState interface {
 		amount uint64,
 	) error
 
+	// SetWeight updates the weight of [vdrID] on [subnetID] to [weight].
+	// Unless the metadata is deleted first, the next call to
+	// WriteValidatorMetadata will write this update to disk.
+	SetWeight(
+		vdrID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+	) error
+
 	// DeleteValidatorMetadata removes in-memory references to the metadata of
 	// [vdrID] on [subnetID]. If there were staged updates from a prior call to
-	// SetUptime or SetDelegateeReward, the updates will be dropped. This call
+	// SetUptime, SetDelegateeReward or SetWeight, the updates will be dropped. This call
 	// will not result in a write to disk.
 	DeleteValidatorMetadata(vdrID ids.NodeID, subnetID ids.ID)
 
 	// WriteValidatorMetadata writes all staged updates from prior calls to
-	// SetUptime or SetDelegateeReward.
+	// SetUptime, SetDelegateeReward or SetWeight.
 	WriteValidatorMetadata(
 		dbPrimary database.KeyValueWriter,
 		dbSubnet database.KeyValueWriter,
@@ -215,6 +271,21 @@ func (m *metadata) SetDelegateeReward(
 	return nil
 }
 
+func (m *metadata) SetWeight(
+	vdrID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+) error {
+	metadata, exists := m.metadata[vdrID][subnetID]
+	if !exists {
+		return database.ErrNotFound
+	}
+	metadata.weight = weight
+
+	m.addUpdatedMetadata(vdrID, subnetID)
+	return nil
+}
+
 func (m *metadata) DeleteValidatorMetadata(vdrID ids.NodeID, subnetID ids.ID) {
 	subnetMetadata := m.metadata[vdrID]
 	delete(subnetMetadata, subnetID)
@@ -238,7 +309,7 @@ func (m *metadata) WriteValidatorMetadata(
 			metadata := m.metadata[vdrID][subnetID]
 			metadata.LastUpdated = uint64(metadata.lastUpdated.Unix())
 
-			metadataBytes, err := genesis.Codec.Marshal(txs.Version, metadata)
+			metadataBytes, err := marshalValidatorMetadata(metadata)
 			if err != nil {
 				return err
 			}
@@ -248,6 +248,32 @@ func TestParseValidatorMetadata(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "uptime + potential reward + potential delegatee reward + weight",
+			bytes: []byte{
+				// codec version
+				0x00, 0x00,
+				// up duration
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x5B, 0x8D, 0x80,
+				// last updated
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x0D, 0xBB, 0xA0,
+				// potential reward
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x86, 0xA0,
+				// potential delegatee reward
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4E, 0x20,
+				// weight
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xE8,
+			},
+			expected: &validatorMetadata{
+				UpDuration:               6000000,
+				LastUpdated:              900000,
+				PotentialReward:          100000,
+				PotentialDelegateeReward: 20000,
+				lastUpdated:              time.Unix(900000, 0),
+				weight:                   1000,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "invalid codec version",
 			bytes: []byte{
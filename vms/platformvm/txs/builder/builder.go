@@ -30,6 +30,8 @@ var (
 	_ Builder = (*builder)(nil)
 
 	ErrNoFunds = errors.New("no spendable funds were found")
+
+	errCantAuthorizeStaker = errors.New("provided keys can't authorize the staker")
 )
 
 type Builder interface {
@@ -159,6 +161,43 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Creates a transaction that sets the weight of [nodeID]
+	// as a validator of [subnetID] to [weight]
+	// keys: keys to use for setting the weight
+	// changeAddr: address to send change to, if there is any
+	NewSetSubnetValidatorWeightTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		keys []*secp256k1.PrivateKey,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that transfers the ownership of [subnetID]
+	// owner: the new owner of the subnet
+	// keys: keys to use for authorizing the transfer
+	// changeAddr: address to send change to, if there is any
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		keys []*secp256k1.PrivateKey,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that sets whether the staker added by [stakerTxID]
+	// is renewed when its staking period ends
+	// autoRenew: true if the staker should be renewed
+	// restakeRewards: true if the rewards should be added to the renewed stake
+	// keys: keys to use for paying the fee and for authorizing the change
+	// changeAddr: address to send change to, if there is any
+	NewSetAutoRenewTx(
+		stakerTxID ids.ID,
+		autoRenew bool,
+		restakeRewards bool,
+		keys []*secp256k1.PrivateKey,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// newAdvanceTimeTx creates a new tx that, if it is accepted and followed by a
 	// Commit block, will set the chain's timestamp to [timestamp].
 	NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error)
@@ -591,6 +630,140 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewSetSubnetValidatorWeightTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	keys []*secp256k1.PrivateKey,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.SetSubnetValidatorWeightTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		Weight:     weight,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	keys []*secp256k1.PrivateKey,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewSetAutoRenewTx(
+	stakerTxID ids.ID,
+	autoRenew bool,
+	restakeRewards bool,
+	keys []*secp256k1.PrivateKey,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	stakerTx, _, err := b.state.GetTx(stakerTxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch staker tx %s: %w", stakerTxID, err)
+	}
+
+	var ownerIntf fx.Owner
+	switch utx := stakerTx.Unsigned.(type) {
+	case *txs.AddPermissionlessValidatorTx:
+		ownerIntf = utx.ValidatorRewardsOwner
+	case *txs.AddPermissionlessDelegatorTx:
+		ownerIntf = utx.DelegationRewardsOwner
+	default:
+		return nil, fmt.Errorf("expected a permissionless staker tx but got %T", utx)
+	}
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", ownerIntf)
+	}
+
+	// Attempt to prove ownership of the rewards of the staker
+	kc := secp256k1fx.NewKeychain(keys...)
+	now := uint64(b.clk.Time().Unix())
+	indices, stakerSigners, matches := kc.Match(owner, now)
+	if !matches {
+		return nil, errCantAuthorizeStaker
+	}
+	signers = append(signers, stakerSigners)
+
+	// Create the tx
+	utx := &txs.SetAutoRenewTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		StakerTxID:     stakerTxID,
+		AutoRenew:      autoRenew,
+		RestakeRewards: restakeRewards,
+		StakerAuth:     &secp256k1fx.Input{SigIndices: indices},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
 func (b *builder) NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error) {
 	utx := &txs.AdvanceTimeTx{Time: uint64(timestamp.Unix())}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
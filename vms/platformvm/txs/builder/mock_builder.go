@@ -14,6 +14,7 @@ import (
 	ids "github.com/ava-labs/avalanchego/ids"
 	secp256k1 "github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	secp256k1fx "github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -189,3 +190,48 @@ func (mr *MockBuilderMockRecorder) NewRewardValidatorTx(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRewardValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRewardValidatorTx), arg0)
 }
+
+// NewSetAutoRenewTx mocks base method.
+func (m *MockBuilder) NewSetAutoRenewTx(arg0 ids.ID, arg1, arg2 bool, arg3 []*secp256k1.PrivateKey, arg4 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSetAutoRenewTx", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSetAutoRenewTx indicates an expected call of NewSetAutoRenewTx.
+func (mr *MockBuilderMockRecorder) NewSetAutoRenewTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSetAutoRenewTx", reflect.TypeOf((*MockBuilder)(nil).NewSetAutoRenewTx), arg0, arg1, arg2, arg3, arg4)
+}
+
+// NewSetSubnetValidatorWeightTx mocks base method.
+func (m *MockBuilder) NewSetSubnetValidatorWeightTx(arg0 ids.NodeID, arg1 ids.ID, arg2 uint64, arg3 []*secp256k1.PrivateKey, arg4 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSetSubnetValidatorWeightTx", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSetSubnetValidatorWeightTx indicates an expected call of NewSetSubnetValidatorWeightTx.
+func (mr *MockBuilderMockRecorder) NewSetSubnetValidatorWeightTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSetSubnetValidatorWeightTx", reflect.TypeOf((*MockBuilder)(nil).NewSetSubnetValidatorWeightTx), arg0, arg1, arg2, arg3, arg4)
+}
+
+// NewTransferSubnetOwnershipTx mocks base method.
+func (m *MockBuilder) NewTransferSubnetOwnershipTx(arg0 ids.ID, arg1 *secp256k1fx.OutputOwners, arg2 []*secp256k1.PrivateKey, arg3 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransferSubnetOwnershipTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewTransferSubnetOwnershipTx indicates an expected call of NewTransferSubnetOwnershipTx.
+func (mr *MockBuilderMockRecorder) NewTransferSubnetOwnershipTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransferSubnetOwnershipTx", reflect.TypeOf((*MockBuilder)(nil).NewTransferSubnetOwnershipTx), arg0, arg1, arg2, arg3)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 // Version is the current default codec version
@@ -41,6 +42,11 @@ func init() {
 		c.SkipRegistrations(5)
 
 		errs.Add(RegisterUnsignedTxsTypes(c))
+
+		// Skip positions for the Banff blocks.
+		c.SkipRegistrations(4)
+
+		errs.Add(RegisterDUnsignedTxsTypes(c))
 	}
 	errs.Add(
 		Codec.RegisterCodec(Version, c),
@@ -97,3 +103,28 @@ func RegisterUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
 	)
 	return errs.Err
 }
+
+// RegisterDUnsignedTxsTypes registers the transaction and fx types added in
+// the Durango upgrade. They are registered after the Banff block types, so
+// positions are skipped for them.
+func RegisterDUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
+		targetCodec.RegisterType(&SetAutoRenewTx{}),
+		targetCodec.RegisterType(&SetSubnetValidatorWeightTx{}),
+	)
+
+	// The secp256r1fx is registered in the same order that it is registered
+	// in the AVM, starting at [secp256r1fx.TypeIDOffset] on both chains. This
+	// ensures that the typeIDs match up for utxos in shared memory.
+	errs.Add(targetCodec.RegisterType(&secp256r1fx.TransferInput{}))
+	targetCodec.SkipRegistrations(1)
+	errs.Add(targetCodec.RegisterType(&secp256r1fx.TransferOutput{}))
+	targetCodec.SkipRegistrations(1)
+	errs.Add(
+		targetCodec.RegisterType(&secp256r1fx.Credential{}),
+		targetCodec.RegisterType(&secp256r1fx.OutputOwners{}),
+	)
+	return errs.Err
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+)
+
+func TestSecp256r1FxTypeIDs(t *testing.T) {
+	// The type IDs must match the ones of the AVM, so that utxos in shared
+	// memory can be parsed by both chains.
+	tests := []struct {
+		val    verify.Verifiable
+		typeID byte
+	}{
+		{
+			val:    &secp256r1fx.TransferInput{},
+			typeID: secp256r1fx.TypeIDOffset,
+		},
+		{
+			val:    &secp256r1fx.TransferOutput{},
+			typeID: secp256r1fx.TypeIDOffset + 2,
+		},
+		{
+			val:    &secp256r1fx.Credential{},
+			typeID: secp256r1fx.TypeIDOffset + 4,
+		},
+		{
+			val:    &secp256r1fx.OutputOwners{},
+			typeID: secp256r1fx.TypeIDOffset + 5,
+		},
+	}
+	for _, test := range tests {
+		bytes, err := Codec.Marshal(Version, &test.val)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x00, 0x00, 0x00, test.typeID}, bytes[2:6], "%T", test.val)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+)
+
+var (
+	_ txs.Visitor = (*secp256r1FxUsageVerifier)(nil)
+
+	ErrSecp256r1FxNotActive = errors.New("secp256r1fx is not active")
+)
+
+// VerifyFxActivation returns an error if [tx] uses an fx that isn't activated
+// at [chainTime]. The secp256r1fx is activated by the Durango upgrade.
+func VerifyFxActivation(backend *Backend, chainTime time.Time, tx *txs.Tx) error {
+	if backend.Config.IsDurangoActivated(chainTime) {
+		return nil
+	}
+	// Spending a secp256r1fx UTXO or authorizing as a secp256r1fx owner
+	// requires a secp256r1fx credential.
+	for _, cred := range tx.Creds {
+		if _, ok := cred.(*secp256r1fx.Credential); ok {
+			return ErrSecp256r1FxNotActive
+		}
+	}
+	return tx.Unsigned.Visit(&secp256r1FxUsageVerifier{})
+}
+
+// secp256r1FxUsageVerifier returns [ErrSecp256r1FxNotActive] if the visited tx
+// creates secp256r1fx outputs or owners.
+type secp256r1FxUsageVerifier struct{}
+
+func (*secp256r1FxUsageVerifier) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs, tx.StakeOuts},
+		tx.RewardsOwner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs, tx.StakeOuts},
+		tx.DelegationRewardsOwner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) CreateChainTx(tx *txs.CreateChainTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs},
+		tx.Owner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) ImportTx(tx *txs.ImportTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) ExportTx(tx *txs.ExportTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs, tx.ExportedOutputs})
+}
+
+func (*secp256r1FxUsageVerifier) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (*secp256r1FxUsageVerifier) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil
+}
+
+func (*secp256r1FxUsageVerifier) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs, tx.StakeOuts},
+		tx.ValidatorRewardsOwner,
+		tx.DelegatorRewardsOwner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs, tx.StakeOuts},
+		tx.DelegationRewardsOwner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return verifyNoSecp256r1Fx(
+		[][]*avax.TransferableOutput{tx.Outs},
+		tx.Owner,
+	)
+}
+
+func (*secp256r1FxUsageVerifier) SetAutoRenewTx(tx *txs.SetAutoRenewTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func (*secp256r1FxUsageVerifier) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	return verifyNoSecp256r1Fx([][]*avax.TransferableOutput{tx.Outs})
+}
+
+func verifyNoSecp256r1Fx(outputs [][]*avax.TransferableOutput, owners ...fx.Owner) error {
+	for _, outs := range outputs {
+		for _, out := range outs {
+			output := out.Output()
+			if inner, ok := output.(*stakeable.LockOut); ok {
+				output = inner.TransferableOut
+			}
+			if _, ok := output.(*secp256r1fx.TransferOutput); ok {
+				return ErrSecp256r1FxNotActive
+			}
+		}
+	}
+	for _, owner := range owners {
+		if _, ok := owner.(*secp256r1fx.OutputOwners); ok {
+			return ErrSecp256r1FxNotActive
+		}
+	}
+	return nil
+}
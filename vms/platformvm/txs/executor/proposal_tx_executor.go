@@ -85,6 +85,18 @@ func (*ProposalTxExecutor) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx)
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetAutoRenewTx(*txs.SetAutoRenewTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
 func (*ProposalTxExecutor) TransformSubnetTx(*txs.TransformSubnetTx) error {
 	return ErrWrongTxType
 }
@@ -359,34 +371,52 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		return fmt.Errorf("failed to get next removed staker tx: %w", err)
 	}
 
+	var (
+		// If [renewal] is non-nil, the stake isn't refunded. Instead, the
+		// staker is renewed into a new staking period.
+		renewal *stakerRenewal
+		// [restakedReward] is added to the stake of the renewed staker if the
+		// reward is committed, rather than being paid out.
+		restakedReward uint64
+	)
 	switch uStakerTx := stakerTx.Unsigned.(type) {
 	case txs.ValidatorTx:
 		e.OnCommitState.DeleteCurrentValidator(stakerToRemove)
 		e.OnAbortState.DeleteCurrentValidator(stakerToRemove)
 
+		renewal, err = getStakerRenewal(e.Backend, e.OnCommitState, stakerToRemove)
+		if err != nil {
+			return fmt.Errorf("failed to get renewal of %s: %w", stakerToRemove.TxID, err)
+		}
+
+		if renewal != nil && renewal.restakeRewards && stakerToRemove.PotentialReward > 0 {
+			weight, err := math.Add64(stakerToRemove.Weight, stakerToRemove.PotentialReward)
+			if err != nil {
+				return err
+			}
+			canRestake, err := canRestakeValidatorReward(e.Backend, e.OnCommitState, stakerToRemove, renewal, weight)
+			if err != nil {
+				return err
+			}
+			if canRestake {
+				restakedReward = stakerToRemove.PotentialReward
+			}
+		}
+
 		stake := uStakerTx.Stake()
 		outputs := uStakerTx.Outputs()
 		// Invariant: The staked asset must be equal to the reward asset.
 		stakeAsset := stake[0].Asset
 
-		// Refund the stake here
-		for i, out := range stake {
-			utxo := &avax.UTXO{
-				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + i),
-				},
-				Asset: out.Asset,
-				Out:   out.Output(),
-			}
-			e.OnCommitState.AddUTXO(utxo)
-			e.OnAbortState.AddUTXO(utxo)
+		// Refund the stake here, unless it's rolled into the renewed staker
+		if renewal == nil {
+			refundStake(e.OnCommitState, e.OnAbortState, tx.TxID, outputs, stake)
 		}
 
 		offset := 0
 
 		// Provide the reward here
-		if stakerToRemove.PotentialReward > 0 {
+		if stakerToRemove.PotentialReward > 0 && restakedReward == 0 {
 			validationRewardsOwner := uStakerTx.ValidationRewardsOwner()
 			outIntf, err := e.Fx.CreateOutput(stakerToRemove.PotentialReward, validationRewardsOwner)
 			if err != nil {
@@ -466,20 +496,6 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		outputs := uStakerTx.Outputs()
 		stakeAsset := stake[0].Asset
 
-		// Refund the stake here
-		for i, out := range stake {
-			utxo := &avax.UTXO{
-				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + i),
-				},
-				Asset: out.Asset,
-				Out:   out.Output(),
-			}
-			e.OnCommitState.AddUTXO(utxo)
-			e.OnAbortState.AddUTXO(utxo)
-		}
-
 		// We're removing a delegator, so we need to fetch the validator they
 		// are delegated to.
 		vdrStaker, err := e.OnCommitState.GetCurrentValidator(
@@ -514,19 +530,47 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 		// Calculate split of reward between delegator/delegatee
 		// The delegator gives stake to the validatee
-		validatorShares := vdrTx.Shares()
-		delegatorShares := reward.PercentDenominator - uint64(validatorShares)                            // parentTx.Shares <= reward.PercentDenominator so no underflow
-		delegatorReward := delegatorShares * (stakerToRemove.PotentialReward / reward.PercentDenominator) // delegatorShares <= reward.PercentDenominator so no overflow
-		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := math.Mul64(delegatorShares, stakerToRemove.PotentialReward); err == nil {
-			delegatorReward = optimisticReward / reward.PercentDenominator
+		delegateeReward, delegatorReward := reward.Split(stakerToRemove.PotentialReward, vdrTx.Shares())
+
+		renewal, err = getStakerRenewal(e.Backend, e.OnCommitState, stakerToRemove)
+		if err != nil {
+			return fmt.Errorf("failed to get renewal of %s: %w", stakerToRemove.TxID, err)
+		}
+
+		if renewal != nil && renewal.restakeRewards && delegatorReward > 0 {
+			weight, err := math.Add64(stakerToRemove.Weight, delegatorReward)
+			if err != nil {
+				return err
+			}
+			canRestake, err := canRenewDelegator(e.Backend, e.OnCommitState, vdrStaker, stakerToRemove, renewal, weight)
+			if err != nil {
+				return err
+			}
+			if canRestake {
+				restakedReward = delegatorReward
+			}
+		}
+		if renewal != nil && restakedReward == 0 {
+			// If the delegator can't be renewed without over-delegating the
+			// validator, its staking period ends.
+			canRenew, err := canRenewDelegator(e.Backend, e.OnCommitState, vdrStaker, stakerToRemove, renewal, stakerToRemove.Weight)
+			if err != nil {
+				return err
+			}
+			if !canRenew {
+				renewal = nil
+			}
+		}
+
+		// Refund the stake here, unless it's rolled into the renewed staker
+		if renewal == nil {
+			refundStake(e.OnCommitState, e.OnAbortState, tx.TxID, outputs, stake)
 		}
-		delegateeReward := stakerToRemove.PotentialReward - delegatorReward // delegatorReward <= reward so no underflow
 
 		offset := 0
 
 		// Reward the delegator here
-		if delegatorReward > 0 {
+		if delegatorReward > 0 && restakedReward == 0 {
 			rewardsOwner := uStakerTx.RewardsOwner()
 			outIntf, err := e.Fx.CreateOutput(delegatorReward, rewardsOwner)
 			if err != nil {
@@ -621,6 +665,24 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 	}
 	e.OnAbortState.SetCurrentSupply(stakerToRemove.SubnetID, newSupply)
 
+	// The staker is renewed whether the reward is committed or aborted. Only
+	// a committed reward can be restaked.
+	//
+	// A staker whose reward is aborted, for example a validator that failed
+	// the uptime requirement, is still renewed. The uptime requirement only
+	// decides whether the staking period is rewarded. Delegators that were
+	// already renewed into the next staking period of a validator rely on the
+	// validator being renewed, and the renewal of an unreliable validator can
+	// be cancelled by the owner of its rewards with a SetAutoRenewTx.
+	if renewal != nil {
+		if err := renewStaker(e.Backend, e.OnCommitState, stakerToRemove, stakerTx, renewal, restakedReward); err != nil {
+			return fmt.Errorf("failed to renew %s: %w", stakerToRemove.TxID, err)
+		}
+		if err := renewStaker(e.Backend, e.OnAbortState, stakerToRemove, stakerTx, renewal, 0); err != nil {
+			return fmt.Errorf("failed to renew %s: %w", stakerToRemove.TxID, err)
+		}
+	}
+
 	var expectedUptimePercentage float64
 	if stakerToRemove.SubnetID != constants.PrimaryNetworkID {
 		transformSubnetIntf, err := e.OnCommitState.GetSubnetTransformation(stakerToRemove.SubnetID)
@@ -700,6 +762,29 @@ func GetValidator(state state.Chain, subnetID ids.ID, nodeID ids.NodeID) (*state
 	return state.GetPendingValidator(subnetID, nodeID)
 }
 
+// refundStake adds the UTXOs that return [stake] of the staker added by [txID]
+// to both [onCommitState] and [onAbortState].
+func refundStake(
+	onCommitState state.Diff,
+	onAbortState state.Diff,
+	txID ids.ID,
+	outputs []*avax.TransferableOutput,
+	stake []*avax.TransferableOutput,
+) {
+	for i, out := range stake {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(len(outputs) + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		}
+		onCommitState.AddUTXO(utxo)
+		onAbortState.AddUTXO(utxo)
+	}
+}
+
 // canDelegate returns true if [delegator] can be added as a delegator of
 // [validator].
 //
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+var errTooManyShares = fmt.Errorf("a staker can only require at most %d shares from delegators", reward.PercentDenominator)
+
+// RewardEstimate is the reward a staker would be paid if it were added to the
+// chain state it was estimated against and met its uptime requirement.
+type RewardEstimate struct {
+	// CurrentSupply is the supply of the staked asset the reward was
+	// calculated against.
+	CurrentSupply uint64
+	// PotentialReward is the total reward generated by the stake.
+	PotentialReward uint64
+	// StakerReward is the part of [PotentialReward] paid to the staker.
+	StakerReward uint64
+	// DelegationFee is the part of [PotentialReward] paid to the validator
+	// being delegated to. Always 0 for validators.
+	DelegationFee uint64
+}
+
+// EstimateReward returns the reward of a staker that stakes [amount] on
+// [subnetID] for [duration], if it were added on top of [chainState].
+//
+// If [isDelegator] is false, the staker is a validator charging [shares] as
+// its delegation fee. Otherwise, the staker is a delegator of a validator
+// charging [shares].
+//
+// The stake is verified against the same rules that are enforced when the
+// staker is added, except for the rules that depend on other stakers.
+func EstimateReward(
+	backend *Backend,
+	chainState state.Chain,
+	subnetID ids.ID,
+	amount uint64,
+	duration time.Duration,
+	shares uint32,
+	isDelegator bool,
+) (*RewardEstimate, error) {
+	if shares > reward.PercentDenominator {
+		return nil, errTooManyShares
+	}
+
+	if isDelegator {
+		delegatorRules, err := getDelegatorRules(backend, chainState, subnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case amount < delegatorRules.minDelegatorStake:
+			return nil, ErrWeightTooSmall
+		case amount > delegatorRules.maxValidatorStake:
+			return nil, ErrWeightTooLarge
+		case duration < delegatorRules.minStakeDuration:
+			return nil, ErrStakeTooShort
+		case duration > delegatorRules.maxStakeDuration:
+			return nil, ErrStakeTooLong
+		}
+	} else {
+		validatorRules, err := getValidatorRules(backend, chainState, subnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case amount < validatorRules.minValidatorStake:
+			return nil, ErrWeightTooSmall
+		case amount > validatorRules.maxValidatorStake:
+			return nil, ErrWeightTooLarge
+		case shares < validatorRules.minDelegationFee:
+			return nil, ErrInsufficientDelegationFee
+		case duration < validatorRules.minStakeDuration:
+			return nil, ErrStakeTooShort
+		case duration > validatorRules.maxStakeDuration:
+			return nil, ErrStakeTooLong
+		}
+	}
+
+	rewards, err := GetRewardsCalculator(backend, chainState, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentSupply, err := chainState.GetCurrentSupply(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	potentialReward := rewards.Calculate(duration, amount, currentSupply)
+	estimate := &RewardEstimate{
+		CurrentSupply:   currentSupply,
+		PotentialReward: potentialReward,
+		StakerReward:    potentialReward,
+	}
+	if isDelegator {
+		estimate.DelegationFee, estimate.StakerReward = reward.Split(potentialReward, shares)
+	}
+	return estimate, nil
+}
@@ -0,0 +1,357 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+)
+
+var _ fx.Fx = (*unsignedFx)(nil)
+
+// Simulation describes the changes a transaction would make to the chain state
+// if it were included in the next block.
+type Simulation struct {
+	// ConsumedUTXOs are the IDs of the UTXOs, including imported UTXOs, that
+	// the transaction spends.
+	ConsumedUTXOs []ids.ID
+	// ProducedUTXOs are the UTXOs the transaction adds to the chain state.
+	ProducedUTXOs []*avax.UTXO
+	// Stakers are the validators and delegators the transaction adds.
+	Stakers []*state.Staker
+	// Burned is the amount of AVAX consumed by the transaction that isn't
+	// produced, staked, or exported.
+	Burned uint64
+}
+
+// Simulate executes [tx] on top of the state of [parentID] the same way it
+// would be executed if it were included in the next block built on
+// [parentID]. The state of [parentID] is never modified.
+//
+// If [tx] is invalid, the error returned by the tx executor is returned.
+func Simulate(
+	backend *Backend,
+	parentID ids.ID,
+	versions state.Versions,
+	tx *txs.Tx,
+) (*Simulation, error) {
+	parentState, ok := versions.GetState(parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", state.ErrMissingParentState, parentID)
+	}
+
+	verifier := MempoolTxVerifier{
+		Backend:       backend,
+		ParentID:      parentID,
+		StateVersions: versions,
+		Tx:            tx,
+	}
+	baseState, err := verifier.standardBaseState()
+	if err != nil {
+		return nil, err
+	}
+
+	var onAccept state.Diff
+	switch tx.Unsigned.(type) {
+	case *txs.AdvanceTimeTx, *txs.RewardValidatorTx:
+		// These txs are only ever created by the block builder.
+		return nil, ErrWrongTxType
+	case *txs.AddValidatorTx, *txs.AddSubnetValidatorTx, *txs.AddDelegatorTx:
+		if backend.Config.IsBanffActivated(baseState.GetTimestamp()) {
+			onAccept, err = simulateStandardTx(backend, baseState, tx)
+			break
+		}
+		onAccept, err = simulateProposalTx(backend, parentID, versions, tx)
+	case *txs.ImportTx, *txs.ExportTx:
+		if backend.Config.IsBanffActivated(baseState.GetTimestamp()) {
+			onAccept, err = simulateStandardTx(backend, baseState, tx)
+			break
+		}
+		onAccept, err = simulateAtomicTx(backend, parentID, versions, tx)
+	default:
+		onAccept, err = simulateStandardTx(backend, baseState, tx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	txID := tx.ID()
+	simulation := &Simulation{
+		ConsumedUTXOs: tx.Unsigned.InputIDs().List(),
+		ProducedUTXOs: tx.UTXOs(),
+	}
+	utils.Sort(simulation.ConsumedUTXOs)
+
+	if staker, ok := tx.Unsigned.(txs.Staker); ok {
+		simulation.Stakers, err = addedStakers(onAccept, txID, staker.SubnetID(), staker.NodeID())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	simulation.Burned, err = burnedAVAX(backend.Ctx.AVAXAssetID, parentState, tx.Unsigned)
+	return simulation, err
+}
+
+func simulateStandardTx(backend *Backend, baseState state.Diff, tx *txs.Tx) (state.Diff, error) {
+	executor := StandardTxExecutor{
+		Backend: backend,
+		State:   baseState,
+		Tx:      tx,
+	}
+	return baseState, tx.Unsigned.Visit(&executor)
+}
+
+// simulateProposalTx returns the state the chain would be in if the proposal
+// were committed.
+func simulateProposalTx(
+	backend *Backend,
+	parentID ids.ID,
+	versions state.Versions,
+	tx *txs.Tx,
+) (state.Diff, error) {
+	onCommitState, err := state.NewDiff(parentID, versions)
+	if err != nil {
+		return nil, err
+	}
+	onAbortState, err := state.NewDiff(parentID, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := ProposalTxExecutor{
+		Backend:       backend,
+		Tx:            tx,
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+	}
+	return onCommitState, tx.Unsigned.Visit(&executor)
+}
+
+func simulateAtomicTx(
+	backend *Backend,
+	parentID ids.ID,
+	versions state.Versions,
+	tx *txs.Tx,
+) (state.Diff, error) {
+	executor := AtomicTxExecutor{
+		Backend:       backend,
+		ParentID:      parentID,
+		StateVersions: versions,
+		Tx:            tx,
+	}
+	err := tx.Unsigned.Visit(&executor)
+	return executor.OnAccept, err
+}
+
+// addedStakers returns the stakers in [chainState] that were added by [txID].
+func addedStakers(
+	chainState state.Chain,
+	txID ids.ID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+) ([]*state.Staker, error) {
+	var stakers []*state.Staker
+	for _, getValidator := range []func(ids.ID, ids.NodeID) (*state.Staker, error){
+		chainState.GetCurrentValidator,
+		chainState.GetPendingValidator,
+	} {
+		staker, err := getValidator(subnetID, nodeID)
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		case staker.TxID == txID:
+			stakers = append(stakers, staker)
+		}
+	}
+
+	for _, getDelegators := range []func(ids.ID, ids.NodeID) (state.StakerIterator, error){
+		chainState.GetCurrentDelegatorIterator,
+		chainState.GetPendingDelegatorIterator,
+	} {
+		it, err := getDelegators(subnetID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			if staker := it.Value(); staker.TxID == txID {
+				stakers = append(stakers, staker)
+			}
+		}
+		it.Release()
+	}
+	return stakers, nil
+}
+
+// burnedAVAX returns the amount of AVAX consumed by [utx] minus the amount of
+// AVAX it produces, stakes, or exports. [parentState] must be the state the
+// non-imported inputs of [utx] are consumed from.
+func burnedAVAX(
+	avaxAssetID ids.ID,
+	parentState state.Chain,
+	utx txs.UnsignedTx,
+) (uint64, error) {
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+
+	inputIDs := utx.InputIDs()
+	if importTx, ok := utx.(*txs.ImportTx); ok {
+		for _, in := range importTx.ImportedInputs {
+			inputIDs.Remove(in.InputID())
+			if in.AssetID() != avaxAssetID {
+				continue
+			}
+			consumed, err = math.Add64(consumed, in.Input().Amount())
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	for inputID := range inputIDs {
+		consumedUTXO, err := parentState.GetUTXO(inputID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+		}
+		out, ok := consumedUTXO.Out.(avax.Amounter)
+		if !ok || consumedUTXO.AssetID() != avaxAssetID {
+			continue
+		}
+		consumed, err = math.Add64(consumed, out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	outs := append([]*avax.TransferableOutput{}, utx.Outputs()...)
+	switch utx := utx.(type) {
+	case txs.PermissionlessStaker:
+		outs = append(outs, utx.Stake()...)
+	case *txs.ExportTx:
+		outs = append(outs, utx.ExportedOutputs...)
+	}
+	for _, out := range outs {
+		if out.AssetID() != avaxAssetID {
+			continue
+		}
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub(consumed, produced)
+}
+
+// WithoutSignatureChecks returns a copy of [backend] that doesn't verify
+// signatures. Amounts and timelocks are still enforced. This allows simulating
+// a transaction before it has been signed, by attaching placeholder
+// credentials created with [PlaceholderCredentials].
+func WithoutSignatureChecks(backend *Backend) *Backend {
+	fx := &unsignedFx{
+		Fx:  backend.Fx,
+		clk: backend.Clk,
+	}
+	unsignedBackend := *backend
+	unsignedBackend.Fx = fx
+	unsignedBackend.FlowChecker = utxo.NewHandler(backend.Ctx, backend.Clk, fx)
+	return &unsignedBackend
+}
+
+// PlaceholderCredentials returns one empty credential for every credential
+// [utx] requires.
+func PlaceholderCredentials(utx txs.UnsignedTx) []verify.Verifiable {
+	numCreds := utx.InputIDs().Len()
+	switch utx.(type) {
+	case *txs.AddSubnetValidatorTx,
+		*txs.CreateChainTx,
+		*txs.RemoveSubnetValidatorTx,
+		*txs.TransformSubnetTx,
+		*txs.TransferSubnetOwnershipTx,
+		*txs.SetAutoRenewTx,
+		*txs.SetSubnetValidatorWeightTx:
+		// The subnet or staker authorization is the last credential.
+		numCreds++
+	}
+
+	creds := make([]verify.Verifiable, numCreds)
+	for i := range creds {
+		creds[i] = &secp256k1fx.Credential{}
+	}
+	return creds
+}
+
+// unsignedFx accepts every credential while still enforcing the amount and
+// timelock of the UTXOs being spent.
+type unsignedFx struct {
+	fx.Fx
+	clk *mockable.Clock
+}
+
+func (u *unsignedFx) VerifyTransfer(_, inIntf, _, utxoIntf interface{}) error {
+	var (
+		in  *secp256k1fx.TransferInput
+		out *secp256k1fx.OutputOwners
+		amt uint64
+		ok  bool
+	)
+	switch utxo := utxoIntf.(type) {
+	case *secp256k1fx.TransferOutput:
+		in, ok = inIntf.(*secp256k1fx.TransferInput)
+		out, amt = &utxo.OutputOwners, utxo.Amt
+	case *secp256r1fx.TransferOutput:
+		var r1In *secp256r1fx.TransferInput
+		r1In, ok = inIntf.(*secp256r1fx.TransferInput)
+		if ok {
+			in = &r1In.TransferInput
+		}
+		out, amt = &utxo.OutputOwners.OutputOwners, utxo.Amt
+	default:
+		return secp256k1fx.ErrWrongUTXOType
+	}
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	if err := verify.All(out, in); err != nil {
+		return err
+	}
+	if amt == 0 {
+		return secp256k1fx.ErrNoValueOutput
+	}
+	if amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", secp256k1fx.ErrMismatchedAmounts, amt, in.Amt)
+	}
+	if out.Locktime > u.clk.Unix() {
+		return secp256k1fx.ErrTimelocked
+	}
+	return nil
+}
+
+func (*unsignedFx) VerifyPermission(_, _, _, ownerIntf interface{}) error {
+	switch owner := ownerIntf.(type) {
+	case *secp256k1fx.OutputOwners:
+		return owner.Verify()
+	case *secp256r1fx.OutputOwners:
+		return owner.Verify()
+	default:
+		return secp256k1fx.ErrWrongOwnerType
+	}
+}
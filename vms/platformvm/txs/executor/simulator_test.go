@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestSimulateProposalTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(false /*=postBanff*/, false /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	nodeID := ids.GenerateTestNodeID()
+	tx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(defaultValidateStartTime.Add(5*time.Second).Unix()),
+		uint64(defaultValidateEndTime.Add(-5*time.Second).Unix()),
+		nodeID,
+		preFundedKeys[0].PublicKey().Address(),
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	simulation, err := Simulate(&env.backend, lastAcceptedID, env, tx)
+	require.NoError(err)
+	require.Len(simulation.Stakers, 1)
+	require.Equal(tx.ID(), simulation.Stakers[0].TxID)
+	require.Equal(nodeID, simulation.Stakers[0].NodeID)
+	require.True(simulation.Stakers[0].Priority.IsPending())
+	require.Equal(env.config.AddPrimaryNetworkValidatorFee, simulation.Burned)
+
+	// The simulation must not modify the last accepted state
+	_, err = env.state.GetPendingValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+	for utxoID := range tx.Unsigned.InputIDs() {
+		_, err := env.state.GetUTXO(utxoID)
+		require.NoError(err)
+	}
+}
+
+func TestSimulateUnsignedTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	signedTx, err := env.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	tx := &txs.Tx{
+		Unsigned: signedTx.Unsigned,
+		Creds:    PlaceholderCredentials(signedTx.Unsigned),
+	}
+	require.NoError(tx.Initialize(txs.Codec))
+	require.Len(tx.Creds, len(signedTx.Creds))
+
+	// Signatures are verified by default
+	_, err = Simulate(&env.backend, lastAcceptedID, env, tx)
+	require.ErrorIs(err, errUnauthorizedSubnetModification)
+
+	simulation, err := Simulate(WithoutSignatureChecks(&env.backend), lastAcceptedID, env, tx)
+	require.NoError(err)
+	require.Empty(simulation.Stakers)
+	require.Equal(env.config.GetCreateBlockchainTxFee(env.state.GetTimestamp()), simulation.Burned)
+}
+
+func TestSimulateWrongTxType(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := env.txBuilder.NewAdvanceTimeTx(defaultGenesisTime.Add(time.Second))
+	require.NoError(err)
+
+	_, err = Simulate(&env.backend, lastAcceptedID, env, tx)
+	require.ErrorIs(err, ErrWrongTxType)
+}
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	stdmath "math"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errStakerNotRenewable             = errors.New("staker can't be auto-renewed")
+	errStakerNotFound                 = errors.New("staker not found")
+	errStakingPeriodEnded             = errors.New("staking period already ended")
+	errUnauthorizedStakerModification = errors.New("unauthorized staker modification")
+	errWrongNumberOfStakerCredentials = errors.New("should have at least one credential for the staker authorization")
+)
+
+// stakerRenewal is the staking period a staker is renewed into when its
+// current staking period ends.
+type stakerRenewal struct {
+	// setAutoRenewTx is the SetAutoRenewTx that configured the renewal. It
+	// also configures the renewal of the renewed staker.
+	setAutoRenewTx *txs.Tx
+	// restakeRewards is true if the reward of the current staking period
+	// should be added to the stake of the renewed staker.
+	restakeRewards bool
+
+	startTime time.Time
+	endTime   time.Time
+}
+
+// verifyStakerAuthorization verifies that [sTx] is authorized by the owner of
+// the rewards of the staker added by [stakerTxID] and that the staker's
+// staking period hasn't ended. The credentials of the base tx are returned.
+func verifyStakerAuthorization(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	stakerTxID ids.ID,
+	stakerAuth verify.Verifiable,
+) ([]verify.Verifiable, error) {
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the staker authorization
+		return nil, errWrongNumberOfStakerCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	stakerCred := sTx.Creds[baseTxCredsLen]
+
+	stakerTx, _, err := chainState.GetTx(stakerTxID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed to fetch staker tx %s: %v",
+			errStakerNotFound,
+			stakerTxID,
+			err,
+		)
+	}
+
+	var owner fx.Owner
+	switch uStakerTx := stakerTx.Unsigned.(type) {
+	case *txs.AddPermissionlessValidatorTx:
+		owner = uStakerTx.ValidatorRewardsOwner
+	case *txs.AddPermissionlessDelegatorTx:
+		owner = uStakerTx.DelegationRewardsOwner
+	default:
+		return nil, fmt.Errorf("%w: %T", errStakerNotRenewable, uStakerTx)
+	}
+
+	staker := stakerTx.Unsigned.(txs.Staker)
+	stakers, err := addedStakers(chainState, stakerTxID, staker.SubnetID(), staker.NodeID())
+	if err != nil {
+		return nil, err
+	}
+	if len(stakers) == 0 {
+		return nil, fmt.Errorf("%w: %s", errStakerNotFound, stakerTxID)
+	}
+
+	// Once the staking period ended, the staker is about to be rewarded and
+	// its renewal can't change anymore. Stakers that follow it into its next
+	// staking period rely on this.
+	if !stakers[0].EndTime.After(chainState.GetTimestamp()) {
+		return nil, fmt.Errorf("%w: %s", errStakingPeriodEnded, stakerTxID)
+	}
+
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, stakerAuth, stakerCred, owner); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnauthorizedStakerModification, err)
+	}
+
+	return sTx.Creds[:baseTxCredsLen], nil
+}
+
+// getStakerRenewal returns the staking period [staker] is renewed into when
+// its current staking period ends. If [staker] isn't renewed, nil is returned.
+//
+// A renewed staker has the same staking duration as [staker], but it can't
+// outlive the validator it depends on. Delegators and subnet validators whose
+// staking period ends with the staking period of that validator are only
+// renewed if that validator is renewed. If the staking period left before the
+// validator ends is shorter than the minimum staking duration, [staker] isn't
+// renewed.
+//
+// The renewal doesn't depend on whether the reward of [staker] is committed
+// or aborted.
+//
+// Invariant: [staker.EndTime] is the timestamp of [chainState].
+func getStakerRenewal(
+	backend *Backend,
+	chainState state.Chain,
+	staker *state.Staker,
+) (*stakerRenewal, error) {
+	if !backend.Config.IsDurangoActivated(staker.EndTime) {
+		return nil, nil
+	}
+
+	setAutoRenewTx, err := chainState.GetStakerAutoRenewal(staker.TxID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uSetAutoRenewTx, ok := setAutoRenewTx.Unsigned.(*txs.SetAutoRenewTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+	if !uSetAutoRenewTx.AutoRenew {
+		return nil, nil
+	}
+
+	renewal := &stakerRenewal{
+		setAutoRenewTx: setAutoRenewTx,
+		restakeRewards: uSetAutoRenewTx.RestakeRewards,
+		startTime:      staker.EndTime,
+		endTime:        staker.EndTime.Add(staker.EndTime.Sub(staker.StartTime)),
+	}
+
+	var validator *state.Staker
+	switch {
+	case staker.Priority.IsDelegator():
+		validator, err = chainState.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+	case staker.SubnetID != constants.PrimaryNetworkID:
+		validator, err = chainState.GetCurrentValidator(constants.PrimaryNetworkID, staker.NodeID)
+	default:
+		return renewal, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get validator of %s: %w",
+			staker.NodeID,
+			err,
+		)
+	}
+
+	maxEndTime := validator.EndTime
+	if !maxEndTime.After(staker.EndTime) {
+		// The staking period of [validator] ends at the same time, so
+		// [staker] can only follow it into its next staking period.
+		validatorRenewal, err := getStakerRenewal(backend, chainState, validator)
+		if err != nil || validatorRenewal == nil {
+			return nil, err
+		}
+		maxEndTime = validatorRenewal.endTime
+	}
+	if !renewal.endTime.After(maxEndTime) {
+		return renewal, nil
+	}
+
+	var minStakeDuration time.Duration
+	if staker.Priority.IsDelegator() {
+		delegatorRules, err := getDelegatorRules(backend, chainState, staker.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+		minStakeDuration = delegatorRules.minStakeDuration
+	} else {
+		validatorRules, err := getValidatorRules(backend, chainState, staker.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+		minStakeDuration = validatorRules.minStakeDuration
+	}
+
+	renewal.endTime = maxEndTime
+	if renewal.endTime.Sub(renewal.startTime) < minStakeDuration {
+		return nil, nil
+	}
+	return renewal, nil
+}
+
+// canRestakeValidatorReward returns true if the validator [staker] can be
+// renewed into [renewal] with a stake of [weight] without exceeding the
+// maximum stake of its subnet, including the stake of its delegators.
+func canRestakeValidatorReward(
+	backend *Backend,
+	chainState state.Chain,
+	staker *state.Staker,
+	renewal *stakerRenewal,
+	weight uint64,
+) (bool, error) {
+	validatorRules, err := getValidatorRules(backend, chainState, staker.SubnetID)
+	if err != nil {
+		return false, err
+	}
+	if weight > validatorRules.maxValidatorStake {
+		return false, nil
+	}
+
+	renewedStaker := *staker
+	renewedStaker.StartTime = renewal.startTime
+	renewedStaker.EndTime = renewal.endTime
+	renewedStaker.Weight = weight
+
+	maxWeight, err := GetMaxWeight(chainState, &renewedStaker, renewal.startTime, renewal.endTime)
+	if err != nil {
+		return false, err
+	}
+	return maxWeight <= validatorRules.maxValidatorStake, nil
+}
+
+// canRenewDelegator returns true if the delegator [staker] can be renewed into
+// [renewal] with a stake of [weight] without over-delegating [validator].
+//
+// If the delegator follows [validator] into its next staking period, the
+// weight of [validator] in its current staking period is used.
+func canRenewDelegator(
+	backend *Backend,
+	chainState state.Chain,
+	validator *state.Staker,
+	staker *state.Staker,
+	renewal *stakerRenewal,
+	weight uint64,
+) (bool, error) {
+	delegatorRules, err := getDelegatorRules(backend, chainState, staker.SubnetID)
+	if err != nil {
+		return false, err
+	}
+
+	maximumWeight, err := math.Mul64(
+		uint64(delegatorRules.maxValidatorWeightFactor),
+		validator.Weight,
+	)
+	if err != nil {
+		maximumWeight = stdmath.MaxUint64
+	}
+	maximumWeight = math.Min(maximumWeight, delegatorRules.maxValidatorStake)
+
+	validatorPeriod := *validator
+	if validatorPeriod.EndTime.Before(renewal.endTime) {
+		validatorPeriod.EndTime = renewal.endTime
+	}
+
+	renewedStaker := *staker
+	renewedStaker.StartTime = renewal.startTime
+	renewedStaker.EndTime = renewal.endTime
+	renewedStaker.Weight = weight
+	return canDelegate(chainState, &validatorPeriod, maximumWeight, &renewedStaker)
+}
+
+// renewStaker adds to [chainState] the staker that renews [staker] into
+// [renewal], with [restakedReward] added to its stake. The renewed staker is
+// added as a current staker, so a renewed validator replaces [staker] without
+// ever leaving the validator set.
+//
+// The renewed staker is described by a tx that is derived from [stakerTx] and
+// stored in [chainState]. The tx is never issued, it has no inputs and its
+// memo is the ID of the tx of [staker].
+//
+// Invariant: [staker] was removed from [chainState].
+func renewStaker(
+	backend *Backend,
+	chainState state.Diff,
+	staker *state.Staker,
+	stakerTx *txs.Tx,
+	renewal *stakerRenewal,
+	restakedReward uint64,
+) error {
+	weight, err := math.Add64(staker.Weight, restakedReward)
+	if err != nil {
+		return err
+	}
+
+	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    backend.Ctx.NetworkID,
+		BlockchainID: backend.Ctx.ChainID,
+		Memo:         staker.TxID[:],
+	}}
+	validator := txs.Validator{
+		NodeID: staker.NodeID,
+		Start:  uint64(renewal.startTime.Unix()),
+		End:    uint64(renewal.endTime.Unix()),
+		Wght:   weight,
+	}
+
+	var renewedUnsignedTx txs.UnsignedTx
+	switch uStakerTx := stakerTx.Unsigned.(type) {
+	case *txs.AddPermissionlessValidatorTx:
+		stake, err := renewedStake(backend, uStakerTx.StakeOuts, restakedReward, uStakerTx.ValidatorRewardsOwner)
+		if err != nil {
+			return err
+		}
+		renewedUnsignedTx = &txs.AddPermissionlessValidatorTx{
+			BaseTx:                baseTx,
+			Validator:             validator,
+			Subnet:                uStakerTx.Subnet,
+			Signer:                uStakerTx.Signer,
+			StakeOuts:             stake,
+			ValidatorRewardsOwner: uStakerTx.ValidatorRewardsOwner,
+			DelegatorRewardsOwner: uStakerTx.DelegatorRewardsOwner,
+			DelegationShares:      uStakerTx.DelegationShares,
+		}
+	case *txs.AddPermissionlessDelegatorTx:
+		stake, err := renewedStake(backend, uStakerTx.StakeOuts, restakedReward, uStakerTx.DelegationRewardsOwner)
+		if err != nil {
+			return err
+		}
+		renewedUnsignedTx = &txs.AddPermissionlessDelegatorTx{
+			BaseTx:                 baseTx,
+			Validator:              validator,
+			Subnet:                 uStakerTx.Subnet,
+			StakeOuts:              stake,
+			DelegationRewardsOwner: uStakerTx.DelegationRewardsOwner,
+		}
+	default:
+		return fmt.Errorf("%w: %T", errStakerNotRenewable, uStakerTx)
+	}
+
+	renewedTx := &txs.Tx{Unsigned: renewedUnsignedTx}
+	if err := renewedTx.Initialize(txs.Codec); err != nil {
+		return err
+	}
+
+	rewards, err := GetRewardsCalculator(backend, chainState, staker.SubnetID)
+	if err != nil {
+		return err
+	}
+	supply, err := chainState.GetCurrentSupply(staker.SubnetID)
+	if err != nil {
+		return err
+	}
+	potentialReward := rewards.Calculate(
+		renewal.endTime.Sub(renewal.startTime),
+		weight,
+		supply,
+	)
+
+	// Invariant: [rewards.Calculate] can never return a [potentialReward]
+	//            such that [supply + potentialReward > maximumSupply].
+	chainState.SetCurrentSupply(staker.SubnetID, supply+potentialReward)
+
+	renewedTxID := renewedTx.ID()
+	renewedStaker, err := state.NewCurrentStaker(
+		renewedTxID,
+		renewedUnsignedTx.(txs.Staker),
+		potentialReward,
+	)
+	if err != nil {
+		return err
+	}
+
+	if renewedStaker.Priority.IsDelegator() {
+		chainState.PutCurrentDelegator(renewedStaker)
+	} else {
+		chainState.PutCurrentValidator(renewedStaker)
+
+		// The delegatee rewards accrued by [staker] were paid out when it was
+		// removed.
+		if err := chainState.SetDelegateeReward(staker.SubnetID, staker.NodeID, 0); err != nil {
+			return err
+		}
+	}
+	chainState.AddTx(renewedTx, status.Committed)
+	chainState.SetStakerAutoRenewal(renewedTxID, renewal.setAutoRenewTx)
+	return nil
+}
+
+// renewedStake returns [stake] with an additional output of [reward] owned by
+// [rewardsOwner], so that the restaked reward is paid to the rewards owner
+// when the stake is returned.
+func renewedStake(
+	backend *Backend,
+	stake []*avax.TransferableOutput,
+	reward uint64,
+	rewardsOwner fx.Owner,
+) ([]*avax.TransferableOutput, error) {
+	if reward == 0 {
+		return stake, nil
+	}
+
+	outIntf, err := backend.Fx.CreateOutput(reward, rewardsOwner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(avax.TransferableOut)
+	if !ok {
+		return nil, ErrInvalidState
+	}
+
+	// Invariant: The staked asset must be equal to the reward asset.
+	newStake := make([]*avax.TransferableOutput, len(stake), len(stake)+1)
+	copy(newStake, stake)
+	newStake = append(newStake, &avax.TransferableOutput{
+		Asset: stake[0].Asset,
+		Out:   out,
+	})
+	avax.SortTransferableOutputs(newStake, txs.Codec)
+	return newStake, nil
+}
@@ -0,0 +1,438 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Ensure the renewal of a staker can't be configured before Durango
+func TestSetAutoRenewTxBeforeDurango(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.config.DurangoTime = mockable.MaxTime
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	rewardsOwnerKey := preFundedKeys[1]
+	vdrTx, _ := newRenewableStakers(t, env, rewardsOwnerKey.Address())
+
+	tx, err := env.txBuilder.NewSetAutoRenewTx(
+		vdrTx.ID(),
+		true, /*=autoRenew*/
+		true, /*=restakeRewards*/
+		[]*secp256k1.PrivateKey{rewardsOwnerKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errDurangoUpgradeNotActive)
+}
+
+// Ensure only the owner of the rewards of a staker can configure its renewal
+func TestSetAutoRenewTxUnauthorized(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	vdrTx, _ := newRenewableStakers(t, env, preFundedKeys[1].Address())
+
+	_, err := env.txBuilder.NewSetAutoRenewTx(
+		vdrTx.ID(),
+		true,  /*=autoRenew*/
+		false, /*=restakeRewards*/
+		[]*secp256k1.PrivateKey{preFundedKeys[2]},
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+}
+
+// Ensure an auto-renewed validator, and the delegator that follows it, are
+// renewed with their restaked rewards instead of being removed
+func TestRewardValidatorTxRenewsStakers(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	rewardsOwnerKey := preFundedKeys[1]
+	vdrTx, delTx := newRenewableStakers(t, env, rewardsOwnerKey.Address())
+	uVdrTx := vdrTx.Unsigned.(*txs.AddPermissionlessValidatorTx)
+
+	// Configure both stakers to be renewed with their rewards.
+	for i, stakerTxID := range []ids.ID{vdrTx.ID(), delTx.ID()} {
+		tx, err := env.txBuilder.NewSetAutoRenewTx(
+			stakerTxID,
+			true, /*=autoRenew*/
+			true, /*=restakeRewards*/
+			[]*secp256k1.PrivateKey{rewardsOwnerKey},
+			rewardsOwnerKey.Address(),
+		)
+		require.NoError(err)
+
+		stateDiff, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+
+		executor := StandardTxExecutor{
+			Backend: &env.backend,
+			State:   stateDiff,
+			Tx:      tx,
+		}
+		require.NoError(tx.Unsigned.Visit(&executor))
+
+		stateDiff.AddTx(tx, status.Committed)
+		require.NoError(stateDiff.Apply(env.state))
+		env.state.SetHeight(uint64(i) + 2)
+		require.NoError(env.state.Commit())
+	}
+
+	endTime := uVdrTx.EndTime()
+	renewedEndTime := endTime.Add(uVdrTx.EndTime().Sub(uVdrTx.StartTime()))
+	env.state.SetTimestamp(endTime)
+	env.state.SetHeight(4)
+	require.NoError(env.state.Commit())
+
+	// The renewal can't be changed once the staking period ended.
+	tx, err := env.txBuilder.NewSetAutoRenewTx(
+		vdrTx.ID(),
+		false, /*=autoRenew*/
+		false, /*=restakeRewards*/
+		[]*secp256k1.PrivateKey{rewardsOwnerKey},
+		rewardsOwnerKey.Address(),
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errStakingPeriodEnded)
+
+	// The delegator is rewarded first and follows the validator into its next
+	// staking period.
+	delReward := getOnlyDelegator(t, env.state, uVdrTx.NodeID()).PotentialReward
+
+	onCommitState, onAbortState := rewardStaker(t, env, delTx.ID())
+
+	_, delegatorReward := reward.Split(delReward, uVdrTx.DelegationShares)
+	renewedDelegator := getOnlyDelegator(t, onCommitState, uVdrTx.NodeID())
+	require.NotEqual(delTx.ID(), renewedDelegator.TxID)
+	require.Equal(endTime, renewedDelegator.StartTime)
+	require.Equal(renewedEndTime, renewedDelegator.EndTime)
+	require.Equal(env.config.MinDelegatorStake+delegatorReward, renewedDelegator.Weight)
+
+	abortedDelegator := getOnlyDelegator(t, onAbortState, uVdrTx.NodeID())
+	require.Equal(env.config.MinDelegatorStake, abortedDelegator.Weight)
+
+	// The restaked reward isn't paid out.
+	delRewardUTXOID := &avax.UTXOID{
+		TxID:        delTx.ID(),
+		OutputIndex: uint32(len(delTx.Unsigned.Outputs()) + 1),
+	}
+	_, err = onCommitState.GetUTXO(delRewardUTXOID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	renewedDelegatorTx, _, err := onCommitState.GetTx(renewedDelegator.TxID)
+	require.NoError(err)
+	delTxID := delTx.ID()
+	require.Equal(delTxID[:], []byte(renewedDelegatorTx.Unsigned.(*txs.AddPermissionlessDelegatorTx).Memo))
+
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(5)
+	require.NoError(env.state.Commit())
+
+	// The validator is renewed in place, with the same BLS key, and keeps its
+	// renewal configuration.
+	vdrStaker, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, uVdrTx.NodeID())
+	require.NoError(err)
+	require.Equal(vdrTx.ID(), vdrStaker.TxID)
+	vdrReward := vdrStaker.PotentialReward
+
+	onCommitState, onAbortState = rewardStaker(t, env, vdrTx.ID())
+
+	renewedValidator, err := onCommitState.GetCurrentValidator(constants.PrimaryNetworkID, uVdrTx.NodeID())
+	require.NoError(err)
+	require.NotEqual(vdrTx.ID(), renewedValidator.TxID)
+	require.Equal(endTime, renewedValidator.StartTime)
+	require.Equal(renewedEndTime, renewedValidator.EndTime)
+	require.Equal(env.config.MinValidatorStake+vdrReward, renewedValidator.Weight)
+	require.Equal(vdrStaker.PublicKey, renewedValidator.PublicKey)
+
+	// A validator that isn't rewarded, for example because it didn't meet the
+	// uptime requirement, is still renewed but its reward isn't restaked.
+	abortedValidator, err := onAbortState.GetCurrentValidator(constants.PrimaryNetworkID, uVdrTx.NodeID())
+	require.NoError(err)
+	require.NotEqual(vdrTx.ID(), abortedValidator.TxID)
+	require.Equal(renewedEndTime, abortedValidator.EndTime)
+	require.Equal(env.config.MinValidatorStake, abortedValidator.Weight)
+
+	autoRenewal, err := onCommitState.GetStakerAutoRenewal(renewedValidator.TxID)
+	require.NoError(err)
+	autoRenewalTx, err := env.state.GetStakerAutoRenewal(vdrTx.ID())
+	require.NoError(err)
+	require.Equal(autoRenewalTx.ID(), autoRenewal.ID())
+
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(6)
+	require.NoError(env.state.Commit())
+
+	// The validator never left the validator set.
+	_, err = env.state.GetCurrentValidator(constants.PrimaryNetworkID, uVdrTx.NodeID())
+	require.NoError(err)
+	vdrSet, ok := env.config.Validators.Get(constants.PrimaryNetworkID)
+	require.True(ok)
+	require.Equal(renewedValidator.Weight+renewedDelegator.Weight, vdrSet.GetWeight(uVdrTx.NodeID()))
+}
+
+// Ensure a delegator isn't renewed if the staking period left before its
+// validator ends is shorter than the minimum staking duration
+func TestGetStakerRenewalTruncated(t *testing.T) {
+	startTime := defaultValidateStartTime.Add(time.Second)
+	validatorEndTime := startTime.Add(3 * defaultMinStakingDuration)
+
+	tests := []struct {
+		name            string
+		endTime         time.Time
+		expectedEndTime time.Time
+		expectedRenewed bool
+	}{
+		{
+			name:            "not truncated",
+			endTime:         startTime.Add(defaultMinStakingDuration),
+			expectedEndTime: startTime.Add(2 * defaultMinStakingDuration),
+			expectedRenewed: true,
+		},
+		{
+			name:            "truncated to minimum staking duration",
+			endTime:         startTime.Add(2 * defaultMinStakingDuration),
+			expectedEndTime: validatorEndTime,
+			expectedRenewed: true,
+		},
+		{
+			name:            "truncated below minimum staking duration",
+			endTime:         startTime.Add(5 * defaultMinStakingDuration / 2),
+			expectedRenewed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			nodeID := ids.GenerateTestNodeID()
+			stateDiff.PutCurrentValidator(&state.Staker{
+				TxID:      ids.GenerateTestID(),
+				NodeID:    nodeID,
+				SubnetID:  constants.PrimaryNetworkID,
+				Weight:    env.config.MinValidatorStake,
+				StartTime: startTime,
+				EndTime:   validatorEndTime,
+				NextTime:  validatorEndTime,
+				Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+			})
+
+			delegator := &state.Staker{
+				TxID:      ids.GenerateTestID(),
+				NodeID:    nodeID,
+				SubnetID:  constants.PrimaryNetworkID,
+				Weight:    env.config.MinDelegatorStake,
+				StartTime: startTime,
+				EndTime:   test.endTime,
+				NextTime:  test.endTime,
+				Priority:  txs.PrimaryNetworkDelegatorCurrentPriority,
+			}
+			stateDiff.PutCurrentDelegator(delegator)
+			stateDiff.SetStakerAutoRenewal(delegator.TxID, &txs.Tx{
+				Unsigned: &txs.SetAutoRenewTx{
+					StakerTxID: delegator.TxID,
+					AutoRenew:  true,
+				},
+			})
+
+			renewal, err := getStakerRenewal(&env.backend, stateDiff, delegator)
+			require.NoError(err)
+			if !test.expectedRenewed {
+				require.Nil(renewal)
+				return
+			}
+			require.NotNil(renewal)
+			require.Equal(test.endTime, renewal.startTime)
+			require.Equal(test.expectedEndTime, renewal.endTime)
+		})
+	}
+}
+
+// newRenewableStakers adds to the current stakers of [env] a primary network
+// validator and a delegator with the same staking period. The rewards of both
+// stakers are owned by [rewardsAddr].
+func newRenewableStakers(
+	t *testing.T,
+	env *environment,
+	rewardsAddr ids.ShortID,
+) (*txs.Tx, *txs.Tx) {
+	require := require.New(t)
+
+	var (
+		startTime = defaultValidateStartTime.Add(time.Second)
+		endTime   = defaultValidateStartTime.Add(2 * defaultMinStakingDuration)
+		nodeID    = ids.GenerateTestNodeID()
+		owner     = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardsAddr},
+		}
+	)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	stake := func(amount uint64) []*avax.TransferableOutput {
+		return []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: *owner,
+			},
+		}}
+	}
+	validator := txs.Validator{
+		NodeID: nodeID,
+		Start:  uint64(startTime.Unix()),
+		End:    uint64(endTime.Unix()),
+	}
+
+	vdrValidator := validator
+	vdrValidator.Wght = env.config.MinValidatorStake
+	vdrTx, err := txs.NewSigned(&txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+		}},
+		Validator:             vdrValidator,
+		Subnet:                constants.PrimaryNetworkID,
+		Signer:                signer.NewProofOfPossession(sk),
+		StakeOuts:             stake(env.config.MinValidatorStake),
+		ValidatorRewardsOwner: owner,
+		DelegatorRewardsOwner: owner,
+		DelegationShares:      reward.PercentDenominator / 4,
+	}, txs.Codec, nil)
+	require.NoError(err)
+
+	delValidator := validator
+	delValidator.Wght = env.config.MinDelegatorStake
+	delTx, err := txs.NewSigned(&txs.AddPermissionlessDelegatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+		}},
+		Validator:              delValidator,
+		Subnet:                 constants.PrimaryNetworkID,
+		StakeOuts:              stake(env.config.MinDelegatorStake),
+		DelegationRewardsOwner: owner,
+	}, txs.Codec, nil)
+	require.NoError(err)
+
+	vdrStaker, err := state.NewCurrentStaker(
+		vdrTx.ID(),
+		vdrTx.Unsigned.(*txs.AddPermissionlessValidatorTx),
+		2000000,
+	)
+	require.NoError(err)
+
+	delStaker, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddPermissionlessDelegatorTx),
+		1000000,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.PutCurrentDelegator(delStaker)
+	env.state.AddTx(delTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+	return vdrTx, delTx
+}
+
+// rewardStaker executes the RewardValidatorTx of [stakerTxID] on top of the
+// last accepted state of [env].
+func rewardStaker(t *testing.T, env *environment, stakerTxID ids.ID) (state.Diff, state.Diff) {
+	require := require.New(t)
+
+	tx, err := env.txBuilder.NewRewardValidatorTx(stakerTxID)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+	return onCommitState, onAbortState
+}
+
+func getOnlyDelegator(t *testing.T, chainState state.Chain, nodeID ids.NodeID) *state.Staker {
+	require := require.New(t)
+
+	delegators, err := chainState.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	defer delegators.Release()
+
+	require.True(delegators.Next())
+	delegator := delegators.Value()
+	require.False(delegators.Next())
+	return delegator
+}
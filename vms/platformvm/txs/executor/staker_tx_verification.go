@@ -20,24 +20,26 @@ import (
 )
 
 var (
-	ErrWeightTooSmall                  = errors.New("weight of this validator is too low")
-	ErrWeightTooLarge                  = errors.New("weight of this validator is too large")
-	ErrInsufficientDelegationFee       = errors.New("staker charges an insufficient delegation fee")
-	ErrStakeTooShort                   = errors.New("staking period is too short")
-	ErrStakeTooLong                    = errors.New("staking period is too long")
-	ErrFlowCheckFailed                 = errors.New("flow check failed")
-	ErrFutureStakeTime                 = fmt.Errorf("staker is attempting to start staking more than %s ahead of the current chain time", MaxFutureStartTime)
-	ErrValidatorSubset                 = errors.New("all subnets' staking period must be a subset of the primary network")
-	ErrNotValidator                    = errors.New("isn't a current or pending validator")
-	ErrRemovePermissionlessValidator   = errors.New("attempting to remove permissionless validator")
-	ErrStakeOverflow                   = errors.New("validator stake exceeds limit")
-	ErrOverDelegated                   = errors.New("validator would be over delegated")
-	ErrIsNotTransformSubnetTx          = errors.New("is not a transform subnet tx")
-	ErrTimestampNotBeforeStartTime     = errors.New("chain timestamp not before start time")
-	ErrAlreadyValidator                = errors.New("already a validator")
-	ErrDuplicateValidator              = errors.New("duplicate validator")
-	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
-	ErrWrongStakedAssetID              = errors.New("incorrect staked assetID")
+	ErrWeightTooSmall                   = errors.New("weight of this validator is too low")
+	ErrWeightTooLarge                   = errors.New("weight of this validator is too large")
+	ErrInsufficientDelegationFee        = errors.New("staker charges an insufficient delegation fee")
+	ErrStakeTooShort                    = errors.New("staking period is too short")
+	ErrStakeTooLong                     = errors.New("staking period is too long")
+	ErrFlowCheckFailed                  = errors.New("flow check failed")
+	ErrFutureStakeTime                  = fmt.Errorf("staker is attempting to start staking more than %s ahead of the current chain time", MaxFutureStartTime)
+	ErrValidatorSubset                  = errors.New("all subnets' staking period must be a subset of the primary network")
+	ErrNotValidator                     = errors.New("isn't a current or pending validator")
+	ErrNotCurrentValidator              = errors.New("isn't a current validator")
+	ErrRemovePermissionlessValidator    = errors.New("attempting to remove permissionless validator")
+	ErrSetPermissionlessValidatorWeight = errors.New("attempting to set weight of permissionless validator")
+	ErrStakeOverflow                    = errors.New("validator stake exceeds limit")
+	ErrOverDelegated                    = errors.New("validator would be over delegated")
+	ErrIsNotTransformSubnetTx           = errors.New("is not a transform subnet tx")
+	ErrTimestampNotBeforeStartTime      = errors.New("chain timestamp not before start time")
+	ErrAlreadyValidator                 = errors.New("already a validator")
+	ErrDuplicateValidator               = errors.New("duplicate validator")
+	ErrDelegateToPermissionedValidator  = errors.New("delegation to permissioned validator")
+	ErrWrongStakedAssetID               = errors.New("incorrect staked assetID")
 )
 
 // verifyAddValidatorTx carries out the validation for an AddValidatorTx.
@@ -316,6 +318,64 @@ func removeSubnetValidatorValidation(
 	return vdr, isCurrentValidator, nil
 }
 
+// verifySetSubnetValidatorWeightTx carries out the validation for a
+// SetSubnetValidatorWeightTx. It returns the current validator whose weight is
+// being set.
+func verifySetSubnetValidatorWeightTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetValidatorWeightTx,
+) (*state.Staker, error) {
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+
+	// Pending validators aren't part of the validator set, so they can be
+	// removed and re-added with a different weight instead.
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s %w of %s: %v",
+			tx.NodeID,
+			ErrNotCurrentValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if !vdr.Priority.IsPermissionedValidator() {
+		return nil, ErrSetPermissionlessValidatorWeight
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
 // verifyAddDelegatorTx carries out the validation for an AddDelegatorTx.
 // It returns the tx outputs that should be returned if this delegator is not
 // added to the staking set.
@@ -23,6 +23,7 @@ var (
 
 	errEmptyNodeID              = errors.New("validator nodeID cannot be empty")
 	errMaxStakeDurationTooLarge = errors.New("max stake duration must be less than or equal to the global max stake duration")
+	errDurangoUpgradeNotActive  = errors.New("attempting to use a Durango-upgrade feature prior to activation")
 )
 
 type StandardTxExecutor struct {
@@ -459,3 +460,121 @@ func (e *StandardTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionl
 
 	return nil
 }
+
+// Verifies a [*txs.TransferSubnetOwnershipTx] and, if it passes, executes it
+// on [e.State]. The tx must be authorized by the current owner of the subnet
+// and results in [tx.Owner] becoming its owner.
+func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsDurangoActivated(currentTimestamp) {
+		return errDurangoUpgradeNotActive
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(e.Backend, e.State, e.Tx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	avax.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	avax.Produce(e.State, txID, tx.Outs)
+	// Transfer the ownership of the subnet in the database
+	e.State.AddSubnetOwnershipTransfer(e.Tx)
+	return nil
+}
+
+// Verifies a [*txs.SetAutoRenewTx] and, if it passes, executes it on
+// [e.State]. The tx must be authorized by the owner of the rewards of the
+// staker and replaces any previous renewal configuration of the staker.
+func (e *StandardTxExecutor) SetAutoRenewTx(tx *txs.SetAutoRenewTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsDurangoActivated(currentTimestamp) {
+		return errDurangoUpgradeNotActive
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	baseTxCreds, err := verifyStakerAuthorization(e.Backend, e.State, e.Tx, tx.StakerTxID, tx.StakerAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	avax.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	avax.Produce(e.State, txID, tx.Outs)
+	// Configure the renewal of the staker
+	e.State.SetStakerAutoRenewal(tx.StakerTxID, e.Tx)
+	return nil
+}
+
+// Verifies a [*txs.SetSubnetValidatorWeightTx] and, if it passes, executes it
+// on [e.State]. The weight of the validator is updated in place, so that it
+// continues its staking period and only the change in weight is recorded in
+// the validator set diffs.
+func (e *StandardTxExecutor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsDurangoActivated(currentTimestamp) {
+		return errDurangoUpgradeNotActive
+	}
+
+	staker, err := verifySetSubnetValidatorWeightTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	updatedStaker := *staker
+	updatedStaker.Weight = tx.Weight
+	e.State.UpdateCurrentValidator(&updatedStaker)
+
+	// Invariant: There are no permissioned subnet delegators to move.
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
@@ -1086,12 +1087,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				// Set dependency expectations.
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil).Times(1)
 				subnetOwner := fx.NewMockOwner(ctrl)
-				subnetTx := &txs.Tx{
-					Unsigned: &txs.CreateSubnetTx{
-						Owner: subnetOwner,
-					},
-				}
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(subnetTx, status.Committed, nil).Times(1)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
 				env.flowChecker.EXPECT().VerifySpend(
 					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
@@ -1227,7 +1223,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env := newValidRemoveSubnetValidatorTxVerifyEnv(t, ctrl)
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(nil, status.Unknown, database.ErrNotFound)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound)
 				e := &StandardTxExecutor{
 					Backend: &Backend{
 						Config: &config.Config{
@@ -1253,12 +1249,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
 				subnetOwner := fx.NewMockOwner(ctrl)
-				subnetTx := &txs.Tx{
-					Unsigned: &txs.CreateSubnetTx{
-						Owner: subnetOwner,
-					},
-				}
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(subnetTx, status.Committed, nil)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(errTest)
 				e := &StandardTxExecutor{
 					Backend: &Backend{
@@ -1285,12 +1276,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				env.state = state.NewMockDiff(ctrl)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
 				subnetOwner := fx.NewMockOwner(ctrl)
-				subnetTx := &txs.Tx{
-					Unsigned: &txs.CreateSubnetTx{
-						Owner: subnetOwner,
-					},
-				}
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(subnetTx, status.Committed, nil)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
 				env.flowChecker.EXPECT().VerifySpend(
 					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
@@ -1525,12 +1511,7 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 				env := newValidTransformSubnetTxVerifyEnv(t, ctrl)
 				env.state = state.NewMockDiff(ctrl)
 				subnetOwner := fx.NewMockOwner(ctrl)
-				subnetTx := &txs.Tx{
-					Unsigned: &txs.CreateSubnetTx{
-						Owner: subnetOwner,
-					},
-				}
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(subnetTx, status.Committed, nil)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.state.EXPECT().GetSubnetTransformation(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound).Times(1)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
 				env.flowChecker.EXPECT().VerifySpend(
@@ -1562,12 +1543,7 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 
 				// Set dependency expectations.
 				subnetOwner := fx.NewMockOwner(ctrl)
-				subnetTx := &txs.Tx{
-					Unsigned: &txs.CreateSubnetTx{
-						Owner: subnetOwner,
-					},
-				}
-				env.state.EXPECT().GetTx(env.unsignedTx.Subnet).Return(subnetTx, status.Committed, nil).Times(1)
+				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.state.EXPECT().GetSubnetTransformation(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
 				env.flowChecker.EXPECT().VerifySpend(
@@ -1609,3 +1585,119 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 		})
 	}
 }
+
+func TestStandardExecutorSetSubnetValidatorWeightTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	// keys[0] is a genesis validator
+	nodeID := ids.NodeID(preFundedKeys[0].PublicKey().Address())
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+
+	addTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()+1),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+
+	addedStaker, err := state.NewCurrentStaker(
+		addTx.ID(),
+		addTx.Unsigned.(*txs.AddSubnetValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(addedStaker)
+	env.state.AddTx(addTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	upDuration := time.Hour
+	lastUpdated := addedStaker.StartTime.Add(2 * time.Hour)
+	require.NoError(env.state.SetUptime(nodeID, subnetID, upDuration, lastUpdated))
+
+	newWeightTx := func(nodeID ids.NodeID, weight uint64) *txs.Tx {
+		tx, err := env.txBuilder.NewSetSubnetValidatorWeightTx(
+			nodeID,
+			subnetID,
+			weight,
+			subnetKeys,
+			ids.ShortEmpty, // change addr
+		)
+		require.NoError(err)
+		return tx
+	}
+	execute := func(tx *txs.Tx) (state.Diff, error) {
+		stateDiff, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+
+		executor := StandardTxExecutor{
+			Backend: &env.backend,
+			State:   stateDiff,
+			Tx:      tx,
+		}
+		return stateDiff, tx.Unsigned.Visit(&executor)
+	}
+
+	{
+		// Case: Durango isn't active
+		env.config.DurangoTime = mockable.MaxTime
+		_, err := execute(newWeightTx(nodeID, 2*defaultWeight))
+		require.ErrorIs(err, errDurangoUpgradeNotActive)
+		env.config.DurangoTime = time.Time{}
+	}
+
+	{
+		// Case: Node isn't a validator of the subnet
+		_, err := execute(newWeightTx(ids.GenerateTestNodeID(), 2*defaultWeight))
+		require.ErrorIs(err, ErrNotCurrentValidator)
+	}
+
+	// Set the weight of the validator
+	tx := newWeightTx(nodeID, 2*defaultWeight)
+	stateDiff, err := execute(tx)
+	require.NoError(err)
+
+	stateDiff.AddTx(tx, status.Committed)
+	require.NoError(stateDiff.Apply(env.state))
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	staker, err := env.state.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(2*defaultWeight, staker.Weight)
+	require.Equal(addedStaker.StartTime, staker.StartTime)
+	require.Equal(addedStaker.EndTime, staker.EndTime)
+
+	// The validator is still described by the tx that added it
+	require.Equal(addedStaker.TxID, staker.TxID)
+
+	// Only the change in weight is recorded
+	weightDiffs, err := env.state.GetValidatorWeightDiffs(2, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*state.ValidatorWeightDiff{
+			nodeID: {
+				Decrease: false,
+				Amount:   defaultWeight,
+			},
+		},
+		weightDiffs,
+	)
+
+	// The uptime of the validator is carried over
+	gotUpDuration, gotLastUpdated, err := env.state.GetUptime(nodeID, subnetID)
+	require.NoError(err)
+	require.Equal(upDuration, gotUpDuration)
+	require.Equal(lastUpdated.Unix(), gotLastUpdated.Unix())
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Ensure the ownership of a subnet can't be transferred before Durango
+func TestTransferSubnetOwnershipTxBeforeDurango(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.config.DurangoTime = mockable.MaxTime
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[3].Address()},
+		},
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errDurangoUpgradeNotActive)
+}
+
+// Ensure only the current owner of a subnet can modify it once its ownership
+// was transferred
+func TestTransferSubnetOwnershipTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(true /*=postBanff*/, true /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	subnetID := testSubnet1.ID()
+	newOwnerKey := preFundedKeys[3]
+	newOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{newOwnerKey.Address()},
+	}
+
+	// The new owner can't transfer the subnet before it owns it.
+	_, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		newOwner,
+		[]*secp256k1.PrivateKey{newOwnerKey},
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	tx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		newOwner,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	owner, err := stateDiff.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(newOwner, owner)
+
+	stateDiff.AddTx(tx, status.Committed)
+	stateDiff.Apply(env.state)
+
+	// The previous owner can no longer modify the subnet.
+	_, err = env.txBuilder.NewCreateChainTx(
+		subnetID,
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	createChainTx, err := env.txBuilder.NewCreateChainTx(
+		subnetID,
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*secp256k1.PrivateKey{newOwnerKey},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor = StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      createChainTx,
+	}
+	require.NoError(createChainTx.Unsigned.Visit(&executor))
+}
@@ -62,6 +62,18 @@ func (v *MempoolTxVerifier) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidato
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) SetAutoRenewTx(tx *txs.SetAutoRenewTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *MempoolTxVerifier) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	return v.standardTx(tx)
 }
@@ -80,6 +92,10 @@ func (v *MempoolTxVerifier) standardTx(tx txs.UnsignedTx) error {
 		return err
 	}
 
+	if err := VerifyFxActivation(v.Backend, baseState.GetTimestamp(), v.Tx); err != nil {
+		return err
+	}
+
 	executor := StandardTxExecutor{
 		Backend: v.Backend,
 		State:   baseState,
@@ -49,6 +49,21 @@ func (i *issuer) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
 	return nil
 }
 
+func (i *issuer) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) SetAutoRenewTx(*txs.SetAutoRenewTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
 func (i *issuer) CreateChainTx(*txs.CreateChainTx) error {
 	i.m.addDecisionTx(i.tx)
 	return nil
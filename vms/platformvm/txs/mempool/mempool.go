@@ -6,12 +6,16 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/txheap"
@@ -29,6 +33,8 @@ const (
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	txTypeLabel = "tx_type"
 )
 
 var (
@@ -44,6 +50,12 @@ type BlockTimer interface {
 	ResetBlockTimer()
 }
 
+// Entry is a transaction in the mempool along with the time it was added.
+type Entry struct {
+	Tx    *txs.Tx
+	Added time.Time
+}
+
 type Mempool interface {
 	// we may want to be able to stop valid transactions
 	// from entering the mempool, e.g. during blocks creation
@@ -54,6 +66,9 @@ type Mempool interface {
 	Has(txID ids.ID) bool
 	Get(txID ids.ID) *txs.Tx
 	Remove(txs []*txs.Tx)
+	// List returns all the transactions in the mempool, ordered by the time
+	// they were added.
+	List() []Entry
 
 	// Following Banff activation, all mempool transactions,
 	// (both decision and staker) are included into Standard blocks.
@@ -86,9 +101,17 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
+	numTxsByType   *prometheus.GaugeVec
+	numBytesByType *prometheus.GaugeVec
+
 	unissuedDecisionTxs txheap.Heap
 	unissuedStakerTxs   txheap.Heap
 
+	// Key: Tx ID
+	// Value: Time the tx was added to the mempool
+	addedTimes map[ids.ID]time.Time
+	clock      mockable.Clock
+
 	// Key: Tx ID
 	// Value: Verification error
 	droppedTxIDs *cache.LRU[ids.ID, error]
@@ -112,6 +135,24 @@ func NewMempool(
 		return nil, err
 	}
 
+	numTxsByType := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "txs",
+		Help:      "Number of transactions in the mempool by tx type",
+	}, []string{txTypeLabel})
+	if err := registerer.Register(numTxsByType); err != nil {
+		return nil, err
+	}
+
+	numBytesByType := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tx_bytes",
+		Help:      "Number of bytes of transactions in the mempool by tx type",
+	}, []string{txTypeLabel})
+	if err := registerer.Register(numBytesByType); err != nil {
+		return nil, err
+	}
+
 	unissuedDecisionTxs, err := txheap.NewWithMetrics(
 		txheap.NewByAge(),
 		fmt.Sprintf("%s_decision_txs", namespace),
@@ -134,8 +175,11 @@ func NewMempool(
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
+		numTxsByType:         numTxsByType,
+		numBytesByType:       numBytesByType,
 		unissuedDecisionTxs:  unissuedDecisionTxs,
 		unissuedStakerTxs:    unissuedStakerTxs,
+		addedTimes:           make(map[ids.ID]time.Time),
 		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
 		dropIncoming:         false, // enable tx adding by default
@@ -219,6 +263,21 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 	}
 }
 
+func (m *mempool) List() []Entry {
+	txs := m.unissuedDecisionTxs.List()
+	txs = append(txs, m.unissuedStakerTxs.List()...)
+
+	entries := make([]Entry, len(txs))
+	for i, tx := range txs {
+		entries[i] = Entry{
+			Tx:    tx,
+			Added: m.addedTimes[tx.ID()],
+		}
+	}
+	utils.Sort(entries)
+	return entries
+}
+
 func (m *mempool) HasTxs() bool {
 	return m.unissuedDecisionTxs.Len() > 0 || m.unissuedStakerTxs.Len() > 0
 }
@@ -288,6 +347,11 @@ func (m *mempool) register(tx *txs.Tx) {
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	txType := TxType(tx.Unsigned)
+	m.numTxsByType.WithLabelValues(txType).Inc()
+	m.numBytesByType.WithLabelValues(txType).Add(float64(len(txBytes)))
+	m.addedTimes[tx.ID()] = m.clock.Time()
 }
 
 func (m *mempool) deregister(tx *txs.Tx) {
@@ -295,6 +359,25 @@ func (m *mempool) deregister(tx *txs.Tx) {
 	m.bytesAvailable += len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
+	txType := TxType(tx.Unsigned)
+	m.numTxsByType.WithLabelValues(txType).Dec()
+	m.numBytesByType.WithLabelValues(txType).Sub(float64(len(txBytes)))
+	delete(m.addedTimes, tx.ID())
+
 	inputs := tx.Unsigned.InputIDs()
 	m.consumedUTXOs.Difference(inputs)
 }
+
+// Less orders entries by the time they were added to the mempool, breaking
+// ties by tx ID.
+func (e Entry) Less(other Entry) bool {
+	if !e.Added.Equal(other.Added) {
+		return e.Added.Before(other.Added)
+	}
+	return e.Tx.ID().Less(other.Tx.ID())
+}
+
+// TxType returns the name of the type of [utx], e.g. "AddValidatorTx".
+func TxType(utx txs.UnsignedTx) string {
+	return reflect.TypeOf(utx).Elem().Name()
+}
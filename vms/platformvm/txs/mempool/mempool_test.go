@@ -13,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -241,3 +243,63 @@ func createTestProposalTxs(count int) ([]*txs.Tx, error) {
 	}
 	return proposalTxs, nil
 }
+
+func TestMempoolList(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(1)
+	require.NoError(err)
+	proposalTxs, err := createTestProposalTxs(1)
+	require.NoError(err)
+
+	// Add the staker tx before the decision tx to make sure the txs are
+	// ordered by the time they were added rather than by their type.
+	now := time.Now().Truncate(time.Second)
+	mpool.(*mempool).clock.Set(now)
+	require.NoError(mpool.Add(proposalTxs[0]))
+	mpool.(*mempool).clock.Set(now.Add(time.Second))
+	require.NoError(mpool.Add(decisionTxs[0]))
+
+	require.Equal(
+		[]Entry{
+			{
+				Tx:    proposalTxs[0],
+				Added: now,
+			},
+			{
+				Tx:    decisionTxs[0],
+				Added: now.Add(time.Second),
+			},
+		},
+		mpool.List(),
+	)
+
+	numTxsByType := mpool.(*mempool).numTxsByType
+	numBytesByType := mpool.(*mempool).numBytesByType
+	require.Equal(float64(1), gaugeValue(t, numTxsByType.WithLabelValues("AddValidatorTx")))
+	require.Equal(float64(1), gaugeValue(t, numTxsByType.WithLabelValues("CreateChainTx")))
+	require.Equal(float64(len(decisionTxs[0].Bytes())), gaugeValue(t, numBytesByType.WithLabelValues("CreateChainTx")))
+
+	mpool.Remove(decisionTxs)
+	require.Equal(
+		[]Entry{
+			{
+				Tx:    proposalTxs[0],
+				Added: now,
+			},
+		},
+		mpool.List(),
+	)
+	require.Zero(gaugeValue(t, numTxsByType.WithLabelValues("CreateChainTx")))
+	require.Zero(gaugeValue(t, numBytesByType.WithLabelValues("CreateChainTx")))
+}
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	metric := &dto.Metric{}
+	require.NoError(t, gauge.Write(metric))
+	return metric.GetGauge().GetValue()
+}
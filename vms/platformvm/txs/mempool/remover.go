@@ -32,6 +32,21 @@ func (r *remover) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
 	return nil
 }
 
+func (r *remover) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) SetAutoRenewTx(*txs.SetAutoRenewTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
 func (r *remover) CreateChainTx(*txs.CreateChainTx) error {
 	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
 	return nil
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*SetAutoRenewTx)(nil)
+
+	ErrEmptyStakerTxID = errors.New("staker tx ID cannot be empty")
+
+	errRestakeWithoutAutoRenew = errors.New("rewards can only be restaked by auto-renewed stakers")
+)
+
+// Sets whether a permissionless staker is renewed into a new staking period
+// when its current staking period ends.
+type SetAutoRenewTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the AddPermissionlessValidatorTx or AddPermissionlessDelegatorTx
+	// that added the staker this tx is configuring
+	StakerTxID ids.ID `serialize:"true" json:"stakerTxID"`
+	// True if the staker should be renewed, with the same NodeID, BLS key and
+	// staking duration, when its current staking period ends. The staker is
+	// renewed even if it isn't rewarded for its current staking period, for
+	// example because it didn't meet the uptime requirement
+	AutoRenew bool `serialize:"true" json:"autoRenew"`
+	// True if the reward earned in a staking period should be added to the
+	// stake of the next staking period rather than being paid out
+	RestakeRewards bool `serialize:"true" json:"restakeRewards"`
+	// Proves that the issuer is the owner of the rewards of the staker
+	StakerAuth verify.Verifiable `serialize:"true" json:"stakerAuthorization"`
+}
+
+func (tx *SetAutoRenewTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.StakerTxID == ids.Empty:
+		return ErrEmptyStakerTxID
+	case tx.RestakeRewards && !tx.AutoRenew:
+		return errRestakeWithoutAutoRenew
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.StakerAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetAutoRenewTx) Visit(visitor Visitor) error {
+	return visitor.SetAutoRenewTx(tx)
+}
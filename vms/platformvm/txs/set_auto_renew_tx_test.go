@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+var errInvalidStakerAuth = errors.New("invalid staker auth")
+
+func TestSetAutoRenewTxSerialization(t *testing.T) {
+	require := require.New(t)
+
+	tx := &Tx{
+		Unsigned: &SetAutoRenewTx{
+			BaseTx: BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    constants.MainnetID,
+					BlockchainID: constants.PlatformChainID,
+					Outs:         []*avax.TransferableOutput{},
+					Ins:          []*avax.TransferableInput{},
+					Memo:         types.JSONByteSlice{},
+				},
+			},
+			StakerTxID:     ids.GenerateTestID(),
+			AutoRenew:      true,
+			RestakeRewards: true,
+			StakerAuth: &secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+	require.NoError(tx.Sign(Codec, nil))
+
+	parsedTx, err := Parse(Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.Unsigned, parsedTx.Unsigned)
+
+	// The type is registered after the TransferSubnetOwnershipTx.
+	typeID := tx.Bytes()[2:6]
+	require.Equal([]byte{0x00, 0x00, 0x00, 0x22}, typeID)
+}
+
+func TestSetAutoRenewTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *SetAutoRenewTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *SetAutoRenewTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *SetAutoRenewTx {
+				return &SetAutoRenewTx{BaseTx: verifiedBaseTx}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "empty staker tx ID",
+			txFunc: func(*gomock.Controller) *SetAutoRenewTx {
+				return &SetAutoRenewTx{BaseTx: validBaseTx}
+			},
+			expectedErr: ErrEmptyStakerTxID,
+		},
+		{
+			name: "restake without auto renew",
+			txFunc: func(*gomock.Controller) *SetAutoRenewTx {
+				return &SetAutoRenewTx{
+					BaseTx:         validBaseTx,
+					StakerTxID:     ids.GenerateTestID(),
+					RestakeRewards: true,
+				}
+			},
+			expectedErr: errRestakeWithoutAutoRenew,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *SetAutoRenewTx {
+				return &SetAutoRenewTx{
+					BaseTx:     invalidBaseTx,
+					StakerTxID: ids.GenerateTestID(),
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid stakerAuth",
+			txFunc: func(ctrl *gomock.Controller) *SetAutoRenewTx {
+				// This StakerAuth fails verification.
+				invalidStakerAuth := verify.NewMockVerifiable(ctrl)
+				invalidStakerAuth.EXPECT().Verify().Return(errInvalidStakerAuth)
+				return &SetAutoRenewTx{
+					BaseTx:     validBaseTx,
+					StakerTxID: ids.GenerateTestID(),
+					StakerAuth: invalidStakerAuth,
+				}
+			},
+			expectedErr: errInvalidStakerAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *SetAutoRenewTx {
+				// This StakerAuth passes verification.
+				validStakerAuth := verify.NewMockVerifiable(ctrl)
+				validStakerAuth.EXPECT().Verify().Return(nil)
+				return &SetAutoRenewTx{
+					BaseTx:         validBaseTx,
+					StakerTxID:     ids.GenerateTestID(),
+					AutoRenew:      true,
+					RestakeRewards: true,
+					StakerAuth:     validStakerAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.True(tx.SyntacticallyVerified)
+			}
+		})
+	}
+}
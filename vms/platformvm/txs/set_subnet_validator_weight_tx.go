@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*SetSubnetValidatorWeightTx)(nil)
+
+	ErrSetPrimaryNetworkValidatorWeight = errors.New("can't set weight of primary network validator with SetSubnetValidatorWeightTx")
+)
+
+// Sets the weight of a validator of a subnet, without removing it from the
+// validator set.
+type SetSubnetValidatorWeightTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node whose weight is being set.
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet the node validates.
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// The new weight of the node on the subnet.
+	Weight uint64 `serialize:"true" json:"weight"`
+	// Proves that the issuer has the right to modify the subnet's validators.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *SetSubnetValidatorWeightTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrSetPrimaryNetworkValidatorWeight
+	case tx.Weight == 0:
+		return ErrWeightTooSmall
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetValidatorWeightTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetValidatorWeightTx(tx)
+}
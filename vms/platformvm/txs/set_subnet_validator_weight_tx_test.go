@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+func TestSetSubnetValidatorWeightTxSerialization(t *testing.T) {
+	require := require.New(t)
+
+	tx := &Tx{
+		Unsigned: &SetSubnetValidatorWeightTx{
+			BaseTx: BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    constants.MainnetID,
+					BlockchainID: constants.PlatformChainID,
+					Outs:         []*avax.TransferableOutput{},
+					Ins:          []*avax.TransferableInput{},
+					Memo:         types.JSONByteSlice{},
+				},
+			},
+			NodeID: ids.GenerateTestNodeID(),
+			Subnet: ids.GenerateTestID(),
+			Weight: 1234,
+			SubnetAuth: &secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+	require.NoError(tx.Sign(Codec, nil))
+
+	parsedTx, err := Parse(Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.Unsigned, parsedTx.Unsigned)
+
+	// The type is registered after the SetAutoRenewTx.
+	typeID := tx.Bytes()[2:6]
+	require.Equal([]byte{0x00, 0x00, 0x00, 0x23}, typeID)
+}
+
+func TestSetSubnetValidatorWeightTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *SetSubnetValidatorWeightTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{BaseTx: verifiedBaseTx}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+					Weight: 1,
+				}
+			},
+			expectedErr: ErrSetPrimaryNetworkValidatorWeight,
+		},
+		{
+			name: "zero weight",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: validBaseTx,
+					Subnet: ids.GenerateTestID(),
+				}
+			},
+			expectedErr: ErrWeightTooSmall,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: invalidBaseTx,
+					Subnet: ids.GenerateTestID(),
+					Weight: 1,
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetValidatorWeightTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &SetSubnetValidatorWeightTx{
+					BaseTx:     validBaseTx,
+					Subnet:     ids.GenerateTestID(),
+					Weight:     1,
+					SubnetAuth: invalidSubnetAuth,
+				}
+			},
+			expectedErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetValidatorWeightTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &SetSubnetValidatorWeightTx{
+					BaseTx:     validBaseTx,
+					NodeID:     ids.GenerateTestNodeID(),
+					Subnet:     ids.GenerateTestID(),
+					Weight:     1,
+					SubnetAuth: validSubnetAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.True(tx.SyntacticallyVerified)
+			}
+		})
+	}
+}
@@ -18,4 +18,7 @@ type Visitor interface {
 	TransformSubnetTx(*TransformSubnetTx) error
 	AddPermissionlessValidatorTx(*AddPermissionlessValidatorTx) error
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
+	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
+	SetAutoRenewTx(*SetAutoRenewTx) error
+	SetSubnetValidatorWeightTx(*SetSubnetValidatorWeightTx) error
 }
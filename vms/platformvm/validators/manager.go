@@ -207,29 +207,35 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 	currentHeight uint64,
 	targetHeight uint64,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
-	currentValidators, ok := m.cfg.Validators.Get(constants.PrimaryNetworkID)
-	if !ok {
-		// This should never happen
-		m.log.Error(ErrMissingValidatorSet.Error(),
-			zap.Stringer("subnetID", constants.PrimaryNetworkID),
-		)
-		return nil, ErrMissingValidatorSet
+	startHeight, validatorSet, err := m.getValidatorSetCheckpoint(currentHeight, targetHeight, constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, err
 	}
-	currentValidatorList := currentValidators.List()
-
-	// Node ID --> Validator information for the node validating the Primary
-	// Network.
-	validatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentValidatorList))
-	for _, vdr := range currentValidatorList {
-		validatorSet[vdr.NodeID] = &validators.GetValidatorOutput{
-			NodeID:    vdr.NodeID,
-			PublicKey: vdr.PublicKey,
-			Weight:    vdr.Weight,
+	if validatorSet == nil {
+		currentValidators, ok := m.cfg.Validators.Get(constants.PrimaryNetworkID)
+		if !ok {
+			// This should never happen
+			m.log.Error(ErrMissingValidatorSet.Error(),
+				zap.Stringer("subnetID", constants.PrimaryNetworkID),
+			)
+			return nil, ErrMissingValidatorSet
+		}
+		currentValidatorList := currentValidators.List()
+
+		// Node ID --> Validator information for the node validating the
+		// Primary Network.
+		validatorSet = make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentValidatorList))
+		for _, vdr := range currentValidatorList {
+			validatorSet[vdr.NodeID] = &validators.GetValidatorOutput{
+				NodeID:    vdr.NodeID,
+				PublicKey: vdr.PublicKey,
+				Weight:    vdr.Weight,
+			}
 		}
 	}
 
 	// Rebuild primary network validators at [height]
-	for diffHeight := currentHeight; diffHeight > targetHeight; diffHeight-- {
+	for diffHeight := startHeight; diffHeight > targetHeight; diffHeight-- {
 		weightDiffs, err := m.state.GetValidatorWeightDiffs(diffHeight, constants.PlatformChainID)
 		if err != nil {
 			return nil, err
@@ -260,27 +266,33 @@ func (m *manager) makeSubnetValidatorSet(
 	targetHeight uint64,
 	subnetID ids.ID,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
-	currentValidators, ok := m.cfg.Validators.Get(subnetID)
-	if !ok {
-		currentValidators = validators.NewSet()
-		if err := m.state.ValidatorSet(subnetID, currentValidators); err != nil {
-			return nil, err
-		}
+	startHeight, subnetValidatorSet, err := m.getValidatorSetCheckpoint(currentHeight, targetHeight, subnetID)
+	if err != nil {
+		return nil, err
 	}
-	currentValidatorList := currentValidators.List()
-
-	// Node ID --> Validator information for the node validating the Subnet.
-	subnetValidatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentValidatorList))
-	for _, vdr := range currentValidatorList {
-		subnetValidatorSet[vdr.NodeID] = &validators.GetValidatorOutput{
-			NodeID: vdr.NodeID,
-			// PublicKey will be picked from primary validators
-			Weight: vdr.Weight,
+	if subnetValidatorSet == nil {
+		currentValidators, ok := m.cfg.Validators.Get(subnetID)
+		if !ok {
+			currentValidators = validators.NewSet()
+			if err := m.state.ValidatorSet(subnetID, currentValidators); err != nil {
+				return nil, err
+			}
+		}
+		currentValidatorList := currentValidators.List()
+
+		// Node ID --> Validator information for the node validating the Subnet.
+		subnetValidatorSet = make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentValidatorList))
+		for _, vdr := range currentValidatorList {
+			subnetValidatorSet[vdr.NodeID] = &validators.GetValidatorOutput{
+				NodeID: vdr.NodeID,
+				// PublicKey will be picked from primary validators
+				Weight: vdr.Weight,
+			}
 		}
 	}
 
 	// Rebuild subnet validators at [targetHeight]
-	for diffHeight := currentHeight; diffHeight > targetHeight; diffHeight-- {
+	for diffHeight := startHeight; diffHeight > targetHeight; diffHeight-- {
 		weightDiffs, err := m.state.GetValidatorWeightDiffs(diffHeight, subnetID)
 		if err != nil {
 			return nil, err
@@ -317,6 +329,29 @@ func (m *manager) makeSubnetValidatorSet(
 	return subnetValidatorSet, nil
 }
 
+// getValidatorSetCheckpoint returns the closest persisted validator set of
+// [subnetID] that can be used to rebuild the validator set at [targetHeight],
+// along with its height. If no such checkpoint exists, [currentHeight] and a
+// nil validator set are returned.
+func (m *manager) getValidatorSetCheckpoint(
+	currentHeight uint64,
+	targetHeight uint64,
+	subnetID ids.ID,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	checkpointHeight, validatorSet, err := m.state.GetValidatorSetCheckpoint(subnetID, targetHeight)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return currentHeight, nil, nil
+	case err != nil:
+		return 0, nil, err
+	case checkpointHeight >= currentHeight:
+		// Starting from the current validator set is at least as fast.
+		return currentHeight, nil, nil
+	default:
+		return checkpointHeight, validatorSet, nil
+	}
+}
+
 func applyWeightDiff(
 	targetSet map[ids.NodeID]*validators.GetValidatorOutput,
 	nodeID ids.NodeID,
@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/api"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const (
+	benchmarkNumValidators      = 100
+	benchmarkCurrentHeight      = 1_100_000
+	benchmarkCheckpointInterval = 1_024
+)
+
+func BenchmarkGetValidatorSet(b *testing.B) {
+	for _, checkpointInterval := range []uint64{0, benchmarkCheckpointInterval} {
+		m := newBenchmarkManager(b, checkpointInterval)
+		for _, blocksBack := range []uint64{1_000, 100_000, 1_000_000} {
+			name := fmt.Sprintf("checkpointInterval=%d/blocksBack=%d", checkpointInterval, blocksBack)
+			b.Run(name, func(b *testing.B) {
+				benchmarkGetValidatorSet(b, m, blocksBack)
+			})
+		}
+	}
+}
+
+// newBenchmarkManager returns a manager backed by a state where every
+// validator is registered with a BLS key at height 1. At every following
+// height, a delegator of one of the validators is either added or removed, so
+// that every height has a weight diff.
+func newBenchmarkManager(b *testing.B, checkpointInterval uint64) *manager {
+	require := require.New(b)
+
+	vdrs := validators.NewManager()
+	require.True(vdrs.Add(constants.PrimaryNetworkID, validators.NewSet()))
+
+	cfg := config.Config{
+		Validators:                     vdrs,
+		ValidatorSetCheckpointInterval: checkpointInterval,
+	}
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	bootstrapped := &utils.Atomic[bool]{}
+	bootstrapped.Set(true)
+
+	s, err := state.New(
+		memdb.New(),
+		buildBenchmarkGenesis(b, ctx),
+		prometheus.NewRegistry(),
+		&cfg,
+		ctx,
+		metrics.Noop,
+		reward.NewCalculator(defaultRewardConfig),
+		bootstrapped,
+	)
+	require.NoError(err)
+
+	var (
+		startTime = time.Unix(0, 0)
+		endTime   = startTime.Add(365 * 24 * time.Hour)
+		nodeIDs   = make([]ids.NodeID, benchmarkNumValidators)
+	)
+	for i := range nodeIDs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		s.PutCurrentValidator(&state.Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    nodeIDs[i],
+			PublicKey: bls.PublicFromSecretKey(sk),
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    units.KiloAvax,
+			StartTime: startTime,
+			EndTime:   endTime,
+			NextTime:  endTime,
+			Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+		})
+	}
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Delegators are removed at the height after they are added, so that the
+	// staker set doesn't grow with the height.
+	var delegator *state.Staker
+	for height := uint64(2); height <= benchmarkCurrentHeight; height++ {
+		if delegator == nil {
+			delegator = &state.Staker{
+				TxID:      ids.GenerateTestID(),
+				NodeID:    nodeIDs[height%benchmarkNumValidators],
+				SubnetID:  constants.PrimaryNetworkID,
+				Weight:    1,
+				StartTime: startTime,
+				EndTime:   endTime,
+				NextTime:  endTime,
+				Priority:  txs.PrimaryNetworkDelegatorCurrentPriority,
+			}
+			s.PutCurrentDelegator(delegator)
+		} else {
+			s.DeleteCurrentDelegator(delegator)
+			delegator = nil
+		}
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+	}
+
+	metrics, err := metrics.New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	return NewManager(logging.NoLog{}, cfg, s, metrics, &mockable.Clock{}).(*manager)
+}
+
+func buildBenchmarkGenesis(b *testing.B, ctx *snow.Context) []byte {
+	require := require.New(b)
+
+	buildGenesisArgs := api.BuildGenesisArgs{
+		NetworkID:     json.Uint32(constants.UnitTestID),
+		AvaxAssetID:   ctx.AVAXAssetID,
+		InitialSupply: json.Uint64(360 * units.MegaAvax),
+		Encoding:      formatting.Hex,
+	}
+	buildGenesisResponse := api.BuildGenesisReply{}
+	platformvmSS := api.StaticService{}
+	require.NoError(platformvmSS.BuildGenesis(nil, &buildGenesisArgs, &buildGenesisResponse))
+
+	genesisBytes, err := formatting.Decode(buildGenesisResponse.Encoding, buildGenesisResponse.Bytes)
+	require.NoError(err)
+	return genesisBytes
+}
+
+func benchmarkGetValidatorSet(b *testing.B, m *manager, blocksBack uint64) {
+	require := require.New(b)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		// Bypass the validator set cache
+		_, err := m.makePrimaryNetworkValidatorSet(benchmarkCurrentHeight, benchmarkCurrentHeight-blocksBack)
+		require.NoError(err)
+	}
+}
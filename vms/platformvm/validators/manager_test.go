@@ -419,6 +419,9 @@ func TestVM_GetValidatorSet(t *testing.T) {
 				mockState.EXPECT().GetValidatorPublicKeyDiffs(height).Return(pkDiff, nil)
 			}
 
+			// No validator set checkpoints are persisted
+			mockState.EXPECT().GetValidatorSetCheckpoint(gomock.Any(), gomock.Any()).Return(uint64(0), nil, database.ErrNotFound).AnyTimes()
+
 			// Tell state last accepted block to report
 			mockTip := blocks.NewMockBlock(ctrl)
 			mockTip.EXPECT().Height().Return(tt.lastAcceptedHeight)
@@ -437,6 +440,101 @@ func TestVM_GetValidatorSet(t *testing.T) {
 	}
 }
 
+func TestGetValidatorSetFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+	)
+
+	mockState := state.NewMockState(ctrl)
+	metrics, err := metrics.New("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	cfg := config.Config{
+		Validators: validators.NewManager(),
+	}
+	validatorSet := NewManager(logging.NoLog{}, cfg, mockState, metrics, &mockable.Clock{})
+
+	// The last accepted height is 100 and the closest checkpoint to height 4
+	// is at height 5. Only the diff at height 5 should be read.
+	mockTip := blocks.NewMockBlock(ctrl)
+	mockTip.EXPECT().Height().Return(uint64(100))
+	mockTipID := ids.GenerateTestID()
+	mockState.EXPECT().GetLastAccepted().Return(mockTipID)
+	mockState.EXPECT().GetStatelessBlock(mockTipID).Return(mockTip, choices.Accepted, nil)
+
+	mockState.EXPECT().GetValidatorSetCheckpoint(subnetID, uint64(4)).Return(
+		uint64(5),
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID: nodeID0,
+				Weight: 2,
+			},
+		},
+		nil,
+	)
+	mockState.EXPECT().GetValidatorWeightDiffs(uint64(5), subnetID).Return(
+		map[ids.NodeID]*state.ValidatorWeightDiff{
+			nodeID0: {
+				Decrease: false,
+				Amount:   1,
+			},
+			nodeID1: {
+				Decrease: true,
+				Amount:   3,
+			},
+		},
+		nil,
+	)
+
+	mockState.EXPECT().GetValidatorSetCheckpoint(constants.PrimaryNetworkID, uint64(4)).Return(
+		uint64(5),
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID:    nodeID0,
+				PublicKey: pk,
+				Weight:    10,
+			},
+			nodeID1: {
+				NodeID: nodeID1,
+				Weight: 10,
+			},
+		},
+		nil,
+	)
+	mockState.EXPECT().GetValidatorWeightDiffs(uint64(5), constants.PrimaryNetworkID).Return(
+		map[ids.NodeID]*state.ValidatorWeightDiff{},
+		nil,
+	)
+	mockState.EXPECT().GetValidatorPublicKeyDiffs(uint64(5)).Return(
+		map[ids.NodeID]*bls.PublicKey{},
+		nil,
+	)
+
+	vdrs, err := validatorSet.GetValidatorSet(context.Background(), 4, subnetID)
+	require.NoError(err)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID:    nodeID0,
+			PublicKey: pk,
+			Weight:    1,
+		},
+		nodeID1: {
+			NodeID: nodeID1,
+			Weight: 3,
+		},
+	}, vdrs)
+}
+
 func copyPrimaryValidator(vdr *validators.Validator) *validators.Validator {
 	newVdr := *vdr
 	return &newVdr
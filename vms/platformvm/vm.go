@@ -86,8 +86,9 @@ type VM struct {
 	// Bootstrapped remembers if this chain has finished bootstrapping or not
 	bootstrapped utils.Atomic[bool]
 
-	txBuilder txbuilder.Builder
-	manager   blockexecutor.Manager
+	txBuilder         txbuilder.Builder
+	txExecutorBackend *txexecutor.Backend
+	manager           blockexecutor.Manager
 }
 
 // Initialize this blockchain.
@@ -121,7 +122,7 @@ func (vm *VM) Initialize(
 	vm.dbManager = dbManager
 
 	vm.codecRegistry = linearcodec.NewDefault()
-	vm.fx = &secp256k1fx.Fx{}
+	vm.fx = &fx.SECPFx{}
 	if err := vm.fx.Initialize(vm); err != nil {
 		return err
 	}
@@ -141,6 +142,19 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	// Indexing the blocks that were accepted before the block ID index existed
+	// requires a single pass over every accepted block, which may take several
+	// minutes on mainnet. It is done in the background so that the chain isn't
+	// blocked in the meantime; until it finishes, looking up blocks by height
+	// reports that the index isn't ready.
+	go func() {
+		if err := vm.state.IndexBlockIDs(&vm.ctx.Lock); err != nil {
+			vm.ctx.Log.Warn("failed to index accepted blocks by height",
+				zap.Error(err),
+			)
+		}
+	}()
+
 	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
@@ -158,7 +172,7 @@ func (vm *VM) Initialize(
 		utxoHandler,
 	)
 
-	txExecutorBackend := &txexecutor.Backend{
+	vm.txExecutorBackend = &txexecutor.Backend{
 		Config:       &vm.Config,
 		Ctx:          vm.ctx,
 		Clk:          &vm.clock,
@@ -180,13 +194,13 @@ func (vm *VM) Initialize(
 		mempool,
 		vm.metrics,
 		vm.state,
-		txExecutorBackend,
+		vm.txExecutorBackend,
 		validatorManager,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
 		vm.txBuilder,
-		txExecutorBackend,
+		vm.txExecutorBackend,
 		vm.manager,
 		toEngine,
 		appSender,
@@ -395,11 +409,27 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		return nil, err
 	}
 
-	return map[string]*common.HTTPHandler{
+	handlers := map[string]*common.HTTPHandler{
 		"": {
 			Handler: server,
 		},
-	}, nil
+	}
+	if !vm.AdminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(json.NewCodec(), "application/json")
+	adminServer.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	if err := adminServer.RegisterService(&AdminService{vm: vm}, "admin"); err != nil {
+		return nil, err
+	}
+	handlers["/admin"] = &common.HTTPHandler{
+		Handler: adminServer,
+	}
+	return handlers, nil
 }
 
 // CreateStaticHandlers returns a map where:
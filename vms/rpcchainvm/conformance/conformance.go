@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package conformance checks that a rpcchainvm plugin behaves as expected by
+// the node. The plugin is launched through the same runtime the node uses and
+// driven through the block.ChainVM lifecycle.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+var errUnknownTest = errors.New("unknown test")
+
+// Status is the outcome of a test.
+type Status string
+
+// Config describes the plugin under test.
+type Config struct {
+	// Path of the plugin binary.
+	PluginPath string `json:"pluginPath"`
+	// Genesis, upgrade and config bytes the VM is initialized with.
+	Genesis []byte `json:"genesis"`
+	Upgrade []byte `json:"upgrade"`
+	Config  []byte `json:"config"`
+	// Names of the tests to run. If empty, all tests are run.
+	Tests []string `json:"tests"`
+	// Maximum duration of each test.
+	Timeout time.Duration `json:"timeout"`
+	// Log of the VMs. Defaults to discarding the logs.
+	Log logging.Logger `json:"-"`
+}
+
+// Result is the outcome of a single test.
+type Result struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Reason   string        `json:"reason,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of every test that was run.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Passed returns true if no test failed.
+func (r *Report) Passed() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Report) String() string {
+	sb := strings.Builder{}
+	counts := make(map[Status]int)
+	for _, result := range r.Results {
+		counts[result.Status]++
+		sb.WriteString(fmt.Sprintf("%-7s %-24s %s", result.Status, result.Name, result.Duration.Round(time.Millisecond)))
+		if result.Reason != "" {
+			sb.WriteString(fmt.Sprintf(": %s", result.Reason))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("%d passed, %d failed, %d skipped",
+		counts[StatusPassed],
+		counts[StatusFailed],
+		counts[StatusSkipped],
+	))
+	return sb.String()
+}
+
+// TestNames returns the names of all the tests, in the order they are run.
+func TestNames() []string {
+	names := make([]string, len(tests))
+	for i, test := range tests {
+		names[i] = test.name
+	}
+	return names
+}
+
+// Run launches a new instance of the plugin for each test and reports the
+// outcome of the tests. An error is only returned if the tests couldn't be
+// run.
+func Run(ctx context.Context, config Config) (*Report, error) {
+	if config.Log == nil {
+		config.Log = logging.NoLog{}
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	selected := tests
+	if len(config.Tests) != 0 {
+		selected = make([]test, 0, len(config.Tests))
+		for _, name := range config.Tests {
+			test, ok := getTest(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", errUnknownTest, name)
+			}
+			selected = append(selected, test)
+		}
+	}
+
+	report := &Report{
+		Results: make([]Result, len(selected)),
+	}
+	for i, test := range selected {
+		report.Results[i] = runTest(ctx, &config, test)
+	}
+	return report, nil
+}
+
+func getTest(name string) (test, bool) {
+	for _, test := range tests {
+		if test.name == name {
+			return test, true
+		}
+	}
+	return test{}, false
+}
+
+func runTest(ctx context.Context, config *Config, test test) Result {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := Result{
+		Name: test.name,
+	}
+	err := runTestInstance(ctx, config, test)
+	result.Duration = time.Since(start)
+
+	var skip *skipError
+	switch {
+	case err == nil:
+		result.Status = StatusPassed
+	case errors.As(err, &skip):
+		result.Status = StatusSkipped
+		result.Reason = skip.reason
+	default:
+		result.Status = StatusFailed
+		result.Reason = err.Error()
+	}
+	return result
+}
+
+func runTestInstance(ctx context.Context, config *Config, test test) error {
+	h, err := newHarness(config)
+	if err != nil {
+		return err
+	}
+
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	if err := h.start(ctx); err != nil {
+		return err
+	}
+
+	err = test.run(ctx, h)
+	// A VM whose process exits fails the test, even if the test recovered
+	// from it.
+	if h.processExited() {
+		if err != nil {
+			return fmt.Errorf("%w: %v", errProcessExited, err)
+		}
+		err = errProcessExited
+	}
+	// If restarting the VM failed, there isn't a VM to shutdown.
+	if h.vm == nil {
+		return err
+	}
+	if shutdownErr := h.shutdown(ctx); err == nil && shutdownErr != nil {
+		err = fmt.Errorf("failed to shutdown: %w", shutdownErr)
+	}
+	return err
+}
@@ -0,0 +1,411 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package conformance
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+)
+
+const (
+	// ephemeralConfig configures the test VM to not persist accepted blocks.
+	ephemeralConfig = "ephemeral"
+	// crashConfig configures the test VM to exit its process once it enters
+	// normal operations.
+	crashConfig = "crash"
+)
+
+var (
+	_ block.ChainVM              = (*testVM)(nil)
+	_ block.HeightIndexedChainVM = (*testVM)(nil)
+	_ snowman.Block              = (*testBlock)(nil)
+
+	lastAcceptedKey = []byte("lastAccepted")
+
+	errInvalidBlockBytes = errors.New("invalid block bytes")
+	errUnknownParent     = errors.New("unknown parent")
+)
+
+// TestMain serves the test VM when the test binary is launched as a plugin.
+func TestMain(m *testing.M) {
+	if os.Getenv(runtime.EngineAddressKey) != "" {
+		if err := rpcchainvm.Serve(context.Background(), &testVM{}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	report, err := Run(context.Background(), Config{
+		PluginPath: os.Args[0],
+		Timeout:    30 * time.Second,
+	})
+	require.NoError(err)
+	require.True(report.Passed(), report.String())
+
+	statuses := make(map[string]Status)
+	for _, result := range report.Results {
+		statuses[result.Name] = result.Status
+	}
+	require.Len(statuses, len(tests))
+	require.Equal(StatusPassed, statuses["build_accept"])
+	require.Equal(StatusPassed, statuses["height_index"])
+	require.Equal(StatusSkipped, statuses["state_sync"])
+	require.Equal(StatusPassed, statuses["upgrade"])
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	require := require.New(t)
+
+	report, err := Run(context.Background(), Config{
+		PluginPath: os.Args[0],
+		Config:     []byte(ephemeralConfig),
+		Tests:      []string{"initialize", "build_accept"},
+		Timeout:    30 * time.Second,
+	})
+	require.NoError(err)
+	require.False(report.Passed())
+	require.Len(report.Results, 2)
+	require.Equal(StatusPassed, report.Results[0].Status)
+	require.Equal(StatusFailed, report.Results[1].Status)
+	require.Contains(report.Results[1].Reason, errUnexpectedLastAccepted.Error())
+}
+
+func TestRunProcessExit(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	config := &Config{
+		PluginPath: os.Args[0],
+		Config:     []byte(crashConfig),
+		Log:        logging.NoLog{},
+	}
+	err := runTestInstance(ctx, config, test{
+		name: "crash",
+		run: func(ctx context.Context, h *harness) error {
+			_ = h.vm.SetState(ctx, snow.NormalOp)
+			select {
+			case <-h.exited:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			// The process isn't restarted.
+			_, err := h.vm.HealthCheck(ctx)
+			require.Error(err)
+			return nil
+		},
+	})
+	require.ErrorIs(err, errProcessExited)
+}
+
+func TestRunUnknownTest(t *testing.T) {
+	_, err := Run(context.Background(), Config{
+		PluginPath: os.Args[0],
+		Tests:      []string{"not_a_test"},
+	})
+	require.ErrorIs(t, err, errUnknownTest)
+}
+
+func TestRunMissingPlugin(t *testing.T) {
+	require := require.New(t)
+
+	report, err := Run(context.Background(), Config{
+		PluginPath: "/not/a/plugin",
+		Tests:      []string{"initialize"},
+	})
+	require.NoError(err)
+	require.False(report.Passed())
+	require.Equal(StatusFailed, report.Results[0].Status)
+}
+
+// testVM is a minimal VM whose blocks only contain their parent, height and
+// timestamp.
+type testVM struct {
+	common.TestVM
+
+	db        database.Database
+	ephemeral bool
+	crash     bool
+
+	preferred    ids.ID
+	lastAccepted *testBlock
+	// blocks that were built or parsed and haven't been accepted.
+	blocks map[ids.ID]*testBlock
+	// accepted blocks that weren't persisted.
+	accepted map[uint64]*testBlock
+}
+
+func (vm *testVM) Initialize(
+	_ context.Context,
+	_ *snow.Context,
+	dbManager manager.Manager,
+	_ []byte,
+	_ []byte,
+	configBytes []byte,
+	_ chan<- common.Message,
+	_ []*common.Fx,
+	_ common.AppSender,
+) error {
+	vm.db = dbManager.Current().Database
+	vm.ephemeral = string(configBytes) == ephemeralConfig
+	vm.crash = string(configBytes) == crashConfig
+	vm.blocks = make(map[ids.ID]*testBlock)
+	vm.accepted = make(map[uint64]*testBlock)
+
+	lastAcceptedID, err := vm.db.Get(lastAcceptedKey)
+	if errors.Is(err, database.ErrNotFound) {
+		genesis := vm.newBlock(ids.Empty, 0, time.Unix(0, 0))
+		genesis.status = choices.Accepted
+		vm.lastAccepted = genesis
+		vm.preferred = genesis.id
+		return vm.putAccepted(genesis)
+	}
+	if err != nil {
+		return err
+	}
+
+	blkID, err := ids.ToID(lastAcceptedID)
+	if err != nil {
+		return err
+	}
+	blk, err := vm.getAccepted(blkID)
+	if err != nil {
+		return err
+	}
+	vm.lastAccepted = blk
+	vm.preferred = blk.id
+	return nil
+}
+
+func (vm *testVM) SetState(_ context.Context, state snow.State) error {
+	if vm.crash && state == snow.NormalOp {
+		go os.Exit(1)
+	}
+	return nil
+}
+
+func (*testVM) Shutdown(context.Context) error {
+	return nil
+}
+
+func (*testVM) Version(context.Context) (string, error) {
+	return version.Current.String(), nil
+}
+
+func (*testVM) HealthCheck(context.Context) (interface{}, error) {
+	return nil, nil
+}
+
+func (vm *testVM) BuildBlock(context.Context) (snowman.Block, error) {
+	parent, err := vm.getBlock(vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+	blk := vm.newBlock(parent.id, parent.height+1, parent.timestamp.Add(time.Second))
+	vm.blocks[blk.id] = blk
+	return blk, nil
+}
+
+func (vm *testVM) ParseBlock(_ context.Context, b []byte) (snowman.Block, error) {
+	if len(b) != hashing.HashLen+2*database.Uint64Size {
+		return nil, errInvalidBlockBytes
+	}
+	blkID := hashing.ComputeHash256Array(b)
+	if blk, err := vm.getBlock(blkID); err == nil {
+		return blk, nil
+	}
+
+	parentID, err := ids.ToID(b[:hashing.HashLen])
+	if err != nil {
+		return nil, err
+	}
+	blk := vm.newBlock(
+		parentID,
+		binary.BigEndian.Uint64(b[hashing.HashLen:]),
+		time.Unix(int64(binary.BigEndian.Uint64(b[hashing.HashLen+database.Uint64Size:])), 0),
+	)
+	vm.blocks[blk.id] = blk
+	return blk, nil
+}
+
+func (vm *testVM) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+	return vm.getBlock(blkID)
+}
+
+func (vm *testVM) SetPreference(_ context.Context, blkID ids.ID) error {
+	vm.preferred = blkID
+	return nil
+}
+
+func (vm *testVM) LastAccepted(context.Context) (ids.ID, error) {
+	return vm.lastAccepted.id, nil
+}
+
+func (*testVM) VerifyHeightIndex(context.Context) error {
+	return nil
+}
+
+func (vm *testVM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
+	if blk, ok := vm.accepted[height]; ok {
+		return blk.id, nil
+	}
+	b, err := vm.db.Get(database.PackUInt64(height))
+	if err != nil {
+		return ids.Empty, err
+	}
+	return hashing.ComputeHash256Array(b), nil
+}
+
+func (vm *testVM) newBlock(parentID ids.ID, height uint64, timestamp time.Time) *testBlock {
+	b := make([]byte, hashing.HashLen+2*database.Uint64Size)
+	copy(b, parentID[:])
+	binary.BigEndian.PutUint64(b[hashing.HashLen:], height)
+	binary.BigEndian.PutUint64(b[hashing.HashLen+database.Uint64Size:], uint64(timestamp.Unix()))
+	return &testBlock{
+		vm:        vm,
+		id:        hashing.ComputeHash256Array(b),
+		parentID:  parentID,
+		height:    height,
+		timestamp: timestamp,
+		bytes:     b,
+		status:    choices.Processing,
+	}
+}
+
+func (vm *testVM) getBlock(blkID ids.ID) (*testBlock, error) {
+	if blk, ok := vm.blocks[blkID]; ok {
+		return blk, nil
+	}
+	return vm.getAccepted(blkID)
+}
+
+func (vm *testVM) getAccepted(blkID ids.ID) (*testBlock, error) {
+	for _, blk := range vm.accepted {
+		if blk.id == blkID {
+			return blk, nil
+		}
+	}
+	heightBytes, err := vm.db.Get(blkID[:])
+	if err != nil {
+		return nil, err
+	}
+	height, err := database.ParseUInt64(heightBytes)
+	if err != nil {
+		return nil, err
+	}
+	b, err := vm.db.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := ids.ToID(b[:hashing.HashLen])
+	if err != nil {
+		return nil, err
+	}
+	blk := vm.newBlock(
+		parentID,
+		height,
+		time.Unix(int64(binary.BigEndian.Uint64(b[hashing.HashLen+database.Uint64Size:])), 0),
+	)
+	blk.status = choices.Accepted
+	return blk, nil
+}
+
+func (vm *testVM) putAccepted(blk *testBlock) error {
+	if vm.ephemeral && blk.height > 0 {
+		vm.accepted[blk.height] = blk
+		return nil
+	}
+	if err := vm.db.Put(blk.id[:], database.PackUInt64(blk.height)); err != nil {
+		return err
+	}
+	if err := vm.db.Put(database.PackUInt64(blk.height), blk.bytes); err != nil {
+		return err
+	}
+	return vm.db.Put(lastAcceptedKey, blk.id[:])
+}
+
+type testBlock struct {
+	vm        *testVM
+	id        ids.ID
+	parentID  ids.ID
+	height    uint64
+	timestamp time.Time
+	bytes     []byte
+	status    choices.Status
+}
+
+func (b *testBlock) ID() ids.ID {
+	return b.id
+}
+
+func (b *testBlock) Accept(context.Context) error {
+	b.status = choices.Accepted
+	b.vm.lastAccepted = b
+	delete(b.vm.blocks, b.id)
+	return b.vm.putAccepted(b)
+}
+
+func (b *testBlock) Reject(context.Context) error {
+	b.status = choices.Rejected
+	delete(b.vm.blocks, b.id)
+	return nil
+}
+
+func (b *testBlock) Status() choices.Status {
+	return b.status
+}
+
+func (b *testBlock) Parent() ids.ID {
+	return b.parentID
+}
+
+func (b *testBlock) Verify(context.Context) error {
+	parent, err := b.vm.getBlock(b.parentID)
+	if err != nil {
+		return errUnknownParent
+	}
+	if parent.height+1 != b.height {
+		return errUnexpectedHeight
+	}
+	return nil
+}
+
+func (b *testBlock) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *testBlock) Height() uint64 {
+	return b.height
+}
+
+func (b *testBlock) Timestamp() time.Time {
+	return b.timestamp
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+)
+
+var (
+	_ resource.ProcessTracker = noProcessTracker{}
+	_ common.AppSender        = appSender{}
+	_ validators.State        = (*validatorState)(nil)
+
+	errNotChainVM    = errors.New("plugin isn't a block.ChainVM")
+	errProcessExited = errors.New("vm process exited unexpectedly")
+)
+
+// harness is an initialized instance of the plugin under test.
+//
+// As with the engine, the VM is only called while [ctx.Lock] is held.
+type harness struct {
+	config    *Config
+	vm        block.ChainVM
+	ctx       *snow.Context
+	dbManager manager.Manager
+	toEngine  chan common.Message
+	runtimes  runtime.Manager
+
+	// exited is closed once a process of the plugin exits before the VM is
+	// shutdown. The process isn't restarted, so that crashes fail the test.
+	exitOnce sync.Once
+	exited   chan struct{}
+}
+
+// newHarness returns a harness with fresh databases. The plugin is launched
+// once the harness is started.
+func newHarness(config *Config) (*harness, error) {
+	chainCtx, err := newContext(config)
+	if err != nil {
+		return nil, err
+	}
+	return &harness{
+		config:    config,
+		ctx:       chainCtx,
+		dbManager: manager.NewMemDB(version.Semantic1_0_0),
+		toEngine:  make(chan common.Message, 1),
+		exited:    make(chan struct{}),
+	}, nil
+}
+
+// start launches the plugin and initializes it with the databases of the
+// harness.
+//
+// Assumes [h.ctx.Lock] is held.
+func (h *harness) start(ctx context.Context) error {
+	h.runtimes = runtime.NewManager()
+	// The metrics of the previous instance are still registered.
+	h.ctx.Metrics = metrics.NewOptionalGatherer()
+
+	factory := rpcchainvm.NewUnsupervisedFactory(
+		h.config.PluginPath,
+		noProcessTracker{},
+		h.runtimes,
+		h.onExit,
+	)
+	vmIntf, err := factory.New(h.config.Log)
+	if err != nil {
+		return fmt.Errorf("failed to launch plugin: %w", err)
+	}
+	vm, ok := vmIntf.(block.ChainVM)
+	if !ok {
+		h.runtimes.Stop(ctx)
+		return errNotChainVM
+	}
+
+	err = vm.Initialize(
+		ctx,
+		h.ctx,
+		h.dbManager,
+		h.config.Genesis,
+		h.config.Upgrade,
+		h.config.Config,
+		h.toEngine,
+		nil,
+		appSender{},
+	)
+	if err != nil {
+		h.runtimes.Stop(ctx)
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	h.vm = vm
+	return nil
+}
+
+// restart shuts down the VM and starts a new instance of the plugin with the
+// same databases.
+//
+// Assumes [h.ctx.Lock] is held.
+func (h *harness) restart(ctx context.Context) error {
+	if err := h.shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown: %w", err)
+	}
+	return h.start(ctx)
+}
+
+// newContext returns a chain context for a chain on a new subnet that is
+// only validated by this node.
+func newContext(config *Config) (*snow.Context, error) {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+		baseDB   = memdb.New()
+		aliaser  = ids.NewAliaser()
+	)
+	if err := aliaser.Alias(chainID, chainID.String()); err != nil {
+		return nil, err
+	}
+
+	keystoreDB := manager.NewMemDB(version.Semantic1_0_0)
+	ks := keystore.New(config.Log, keystoreDB)
+	memory := atomic.NewMemory(prefixdb.New([]byte("atomic"), baseDB))
+
+	return &snow.Context{
+		NetworkID:    constants.LocalID,
+		SubnetID:     subnetID,
+		ChainID:      chainID,
+		NodeID:       nodeID,
+		PublicKey:    bls.PublicFromSecretKey(sk),
+		XChainID:     ids.GenerateTestID(),
+		CChainID:     ids.GenerateTestID(),
+		AVAXAssetID:  ids.GenerateTestID(),
+		Log:          config.Log,
+		Keystore:     ks.NewBlockchainKeyStore(chainID),
+		SharedMemory: memory.NewSharedMemory(chainID),
+		BCLookup:     aliaser,
+		WarpSigner:   warp.NewSigner(sk, chainID),
+		ValidatorState: &validatorState{
+			subnetID: subnetID,
+			validators: map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(sk),
+					Weight:    1,
+				},
+			},
+		},
+	}, nil
+}
+
+// onExit is called when a process of the plugin exits before the VM is
+// shutdown.
+func (h *harness) onExit() {
+	h.exitOnce.Do(func() {
+		close(h.exited)
+	})
+}
+
+// processExited returns true if a process of the plugin exited before the VM
+// was shutdown.
+func (h *harness) processExited() bool {
+	select {
+	case <-h.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown shuts down the VM and ensures that the plugin has exited.
+//
+// Assumes [h.ctx.Lock] is held.
+func (h *harness) shutdown(ctx context.Context) error {
+	err := h.vm.Shutdown(ctx)
+	h.runtimes.Stop(ctx)
+	h.vm = nil
+	return err
+}
+
+type noProcessTracker struct{}
+
+func (noProcessTracker) TrackProcess(int) {}
+
+func (noProcessTracker) UntrackProcess(int) {}
+
+// appSender drops all the messages sent by the VM.
+type appSender struct{}
+
+func (appSender) SendAppRequest(context.Context, set.Set[ids.NodeID], uint32, []byte) error {
+	return nil
+}
+
+func (appSender) SendAppResponse(context.Context, ids.NodeID, uint32, []byte) error {
+	return nil
+}
+
+func (appSender) SendAppGossip(context.Context, []byte) error {
+	return nil
+}
+
+func (appSender) SendAppGossipSpecific(context.Context, set.Set[ids.NodeID], []byte) error {
+	return nil
+}
+
+func (appSender) SendCrossChainAppRequest(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+func (appSender) SendCrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+// validatorState reports a fixed P-chain height and validator set.
+type validatorState struct {
+	subnetID   ids.ID
+	validators map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (*validatorState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (*validatorState) GetCurrentHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (s *validatorState) GetSubnetID(context.Context, ids.ID) (ids.ID, error) {
+	return s.subnetID, nil
+}
+
+func (s *validatorState) GetValidatorSet(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.validators, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/conformance"
+)
+
+const (
+	pluginPathKey  = "plugin-path"
+	genesisFileKey = "genesis-file"
+	upgradeFileKey = "upgrade-file"
+	configFileKey  = "config-file"
+	testsKey       = "tests"
+	timeoutKey     = "timeout"
+	logLevelKey    = "log-level"
+	jsonKey        = "json"
+)
+
+var errNoPluginPath = errors.New("--plugin-path must be provided")
+
+func main() {
+	fs := pflag.NewFlagSet("rpcchainvm-conformance", pflag.ContinueOnError)
+	fs.String(pluginPathKey, "", "Path of the plugin binary to test")
+	fs.String(genesisFileKey, "", "Path of the genesis the VM is initialized with")
+	fs.String(upgradeFileKey, "", "Path of the upgrade bytes the VM is initialized with")
+	fs.String(configFileKey, "", "Path of the config the VM is initialized with")
+	fs.StringSlice(testsKey, nil, fmt.Sprintf("Comma separated tests to run. If empty, all tests are run. Tests: %s", strings.Join(conformance.TestNames(), ", ")))
+	fs.Duration(timeoutKey, conformance.DefaultTimeout, "Maximum duration of each test")
+	fs.String(logLevelKey, logging.Off.String(), "Level of the logs of the VM")
+	fs.Bool(jsonKey, false, "If true, the report is printed as JSON")
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+
+	config, err := getConfig(fs)
+	if err != nil {
+		fmt.Printf("couldn't load config: %s\n", err)
+		os.Exit(1)
+	}
+
+	report, err := conformance.Run(context.Background(), config)
+	if err != nil {
+		fmt.Printf("couldn't run tests: %s\n", err)
+		os.Exit(1)
+	}
+
+	printJSON, _ := fs.GetBool(jsonKey)
+	if !printJSON {
+		fmt.Println(report)
+	} else {
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Printf("couldn't marshal report: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(reportJSON))
+	}
+
+	if !report.Passed() {
+		os.Exit(1)
+	}
+}
+
+func getConfig(fs *pflag.FlagSet) (conformance.Config, error) {
+	config := conformance.Config{}
+	config.PluginPath, _ = fs.GetString(pluginPathKey)
+	if config.PluginPath == "" {
+		return conformance.Config{}, errNoPluginPath
+	}
+
+	var err error
+	config.Genesis, err = readFile(fs, genesisFileKey)
+	if err != nil {
+		return conformance.Config{}, err
+	}
+	config.Upgrade, err = readFile(fs, upgradeFileKey)
+	if err != nil {
+		return conformance.Config{}, err
+	}
+	config.Config, err = readFile(fs, configFileKey)
+	if err != nil {
+		return conformance.Config{}, err
+	}
+
+	config.Tests, _ = fs.GetStringSlice(testsKey)
+	config.Timeout, _ = fs.GetDuration(timeoutKey)
+
+	logLevelStr, _ := fs.GetString(logLevelKey)
+	logLevel, err := logging.ToLevel(logLevelStr)
+	if err != nil {
+		return conformance.Config{}, err
+	}
+	config.Log = logging.NewLogger(
+		"",
+		logging.NewWrappedCore(logLevel, os.Stderr, logging.Colors.ConsoleEncoder()),
+	)
+	return config, nil
+}
+
+// readFile returns the contents of the file at the path given by [key], or
+// nil if the path wasn't provided.
+func readFile(fs *pflag.FlagSet, key string) ([]byte, error) {
+	path, _ := fs.GetString(key)
+	if path == "" {
+		return nil, nil
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read --%s: %w", key, err)
+	}
+	return bytes, nil
+}
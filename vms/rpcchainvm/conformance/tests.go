@@ -0,0 +1,697 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+// DefaultTimeout is the default maximum duration of each test.
+const DefaultTimeout = time.Minute
+
+var (
+	errUnexpectedStatus       = errors.New("unexpected status")
+	errUnexpectedBlock        = errors.New("unexpected block")
+	errUnexpectedLastAccepted = errors.New("unexpected last accepted block")
+	errUnexpectedParent       = errors.New("unexpected parent")
+	errUnexpectedHeight       = errors.New("unexpected height")
+	errUnexpectedTimestamp    = errors.New("unexpected timestamp")
+	errUnexpectedSummary      = errors.New("unexpected state summary")
+	errUnexpectedAncestors    = errors.New("unexpected ancestors")
+	errParsedInvalidBytes     = errors.New("parsed invalid bytes")
+	errMissingHandler         = errors.New("missing handler")
+	errNotUpgradable          = errors.New("vm can't be upgraded")
+
+	// invalidBytes are expected to not be a valid block or state summary.
+	invalidBytes = []byte{0xde, 0xad, 0xbe, 0xef}
+
+	tests = []test{
+		{name: "initialize", run: testInitialize},
+		{name: "set_state", run: testSetState},
+		{name: "health", run: testHealth},
+		{name: "handlers", run: testHandlers},
+		{name: "parse_block", run: testParseBlock},
+		{name: "build_accept", run: testBuildAccept},
+		{name: "reorg", run: testReorg},
+		{name: "get_ancestors", run: testGetAncestors},
+		{name: "height_index", run: testHeightIndex},
+		{name: "state_sync", run: testStateSync},
+		{name: "app_messaging", run: testAppMessaging},
+		{name: "upgrade", run: testUpgrade},
+	}
+)
+
+type test struct {
+	name string
+	run  func(ctx context.Context, h *harness) error
+}
+
+// skipError is returned by tests of features that the VM doesn't support.
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return "skipped: " + e.reason
+}
+
+func skip(format string, args ...interface{}) error {
+	return &skipError{
+		reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// testInitialize checks that the VM starts from an accepted block and that it
+// resumes from the same block once restarted.
+func testInitialize(ctx context.Context, h *harness) error {
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	if _, err := h.vm.Version(ctx); err != nil {
+		return fmt.Errorf("failed to get version: %w", err)
+	}
+
+	if err := h.restart(ctx); err != nil {
+		return err
+	}
+	return checkLastAccepted(ctx, h, lastAccepted.ID())
+}
+
+// testSetState checks the state transitions performed by the engine, both
+// before and after the VM is restarted.
+func testSetState(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+
+	if err := h.restart(ctx); err != nil {
+		return err
+	}
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	return checkLastAccepted(ctx, h, lastAccepted.ID())
+}
+
+func testHealth(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	if _, err := h.vm.HealthCheck(ctx); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
+
+func testHandlers(ctx context.Context, h *harness) error {
+	staticHandlers, err := h.vm.CreateStaticHandlers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create static handlers: %w", err)
+	}
+	for endpoint, handler := range staticHandlers {
+		if handler == nil || handler.Handler == nil {
+			return fmt.Errorf("%w: static endpoint %q", errMissingHandler, endpoint)
+		}
+	}
+
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	handlers, err := h.vm.CreateHandlers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create handlers: %w", err)
+	}
+	for endpoint, handler := range handlers {
+		if handler == nil || handler.Handler == nil {
+			return fmt.Errorf("%w: endpoint %q", errMissingHandler, endpoint)
+		}
+	}
+	return nil
+}
+
+// testParseBlock checks that blocks are parsed into the same block by a new
+// instance of the VM.
+func testParseBlock(ctx context.Context, h *harness) error {
+	if _, err := h.vm.ParseBlock(ctx, invalidBytes); err == nil {
+		return fmt.Errorf("%w: %x", errParsedInvalidBytes, invalidBytes)
+	}
+
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	blk, err := buildBlock(ctx, h, lastAccepted)
+	if err != nil {
+		return err
+	}
+
+	if err := h.restart(ctx); err != nil {
+		return err
+	}
+
+	parsedLastAccepted, err := h.vm.ParseBlock(ctx, lastAccepted.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to parse last accepted block: %w", err)
+	}
+	if err := checkBlock(parsedLastAccepted, lastAccepted, choices.Accepted); err != nil {
+		return err
+	}
+
+	parsedBlk, err := h.vm.ParseBlock(ctx, blk.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to parse built block: %w", err)
+	}
+	return checkBlock(parsedBlk, blk, choices.Processing)
+}
+
+// testBuildAccept checks that an accepted block is persisted as the last
+// accepted block.
+func testBuildAccept(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	blk, err := buildBlock(ctx, h, lastAccepted)
+	if err != nil {
+		return err
+	}
+	if err := acceptBlock(ctx, h, blk); err != nil {
+		return err
+	}
+	if err := checkLastAccepted(ctx, h, blk.ID()); err != nil {
+		return err
+	}
+
+	if err := h.restart(ctx); err != nil {
+		return err
+	}
+	if err := checkLastAccepted(ctx, h, blk.ID()); err != nil {
+		return err
+	}
+	persistedBlk, err := h.vm.GetBlock(ctx, blk.ID())
+	if err != nil {
+		return fmt.Errorf("failed to get accepted block: %w", err)
+	}
+	return checkBlock(persistedBlk, blk, choices.Accepted)
+}
+
+// testReorg checks that a processing chain of blocks can be rejected and that
+// the VM then builds on the last accepted block.
+func testReorg(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+
+	// Build a chain of processing blocks on top of the last accepted block.
+	parent, err := buildBlock(ctx, h, lastAccepted)
+	if err != nil {
+		return err
+	}
+	if err := verifyBlock(ctx, h, parent); err != nil {
+		return err
+	}
+	processing := []snowman.Block{parent}
+	if child, err := h.vm.BuildBlock(ctx); err == nil {
+		if err := checkChild(child, parent); err != nil {
+			return err
+		}
+		if err := verifyBlock(ctx, h, child); err != nil {
+			return err
+		}
+		processing = append(processing, child)
+	}
+
+	// Reject the chain, as consensus would once a conflicting block is
+	// accepted.
+	if err := h.vm.SetPreference(ctx, lastAccepted.ID()); err != nil {
+		return fmt.Errorf("failed to set preference: %w", err)
+	}
+	for _, blk := range processing {
+		if err := blk.Reject(ctx); err != nil {
+			return fmt.Errorf("failed to reject block %s: %w", blk.ID(), err)
+		}
+		if status := blk.Status(); status != choices.Rejected {
+			return fmt.Errorf("%w of rejected block %s: %s", errUnexpectedStatus, blk.ID(), status)
+		}
+	}
+	if err := checkLastAccepted(ctx, h, lastAccepted.ID()); err != nil {
+		return err
+	}
+
+	// The next block must be built on the last accepted block.
+	if blk, err := h.vm.BuildBlock(ctx); err == nil {
+		if err := checkChild(blk, lastAccepted); err != nil {
+			return err
+		}
+		if err := acceptBlock(ctx, h, blk); err != nil {
+			return err
+		}
+		lastAccepted = blk
+	}
+
+	if err := h.restart(ctx); err != nil {
+		return err
+	}
+	if err := checkLastAccepted(ctx, h, lastAccepted.ID()); err != nil {
+		return err
+	}
+	for _, blk := range processing {
+		// A deterministic VM may rebuild a rejected block once its parent is
+		// preferred again.
+		if blk.ID() == lastAccepted.ID() {
+			continue
+		}
+		rejectedBlk, err := h.vm.GetBlock(ctx, blk.ID())
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get rejected block %s: %w", blk.ID(), err)
+		}
+		if status := rejectedBlk.Status(); status == choices.Accepted {
+			return fmt.Errorf("%w of rejected block %s: %s", errUnexpectedStatus, blk.ID(), status)
+		}
+	}
+	return nil
+}
+
+// testGetAncestors checks that the ancestors of a block are returned in order
+// and can be parsed in a batch.
+func testGetAncestors(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		blk, err := h.vm.BuildBlock(ctx)
+		if err != nil {
+			break
+		}
+		if err := checkChild(blk, lastAccepted); err != nil {
+			return err
+		}
+		if err := acceptBlock(ctx, h, blk); err != nil {
+			return err
+		}
+		lastAccepted = blk
+	}
+
+	ancestors, err := block.GetAncestors(
+		ctx,
+		h.ctx.Log,
+		h.vm,
+		lastAccepted.ID(),
+		constants.MaxContainersLen,
+		constants.MaxContainersLen,
+		time.Second,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get ancestors: %w", err)
+	}
+	if len(ancestors) == 0 {
+		return fmt.Errorf("%w: no ancestors of the last accepted block", errUnexpectedAncestors)
+	}
+
+	blks, err := block.BatchedParseBlock(ctx, h.vm, ancestors)
+	if err != nil {
+		return fmt.Errorf("failed to parse ancestors: %w", err)
+	}
+	if len(blks) != len(ancestors) {
+		return fmt.Errorf("%w: parsed %d blocks from %d ancestors", errUnexpectedAncestors, len(blks), len(ancestors))
+	}
+	if blks[0].ID() != lastAccepted.ID() {
+		return fmt.Errorf("%w: first ancestor is %s rather than %s", errUnexpectedAncestors, blks[0].ID(), lastAccepted.ID())
+	}
+	for i := 1; i < len(blks); i++ {
+		if err := checkChild(blks[i-1], blks[i]); err != nil {
+			return fmt.Errorf("%w: %v", errUnexpectedAncestors, err)
+		}
+	}
+	return nil
+}
+
+// testHeightIndex checks that accepted blocks can be looked up by height.
+func testHeightIndex(ctx context.Context, h *harness) error {
+	vm, ok := h.vm.(block.HeightIndexedChainVM)
+	if !ok {
+		return skip("height index isn't implemented")
+	}
+	err := vm.VerifyHeightIndex(ctx)
+	switch {
+	case errors.Is(err, block.ErrHeightIndexedVMNotImplemented):
+		return skip("height index isn't implemented")
+	case errors.Is(err, block.ErrIndexIncomplete):
+		return skip("height index is incomplete")
+	case err != nil:
+		return fmt.Errorf("failed to verify height index: %w", err)
+	}
+
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	if blk, err := h.vm.BuildBlock(ctx); err == nil {
+		if err := checkChild(blk, lastAccepted); err != nil {
+			return err
+		}
+		if err := acceptBlock(ctx, h, blk); err != nil {
+			return err
+		}
+		lastAccepted = blk
+	}
+
+	height := lastAccepted.Height()
+	blkID, err := vm.GetBlockIDAtHeight(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to get block at height %d: %w", height, err)
+	}
+	if blkID != lastAccepted.ID() {
+		return fmt.Errorf("%w at height %d: %s rather than %s", errUnexpectedBlock, height, blkID, lastAccepted.ID())
+	}
+	if _, err := vm.GetBlockIDAtHeight(ctx, height+1); !errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w at height %d: expected %v but got %v", errUnexpectedBlock, height+1, database.ErrNotFound, err)
+	}
+	return nil
+}
+
+// testStateSync checks that state summaries are consistently served.
+func testStateSync(ctx context.Context, h *harness) error {
+	vm, ok := h.vm.(block.StateSyncableVM)
+	if !ok {
+		return skip("state sync isn't implemented")
+	}
+	enabled, err := vm.StateSyncEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if state sync is enabled: %w", err)
+	}
+	if !enabled {
+		return skip("state sync isn't enabled")
+	}
+
+	if _, err := vm.ParseStateSummary(ctx, invalidBytes); err == nil {
+		return fmt.Errorf("%w: %x", errParsedInvalidBytes, invalidBytes)
+	}
+	if _, err := vm.GetOngoingSyncStateSummary(ctx); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("failed to get ongoing state summary: %w", err)
+	}
+
+	summary, err := vm.GetLastStateSummary(ctx)
+	if errors.Is(err, database.ErrNotFound) {
+		return skip("no state summary is available")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get last state summary: %w", err)
+	}
+
+	parsedSummary, err := vm.ParseStateSummary(ctx, summary.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to parse state summary: %w", err)
+	}
+	if err := checkSummary(parsedSummary, summary); err != nil {
+		return err
+	}
+
+	summaryAtHeight, err := vm.GetStateSummary(ctx, summary.Height())
+	if err != nil {
+		return fmt.Errorf("failed to get state summary at height %d: %w", summary.Height(), err)
+	}
+	return checkSummary(summaryAtHeight, summary)
+}
+
+// testAppMessaging checks that unexpected messages from peers and other
+// chains aren't treated as fatal by the VM.
+func testAppMessaging(ctx context.Context, h *harness) error {
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+
+	var (
+		nodeID   = ids.GenerateTestNodeID()
+		chainID  = ids.GenerateTestID()
+		deadline = time.Now().Add(time.Minute)
+	)
+	if err := h.vm.Connected(ctx, nodeID, version.CurrentApp); err != nil {
+		return fmt.Errorf("failed to connect peer: %w", err)
+	}
+	steps := []struct {
+		name string
+		send func() error
+	}{
+		{
+			name: "AppRequest",
+			send: func() error {
+				return h.vm.AppRequest(ctx, nodeID, 1, deadline, invalidBytes)
+			},
+		},
+		{
+			name: "AppResponse",
+			send: func() error {
+				return h.vm.AppResponse(ctx, nodeID, 2, invalidBytes)
+			},
+		},
+		{
+			name: "AppRequestFailed",
+			send: func() error {
+				return h.vm.AppRequestFailed(ctx, nodeID, 3)
+			},
+		},
+		{
+			name: "AppGossip",
+			send: func() error {
+				return h.vm.AppGossip(ctx, nodeID, invalidBytes)
+			},
+		},
+		{
+			name: "CrossChainAppRequest",
+			send: func() error {
+				return h.vm.CrossChainAppRequest(ctx, chainID, 4, deadline, invalidBytes)
+			},
+		},
+		{
+			name: "CrossChainAppResponse",
+			send: func() error {
+				return h.vm.CrossChainAppResponse(ctx, chainID, 5, invalidBytes)
+			},
+		},
+		{
+			name: "CrossChainAppRequestFailed",
+			send: func() error {
+				return h.vm.CrossChainAppRequestFailed(ctx, chainID, 6)
+			},
+		},
+	}
+	for _, step := range steps {
+		if err := step.send(); err != nil {
+			return fmt.Errorf("%s failed: %w", step.name, err)
+		}
+	}
+	if err := h.vm.Disconnected(ctx, nodeID); err != nil {
+		return fmt.Errorf("failed to disconnect peer: %w", err)
+	}
+
+	if _, err := h.vm.HealthCheck(ctx); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
+
+// testUpgrade checks that the VM resumes when its process is replaced while a
+// block is processing, as happens when the VM is upgraded.
+func testUpgrade(ctx context.Context, h *harness) error {
+	upgrader, ok := h.vm.(vms.Upgrader)
+	if !ok {
+		return errNotUpgradable
+	}
+
+	if err := startNormalOp(ctx, h); err != nil {
+		return err
+	}
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	blk, err := buildBlock(ctx, h, lastAccepted)
+	if err != nil {
+		return err
+	}
+	if err := verifyBlock(ctx, h, blk); err != nil {
+		return err
+	}
+
+	// As with the admin API, the VM is upgraded without holding the context
+	// lock, which is taken by the upgrade itself.
+	h.ctx.Lock.Unlock()
+	err = upgrader.Upgrade(ctx)
+	h.ctx.Lock.Lock()
+	if err != nil {
+		return fmt.Errorf("failed to upgrade: %w", err)
+	}
+	if err := checkLastAccepted(ctx, h, lastAccepted.ID()); err != nil {
+		return err
+	}
+	if _, err := h.vm.HealthCheck(ctx); err != nil {
+		return fmt.Errorf("health check failed after upgrade: %w", err)
+	}
+
+	// The processing block must have been verified by the new process.
+	if err := acceptBlock(ctx, h, blk); err != nil {
+		return err
+	}
+	return checkLastAccepted(ctx, h, blk.ID())
+}
+
+// startNormalOp transitions the VM through the states of a bootstrapping
+// chain.
+func startNormalOp(ctx context.Context, h *harness) error {
+	for _, state := range []snow.State{snow.Bootstrapping, snow.NormalOp} {
+		if err := h.vm.SetState(ctx, state); err != nil {
+			return fmt.Errorf("failed to set state to %s: %w", state, err)
+		}
+	}
+	return nil
+}
+
+func getLastAccepted(ctx context.Context, h *harness) (snowman.Block, error) {
+	lastAcceptedID, err := h.vm.LastAccepted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block ID: %w", err)
+	}
+	lastAccepted, err := h.vm.GetBlock(ctx, lastAcceptedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+	if status := lastAccepted.Status(); status != choices.Accepted {
+		return nil, fmt.Errorf("%w of last accepted block: %s", errUnexpectedStatus, status)
+	}
+	return lastAccepted, nil
+}
+
+func checkLastAccepted(ctx context.Context, h *harness, expectedID ids.ID) error {
+	lastAccepted, err := getLastAccepted(ctx, h)
+	if err != nil {
+		return err
+	}
+	if lastAccepted.ID() != expectedID {
+		return fmt.Errorf("%w: %s rather than %s", errUnexpectedLastAccepted, lastAccepted.ID(), expectedID)
+	}
+	return nil
+}
+
+// buildBlock builds a block on top of [parent]. If the VM doesn't build a
+// block, the test is skipped.
+func buildBlock(ctx context.Context, h *harness, parent snowman.Block) (snowman.Block, error) {
+	blk, err := h.vm.BuildBlock(ctx)
+	if err != nil {
+		return nil, skip("VM didn't build a block: %v", err)
+	}
+	if err := checkChild(blk, parent); err != nil {
+		return nil, err
+	}
+	if status := blk.Status(); status != choices.Processing {
+		return nil, fmt.Errorf("%w of built block: %s", errUnexpectedStatus, status)
+	}
+	return blk, nil
+}
+
+func verifyBlock(ctx context.Context, h *harness, blk snowman.Block) error {
+	if err := blk.Verify(ctx); err != nil {
+		return fmt.Errorf("failed to verify block %s: %w", blk.ID(), err)
+	}
+	if err := h.vm.SetPreference(ctx, blk.ID()); err != nil {
+		return fmt.Errorf("failed to set preference: %w", err)
+	}
+	return nil
+}
+
+func acceptBlock(ctx context.Context, h *harness, blk snowman.Block) error {
+	if err := verifyBlock(ctx, h, blk); err != nil {
+		return err
+	}
+	if err := blk.Accept(ctx); err != nil {
+		return fmt.Errorf("failed to accept block %s: %w", blk.ID(), err)
+	}
+	if status := blk.Status(); status != choices.Accepted {
+		return fmt.Errorf("%w of accepted block %s: %s", errUnexpectedStatus, blk.ID(), status)
+	}
+	return nil
+}
+
+// checkChild checks that [blk] is a child of [parent].
+func checkChild(blk snowman.Block, parent snowman.Block) error {
+	if blk.Parent() != parent.ID() {
+		return fmt.Errorf("%w of block %s: %s rather than %s", errUnexpectedParent, blk.ID(), blk.Parent(), parent.ID())
+	}
+	if blk.Height() != parent.Height()+1 {
+		return fmt.Errorf("%w of block %s: %d rather than %d", errUnexpectedHeight, blk.ID(), blk.Height(), parent.Height()+1)
+	}
+	if blk.Timestamp().Before(parent.Timestamp()) {
+		return fmt.Errorf("%w of block %s: %s is before its parent's %s", errUnexpectedTimestamp, blk.ID(), blk.Timestamp(), parent.Timestamp())
+	}
+	return nil
+}
+
+// checkBlock checks that [blk] is the same block as [expected] and has the
+// [expectedStatus].
+func checkBlock(blk snowman.Block, expected snowman.Block, expectedStatus choices.Status) error {
+	switch {
+	case blk.ID() != expected.ID():
+		return fmt.Errorf("%w: %s rather than %s", errUnexpectedBlock, blk.ID(), expected.ID())
+	case blk.Parent() != expected.Parent():
+		return fmt.Errorf("%w of block %s: %s rather than %s", errUnexpectedParent, blk.ID(), blk.Parent(), expected.Parent())
+	case blk.Height() != expected.Height():
+		return fmt.Errorf("%w of block %s: %d rather than %d", errUnexpectedHeight, blk.ID(), blk.Height(), expected.Height())
+	case !blk.Timestamp().Equal(expected.Timestamp()):
+		return fmt.Errorf("%w of block %s: %s rather than %s", errUnexpectedTimestamp, blk.ID(), blk.Timestamp(), expected.Timestamp())
+	case blk.Status() != expectedStatus:
+		return fmt.Errorf("%w of block %s: %s rather than %s", errUnexpectedStatus, blk.ID(), blk.Status(), expectedStatus)
+	}
+	return nil
+}
+
+func checkSummary(summary block.StateSummary, expected block.StateSummary) error {
+	if summary.ID() != expected.ID() || summary.Height() != expected.Height() {
+		return fmt.Errorf("%w: %s at height %d rather than %s at height %d",
+			errUnexpectedSummary,
+			summary.ID(),
+			summary.Height(),
+			expected.ID(),
+			expected.Height(),
+		)
+	}
+	return nil
+}
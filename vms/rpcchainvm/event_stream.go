@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/events"
+
+	eventspb "github.com/ava-labs/avalanchego/proto/pb/vm/events"
+)
+
+// eventsEndpoint is the endpoint that the logs published by the VM are served
+// from, unless the VM serves its own handler at this endpoint.
+const eventsEndpoint = "/events"
+
+// eventsRetryDelay is the duration to wait before resubscribing to the events
+// of the VM process after its event stream failed.
+const eventsRetryDelay = time.Second
+
+var (
+	errEventStream = errors.New("event stream failed")
+
+	eventsHeightKey = []byte("eventsHeight")
+)
+
+// StreamTxs causes the transactions of the blocks accepted by the VM to be
+// passed to the TxAcceptor of [ctx]. The height of the last block whose
+// transactions were passed is persisted in [db]. If the node was restarted,
+// the events of the VM process are resubscribed to from that height, so the
+// transactions of the blocks accepted while the node was down are passed too.
+// Returns false if the VM didn't publish events when it was initialized.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) StreamTxs(ctx *snow.ConsensusContext, db database.Database) (bool, error) {
+	if !vm.publishesEvents {
+		return false, nil
+	}
+
+	height, err := database.GetUInt64(db, eventsHeightKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		// The transactions of the VM were never streamed, so they are
+		// streamed from the last accepted block.
+		height = vm.eventsHeight.Get()
+	case err != nil:
+		return false, err
+	}
+
+	vm.txAcceptor.Set(&txAcceptor{
+		ctx: ctx,
+		db:  db,
+	})
+	if height >= vm.eventsHeight.Get() {
+		return true, nil
+	}
+
+	// The events of the blocks accepted after [height] were forwarded before
+	// their transactions were streamed, so they are replayed.
+	vm.closeEvents()
+	vm.eventsHeight.Set(height)
+	_, err = vm.subscribeEvents()
+	return true, err
+}
+
+// subscribeEvents starts forwarding the events published by the current VM
+// process, starting with the events of the blocks accepted after the last
+// block whose events were forwarded. Returns false if the VM process doesn't
+// publish events.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) subscribeEvents() (bool, error) {
+	if vm.clientConn == nil {
+		return false, nil
+	}
+
+	// The stream outlives the request that created it, so it's only closed
+	// once the VM process is replaced or the VM is shutdown.
+	ctx, cancel := context.WithCancel(context.Background())
+	client := events.NewClient(eventspb.NewEventsClient(vm.clientConn))
+	stream, err := client.Subscribe(ctx, vm.eventsHeight.Get())
+	if err != nil {
+		cancel()
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	vm.cancelEvents = cancel
+	go vm.forwardEvents(ctx, client, stream)
+	return true, nil
+}
+
+// closeEvents stops forwarding the events published by the current VM
+// process.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) closeEvents() {
+	if vm.cancelEvents != nil {
+		vm.cancelEvents()
+		vm.cancelEvents = nil
+	}
+}
+
+// forwardEvents forwards the events received from [stream] until [ctx] is
+// cancelled. If the stream fails, or an event fails to be forwarded, [client]
+// is resubscribed to from the last block whose events were forwarded.
+func (vm *VMClient) forwardEvents(ctx context.Context, client *events.Client, stream *events.Stream) {
+	for {
+		err := vm.forwardStream(stream)
+		if ctx.Err() != nil {
+			return
+		}
+		vm.eventsErr.Set(err)
+		vm.chainCtx.Log.Error("failed to forward events",
+			zap.Uint64("height", vm.eventsHeight.Get()),
+			zap.Error(err),
+		)
+
+		stream, err = vm.resubscribeEvents(ctx, client)
+		if err != nil {
+			return
+		}
+		vm.eventsErr.Set(nil)
+	}
+}
+
+// resubscribeEvents subscribes to the events of [client], from the last block
+// whose events were forwarded, until it succeeds or [ctx] is cancelled.
+func (vm *VMClient) resubscribeEvents(ctx context.Context, client *events.Client) (*events.Stream, error) {
+	for {
+		timer := time.NewTimer(eventsRetryDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		stream, err := client.Subscribe(ctx, vm.eventsHeight.Get())
+		if err == nil {
+			return stream, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		vm.eventsErr.Set(err)
+		vm.chainCtx.Log.Warn("failed to resubscribe to events",
+			zap.Error(err),
+		)
+	}
+}
+
+// forwardStream forwards the events received from [stream] until the stream
+// fails or an event fails to be forwarded.
+func (vm *VMClient) forwardStream(stream *events.Stream) error {
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := vm.forwardEvent(event); err != nil {
+			return err
+		}
+	}
+}
+
+// txAcceptor is where the transactions of accepted blocks are streamed to.
+type txAcceptor struct {
+	// ctx is the context whose TxAcceptor is notified of the transactions.
+	ctx *snow.ConsensusContext
+	// db persists the height of the last block whose transactions were
+	// passed to the TxAcceptor of [ctx].
+	db database.Database
+}
+
+// forwardEvent forwards [event]. Once all the transactions of an accepted
+// block have been passed to the TxAcceptor, the events of the block aren't
+// replayed when the VM process is resubscribed to, nor after the node is
+// restarted.
+func (vm *VMClient) forwardEvent(event events.Event) error {
+	if event.Log != nil {
+		vm.pubsub.Publish(events.NewPubSubFilterer(event.Log))
+		return nil
+	}
+
+	blk := event.BlockAccepted
+	if acceptor := vm.txAcceptor.Get(); acceptor != nil {
+		ctx := acceptor.ctx
+		for _, tx := range blk.Txs {
+			if err := ctx.TxAcceptor.Accept(ctx, tx.ID, tx.Bytes); err != nil {
+				return fmt.Errorf("failed to accept tx %s of block %s: %w", tx.ID, blk.ID, err)
+			}
+		}
+		if err := database.PutUInt64(acceptor.db, eventsHeightKey, blk.Height); err != nil {
+			return fmt.Errorf("failed to persist the events height %d: %w", blk.Height, err)
+		}
+	}
+	vm.eventsHeight.Set(blk.Height)
+	return nil
+}
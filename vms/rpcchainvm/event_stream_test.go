@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/events"
+)
+
+func TestForwardEventTxs(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	acceptor := snow.NewAcceptorTracker()
+	ctx.TxAcceptor = acceptor
+
+	vm := &VMClient{
+		chainCtx: ctx.Context,
+	}
+	db := memdb.New()
+	streamed, err := vm.StreamTxs(ctx, db)
+	require.NoError(err)
+	require.False(streamed)
+
+	tx0 := events.Tx{ID: ids.GenerateTestID()}
+	tx1 := events.Tx{ID: ids.GenerateTestID()}
+	event := events.Event{
+		BlockAccepted: &events.BlockAccepted{
+			ID:     ids.GenerateTestID(),
+			Height: 1,
+			Txs:    []events.Tx{tx0, tx1},
+		},
+	}
+
+	// Transactions are dropped until the VM is asked to stream them.
+	require.NoError(vm.forwardEvent(event))
+	_, accepted := acceptor.IsAccepted(tx0.ID)
+	require.False(accepted)
+
+	vm.publishesEvents = true
+	streamed, err = vm.StreamTxs(ctx, db)
+	require.NoError(err)
+	require.True(streamed)
+	require.NoError(vm.forwardEvent(event))
+
+	for _, tx := range []events.Tx{tx0, tx1} {
+		count, accepted := acceptor.IsAccepted(tx.ID)
+		require.True(accepted)
+		require.Equal(1, count)
+	}
+
+	height, err := database.GetUInt64(db, eventsHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+}
+
+func TestStreamTxsResumesFromPersistedHeight(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	db := memdb.New()
+	require.NoError(database.PutUInt64(db, eventsHeightKey, 3))
+
+	// The VM was initialized at a later height than the last block whose
+	// transactions were streamed before the node was restarted.
+	vm := &VMClient{
+		chainCtx:        ctx.Context,
+		publishesEvents: true,
+	}
+	vm.eventsHeight.Set(5)
+
+	streamed, err := vm.StreamTxs(ctx, db)
+	require.NoError(err)
+	require.True(streamed)
+	require.Equal(uint64(3), vm.eventsHeight.Get())
+
+	// Without a persisted height, the transactions are streamed from the
+	// height the VM was initialized at.
+	vm.eventsHeight.Set(5)
+	streamed, err = vm.StreamTxs(ctx, memdb.New())
+	require.NoError(err)
+	require.True(streamed)
+	require.Equal(uint64(5), vm.eventsHeight.Get())
+}
+
+type failingAcceptor struct {
+	err error
+}
+
+func (a failingAcceptor) Accept(*snow.ConsensusContext, ids.ID, []byte) error {
+	return a.err
+}
+
+func TestForwardEventTxsFailure(t *testing.T) {
+	require := require.New(t)
+
+	errTest := errors.New("non-nil error")
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.TxAcceptor = failingAcceptor{err: errTest}
+
+	vm := &VMClient{
+		chainCtx:        ctx.Context,
+		publishesEvents: true,
+	}
+	vm.eventsHeight.Set(1)
+	streamed, err := vm.StreamTxs(ctx, memdb.New())
+	require.NoError(err)
+	require.True(streamed)
+
+	event := events.Event{
+		BlockAccepted: &events.BlockAccepted{
+			ID:     ids.GenerateTestID(),
+			Height: 2,
+			Txs:    []events.Tx{{ID: ids.GenerateTestID()}},
+		},
+	}
+	err = vm.forwardEvent(event)
+	require.ErrorIs(err, errTest)
+
+	// The events of the block are replayed once the events are resubscribed
+	// to.
+	require.Equal(uint64(1), vm.eventsHeight.Get())
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	eventspb "github.com/ava-labs/avalanchego/proto/pb/vm/events"
+)
+
+var (
+	errUnknownEvent  = errors.New("unknown event")
+	errNotSubscribed = errors.New("stream wasn't subscribed")
+)
+
+// Client subscribes to the events published by a VM over RPC.
+type Client struct {
+	client eventspb.EventsClient
+}
+
+// NewClient returns a client that subscribes to the events streamed by
+// [client].
+func NewClient(client eventspb.EventsClient) *Client {
+	return &Client{client: client}
+}
+
+// Subscribe returns once the VM has subscribed the returned stream to its
+// events. If the VM is a Replayer, the events of the blocks accepted after
+// [height] are received first. The stream is closed once [ctx] is cancelled.
+//
+// If the VM doesn't publish events, the returned error has the Unimplemented
+// status code.
+func (c *Client) Subscribe(ctx context.Context, height uint64) (*Stream, error) {
+	stream, err := c.client.Subscribe(ctx, &eventspb.SubscribeRequest{
+		Height: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		return nil, err
+	}
+	if len(header.Get(subscribedKey)) == 0 {
+		// The stream was closed without being subscribed, so the error is
+		// returned when receiving.
+		_, err := stream.Recv()
+		if err == nil {
+			err = errNotSubscribed
+		}
+		return nil, err
+	}
+	return &Stream{stream: stream}, nil
+}
+
+type Stream struct {
+	stream eventspb.Events_SubscribeClient
+}
+
+// Recv blocks until the next event is received or the stream fails.
+func (s *Stream) Recv() (Event, error) {
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return Event{}, err
+	}
+	return eventFromProto(resp)
+}
+
+func eventFromProto(event *eventspb.Event) (Event, error) {
+	switch e := event.Event.(type) {
+	case *eventspb.Event_BlockAccepted:
+		blkID, err := ids.ToID(e.BlockAccepted.Id)
+		if err != nil {
+			return Event{}, err
+		}
+		txs := make([]Tx, len(e.BlockAccepted.Txs))
+		for i, tx := range e.BlockAccepted.Txs {
+			txID, err := ids.ToID(tx.Id)
+			if err != nil {
+				return Event{}, err
+			}
+			txs[i] = Tx{
+				ID:    txID,
+				Bytes: tx.Bytes,
+			}
+		}
+		return Event{
+			BlockAccepted: &BlockAccepted{
+				ID:     blkID,
+				Height: e.BlockAccepted.Height,
+				Txs:    txs,
+			},
+		}, nil
+	case *eventspb.Event_Log:
+		blkID, err := ids.ToID(e.Log.BlockId)
+		if err != nil {
+			return Event{}, err
+		}
+		txID, err := ids.ToID(e.Log.TxId)
+		if err != nil {
+			return Event{}, err
+		}
+		return Event{
+			Log: &Log{
+				BlockID:   blkID,
+				TxID:      txID,
+				Addresses: e.Log.Addresses,
+				Data:      e.Log.Data,
+			},
+		}, nil
+	default:
+		return Event{}, fmt.Errorf("%w: %T", errUnknownEvent, event.Event)
+	}
+}
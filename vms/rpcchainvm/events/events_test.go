@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	eventspb "github.com/ava-labs/avalanchego/proto/pb/vm/events"
+)
+
+type testPublisher struct {
+	feed *Feed
+}
+
+func (p *testPublisher) EventFeed() *Feed {
+	return p.feed
+}
+
+type testReplayer struct {
+	testPublisher
+	height uint64
+	events []Event
+}
+
+func (r *testReplayer) Replay(_ context.Context, height uint64) ([]Event, error) {
+	r.height = height
+	return r.events, nil
+}
+
+func newTestClient(t *testing.T, publisher Publisher) *Client {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	server := grpcutils.NewServer()
+	eventspb.RegisterEventsServer(server, NewServer(publisher))
+	go grpcutils.Serve(listener, server)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return NewClient(eventspb.NewEventsClient(conn))
+}
+
+func TestStreamEvents(t *testing.T) {
+	require := require.New(t)
+
+	feed := NewFeed()
+	client := newTestClient(t, &testPublisher{feed: feed})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Subscribe(ctx, 0)
+	require.NoError(err)
+	require.Equal(1, feed.Len())
+
+	blk := &BlockAccepted{
+		ID:     ids.GenerateTestID(),
+		Height: 5,
+		Txs: []Tx{
+			{
+				ID:    ids.GenerateTestID(),
+				Bytes: []byte{1, 2, 3},
+			},
+		},
+	}
+	log := &Log{
+		BlockID:   blk.ID,
+		TxID:      blk.Txs[0].ID,
+		Addresses: [][]byte{{4}, {5}},
+		Data:      []byte{6},
+	}
+	feed.PublishBlockAccepted(blk)
+	feed.PublishLog(log)
+
+	event, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(Event{BlockAccepted: blk}, event)
+
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(Event{Log: log}, event)
+
+	cancel()
+	_, err = stream.Recv()
+	require.Equal(codes.Canceled, status.Code(err))
+}
+
+func TestStreamReplayedEvents(t *testing.T) {
+	require := require.New(t)
+
+	replayedBlk := &BlockAccepted{
+		ID:     ids.GenerateTestID(),
+		Height: 6,
+		Txs:    []Tx{},
+	}
+	replayedLog := &Log{
+		BlockID:   replayedBlk.ID,
+		TxID:      ids.GenerateTestID(),
+		Addresses: [][]byte{{1}},
+		Data:      []byte{2},
+	}
+	feed := NewFeed()
+	replayer := &testReplayer{
+		testPublisher: testPublisher{feed: feed},
+		events: []Event{
+			{BlockAccepted: replayedBlk},
+			{Log: replayedLog},
+		},
+	}
+	client := newTestClient(t, replayer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Subscribe(ctx, 5)
+	require.NoError(err)
+	require.Equal(uint64(5), replayer.height)
+
+	event, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(Event{BlockAccepted: replayedBlk}, event)
+
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(Event{Log: replayedLog}, event)
+
+	// Events of blocks that were replayed aren't streamed again.
+	blk := &BlockAccepted{
+		ID:     ids.GenerateTestID(),
+		Height: 7,
+		Txs:    []Tx{},
+	}
+	feed.PublishBlockAccepted(replayedBlk)
+	feed.PublishLog(replayedLog)
+	feed.PublishBlockAccepted(blk)
+
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(Event{BlockAccepted: blk}, event)
+}
+
+func TestSubscribeWithoutFeed(t *testing.T) {
+	client := newTestClient(t, &testPublisher{})
+
+	_, err := client.Subscribe(context.Background(), 0)
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestSubscribeUnregistered(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	server := grpcutils.NewServer()
+	go grpcutils.Serve(listener, server)
+	defer server.Stop()
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	client := NewClient(eventspb.NewEventsClient(conn))
+	_, err = client.Subscribe(context.Background(), 0)
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// subscriptionSize is the number of events that are buffered for a
+// subscription before publishing blocks.
+const subscriptionSize = 1024
+
+// Publisher is implemented by VMs that publish events to AvalancheGo.
+type Publisher interface {
+	// EventFeed returns the feed that the VM publishes its events to. It's
+	// only called once the VM has been initialized.
+	EventFeed() *Feed
+}
+
+// Replayer is optionally implemented by a Publisher that is able to republish
+// the events of blocks that it already accepted. This allows the events of
+// blocks that were accepted while AvalancheGo wasn't subscribed, such as while
+// the VM process was being restarted, to be delivered.
+type Replayer interface {
+	// Replay returns the events of the blocks accepted after [height], in the
+	// order they were published.
+	Replay(ctx context.Context, height uint64) ([]Event, error)
+}
+
+// Event is either a BlockAccepted or a Log.
+type Event struct {
+	BlockAccepted *BlockAccepted
+	Log           *Log
+}
+
+// BlockAccepted is published once a block has been accepted.
+type BlockAccepted struct {
+	ID     ids.ID
+	Height uint64
+	// Transactions of the block, in the order they were executed.
+	Txs []Tx
+}
+
+type Tx struct {
+	ID    ids.ID
+	Bytes []byte
+}
+
+// Log is published for a state change of an accepted transaction.
+type Log struct {
+	BlockID ids.ID
+	TxID    ids.ID
+	// Addresses the log is relevant to. Used to filter subscriptions.
+	Addresses [][]byte
+	Data      []byte
+}
+
+// Feed delivers the events published by a VM to its subscribers, in the
+// order they were published.
+type Feed struct {
+	lock          sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func NewFeed() *Feed {
+	return &Feed{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// PublishBlockAccepted should be called once [blk] has been accepted, before
+// any logs of the block are published.
+func (f *Feed) PublishBlockAccepted(blk *BlockAccepted) {
+	f.publish(Event{BlockAccepted: blk})
+}
+
+// PublishLog publishes [log] to the subscribers of the feed.
+func (f *Feed) PublishLog(log *Log) {
+	f.publish(Event{Log: log})
+}
+
+// publish blocks while the buffer of a subscription is full, so that no events
+// are dropped.
+func (f *Feed) publish(event Event) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for sub := range f.subscriptions {
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events that are published from now
+// on. The subscription must be cancelled with Unsubscribe once it's no longer
+// read from.
+func (f *Feed) Subscribe() *Subscription {
+	sub := &Subscription{
+		feed:   f,
+		events: make(chan Event, subscriptionSize),
+		done:   make(chan struct{}),
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+// Len returns the number of subscriptions to the feed.
+func (f *Feed) Len() int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return len(f.subscriptions)
+}
+
+type Subscription struct {
+	feed   *Feed
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events returns the channel the events of the subscription are delivered on.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Unsubscribe stops the delivery of events to the subscription. It's safe to
+// call Unsubscribe multiple times.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		// Unblock any publisher that is waiting on this subscription before
+		// the feed's lock is acquired.
+		close(s.done)
+
+		s.feed.lock.Lock()
+		defer s.feed.lock.Unlock()
+
+		delete(s.feed.subscriptions, s)
+	})
+}
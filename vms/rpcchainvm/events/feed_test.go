@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestFeedPublishOrder(t *testing.T) {
+	require := require.New(t)
+
+	feed := NewFeed()
+	sub1 := feed.Subscribe()
+	defer sub1.Unsubscribe()
+	sub2 := feed.Subscribe()
+	defer sub2.Unsubscribe()
+	require.Equal(2, feed.Len())
+
+	blk := &BlockAccepted{
+		ID:     ids.GenerateTestID(),
+		Height: 1,
+	}
+	log := &Log{
+		BlockID: blk.ID,
+		TxID:    ids.GenerateTestID(),
+	}
+	feed.PublishBlockAccepted(blk)
+	feed.PublishLog(log)
+
+	for _, sub := range []*Subscription{sub1, sub2} {
+		require.Equal(Event{BlockAccepted: blk}, <-sub.Events())
+		require.Equal(Event{Log: log}, <-sub.Events())
+	}
+}
+
+func TestFeedUnsubscribeUnblocksPublish(t *testing.T) {
+	require := require.New(t)
+
+	feed := NewFeed()
+	sub := feed.Subscribe()
+	for i := 0; i < subscriptionSize; i++ {
+		feed.PublishLog(&Log{})
+	}
+
+	published := make(chan struct{})
+	go func() {
+		feed.PublishLog(&Log{})
+		close(published)
+	}()
+
+	sub.Unsubscribe()
+	<-published
+	require.Zero(feed.Len())
+
+	// Unsubscribing again is a no-op.
+	sub.Unsubscribe()
+}
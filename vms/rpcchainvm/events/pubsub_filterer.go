@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+var _ pubsub.Filterer = (*logFilterer)(nil)
+
+// JSONLog is the representation of a Log that is sent to pubsub subscribers.
+type JSONLog struct {
+	BlockID ids.ID              `json:"blockID"`
+	TxID    ids.ID              `json:"txID"`
+	Data    types.JSONByteSlice `json:"data"`
+}
+
+type logFilterer struct {
+	log *Log
+}
+
+// NewPubSubFilterer returns a filterer that sends [log] to the connections that
+// are subscribed to any of its addresses.
+func NewPubSubFilterer(log *Log) pubsub.Filterer {
+	return &logFilterer{log: log}
+}
+
+func (f *logFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for _, address := range f.log.Addresses {
+		for i, c := range filters {
+			if resp[i] {
+				continue
+			}
+			resp[i] = c.Check(address)
+		}
+	}
+	return resp, JSONLog{
+		BlockID: f.log.BlockID,
+		TxID:    f.log.TxID,
+		Data:    f.log.Data,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	eventspb "github.com/ava-labs/avalanchego/proto/pb/vm/events"
+)
+
+// subscribedKey is sent in the header of a stream once the subscription is
+// active.
+const subscribedKey = "avalanche-vm-events-subscribed"
+
+var (
+	_ eventspb.EventsServer = (*Server)(nil)
+
+	errNoFeed = status.Error(codes.Unimplemented, "vm doesn't have an event feed")
+)
+
+// Server streams the events published by a VM over RPC.
+type Server struct {
+	eventspb.UnsafeEventsServer
+	publisher Publisher
+}
+
+// NewServer returns a server that streams the events published to the feed of
+// [publisher].
+func NewServer(publisher Publisher) *Server {
+	return &Server{publisher: publisher}
+}
+
+func (s *Server) Subscribe(req *eventspb.SubscribeRequest, stream eventspb.Events_SubscribeServer) error {
+	feed := s.publisher.EventFeed()
+	if feed == nil {
+		return errNoFeed
+	}
+
+	sub := feed.Subscribe()
+	defer sub.Unsubscribe()
+
+	if err := stream.SendHeader(metadata.Pairs(subscribedKey, "true")); err != nil {
+		return err
+	}
+
+	// The events are replayed after the subscription is created so that no
+	// events are missed. Events that are published to the subscription before
+	// the replay completes may have been replayed, so they are dropped.
+	ctx := stream.Context()
+	replayed := set.Set[ids.ID]{}
+	if replayer, ok := s.publisher.(Replayer); ok {
+		events, err := replayer.Replay(ctx, req.Height)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+			replayed.Add(blockID(event))
+		}
+	}
+
+	for {
+		select {
+		case event := <-sub.Events():
+			if replayed.Contains(blockID(event)) {
+				continue
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// blockID returns the ID of the block that [event] was published for.
+func blockID(event Event) ids.ID {
+	if event.Log != nil {
+		return event.Log.BlockID
+	}
+	return event.BlockAccepted.ID
+}
+
+func eventToProto(event Event) *eventspb.Event {
+	if event.Log != nil {
+		return &eventspb.Event{
+			Event: &eventspb.Event_Log{
+				Log: &eventspb.Log{
+					BlockId:   event.Log.BlockID[:],
+					TxId:      event.Log.TxID[:],
+					Addresses: event.Log.Addresses,
+					Data:      event.Log.Data,
+				},
+			},
+		}
+	}
+
+	blk := event.BlockAccepted
+	txs := make([]*eventspb.Tx, len(blk.Txs))
+	for i, tx := range blk.Txs {
+		txID := tx.ID
+		txs[i] = &eventspb.Tx{
+			Id:    txID[:],
+			Bytes: tx.Bytes,
+		}
+	}
+	return &eventspb.Event{
+		Event: &eventspb.Event_BlockAccepted{
+			BlockAccepted: &eventspb.BlockAccepted{
+				Id:     blk.ID[:],
+				Height: blk.Height,
+				Txs:    txs,
+			},
+		},
+	}
+}
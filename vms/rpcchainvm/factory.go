@@ -6,23 +6,41 @@ package rpcchainvm
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
+)
+
+const (
+	unixSocketDirPattern = "avalanchego-vm-"
+	pluginDirPattern     = "avalanchego-plugin-"
 
-	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+	// currentPluginFileName is the name of the copy of the plugin the VM
+	// process is started from.
+	currentPluginFileName = "current"
+	// upgradePluginFileName is the name of the copy of the plugin the VM is
+	// being upgraded to.
+	upgradePluginFileName = "upgrade"
 )
 
-var _ vms.Factory = (*factory)(nil)
+var _ vms.SandboxedFactory = (*factory)(nil)
 
 type factory struct {
 	path           string
 	processTracker resource.ProcessTracker
 	runtimeTracker runtime.Tracker
+	// onExit, if non-nil, is called instead of restarting the VM process when
+	// it exits unexpectedly.
+	onExit func()
 }
 
 func NewFactory(path string, processTracker resource.ProcessTracker, runtimeTracker runtime.Tracker) vms.Factory {
@@ -33,11 +51,141 @@ func NewFactory(path string, processTracker resource.ProcessTracker, runtimeTrac
 	}
 }
 
+// NewUnsupervisedFactory returns a factory of VMs whose processes aren't
+// restarted when they exit unexpectedly. Instead, [onExit] is called and the
+// VM is reported as unhealthy until it is upgraded.
+func NewUnsupervisedFactory(
+	path string,
+	processTracker resource.ProcessTracker,
+	runtimeTracker runtime.Tracker,
+	onExit func(),
+) vms.Factory {
+	return &factory{
+		path:           path,
+		processTracker: processTracker,
+		runtimeTracker: runtimeTracker,
+		onExit:         onExit,
+	}
+}
+
 func (f *factory) New(log logging.Logger) (interface{}, error) {
+	return f.NewSandboxed(log, nil)
+}
+
+// NewSandboxed returns a VM whose processes are started in the sandbox
+// described by [sandbox]. If [sandbox] is nil, the VM processes aren't
+// sandboxed.
+func (f *factory) NewSandboxed(log logging.Logger, sandbox *subprocess.SandboxConfig) (interface{}, error) {
+	// The directory is kept for the lifetime of the VM, rather than the VM
+	// process, as the sockets of the servers exposed to the VM are created in
+	// it.
+	unixSocketDir, err := os.MkdirTemp("", unixSocketDirPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unix socket directory: %w", err)
+	}
+
+	// VM processes are started from a copy of the plugin, so that the VM can
+	// be restarted from the binary it is running after the plugin is replaced.
+	// The copy isn't kept in the unix socket directory as the VM process is
+	// able to write to it.
+	pluginDir, err := os.MkdirTemp("", pluginDirPattern)
+	if err != nil {
+		_ = os.RemoveAll(unixSocketDir)
+		return nil, fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+	release := func() {
+		_ = os.RemoveAll(unixSocketDir)
+		_ = os.RemoveAll(pluginDir)
+	}
+
+	currentPath := filepath.Join(pluginDir, currentPluginFileName)
+	if err := copyPlugin(f.path, currentPath); err != nil {
+		release()
+		return nil, err
+	}
+
+	p, err := f.start(context.TODO(), log, unixSocketDir, currentPath, sandbox)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	vm := NewClient(p.client())
+	vm.SetProcess(p.runtime, p.pid, f.processTracker)
+	// The VM opts into Unix domain sockets by serving on one.
+	if grpcutils.IsUnixAddr(p.addr) {
+		vm.transport = &grpcutils.Transport{
+			UnixSocketDir: unixSocketDir,
+		}
+	}
+	vm.onShutdown = release
+	vm.pluginPath = f.path
+	vm.onExit = f.onExit
+	vm.supervise(p, func(ctx context.Context) (*process, error) {
+		return f.start(ctx, log, unixSocketDir, currentPath, sandbox)
+	})
+	vm.launchUpgrade = func(ctx context.Context) (*process, func() error, error) {
+		upgradePath := filepath.Join(pluginDir, upgradePluginFileName)
+		if err := copyPlugin(f.path, upgradePath); err != nil {
+			return nil, nil, err
+		}
+		p, err := f.start(ctx, log, unixSocketDir, upgradePath, sandbox)
+		if err != nil {
+			return nil, nil, err
+		}
+		return p, func() error {
+			return os.Rename(upgradePath, currentPath)
+		}, nil
+	}
+	return vm, nil
+}
+
+// copyPlugin copies the plugin at [src] to [dst]. The copy is written to a
+// temporary file first, so [dst] can be replaced while a process started from
+// it is running.
+func copyPlugin(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open plugin: %w", err)
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst))
+	if err != nil {
+		return fmt.Errorf("failed to copy plugin: %w", err)
+	}
+	_, err = io.Copy(dstFile, srcFile)
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		dstFile.Chmod(perms.ReadWriteExecute),
+		dstFile.Close(),
+	)
+	if errs.Err == nil {
+		errs.Add(os.Rename(dstFile.Name(), dst))
+	}
+	if errs.Errored() {
+		_ = os.Remove(dstFile.Name())
+		return fmt.Errorf("failed to copy plugin: %w", errs.Err)
+	}
+	return nil
+}
+
+// start launches a new instance of the VM process from the plugin at [path],
+// in [sandbox] if it is non-nil, and connects to it.
+func (f *factory) start(
+	ctx context.Context,
+	log logging.Logger,
+	unixSocketDir string,
+	path string,
+	sandbox *subprocess.SandboxConfig,
+) (*process, error) {
 	config := &subprocess.Config{
 		Stderr:           log,
 		Stdout:           log,
 		HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+		UnixSocketDir:    unixSocketDir,
+		Sandbox:          sandbox,
 		Log:              log,
 	}
 
@@ -47,9 +195,9 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 	}
 
 	status, stopper, err := subprocess.Bootstrap(
-		context.TODO(),
+		ctx,
 		listener,
-		subprocess.NewCmd(f.path),
+		subprocess.NewCmd(path),
 		config,
 	)
 	if err != nil {
@@ -58,13 +206,18 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 
 	clientConn, err := grpcutils.Dial(status.Addr)
 	if err != nil {
+		stopper.Stop(ctx)
 		return nil, err
 	}
 
-	vm := NewClient(vmpb.NewVMClient(clientConn))
-	vm.SetProcess(stopper, status.Pid, f.processTracker)
-
 	f.runtimeTracker.TrackRuntime(stopper)
-
-	return vm, nil
+	return &process{
+		conn:            clientConn,
+		runtime:         stopper,
+		addr:            status.Addr,
+		pid:             status.Pid,
+		exited:          status.Exited,
+		protocolVersion: status.ProtocolVersion,
+		sandbox:         status.Sandbox,
+	}, nil
 }
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+// Tests that the copy of a plugin is unaffected by the plugin being replaced
+// and can itself be replaced.
+func TestCopyPlugin(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir = t.TempDir()
+		src = filepath.Join(dir, "plugin")
+		dst = filepath.Join(dir, "copy")
+	)
+	require.NoError(os.WriteFile(src, []byte("previous"), perms.ReadWriteExecute))
+	require.NoError(copyPlugin(src, dst))
+
+	require.NoError(os.WriteFile(src, []byte("upgraded"), perms.ReadWriteExecute))
+	copied, err := os.ReadFile(dst)
+	require.NoError(err)
+	require.Equal([]byte("previous"), copied)
+
+	require.NoError(copyPlugin(src, dst))
+	copied, err = os.ReadFile(dst)
+	require.NoError(err)
+	require.Equal([]byte("upgraded"), copied)
+
+	info, err := os.Stat(dst)
+	require.NoError(err)
+	require.Equal(os.FileMode(perms.ReadWriteExecute), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 2)
+}
@@ -30,15 +30,19 @@ var (
 
 // Client is an http.ResponseWriter that talks over RPC.
 type Client struct {
-	client responsewriterpb.WriterClient
-	header http.Header
+	client    responsewriterpb.WriterClient
+	header    http.Header
+	transport *grpcutils.Transport
 }
 
-// NewClient returns a response writer connected to a remote response writer
-func NewClient(header http.Header, client responsewriterpb.WriterClient) *Client {
+// NewClient returns a response writer connected to a remote response writer.
+// [transport] is used to connect to the servers created by the remote response
+// writer.
+func NewClient(header http.Header, client responsewriterpb.WriterClient, transport *grpcutils.Transport) *Client {
 	return &Client{
-		client: client,
-		header: header,
+		client:    client,
+		header:    header,
+		transport: transport,
 	}
 }
 
@@ -103,7 +107,7 @@ func (c *Client) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 		return nil, nil, err
 	}
 
-	clientConn, err := grpcutils.Dial(resp.ServerAddr)
+	clientConn, err := c.transport.Dial(resp.ServerAddr)
 	if err != nil {
 		return nil, nil, err
 	}
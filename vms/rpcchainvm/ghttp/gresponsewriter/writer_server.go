@@ -33,13 +33,16 @@ var (
 // Server is an http.ResponseWriter that is managed over RPC.
 type Server struct {
 	responsewriterpb.UnsafeWriterServer
-	writer http.ResponseWriter
+	writer    http.ResponseWriter
+	transport *grpcutils.Transport
 }
 
-// NewServer returns an http.ResponseWriter instance managed remotely
-func NewServer(writer http.ResponseWriter) *Server {
+// NewServer returns an http.ResponseWriter instance managed remotely.
+// [transport] is used to expose the servers created when hijacking.
+func NewServer(writer http.ResponseWriter, transport *grpcutils.Transport) *Server {
 	return &Server{
-		writer: writer,
+		writer:    writer,
+		transport: transport,
 	}
 }
 
@@ -94,12 +97,12 @@ func (s *Server) Hijack(context.Context, *emptypb.Empty) (*responsewriterpb.Hija
 		return nil, err
 	}
 
-	serverListener, err := grpcutils.NewListener()
+	serverListener, err := s.transport.NewListener()
 	if err != nil {
 		return nil, err
 	}
 
-	server := grpcutils.NewServer()
+	server := s.transport.NewServer()
 	closer := grpcutils.ServerCloser{}
 	closer.Add(server)
 
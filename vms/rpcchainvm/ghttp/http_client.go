@@ -18,14 +18,17 @@ var _ http.Handler = (*Client)(nil)
 
 // Client is an http.Handler that talks over RPC.
 type Client struct {
-	client httppb.HTTPClient
+	client    httppb.HTTPClient
+	transport *grpcutils.Transport
 }
 
 // NewClient returns an HTTP handler database instance connected to a remote
-// HTTP handler instance
-func NewClient(client httppb.HTTPClient) *Client {
+// HTTP handler instance. [transport] is used to expose the response writer to
+// the remote HTTP handler.
+func NewClient(client httppb.HTTPClient, transport *grpcutils.Transport) *Client {
 	return &Client{
-		client: client,
+		client:    client,
+		transport: transport,
 	}
 }
 
@@ -45,15 +48,15 @@ func (c *Client) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Wrap [w] with a lock to ensure that it is accessed in a thread-safe manner.
 	w = gresponsewriter.NewLockedWriter(w)
 
-	serverListener, err := grpcutils.NewListener()
+	serverListener, err := c.transport.NewListener()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	server := grpcutils.NewServer()
+	server := c.transport.NewServer()
 	closer.Add(server)
-	responsewriterpb.RegisterWriterServer(server, gresponsewriter.NewServer(w))
+	responsewriterpb.RegisterWriterServer(server, gresponsewriter.NewServer(w, c.transport))
 
 	// Start responsewriter gRPC service.
 	go grpcutils.Serve(serverListener, server)
@@ -28,18 +28,21 @@ var (
 // Server is an http.Handler that is managed over RPC.
 type Server struct {
 	httppb.UnsafeHTTPServer
-	handler http.Handler
+	handler   http.Handler
+	transport *grpcutils.Transport
 }
 
-// NewServer returns an http.Handler instance managed remotely
-func NewServer(handler http.Handler) *Server {
+// NewServer returns an http.Handler instance managed remotely. [transport] is
+// used to connect to the response writers provided by the remote client.
+func NewServer(handler http.Handler, transport *grpcutils.Transport) *Server {
 	return &Server{
-		handler: handler,
+		handler:   handler,
+		transport: transport,
 	}
 }
 
 func (s *Server) Handle(ctx context.Context, req *httppb.HTTPRequest) (*emptypb.Empty, error) {
-	clientConn, err := grpcutils.Dial(req.ResponseWriter.ServerAddr)
+	clientConn, err := s.transport.Dial(req.ResponseWriter.ServerAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +52,7 @@ func (s *Server) Handle(ctx context.Context, req *httppb.HTTPRequest) (*emptypb.
 		writerHeaders[elem.Key] = elem.Values
 	}
 
-	writer := gresponsewriter.NewClient(writerHeaders, responsewriterpb.NewWriterClient(clientConn))
+	writer := gresponsewriter.NewClient(writerHeaders, responsewriterpb.NewWriterClient(clientConn), s.transport)
 
 	// create the request with the current context
 	request, err := http.NewRequestWithContext(
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -62,7 +63,12 @@ var DefaultDialOptions = []grpc.DialOption{
 // Dial returns a gRPC ClientConn with the dial options as defined by
 // DefaultDialOptions. DialOption can also optionally be passed.
 func Dial(addr string, opts ...DialOption) (*grpc.ClientConn, error) {
-	return grpc.Dial(fmt.Sprintf("passthrough:///%s", addr), newDialOpts(opts...)...)
+	// Unix domain socket addresses already include the scheme of their
+	// resolver.
+	if !IsUnixAddr(addr) {
+		addr = fmt.Sprintf("passthrough:///%s", addr)
+	}
+	return grpc.Dial(addr, newDialOpts(opts...)...)
 }
 
 // DialOptions are options which can be applied to a gRPC client in addition to
@@ -103,3 +109,11 @@ func WithChainStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) Di
 		d.opts = append(d.opts, grpc.WithChainStreamInterceptor(interceptors...))
 	}
 }
+
+// withTransportCredentials replaces the default insecure transport
+// credentials.
+func withTransportCredentials(creds credentials.TransportCredentials) DialOption {
+	return func(d *DialOptions) {
+		d.opts = append(d.opts, grpc.WithTransportCredentials(creds))
+	}
+}
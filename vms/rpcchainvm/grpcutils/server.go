@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 )
 
@@ -103,6 +104,14 @@ func WithStreamInterceptor(streamInterceptor grpc.StreamServerInterceptor) Serve
 	}
 }
 
+// withCreds sets the credentials used to authenticate connections to the
+// gRPC server.
+func withCreds(creds credentials.TransportCredentials) ServerOption {
+	return func(s *ServerOptions) {
+		s.opts = append(s.opts, grpc.Creds(creds))
+	}
+}
+
 // NewListener returns a TCP listener listening against the next available port
 // on the system bound to localhost.
 func NewListener() (net.Listener, error) {
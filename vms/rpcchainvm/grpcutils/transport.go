@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	unixScheme = "unix"
+	// Number of random bytes in the name of a Unix domain socket. The name is
+	// kept short as the length of socket paths is limited.
+	unixSocketNameLen = 8
+)
+
+var errNoCACerts = errors.New("no CA certificates found")
+
+// Transport determines how the gRPC servers used between AvalancheGo and a VM
+// are exposed and how clients connect to them.
+//
+// A nil *Transport only exposes servers on localhost and doesn't authenticate
+// connections, which is how subprocess VMs are connected.
+type Transport struct {
+	// Directory that new Unix domain sockets are created in. If non-empty,
+	// listeners are bound to Unix domain sockets rather than TCP ports, which
+	// avoids the overhead of TCP loopback connections.
+	UnixSocketDir string
+	// Host that new listeners are bound to. If empty, listeners are bound to
+	// localhost.
+	ListenHost string
+	// Host that the other side should use to reach the listeners. If empty,
+	// the address of the listener is used.
+	AdvertiseHost string
+	// TLS is used to authenticate both sides of every connection. If nil,
+	// connections are neither encrypted nor authenticated.
+	TLS *tls.Config
+}
+
+// NewListener returns a TCP listener bound to the next available port. The
+// address of the returned listener is the address that should be provided to
+// the other side.
+func (t *Transport) NewListener() (net.Listener, error) {
+	switch {
+	case t == nil:
+		return NewListener()
+	case t.UnixSocketDir != "":
+		return NewUnixListener(t.UnixSocketDir)
+	}
+
+	host := t.ListenHost
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return t.Listen(net.JoinHostPort(host, "0"))
+}
+
+// Listen returns a TCP listener bound to [addr].
+func (t *Transport) Listen(addr string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil || t == nil || t.AdvertiseHost == "" {
+		return listener, err
+	}
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return &advertisedListener{
+		Listener: listener,
+		addr:     advertisedAddr(net.JoinHostPort(t.AdvertiseHost, port)),
+	}, nil
+}
+
+// NewServer returns a gRPC server that requires connections to be
+// authenticated if [t] is configured with TLS.
+func (t *Transport) NewServer(opts ...ServerOption) *grpc.Server {
+	if t != nil && t.TLS != nil {
+		opts = append(opts, withCreds(credentials.NewTLS(t.TLS)))
+	}
+	return NewServer(opts...)
+}
+
+// Dial returns a gRPC ClientConn that authenticates the server if [t] is
+// configured with TLS.
+func (t *Transport) Dial(addr string, opts ...DialOption) (*grpc.ClientConn, error) {
+	if t != nil && t.TLS != nil {
+		opts = append(opts, withTransportCredentials(credentials.NewTLS(t.TLS)))
+	}
+	return Dial(addr, opts...)
+}
+
+// NewMutualTLSConfig returns a TLS config that presents the certificate in
+// [certFile] and requires the peer to present a certificate signed by one of
+// the CAs in [caFile]. The config may be used by both clients and servers.
+func NewMutualTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("%w in %q", errNoCACerts, caFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+type advertisedListener struct {
+	net.Listener
+	addr net.Addr
+}
+
+func (l *advertisedListener) Addr() net.Addr {
+	return l.addr
+}
+
+// NewUnixListener returns a listener bound to a new Unix domain socket in
+// [dir]. The address of the returned listener includes the unix scheme, so it
+// can be provided to Dial.
+func NewUnixListener(dir string) (net.Listener, error) {
+	name := make([]byte, unixSocketNameLen)
+	if _, err := rand.Read(name); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, hex.EncodeToString(name)+".sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &advertisedListener{
+		Listener: listener,
+		addr:     unixAddr(unixScheme + "://" + path),
+	}, nil
+}
+
+// IsUnixAddr returns true if [addr] is the address of a Unix domain socket
+// returned by NewUnixListener.
+func IsUnixAddr(addr string) bool {
+	return strings.HasPrefix(addr, unixScheme+":")
+}
+
+type unixAddr string
+
+func (unixAddr) Network() string {
+	return "unix"
+}
+
+func (a unixAddr) String() string {
+	return string(a)
+}
+
+type advertisedAddr string
+
+func (advertisedAddr) Network() string {
+	return "tcp"
+}
+
+func (a advertisedAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/rpcdb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
+)
+
+func TestTransportMutualTLS(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	caFile := writeTestCerts(t, dir)
+	tlsConfig, err := NewMutualTLSConfig(
+		filepath.Join(dir, "node.crt"),
+		filepath.Join(dir, "node.key"),
+		caFile,
+	)
+	require.NoError(err)
+
+	transport := &Transport{
+		AdvertiseHost: "127.0.0.1",
+		TLS:           tlsConfig,
+	}
+	listener, err := transport.NewListener()
+	require.NoError(err)
+
+	server := transport.NewServer()
+	defer server.Stop()
+	pb.RegisterDatabaseServer(server, rpcdb.NewServer(memdb.New()))
+	go Serve(listener, server)
+
+	conn, err := transport.Dial(listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	db := rpcdb.NewClient(pb.NewDatabaseClient(conn))
+	require.NoError(db.Put([]byte("foo"), []byte("bar")))
+
+	// A client that doesn't present a certificate must be rejected.
+	noCertTransport := &Transport{
+		TLS: &tls.Config{
+			RootCAs:    tlsConfig.RootCAs,
+			MinVersion: tls.VersionTLS13,
+		},
+	}
+	noCertConn, err := noCertTransport.Dial(listener.Addr().String())
+	require.NoError(err)
+	defer noCertConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = pb.NewDatabaseClient(noCertConn).Has(ctx, &pb.HasRequest{Key: []byte("foo")})
+	require.Error(err) //nolint:forbidigo // the error is returned by the gRPC library
+}
+
+func TestNewMutualTLSConfigNoCACerts(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	_ = writeTestCerts(t, dir)
+	emptyCAFile := filepath.Join(dir, "empty.crt")
+	require.NoError(os.WriteFile(emptyCAFile, nil, 0o600))
+
+	_, err := NewMutualTLSConfig(
+		filepath.Join(dir, "node.crt"),
+		filepath.Join(dir, "node.key"),
+		emptyCAFile,
+	)
+	require.ErrorIs(err, errNoCACerts)
+}
+
+// writeTestCerts writes a CA certificate and a certificate signed by it, valid
+// for 127.0.0.1, to [dir]. Returns the path of the CA certificate.
+func writeTestCerts(t *testing.T, dir string) string {
+	require := require.New(t)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	require.NoError(err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caBytes}), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(dir, "node.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(dir, "node.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0o600))
+	return caFile
+}
+
+func TestTransportUnixSocket(t *testing.T) {
+	require := require.New(t)
+
+	transport := &Transport{
+		UnixSocketDir: t.TempDir(),
+	}
+	listener, err := transport.NewListener()
+	require.NoError(err)
+	require.True(IsUnixAddr(listener.Addr().String()))
+	require.Equal("unix", listener.Addr().Network())
+
+	server := transport.NewServer()
+	defer server.Stop()
+	pb.RegisterDatabaseServer(server, rpcdb.NewServer(memdb.New()))
+	go Serve(listener, server)
+
+	conn, err := transport.Dial(listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	db := rpcdb.NewClient(pb.NewDatabaseClient(conn))
+	require.NoError(db.Put([]byte("foo"), []byte("bar")))
+	value, err := db.Get([]byte("foo"))
+	require.NoError(err)
+	require.Equal([]byte("bar"), value)
+}
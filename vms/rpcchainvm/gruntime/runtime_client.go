@@ -5,6 +5,11 @@ package gruntime
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 
@@ -22,10 +27,64 @@ func NewClient(client pb.RuntimeClient) *Client {
 	return &Client{client: client}
 }
 
-func (c *Client) Initialize(ctx context.Context, protocolVersion uint, vmAddr string) error {
-	_, err := c.client.Initialize(ctx, &pb.InitializeRequest{
-		ProtocolVersion: uint32(protocolVersion),
-		Addr:            vmAddr,
-	})
-	return err
+func (c *Client) Initialize(ctx context.Context, minProtocolVersion, maxProtocolVersion uint, vmAddr string) (uint, error) {
+	ctx = WithMinProtocolVersion(ctx, minProtocolVersion)
+
+	var header metadata.MD
+	_, err := c.client.Initialize(
+		ctx,
+		&pb.InitializeRequest{
+			ProtocolVersion: uint32(maxProtocolVersion),
+			Addr:            vmAddr,
+		},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return ProtocolVersion(header, minProtocolVersion, maxProtocolVersion)
+}
+
+// WithMinProtocolVersion returns a context that reports
+// [minProtocolVersion] as the oldest supported protocol version when used to
+// send the handshake.
+func WithMinProtocolVersion(ctx context.Context, minProtocolVersion uint) context.Context {
+	return metadata.AppendToOutgoingContext(
+		ctx,
+		runtime.MinProtocolVersionHeader,
+		strconv.FormatUint(uint64(minProtocolVersion), 10),
+	)
+}
+
+// ProtocolVersion returns the protocol version reported in the [header] of a
+// handshake response to a request that supported the protocol versions in
+// [minProtocolVersion, maxProtocolVersion]. If the receiver didn't report a
+// protocol version, it only accepted [maxProtocolVersion].
+func ProtocolVersion(header metadata.MD, minProtocolVersion, maxProtocolVersion uint) (uint, error) {
+	protocolVersion, err := parseProtocolVersion(header, runtime.ProtocolVersionHeader, maxProtocolVersion)
+	if err != nil {
+		return 0, err
+	}
+	if protocolVersion < minProtocolVersion || protocolVersion > maxProtocolVersion {
+		return 0, fmt.Errorf(
+			"%w supported: [%d, %d], negotiated: %d",
+			runtime.ErrProtocolVersionMismatch,
+			minProtocolVersion,
+			maxProtocolVersion,
+			protocolVersion,
+		)
+	}
+	return protocolVersion, nil
+}
+
+func parseProtocolVersion(md metadata.MD, key string, defaultVersion uint) (uint, error) {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return defaultVersion, nil
+	}
+	protocolVersion, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return uint(protocolVersion), nil
 }
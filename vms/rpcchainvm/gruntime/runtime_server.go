@@ -5,7 +5,10 @@ package gruntime
 
 import (
 	"context"
+	"strconv"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
@@ -28,5 +31,24 @@ func NewServer(runtime runtime.Initializer) *Server {
 }
 
 func (s *Server) Initialize(ctx context.Context, req *pb.InitializeRequest) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, s.runtime.Initialize(ctx, uint(req.ProtocolVersion), req.Addr)
+	maxProtocolVersion := uint(req.ProtocolVersion)
+
+	// Senders that predate protocol version negotiation only support the
+	// protocol version in the request.
+	md, _ := metadata.FromIncomingContext(ctx)
+	minProtocolVersion, err := parseProtocolVersion(md, runtime.MinProtocolVersionHeader, maxProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolVersion, err := s.runtime.Initialize(ctx, minProtocolVersion, maxProtocolVersion, req.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	header := metadata.Pairs(
+		runtime.ProtocolVersionHeader,
+		strconv.FormatUint(uint64(protocolVersion), 10),
+	)
+	return &emptypb.Empty{}, grpc.SetHeader(ctx, header)
 }
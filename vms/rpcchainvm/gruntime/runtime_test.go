@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gruntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+type testInitializer struct {
+	minProtocolVersion uint
+	maxProtocolVersion uint
+}
+
+func (i *testInitializer) Initialize(_ context.Context, minProtocolVersion, maxProtocolVersion uint, _ string) (uint, error) {
+	i.minProtocolVersion = minProtocolVersion
+	i.maxProtocolVersion = maxProtocolVersion
+	return runtime.NegotiateProtocolVersion(minProtocolVersion, maxProtocolVersion)
+}
+
+func setupRuntime(t *testing.T, initializer runtime.Initializer) pb.RuntimeClient {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+
+	server := grpcutils.NewServer()
+	pb.RegisterRuntimeServer(server, NewServer(initializer))
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return pb.NewRuntimeClient(conn)
+}
+
+func TestInitializeNegotiatesProtocolVersion(t *testing.T) {
+	require := require.New(t)
+
+	initializer := &testInitializer{}
+	client := NewClient(setupRuntime(t, initializer))
+
+	protocolVersion, err := client.Initialize(
+		context.Background(),
+		version.RPCChainVMProtocolMin,
+		version.RPCChainVMProtocol+1,
+		"",
+	)
+	require.NoError(err)
+	require.Equal(version.RPCChainVMProtocol, protocolVersion)
+	require.Equal(version.RPCChainVMProtocolMin, initializer.minProtocolVersion)
+	require.Equal(version.RPCChainVMProtocol+1, initializer.maxProtocolVersion)
+}
+
+// Senders that predate protocol version negotiation only report a single
+// protocol version.
+func TestInitializeLegacySender(t *testing.T) {
+	require := require.New(t)
+
+	initializer := &testInitializer{}
+	client := setupRuntime(t, initializer)
+
+	var header metadata.MD
+	_, err := client.Initialize(
+		context.Background(),
+		&pb.InitializeRequest{
+			ProtocolVersion: uint32(version.RPCChainVMProtocolMin),
+		},
+		grpc.Header(&header),
+	)
+	require.NoError(err)
+	require.Equal(version.RPCChainVMProtocolMin, initializer.minProtocolVersion)
+	require.Equal(version.RPCChainVMProtocolMin, initializer.maxProtocolVersion)
+
+	protocolVersion, err := ProtocolVersion(header, version.RPCChainVMProtocolMin, version.RPCChainVMProtocolMin)
+	require.NoError(err)
+	require.Equal(version.RPCChainVMProtocolMin, protocolVersion)
+}
+
+// Receivers that predate protocol version negotiation don't report the
+// negotiated protocol version.
+func TestProtocolVersionLegacyReceiver(t *testing.T) {
+	require := require.New(t)
+
+	protocolVersion, err := ProtocolVersion(metadata.MD{}, version.RPCChainVMProtocolMin, version.RPCChainVMProtocol)
+	require.NoError(err)
+	require.Equal(version.RPCChainVMProtocol, protocolVersion)
+
+	header := metadata.Pairs(runtime.ProtocolVersionHeader, "1")
+	_, err = ProtocolVersion(header, version.RPCChainVMProtocolMin, version.RPCChainVMProtocol)
+	require.ErrorIs(err, runtime.ErrProtocolVersionMismatch)
+}
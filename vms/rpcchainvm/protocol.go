@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/gvalidators"
+
+	validatorstatepb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
+)
+
+// Protocol versions that changed the RPCs between AvalancheGo and the VM. The
+// client and the server must behave as expected by the negotiated protocol
+// version, which may be older than version.RPCChainVMProtocol.
+const (
+	// Validator public keys are sent uncompressed.
+	uncompressedPublicKeysProtocol uint = 26
+)
+
+func newValidatorStateServer(protocolVersion uint, state validators.State) *gvalidators.Server {
+	if protocolVersion < uncompressedPublicKeysProtocol {
+		return gvalidators.NewCompressedServer(state)
+	}
+	return gvalidators.NewServer(state)
+}
+
+func newValidatorStateClient(protocolVersion uint, conn *grpc.ClientConn) *gvalidators.Client {
+	client := validatorstatepb.NewValidatorStateClient(conn)
+	if protocolVersion < uncompressedPublicKeysProtocol {
+		return gvalidators.NewCompressedClient(client)
+	}
+	return gvalidators.NewClient(client)
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/metadata"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+	runtimepb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+const instanceIDLen = 16
+
+var (
+	_ vms.Factory         = (*remoteFactory)(nil)
+	_ runtime.Initializer = (*remoteInitializer)(nil)
+)
+
+type remoteFactory struct {
+	config remote.Config
+}
+
+// NewRemoteFactory returns a factory for a VM served by a process that is
+// managed outside of AvalancheGo. If the connection to the VM server is lost,
+// it is re-established with backoff.
+func NewRemoteFactory(config remote.Config) vms.Factory {
+	return &remoteFactory{
+		config: config,
+	}
+}
+
+func (f *remoteFactory) New(log logging.Logger) (interface{}, error) {
+	p, err := f.connect(context.TODO(), log)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := NewClient(p.client())
+	vm.runtime = p.runtime
+	vm.transport = f.config.Transport
+	vm.supervise(p, func(ctx context.Context) (*process, error) {
+		return f.connect(ctx, log)
+	})
+	return vm, nil
+}
+
+// connect connects to the VM server.
+func (f *remoteFactory) connect(ctx context.Context, log logging.Logger) (*process, error) {
+	config := f.config
+	config.Log = log
+
+	clientConn, status, stopper, err := remote.Connect(ctx, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &process{
+		conn:            clientConn,
+		runtime:         stopper,
+		exited:          status.Lost,
+		instanceID:      status.InstanceID,
+		protocolVersion: status.ProtocolVersion,
+	}, nil
+}
+
+// serveRemote serves [vm] on [listenAddr] and waits for AvalancheGo to
+// connect. Connections are authenticated with mutual TLS.
+//
+// The server is stopped once the VM is shutdown, so the process should be
+// restarted by whatever manages it.
+func serveRemote(ctx context.Context, vm block.ChainVM, listenAddr string, opts ...grpcutils.ServerOption) error {
+	tlsConfig, err := grpcutils.NewMutualTLSConfig(
+		os.Getenv(runtime.TLSCertFileKey),
+		os.Getenv(runtime.TLSKeyFileKey),
+		os.Getenv(runtime.TLSCAFileKey),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS config: %w", err)
+	}
+
+	listenHost, _, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", listenAddr, err)
+	}
+
+	transport := &grpcutils.Transport{
+		ListenHost:    listenHost,
+		AdvertiseHost: os.Getenv(runtime.AdvertiseHostKey),
+		TLS:           tlsConfig,
+	}
+	listener, err := transport.Listen(listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create listener: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	vmServer := newServer(vm, transport)
+	vmServer.onShutdown = cancel
+
+	initializer, err := newRemoteInitializer(vmServer)
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	server := transport.NewServer(opts...)
+	vmpb.RegisterVMServer(server, vmServer)
+	registerEventsServer(server, vm)
+	runtimepb.RegisterRuntimeServer(server, gruntime.NewServer(initializer))
+
+	health := health.NewServer()
+	health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, health)
+
+	go stopOnSignal(ctx, server)
+
+	grpcutils.Serve(listener, server)
+	return nil
+}
+
+// remoteInitializer handles the handshake performed by AvalancheGo every time
+// it connects to the VM server.
+type remoteInitializer struct {
+	vmServer   *VMServer
+	instanceID string
+}
+
+func newRemoteInitializer(vmServer *VMServer) (*remoteInitializer, error) {
+	instanceID := make([]byte, instanceIDLen)
+	if _, err := rand.Read(instanceID); err != nil {
+		return nil, fmt.Errorf("failed to generate instance ID: %w", err)
+	}
+	return &remoteInitializer{
+		vmServer:   vmServer,
+		instanceID: hex.EncodeToString(instanceID),
+	}, nil
+}
+
+func (i *remoteInitializer) Initialize(ctx context.Context, minProtocolVersion, maxProtocolVersion uint, _ string) (uint, error) {
+	protocolVersion, err := runtime.NegotiateProtocolVersion(minProtocolVersion, maxProtocolVersion)
+	if err != nil {
+		return 0, err
+	}
+	i.vmServer.protocolVersion.Set(protocolVersion)
+	return protocolVersion, grpc.SetHeader(ctx, metadata.Pairs(runtime.InstanceIDHeader, i.instanceID))
+}
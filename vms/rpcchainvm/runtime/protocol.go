@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// NegotiateProtocolVersion returns the newest protocol version in
+// [minProtocolVersion, maxProtocolVersion] that is also supported by this
+// binary.
+func NegotiateProtocolVersion(minProtocolVersion, maxProtocolVersion uint) (uint, error) {
+	protocolVersion := math.Min(maxProtocolVersion, version.RPCChainVMProtocol)
+	if minProtocolVersion > maxProtocolVersion ||
+		protocolVersion < minProtocolVersion ||
+		protocolVersion < version.RPCChainVMProtocolMin {
+		return 0, fmt.Errorf(
+			"%w supported: [%d, %d], requested: [%d, %d]",
+			ErrProtocolVersionMismatch,
+			version.RPCChainVMProtocolMin,
+			version.RPCChainVMProtocol,
+			minProtocolVersion,
+			maxProtocolVersion,
+		)
+	}
+	return protocolVersion, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		name                    string
+		minProtocolVersion      uint
+		maxProtocolVersion      uint
+		expectedProtocolVersion uint
+		expectedErr             error
+	}{
+		{
+			name:                    "same version",
+			minProtocolVersion:      version.RPCChainVMProtocol,
+			maxProtocolVersion:      version.RPCChainVMProtocol,
+			expectedProtocolVersion: version.RPCChainVMProtocol,
+		},
+		{
+			name:                    "older peer",
+			minProtocolVersion:      version.RPCChainVMProtocolMin,
+			maxProtocolVersion:      version.RPCChainVMProtocolMin,
+			expectedProtocolVersion: version.RPCChainVMProtocolMin,
+		},
+		{
+			name:                    "newer peer",
+			minProtocolVersion:      version.RPCChainVMProtocolMin,
+			maxProtocolVersion:      version.RPCChainVMProtocol + 1,
+			expectedProtocolVersion: version.RPCChainVMProtocol,
+		},
+		{
+			name:               "peer too old",
+			minProtocolVersion: version.RPCChainVMProtocolMin - 1,
+			maxProtocolVersion: version.RPCChainVMProtocolMin - 1,
+			expectedErr:        ErrProtocolVersionMismatch,
+		},
+		{
+			name:               "peer too new",
+			minProtocolVersion: version.RPCChainVMProtocol + 1,
+			maxProtocolVersion: version.RPCChainVMProtocol + 2,
+			expectedErr:        ErrProtocolVersionMismatch,
+		},
+		{
+			name:               "invalid range",
+			minProtocolVersion: version.RPCChainVMProtocol,
+			maxProtocolVersion: version.RPCChainVMProtocolMin,
+			expectedErr:        ErrProtocolVersionMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			protocolVersion, err := NegotiateProtocolVersion(test.minProtocolVersion, test.maxProtocolVersion)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedProtocolVersion, protocolVersion)
+		})
+	}
+}
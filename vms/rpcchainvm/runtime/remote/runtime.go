@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package remote attaches to VM servers that are managed outside of
+// AvalancheGo, potentially on other hosts.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+type Config struct {
+	// Address of the VM server.
+	Addr string
+	// Transport used to connect to the VM server. It must be configured with
+	// TLS so that both sides are authenticated.
+	Transport *grpcutils.Transport
+	// Duration to wait for the handshake to succeed.
+	HandshakeTimeout time.Duration
+	Log              logging.Logger
+}
+
+type Status struct {
+	// Identifies the instance of the VM server. If this changes after
+	// reconnecting, the VM server was restarted and lost its state.
+	InstanceID string
+	// Lost is closed once the connection to the VM server is lost, or once it
+	// is re-established to a different instance of the VM server.
+	Lost <-chan struct{}
+	// Protocol version negotiated with the VM server.
+	ProtocolVersion uint
+}
+
+// Connect dials the VM server and performs the runtime handshake.
+//
+// The returned stopper closes the connection to the VM server. It doesn't stop
+// the VM server, as its lifecycle is managed outside of AvalancheGo.
+func Connect(ctx context.Context, config *Config) (*grpc.ClientConn, *Status, runtime.Stopper, error) {
+	switch {
+	case config.Addr == "":
+		return nil, nil, nil, fmt.Errorf("%w: address required", runtime.ErrInvalidConfig)
+	case config.Transport == nil, config.Transport.TLS == nil:
+		return nil, nil, nil, fmt.Errorf("%w: mutual TLS required", runtime.ErrInvalidConfig)
+	case config.Log == nil:
+		return nil, nil, nil, fmt.Errorf("%w: logger required", runtime.ErrInvalidConfig)
+	}
+
+	clientConn, err := config.Transport.Dial(config.Addr)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create client conn: %w", err)
+	}
+
+	status, err := handshake(ctx, clientConn, config)
+	if err != nil {
+		_ = clientConn.Close()
+		return nil, nil, nil, err
+	}
+
+	config.Log.Info("remote vm handshake succeeded",
+		zap.String("addr", config.Addr),
+		zap.String("instanceID", status.InstanceID),
+		zap.Uint("protocolVersion", status.ProtocolVersion),
+	)
+
+	lost := make(chan struct{})
+	go watch(clientConn, config, status.InstanceID, lost)
+	status.Lost = lost
+	return clientConn, status, &stopper{conn: clientConn}, nil
+}
+
+// handshake sends the runtime Initialize RPC to the VM server, which
+// negotiates the protocol version, and returns the instance ID of the VM
+// server.
+func handshake(ctx context.Context, clientConn *grpc.ClientConn, config *Config) (*Status, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.HandshakeTimeout)
+	defer cancel()
+	ctx = gruntime.WithMinProtocolVersion(ctx, version.RPCChainVMProtocolMin)
+
+	var header metadata.MD
+	_, err := pb.NewRuntimeClient(clientConn).Initialize(
+		ctx,
+		&pb.InitializeRequest{
+			ProtocolVersion: uint32(version.RPCChainVMProtocol),
+			Addr:            config.Addr,
+		},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", runtime.ErrHandshakeFailed, err)
+	}
+
+	protocolVersion, err := gruntime.ProtocolVersion(header, version.RPCChainVMProtocolMin, version.RPCChainVMProtocol)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", runtime.ErrHandshakeFailed, err)
+	}
+
+	instanceIDs := header.Get(runtime.InstanceIDHeader)
+	if len(instanceIDs) != 1 || instanceIDs[0] == "" {
+		return nil, fmt.Errorf("%w: missing instance ID", runtime.ErrHandshakeFailed)
+	}
+	return &Status{
+		InstanceID:      instanceIDs[0],
+		ProtocolVersion: protocolVersion,
+	}, nil
+}
+
+// watch closes [lost] once [clientConn] fails to connect to the VM server or
+// is closed.
+//
+// The connection may go idle and be re-established without the VM server
+// losing its state. However, if the connection is re-established to a VM
+// server whose instance ID isn't [instanceID], the VM server was restarted and
+// [lost] is closed so that its state is restored.
+func watch(clientConn *grpc.ClientConn, config *Config, instanceID string, lost chan<- struct{}) {
+	defer close(lost)
+
+	state := clientConn.GetState()
+	for {
+		switch state {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			config.Log.Warn("lost connection to remote vm",
+				zap.String("addr", config.Addr),
+				zap.Stringer("state", state),
+			)
+			return
+		case connectivity.Idle:
+			// Reconnect eagerly, so that a restart of the VM server is
+			// noticed before the next request is sent to it.
+			clientConn.Connect()
+		}
+
+		previousState := state
+		clientConn.WaitForStateChange(context.Background(), state)
+		state = clientConn.GetState()
+		if state != connectivity.Ready || previousState == connectivity.Ready {
+			continue
+		}
+
+		status, err := handshake(context.Background(), clientConn, config)
+		if err != nil {
+			config.Log.Warn("remote vm handshake failed after reconnecting",
+				zap.String("addr", config.Addr),
+				zap.Error(err),
+			)
+			return
+		}
+		if status.InstanceID != instanceID {
+			config.Log.Warn("remote vm was restarted",
+				zap.String("addr", config.Addr),
+				zap.String("previousInstanceID", instanceID),
+				zap.String("instanceID", status.InstanceID),
+			)
+			return
+		}
+	}
+}
+
+type stopper struct {
+	once sync.Once
+	conn *grpc.ClientConn
+}
+
+func (s *stopper) Stop(context.Context) {
+	s.once.Do(func() {
+		_ = s.conn.Close()
+	})
+}
+
+// VMConfig describes how to reach a VM server that is managed outside of
+// AvalancheGo.
+type VMConfig struct {
+	// Address of the VM server.
+	Address string `json:"address"`
+	// Host that the servers AvalancheGo exposes to the VM are bound to. If
+	// empty, they are bound to localhost.
+	ListenHost string `json:"listenHost"`
+	// Host that the VM should use to reach the servers AvalancheGo exposes to
+	// it. If empty, [ListenHost] is used.
+	AdvertiseHost string `json:"advertiseHost"`
+	// Paths of the certificate, key and CA certificates used to authenticate
+	// connections with the VM.
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	CAFile   string `json:"caFile"`
+}
+
+// Transport returns the transport described by [c].
+func (c *VMConfig) Transport() (*grpcutils.Transport, error) {
+	if c.Address == "" {
+		return nil, fmt.Errorf("%w: address required", runtime.ErrInvalidConfig)
+	}
+	tlsConfig, err := grpcutils.NewMutualTLSConfig(c.CertFile, c.KeyFile, c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcutils.Transport{
+		ListenHost:    c.ListenHost,
+		AdvertiseHost: c.AdvertiseHost,
+		TLS:           tlsConfig,
+	}, nil
+}
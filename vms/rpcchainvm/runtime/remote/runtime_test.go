@@ -0,0 +1,286 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+type testInitializer struct {
+	lock       sync.Mutex
+	instanceID string
+	handshakes int
+}
+
+func (i *testInitializer) Initialize(ctx context.Context, minProtocolVersion, maxProtocolVersion uint, _ string) (uint, error) {
+	protocolVersion, err := runtime.NegotiateProtocolVersion(minProtocolVersion, maxProtocolVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.handshakes++
+	return protocolVersion, grpc.SetHeader(ctx, metadata.Pairs(runtime.InstanceIDHeader, i.instanceID))
+}
+
+func (i *testInitializer) setInstanceID(instanceID string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.instanceID = instanceID
+}
+
+func (i *testInitializer) numHandshakes() int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.handshakes
+}
+
+// connListener records the connections it accepted, so that they can be
+// closed without stopping the server.
+type connListener struct {
+	net.Listener
+
+	lock  sync.Mutex
+	conns []net.Conn
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.conns = append(l.conns, conn)
+	return conn, nil
+}
+
+func (l *connListener) closeConns() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, conn := range l.conns {
+		_ = conn.Close()
+	}
+	l.conns = nil
+}
+
+func TestConnect(t *testing.T) {
+	require := require.New(t)
+
+	transport := &grpcutils.Transport{
+		TLS: newTestTLSConfig(t),
+	}
+	listener, err := transport.NewListener()
+	require.NoError(err)
+
+	server := transport.NewServer()
+	pb.RegisterRuntimeServer(server, gruntime.NewServer(&testInitializer{
+		instanceID: "instance",
+	}))
+	go grpcutils.Serve(listener, server)
+
+	conn, status, stopper, err := Connect(context.Background(), &Config{
+		Addr:             listener.Addr().String(),
+		Transport:        transport,
+		HandshakeTimeout: 5 * time.Second,
+		Log:              logging.NoLog{},
+	})
+	require.NoError(err)
+	require.NotNil(conn)
+	require.Equal("instance", status.InstanceID)
+	require.Equal(version.RPCChainVMProtocol, status.ProtocolVersion)
+
+	select {
+	case <-status.Lost:
+		require.FailNow("connection unexpectedly lost")
+	default:
+	}
+
+	// Stopping the server must be reported as the connection being lost.
+	server.Stop()
+	select {
+	case <-status.Lost:
+	case <-time.After(5 * time.Second):
+		require.FailNow("connection loss wasn't reported")
+	}
+
+	stopper.Stop(context.Background())
+	stopper.Stop(context.Background())
+}
+
+func TestConnectReconnect(t *testing.T) {
+	tests := []struct {
+		name          string
+		newInstanceID string
+		expectLost    bool
+	}{
+		{
+			name:          "same instance",
+			newInstanceID: "instance",
+			expectLost:    false,
+		},
+		{
+			name:          "restarted instance",
+			newInstanceID: "restarted",
+			expectLost:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			transport := &grpcutils.Transport{
+				TLS: newTestTLSConfig(t),
+			}
+			tcpListener, err := transport.NewListener()
+			require.NoError(err)
+			listener := &connListener{Listener: tcpListener}
+
+			initializer := &testInitializer{
+				instanceID: "instance",
+			}
+			server := transport.NewServer()
+			defer server.Stop()
+			pb.RegisterRuntimeServer(server, gruntime.NewServer(initializer))
+			go grpcutils.Serve(listener, server)
+
+			_, status, stopper, err := Connect(context.Background(), &Config{
+				Addr:             listener.Addr().String(),
+				Transport:        transport,
+				HandshakeTimeout: 5 * time.Second,
+				Log:              logging.NoLog{},
+			})
+			require.NoError(err)
+			defer stopper.Stop(context.Background())
+
+			// The connection is re-established to the same server.
+			initializer.setInstanceID(test.newInstanceID)
+			listener.closeConns()
+
+			if test.expectLost {
+				select {
+				case <-status.Lost:
+				case <-time.After(5 * time.Second):
+					require.FailNow("restart wasn't reported")
+				}
+				return
+			}
+
+			require.Eventually(func() bool {
+				return initializer.numHandshakes() == 2
+			}, 5*time.Second, time.Millisecond)
+			require.Never(func() bool {
+				select {
+				case <-status.Lost:
+					return true
+				default:
+					return false
+				}
+			}, 100*time.Millisecond, time.Millisecond)
+		})
+	}
+}
+
+func TestConnectMissingInstanceID(t *testing.T) {
+	require := require.New(t)
+
+	transport := &grpcutils.Transport{
+		TLS: newTestTLSConfig(t),
+	}
+	listener, err := transport.NewListener()
+	require.NoError(err)
+
+	server := transport.NewServer()
+	defer server.Stop()
+	pb.RegisterRuntimeServer(server, gruntime.NewServer(&testInitializer{}))
+	go grpcutils.Serve(listener, server)
+
+	_, _, _, err = Connect(context.Background(), &Config{
+		Addr:             listener.Addr().String(),
+		Transport:        transport,
+		HandshakeTimeout: 5 * time.Second,
+		Log:              logging.NoLog{},
+	})
+	require.ErrorIs(err, runtime.ErrHandshakeFailed)
+}
+
+func TestConnectRequiresTLS(t *testing.T) {
+	require := require.New(t)
+
+	_, _, _, err := Connect(context.Background(), &Config{
+		Addr:             "127.0.0.1:9650",
+		Transport:        &grpcutils.Transport{},
+		HandshakeTimeout: time.Second,
+		Log:              logging.NoLog{},
+	})
+	require.ErrorIs(err, runtime.ErrInvalidConfig)
+}
+
+// newTestTLSConfig returns a TLS config with a self-signed certificate, valid
+// for 127.0.0.1, that trusts only itself.
+func newTestTLSConfig(t *testing.T) *tls.Config {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certBytes},
+			PrivateKey:  key,
+		}},
+		RootCAs:    pool,
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		MinVersion: tls.VersionTLS13,
+	}
+}
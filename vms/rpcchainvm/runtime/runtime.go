@@ -13,11 +13,60 @@ const (
 	// Address of the runtime engine server.
 	EngineAddressKey = "AVALANCHE_VM_RUNTIME_ENGINE_ADDR"
 
+	// Directory the VM should create its Unix domain sockets in. If set,
+	// AvalancheGo supports connecting to the VM over Unix domain sockets. The
+	// VM opts in by reporting the address of a Unix domain socket during the
+	// handshake.
+	UnixSocketDirKey = "AVALANCHE_VM_RUNTIME_UNIX_SOCKET_DIR"
+
+	// Address the VM server listens on if the VM is managed outside of
+	// AvalancheGo. If set, AvalancheGo connects to the VM rather than the VM
+	// connecting to the runtime engine server.
+	ListenAddressKey = "AVALANCHE_VM_RUNTIME_LISTEN_ADDR"
+
+	// Host AvalancheGo uses to reach the servers created by a remote VM.
+	AdvertiseHostKey = "AVALANCHE_VM_RUNTIME_ADVERTISE_HOST"
+
+	// Paths of the certificate, key and CA certificates used by a remote VM
+	// to authenticate connections with AvalancheGo.
+	TLSCertFileKey = "AVALANCHE_VM_RUNTIME_TLS_CERT_FILE"
+	TLSKeyFileKey  = "AVALANCHE_VM_RUNTIME_TLS_KEY_FILE"
+	TLSCAFileKey   = "AVALANCHE_VM_RUNTIME_TLS_CA_FILE"
+
+	// Header the sender of the handshake includes to report the oldest
+	// protocol version it supports. If omitted, the sender only supports the
+	// protocol version in the handshake request.
+	MinProtocolVersionHeader = "avalanche-vm-min-protocol-version"
+
+	// Header the receiver of the handshake includes in its response to report
+	// the protocol version that both sides must use. If omitted, the receiver
+	// only accepted the protocol version in the handshake request.
+	ProtocolVersionHeader = "avalanche-vm-protocol-version"
+
+	// Header a remote VM server includes in its handshake response to
+	// identify the instance of the VM server.
+	InstanceIDHeader = "avalanche-vm-instance-id"
+
 	// Duration before handshake timeout during bootstrap.
 	DefaultHandshakeTimeout = 5 * time.Second
 
 	// Duration of time to wait for graceful termination to complete.
 	DefaultGracefulTimeout = 5 * time.Second
+
+	// Duration to wait before restarting a VM process that exited
+	// unexpectedly. The duration doubles for every restart within the crash
+	// loop window.
+	DefaultRestartInitialBackoff = time.Second
+
+	// Maximum duration to wait before restarting a VM process.
+	DefaultRestartMaxBackoff = time.Minute
+
+	// Duration over which restarts are counted to detect crash loops.
+	DefaultCrashLoopWindow = 10 * time.Minute
+
+	// Number of restarts within the crash loop window after which the VM is
+	// reported as unhealthy.
+	DefaultCrashLoopThreshold = 3
 )
 
 var (
@@ -30,7 +79,11 @@ var (
 type Initializer interface {
 	// Initialize provides AvalancheGo with compatibility, networking and
 	// process information of a VM.
-	Initialize(ctx context.Context, protocolVersion uint, vmAddr string) error
+	//
+	// The caller supports the protocol versions in
+	// [minProtocolVersion, maxProtocolVersion]. Returns the protocol version
+	// that both sides must use.
+	Initialize(ctx context.Context, minProtocolVersion, maxProtocolVersion uint, vmAddr string) (uint, error)
 }
 
 type Stopper interface {
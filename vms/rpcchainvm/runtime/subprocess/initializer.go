@@ -5,10 +5,8 @@ package subprocess
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
-	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
@@ -19,6 +17,8 @@ type initializer struct {
 	once sync.Once
 	// Address of the RPC Chain VM server
 	vmAddr string
+	// Protocol version negotiated with the VM
+	protocolVersion uint
 	// Error, if one occurred, during Initialization
 	err error
 	// Initialized is closed once Initialize is called
@@ -31,18 +31,11 @@ func newInitializer() *initializer {
 	}
 }
 
-func (i *initializer) Initialize(_ context.Context, protocolVersion uint, vmAddr string) error {
+func (i *initializer) Initialize(_ context.Context, minProtocolVersion, maxProtocolVersion uint, vmAddr string) (uint, error) {
 	i.once.Do(func() {
-		if version.RPCChainVMProtocol != protocolVersion {
-			i.err = fmt.Errorf(
-				"%w avalanchego: %d, vm: %d",
-				runtime.ErrProtocolVersionMismatch,
-				version.RPCChainVMProtocol,
-				protocolVersion,
-			)
-		}
+		i.protocolVersion, i.err = runtime.NegotiateProtocolVersion(minProtocolVersion, maxProtocolVersion)
 		i.vmAddr = vmAddr
 		close(i.initialized)
 	})
-	return i.err
+	return i.protocolVersion, i.err
 }
@@ -30,7 +30,13 @@ type Config struct {
 	Stdout io.Writer
 	// Duration engine server will wait for handshake success.
 	HandshakeTimeout time.Duration
-	Log              logging.Logger
+	// Directory the VM may create Unix domain sockets in. If empty, the VM
+	// is only connected over TCP.
+	UnixSocketDir string
+	// Sandbox the VM process is started in. If nil, the VM process isn't
+	// sandboxed.
+	Sandbox *SandboxConfig
+	Log     logging.Logger
 }
 
 type Status struct {
@@ -38,6 +44,13 @@ type Status struct {
 	Pid int
 	// Address of the VM gRPC service.
 	Addr string
+	// Exited is closed once the process has exited.
+	Exited <-chan struct{}
+	// Protocol version negotiated with the VM.
+	ProtocolVersion uint
+	// Sandbox the process was started in. Nil if the process isn't
+	// sandboxed.
+	Sandbox *Sandbox
 }
 
 // Bootstrap starts a VM as a subprocess after initialization completes and
@@ -74,6 +87,9 @@ func Bootstrap(
 
 	serverAddr := listener.Addr()
 	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", runtime.EngineAddressKey, serverAddr.String()))
+	if config.UnixSocketDir != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", runtime.UnixSocketDirKey, config.UnixSocketDir))
+	}
 	// pass golang debug env to subprocess
 	for _, env := range os.Environ() {
 		if strings.HasPrefix(env, "GRPC_") || strings.HasPrefix(env, "GODEBUG") {
@@ -90,13 +106,35 @@ func Bootstrap(
 		return nil, nil, fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
+	var sandbox *Sandbox
+	if config.Sandbox != nil {
+		var writablePaths []string
+		if config.UnixSocketDir != "" {
+			writablePaths = append(writablePaths, config.UnixSocketDir)
+		}
+		sandbox, err = newSandbox(config.Log, cmd, config.Sandbox, writablePaths)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create sandbox: %w", err)
+		}
+	}
+
 	// start subproccess
 	if err := cmd.Start(); err != nil {
+		if sandbox != nil {
+			sandbox.close()
+		}
 		return nil, nil, fmt.Errorf("failed to start process: %w", err)
 	}
 
 	log := config.Log
-	stopper := NewStopper(log, cmd)
+	stopper := newStopper(log, cmd)
+
+	if sandbox != nil {
+		go func() {
+			<-stopper.exited
+			sandbox.close()
+		}()
+	}
 
 	// start stdout collector
 	go func() {
@@ -142,11 +180,15 @@ func Bootstrap(
 
 	log.Info("plugin handshake succeeded",
 		zap.String("addr", intitializer.vmAddr),
+		zap.Uint("protocolVersion", intitializer.protocolVersion),
 	)
 
 	status := &Status{
-		Pid:  cmd.Process.Pid,
-		Addr: intitializer.vmAddr,
+		Pid:             cmd.Process.Pid,
+		Addr:            intitializer.vmAddr,
+		Exited:          stopper.exited,
+		ProtocolVersion: intitializer.protocolVersion,
+		Sandbox:         sandbox,
 	}
 	return status, stopper, nil
 }
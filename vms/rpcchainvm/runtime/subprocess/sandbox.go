@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subprocess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// sandboxInitName is the name AvalancheGo is re-executed with to set up the
+// sandbox of a VM process before executing the VM.
+const sandboxInitName = "avalanchego-vm-sandbox-init"
+
+var (
+	ErrSandboxUnsupported  = errors.New("vm sandbox is not supported on this platform")
+	ErrMemoryLimitExceeded = errors.New("vm process exceeded its memory limit")
+
+	errInvalidCPUMax        = errors.New("cpu max must be non-negative")
+	errCgroupParentRequired = errors.New("cgroup parent is required to limit memory or cpu")
+	errUnknownSyscall       = errors.New("unknown syscall")
+	errMalformedCgroupKV    = errors.New("malformed cgroup key value pair")
+)
+
+// SandboxConfig restricts the resources available to a VM process. The zero
+// value doesn't restrict the VM process.
+type SandboxConfig struct {
+	// Maximum amount of memory, in bytes, the VM process may use. If the VM
+	// process exceeds the limit it is killed, rather than the node. 0 means
+	// unlimited.
+	MemoryMax uint64 `json:"memoryMax"`
+	// Maximum number of CPU cores the VM process may use. 0 means unlimited.
+	CPUMax float64 `json:"cpuMax"`
+	// cgroup v2 that the cgroups of the VM processes are created under.
+	// Required if MemoryMax or CPUMax is set. It must be delegated to the
+	// node, have the memory and cpu controllers available and not contain any
+	// processes. As cgroup v2 only allows processes in leaf cgroups, it can't
+	// be the cgroup of the node. Typically, the node is run in a leaf cgroup
+	// that is a sibling of the parent.
+	CgroupParent string `json:"cgroupParent"`
+	// Syscalls the VM process may make. Other syscalls fail with EPERM. If
+	// empty, syscalls aren't restricted.
+	SyscallAllowlist []string `json:"syscallAllowlist"`
+	// If true, the VM process can't write to any file system other than its
+	// private tmp, if any.
+	ReadOnlyRoot bool `json:"readOnlyRoot"`
+	// If true, the VM process is given an empty /tmp that isn't shared with
+	// any other process.
+	PrivateTmp bool `json:"privateTmp"`
+}
+
+// Verify returns an error if [c] can't be applied on this platform.
+func (c *SandboxConfig) Verify() error {
+	if c.CPUMax < 0 {
+		return errInvalidCPUMax
+	}
+	if c.hasCgroup() && c.CgroupParent == "" {
+		return errCgroupParentRequired
+	}
+	return verifySandbox(c)
+}
+
+func (c *SandboxConfig) hasCgroup() bool {
+	return c.MemoryMax > 0 || c.CPUMax > 0
+}
+
+func (c *SandboxConfig) hasMountNamespace() bool {
+	return c.ReadOnlyRoot || c.PrivateTmp
+}
+
+// sandboxInitConfig is passed to the sandbox init process.
+type sandboxInitConfig struct {
+	// cgroup to join. If empty, the cgroup isn't changed.
+	CgroupDir string `json:"cgroupDir"`
+	// Syscalls to allow. If empty, syscalls aren't restricted.
+	Syscalls      []string `json:"syscalls"`
+	ReadOnlyRoot  bool     `json:"readOnlyRoot"`
+	PrivateTmp    bool     `json:"privateTmp"`
+	WritablePaths []string `json:"writablePaths"`
+}
+
+// IsSandboxInit returns true if the current process was started to set up the
+// sandbox of a VM process, in which case RunSandboxInit must be called.
+func IsSandboxInit() bool {
+	return len(os.Args) > 0 && os.Args[0] == sandboxInitName
+}
+
+// Sandbox is the sandbox a VM process was started in.
+type Sandbox struct {
+	log       logging.Logger
+	memoryMax uint64
+	// cgroup of the VM process. Empty if the VM process isn't in its own
+	// cgroup.
+	cgroupDir string
+
+	lock sync.Mutex
+	// number of times the OOM killer was invoked as of the last health check
+	oomEvents uint64
+	// true once the VM process has exited
+	closed bool
+	// true if the VM process was killed for exceeding its memory limit
+	oomKilled bool
+}
+
+// Health returns an error if the VM process ran out of memory since the last
+// health check or was killed for exceeding its memory limit. Reaching the
+// limit isn't reported, as the kernel then reclaims memory from the VM
+// process.
+func (s *Sandbox) Health() error {
+	if s.memoryMax == 0 {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		if s.oomKilled {
+			return fmt.Errorf("%w of %d bytes", ErrMemoryLimitExceeded, s.memoryMax)
+		}
+		return nil
+	}
+
+	events, err := s.memoryEvents()
+	if err != nil {
+		return err
+	}
+	if events["oom_kill"] > 0 {
+		return fmt.Errorf("%w of %d bytes: %d processes were killed",
+			ErrMemoryLimitExceeded,
+			s.memoryMax,
+			events["oom_kill"],
+		)
+	}
+
+	oomEvents := events["oom"]
+	newOOMEvents := oomEvents - s.oomEvents
+	s.oomEvents = oomEvents
+	if newOOMEvents > 0 {
+		return fmt.Errorf("%w of %d bytes: ran out of memory %d times since the last health check",
+			ErrMemoryLimitExceeded,
+			s.memoryMax,
+			newOOMEvents,
+		)
+	}
+	return nil
+}
+
+// close removes the cgroup of the VM process. Must only be called once the VM
+// process has exited.
+func (s *Sandbox) close() {
+	if s.cgroupDir == "" {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	if s.memoryMax > 0 {
+		events, err := s.memoryEvents()
+		if err != nil {
+			s.log.Warn("failed to read vm process memory events",
+				zap.Error(err),
+			)
+		}
+		if events["oom_kill"] > 0 {
+			s.oomKilled = true
+			s.log.Warn("vm process was killed for exceeding its memory limit",
+				zap.Uint64("memoryMax", s.memoryMax),
+			)
+		}
+	}
+
+	if err := os.Remove(s.cgroupDir); err != nil {
+		s.log.Warn("failed to remove vm process cgroup",
+			zap.String("cgroup", s.cgroupDir),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *Sandbox) memoryEvents() (map[string]uint64, error) {
+	events, err := readCgroupKVs(filepath.Join(s.cgroupDir, "memory.events"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory events: %w", err)
+	}
+	return events, nil
+}
+
+// readCgroupKVs reads a cgroup file formatted as lines of space separated
+// keys and values.
+func readCgroupKVs(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	kvs := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: %q", errMalformedCgroupKV, scanner.Text())
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errMalformedCgroupKV, scanner.Text())
+		}
+		kvs[fields[0]] = value
+	}
+	return kvs, scanner.Err()
+}
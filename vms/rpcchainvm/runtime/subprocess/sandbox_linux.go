@@ -0,0 +1,484 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+// +build linux
+
+package subprocess
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// cgroupPattern is the pattern of the names of the cgroups created for
+	// VM processes.
+	cgroupPattern = "avalanchego-vm-"
+	// cpuMaxPeriod is the period, in microseconds, over which the CPU usage
+	// of a VM process is limited.
+	cpuMaxPeriod = 100_000
+
+	prSetNoNewPrivs      = 38
+	prCapAmbient         = 47
+	prCapAmbientClearAll = 4
+	seccompModeFilter    = 2
+
+	seccompRetKillProcess = 0x80000000
+	seccompRetErrno       = 0x00050000
+	seccompRetAllow       = 0x7fff0000
+
+	// offsets of the fields of struct seccomp_data
+	seccompDataNrOffset   = 0
+	seccompDataArchOffset = 4
+
+	// x32 syscalls on amd64 have this bit set
+	x32SyscallBit = 0x40000000
+)
+
+var (
+	errCgroupV2Required            = errors.New("cgroup v2 is required")
+	errCgroupControllerUnavailable = errors.New("cgroup controller unavailable")
+	errCgroupHasProcesses          = errors.New("cgroup parent contains processes")
+
+	// mountFlags are the mount options that must be preserved when a mount is
+	// remounted from within a user namespace.
+	mountFlags = map[string]uintptr{
+		"nosuid":      syscall.MS_NOSUID,
+		"nodev":       syscall.MS_NODEV,
+		"noexec":      syscall.MS_NOEXEC,
+		"noatime":     syscall.MS_NOATIME,
+		"nodiratime":  syscall.MS_NODIRATIME,
+		"relatime":    syscall.MS_RELATIME,
+		"strictatime": syscall.MS_STRICTATIME,
+	}
+)
+
+func verifySandbox(config *SandboxConfig) error {
+	if len(config.SyscallAllowlist) > 0 && auditArch == 0 {
+		return fmt.Errorf("%w: syscall allowlist on %s", ErrSandboxUnsupported, runtime.GOARCH)
+	}
+	for _, name := range config.SyscallAllowlist {
+		if _, ok := syscalls[name]; !ok {
+			return fmt.Errorf("%w: %q", errUnknownSyscall, name)
+		}
+	}
+	return nil
+}
+
+// newSandbox modifies [cmd] to be started in a sandbox defined by [config].
+// The VM process is still able to write to [writablePaths].
+func newSandbox(log logging.Logger, cmd *exec.Cmd, config *SandboxConfig, writablePaths []string) (*Sandbox, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	sandbox := &Sandbox{
+		log:       log,
+		memoryMax: config.MemoryMax,
+	}
+	if config.hasCgroup() {
+		cgroupDir, err := newCgroup(config)
+		if err != nil {
+			return nil, err
+		}
+		sandbox.cgroupDir = cgroupDir
+	}
+
+	initConfig := sandboxInitConfig{
+		CgroupDir:     sandbox.cgroupDir,
+		ReadOnlyRoot:  config.ReadOnlyRoot,
+		PrivateTmp:    config.PrivateTmp,
+		WritablePaths: writablePaths,
+	}
+	if len(config.SyscallAllowlist) > 0 {
+		// The VM is executed by the sandbox init process once the filter is
+		// installed.
+		initConfig.Syscalls = append([]string{"execve"}, config.SyscallAllowlist...)
+	}
+	initConfigBytes, err := json.Marshal(initConfig)
+	if err != nil {
+		sandbox.close()
+		return nil, err
+	}
+
+	cmd.Args = append([]string{sandboxInitName, string(initConfigBytes), cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	if config.hasMountNamespace() {
+		cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWNS
+		// Unprivileged processes can only create a mount namespace from
+		// within a user namespace they own.
+		if uid := os.Geteuid(); uid != 0 {
+			cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER
+			cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{
+				ContainerID: 0,
+				HostID:      uid,
+				Size:        1,
+			}}
+			cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{
+				ContainerID: 0,
+				HostID:      os.Getegid(),
+				Size:        1,
+			}}
+		}
+	}
+	return sandbox, nil
+}
+
+// newCgroup creates a cgroup with the memory and CPU limits defined by
+// [config] under the configured parent and returns its path.
+func newCgroup(config *SandboxConfig) (string, error) {
+	parent := config.CgroupParent
+	if parent == "" {
+		return "", errCgroupParentRequired
+	}
+
+	availableControllers, err := os.ReadFile(filepath.Join(parent, "cgroup.controllers"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s isn't a cgroup: %v", errCgroupV2Required, parent, err)
+	}
+	var controllers []string
+	if config.MemoryMax > 0 {
+		controllers = append(controllers, "memory")
+	}
+	if config.CPUMax > 0 {
+		controllers = append(controllers, "cpu")
+	}
+	enable := make([]string, len(controllers))
+	for i, controller := range controllers {
+		if !slices.Contains(strings.Fields(string(availableControllers)), controller) {
+			return "", fmt.Errorf("%w: %s in %s", errCgroupControllerUnavailable, controller, parent)
+		}
+		enable[i] = "+" + controller
+	}
+	// Controllers can't be enabled for the children of a cgroup that
+	// contains processes.
+	procs, err := os.ReadFile(filepath.Join(parent, "cgroup.procs"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read processes of %s: %w", parent, err)
+	}
+	if len(strings.TrimSpace(string(procs))) != 0 {
+		return "", fmt.Errorf("%w: %s", errCgroupHasProcesses, parent)
+	}
+	if err := writeCgroupFile(parent, "cgroup.subtree_control", strings.Join(enable, " ")); err != nil {
+		return "", fmt.Errorf("failed to enable controllers of %s, it must be delegated to the node: %w", parent, err)
+	}
+
+	dir, err := os.MkdirTemp(parent, cgroupPattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	if err := setCgroupLimits(dir, config); err != nil {
+		_ = os.Remove(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+func setCgroupLimits(dir string, config *SandboxConfig) error {
+	if config.MemoryMax > 0 {
+		if err := writeCgroupFile(dir, "memory.max", strconv.FormatUint(config.MemoryMax, 10)); err != nil {
+			return err
+		}
+		// Swap would allow the VM process to exceed its memory limit. If
+		// swap isn't accounted for, it isn't available to the cgroup.
+		if err := writeCgroupFile(dir, "memory.swap.max", "0"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		// If any process of the VM is killed, they all are.
+		if err := writeCgroupFile(dir, "memory.oom.group", "1"); err != nil {
+			return err
+		}
+	}
+	if config.CPUMax > 0 {
+		quota := uint64(config.CPUMax * cpuMaxPeriod)
+		if quota == 0 {
+			quota = 1
+		}
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%d %d", quota, cpuMaxPeriod)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCgroupFile(dir string, name string, value string) error {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// RunSandboxInit sets up the sandbox of the current process and then executes
+// the VM. It never returns.
+func RunSandboxInit() {
+	if err := runSandboxInit(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to sandbox vm: %s\n", err)
+	}
+	os.Exit(1)
+}
+
+func runSandboxInit(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("expected at least 3 arguments but got %d", len(args))
+	}
+	var config sandboxInitConfig
+	if err := json.Unmarshal([]byte(args[0]), &config); err != nil {
+		return err
+	}
+
+	// Capabilities and syscall filters apply to the thread that executes the
+	// VM.
+	runtime.LockOSThread()
+
+	if config.CgroupDir != "" {
+		// Writing 0 moves the writing process.
+		if err := writeCgroupFile(config.CgroupDir, "cgroup.procs", "0"); err != nil {
+			return err
+		}
+	}
+	if config.ReadOnlyRoot || config.PrivateTmp {
+		if err := setupMounts(&config); err != nil {
+			return err
+		}
+	}
+	// Otherwise the VM could undo the mounts or leave its cgroup.
+	if err := dropCapabilities(); err != nil {
+		return err
+	}
+	if len(config.Syscalls) > 0 {
+		if err := installSyscallFilter(config.Syscalls); err != nil {
+			return err
+		}
+	}
+	return syscall.Exec(args[1], args[2:], os.Environ())
+}
+
+func setupMounts(config *sandboxInitConfig) error {
+	// Changes to the mounts must not propagate to the rest of the system.
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+
+	// The writable paths are opened before the private tmp is mounted as it
+	// may hide them.
+	writablePaths := make([]*os.File, len(config.WritablePaths))
+	for i, path := range config.WritablePaths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		writablePaths[i] = f
+	}
+
+	skippedMountPoints := make(map[string]struct{})
+	if config.PrivateTmp {
+		if err := syscall.Mount("tmpfs", "/tmp", "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+			return fmt.Errorf("failed to mount private tmp: %w", err)
+		}
+		skippedMountPoints["/tmp"] = struct{}{}
+	}
+	for _, f := range writablePaths {
+		path := f.Name()
+		if err := os.MkdirAll(path, 0o700); err != nil {
+			return err
+		}
+		source := fmt.Sprintf("/proc/self/fd/%d", f.Fd())
+		if err := syscall.Mount(source, path, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return fmt.Errorf("failed to mount %s: %w", path, err)
+		}
+		skippedMountPoints[path] = struct{}{}
+	}
+
+	if !config.ReadOnlyRoot {
+		return nil
+	}
+	mounts, err := readMounts("/proc/self/mountinfo")
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if _, ok := skippedMountPoints[m.point]; ok {
+			continue
+		}
+		flags := syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY | m.flags
+		if err := syscall.Mount("", m.point, "", flags, ""); err != nil {
+			return fmt.Errorf("failed to remount %s read-only: %w", m.point, err)
+		}
+	}
+	return nil
+}
+
+type mount struct {
+	point string
+	// flags that must be preserved when remounting
+	flags uintptr
+}
+
+// readMounts parses the mount points, and their flags, from a mountinfo file.
+func readMounts(path string) ([]mount, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []mount
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// See proc(5) for the format.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("malformed mountinfo line %q", scanner.Text())
+		}
+		point, err := unescapeMountPoint(fields[4])
+		if err != nil {
+			return nil, err
+		}
+		m := mount{
+			point: point,
+		}
+		for _, option := range strings.Split(fields[5], ",") {
+			m.flags |= mountFlags[option]
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPoint replaces the octal escape sequences used for whitespace
+// and backslashes in mountinfo files.
+func unescapeMountPoint(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+3 >= len(s) {
+			return "", fmt.Errorf("malformed mount point %q", s)
+		}
+		c, err := strconv.ParseUint(s[i+1:i+4], 8, 8)
+		if err != nil {
+			return "", fmt.Errorf("malformed mount point %q: %w", s, err)
+		}
+		sb.WriteByte(byte(c))
+		i += 3
+	}
+	return sb.String(), nil
+}
+
+// dropCapabilities prevents the processes executed by the current thread
+// from holding any capabilities.
+//
+// Setting no new privileges prevents capabilities from being gained from file
+// capabilities or set-user-ID binaries, and clearing the ambient capabilities
+// prevents them from being inherited. A process running as root, including
+// within a user namespace, is granted the capabilities of its bounding set on
+// exec, so the bounding set is cleared as well.
+func dropCapabilities() error {
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+		return fmt.Errorf("failed to set no new privileges: %w", errno)
+	}
+	// EINVAL is returned by kernels that don't support ambient capabilities.
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prCapAmbient, prCapAmbientClearAll, 0); errno != 0 && errno != syscall.EINVAL {
+		return fmt.Errorf("failed to clear ambient capabilities: %w", errno)
+	}
+	if os.Geteuid() != 0 {
+		return nil
+	}
+
+	lastCapBytes, err := os.ReadFile("/proc/sys/kernel/cap_last_cap")
+	if err != nil {
+		return err
+	}
+	lastCap, err := strconv.Atoi(strings.TrimSpace(string(lastCapBytes)))
+	if err != nil {
+		return err
+	}
+	for c := 0; c <= lastCap; c++ {
+		if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_CAPBSET_DROP, uintptr(c), 0); errno != 0 {
+			return fmt.Errorf("failed to drop capability %d: %w", c, errno)
+		}
+	}
+	return nil
+}
+
+// installSyscallFilter only allows the current thread, and the processes it
+// executes, to make the syscalls with the provided names.
+func installSyscallFilter(names []string) error {
+	nrs := make([]uint32, len(names))
+	for i, name := range names {
+		nr, ok := syscalls[name]
+		if !ok {
+			return fmt.Errorf("%w: %q", errUnknownSyscall, name)
+		}
+		nrs[i] = nr
+	}
+	filter := newSyscallFilter(nrs)
+
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+		return fmt.Errorf("failed to set no new privileges: %w", errno)
+	}
+	prog := syscall.SockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, seccompModeFilter, uintptr(unsafe.Pointer(&prog))); errno != 0 {
+		return fmt.Errorf("failed to install syscall filter: %w", errno)
+	}
+	runtime.KeepAlive(filter)
+	return nil
+}
+
+// newSyscallFilter returns a seccomp BPF program that allows the syscalls
+// [nrs] and fails all other syscalls with EPERM.
+func newSyscallFilter(nrs []uint32) []syscall.SockFilter {
+	filter := []syscall.SockFilter{
+		// Syscall numbers are only meaningful for the expected architecture.
+		bpfStmt(syscall.BPF_LD|syscall.BPF_W|syscall.BPF_ABS, seccompDataArchOffset),
+		bpfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, auditArch, 1, 0),
+		bpfStmt(syscall.BPF_RET|syscall.BPF_K, seccompRetKillProcess),
+		bpfStmt(syscall.BPF_LD|syscall.BPF_W|syscall.BPF_ABS, seccompDataNrOffset),
+	}
+	if runtime.GOARCH == "amd64" {
+		filter = append(filter,
+			bpfJump(syscall.BPF_JMP|syscall.BPF_JGE|syscall.BPF_K, x32SyscallBit, 0, 1),
+			bpfStmt(syscall.BPF_RET|syscall.BPF_K, seccompRetErrno|uint32(syscall.EPERM)),
+		)
+	}
+	for _, nr := range nrs {
+		filter = append(filter,
+			bpfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, nr, 0, 1),
+			bpfStmt(syscall.BPF_RET|syscall.BPF_K, seccompRetAllow),
+		)
+	}
+	return append(filter, bpfStmt(syscall.BPF_RET|syscall.BPF_K, seccompRetErrno|uint32(syscall.EPERM)))
+}
+
+func bpfStmt(code uint16, k uint32) syscall.SockFilter {
+	return syscall.SockFilter{Code: code, K: k}
+}
+
+func bpfJump(code uint16, k uint32, jt, jf uint8) syscall.SockFilter {
+	return syscall.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+}
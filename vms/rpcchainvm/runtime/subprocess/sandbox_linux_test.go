@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+// +build linux
+
+package subprocess
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// TestMain sets up the sandbox of a VM process when the test binary is
+// re-executed to do so.
+func TestMain(m *testing.M) {
+	if IsSandboxInit() {
+		RunSandboxInit()
+	}
+	os.Exit(m.Run())
+}
+
+// runSyscallFilter evaluates the subset of BPF used by syscall filters.
+func runSyscallFilter(t *testing.T, filter []syscall.SockFilter, arch uint32, nr uint32) uint32 {
+	var acc uint32
+	for pc := 0; pc < len(filter); pc++ {
+		ins := filter[pc]
+		switch ins.Code {
+		case syscall.BPF_LD | syscall.BPF_W | syscall.BPF_ABS:
+			switch ins.K {
+			case seccompDataNrOffset:
+				acc = nr
+			case seccompDataArchOffset:
+				acc = arch
+			default:
+				require.FailNow(t, "unexpected offset", ins.K)
+			}
+		case syscall.BPF_JMP | syscall.BPF_JEQ | syscall.BPF_K:
+			if acc == ins.K {
+				pc += int(ins.Jt)
+			} else {
+				pc += int(ins.Jf)
+			}
+		case syscall.BPF_JMP | syscall.BPF_JGE | syscall.BPF_K:
+			if acc >= ins.K {
+				pc += int(ins.Jt)
+			} else {
+				pc += int(ins.Jf)
+			}
+		case syscall.BPF_RET | syscall.BPF_K:
+			return ins.K
+		default:
+			require.FailNow(t, "unexpected instruction", ins.Code)
+		}
+	}
+	require.FailNow(t, "filter didn't return")
+	return 0
+}
+
+func TestSyscallFilter(t *testing.T) {
+	if auditArch == 0 {
+		t.Skip("syscall filtering isn't supported on this architecture")
+	}
+
+	filter := newSyscallFilter([]uint32{syscalls["read"], syscalls["write"]})
+
+	eperm := seccompRetErrno | uint32(syscall.EPERM)
+	tests := []struct {
+		name     string
+		arch     uint32
+		nr       uint32
+		expected uint32
+	}{
+		{
+			name:     "allowed",
+			arch:     auditArch,
+			nr:       syscalls["write"],
+			expected: seccompRetAllow,
+		},
+		{
+			name:     "denied",
+			arch:     auditArch,
+			nr:       syscalls["ptrace"],
+			expected: eperm,
+		},
+		{
+			name:     "unexpected architecture",
+			arch:     auditArch + 1,
+			nr:       syscalls["read"],
+			expected: seccompRetKillProcess,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, runSyscallFilter(t, filter, test.arch, test.nr))
+		})
+	}
+}
+
+func TestSandboxConfigVerify(t *testing.T) {
+	require := require.New(t)
+
+	config := &SandboxConfig{
+		CPUMax: -1,
+	}
+	err := config.Verify()
+	require.ErrorIs(err, errInvalidCPUMax)
+
+	if auditArch == 0 {
+		return
+	}
+	config = &SandboxConfig{
+		SyscallAllowlist: []string{"read", "not_a_syscall"},
+	}
+	err = config.Verify()
+	require.ErrorIs(err, errUnknownSyscall)
+}
+
+func TestNewCgroup(t *testing.T) {
+	require := require.New(t)
+
+	parent := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(parent, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(parent, "cgroup.procs"), nil, 0o600))
+	dir, err := newCgroup(&SandboxConfig{
+		MemoryMax:    1 << 30,
+		CPUMax:       1.5,
+		CgroupParent: parent,
+	})
+	require.NoError(err)
+	require.Equal(parent, filepath.Dir(dir))
+
+	for name, expected := range map[string]string{
+		filepath.Join(parent, "cgroup.subtree_control"): "+memory +cpu",
+		filepath.Join(dir, "memory.max"):                "1073741824",
+		filepath.Join(dir, "memory.swap.max"):           "0",
+		filepath.Join(dir, "memory.oom.group"):          "1",
+		filepath.Join(dir, "cpu.max"):                   "150000 100000",
+	} {
+		value, err := os.ReadFile(name)
+		require.NoError(err)
+		require.Equal(expected, string(value), name)
+	}
+}
+
+func TestNewCgroupControllerUnavailable(t *testing.T) {
+	require := require.New(t)
+
+	parent := t.TempDir()
+	_, err := newCgroup(&SandboxConfig{
+		MemoryMax:    1 << 30,
+		CgroupParent: parent,
+	})
+	require.ErrorIs(err, errCgroupV2Required)
+
+	require.NoError(os.WriteFile(filepath.Join(parent, "cgroup.controllers"), []byte("cpu pids\n"), 0o600))
+	_, err = newCgroup(&SandboxConfig{
+		MemoryMax:    1 << 30,
+		CgroupParent: parent,
+	})
+	require.ErrorIs(err, errCgroupControllerUnavailable)
+}
+
+// Tests that the cgroups of VM processes aren't created under a cgroup that
+// contains processes, such as the cgroup of the node, as cgroup v2 doesn't
+// allow controllers to be enabled for its children.
+func TestNewCgroupParentHasProcesses(t *testing.T) {
+	require := require.New(t)
+
+	parent := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(parent, "cgroup.controllers"), []byte("cpu memory\n"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(parent, "cgroup.procs"), []byte("1234\n"), 0o600))
+	_, err := newCgroup(&SandboxConfig{
+		MemoryMax:    1 << 30,
+		CgroupParent: parent,
+	})
+	require.ErrorIs(err, errCgroupHasProcesses)
+
+	_, err = os.Stat(filepath.Join(parent, "cgroup.subtree_control"))
+	require.ErrorIs(err, os.ErrNotExist)
+
+	_, err = newCgroup(&SandboxConfig{
+		MemoryMax: 1 << 30,
+	})
+	require.ErrorIs(err, errCgroupParentRequired)
+}
+
+// Tests that a sandboxed VM process holds no capabilities, even if it isn't
+// started in a mount namespace.
+func TestSandboxDropsCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	path, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat is required")
+	}
+
+	cmd := NewCmd(path, "/proc/self/status")
+	_, err = newSandbox(logging.NoLog{}, cmd, &SandboxConfig{}, nil)
+	require.NoError(err)
+	output, err := cmd.Output()
+	require.NoError(err)
+
+	status := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok {
+			status[key] = strings.TrimSpace(value)
+		}
+	}
+	require.Equal("1", status["NoNewPrivs"])
+	for _, key := range []string{"CapPrm", "CapEff", "CapAmb"} {
+		require.Equal("0000000000000000", status[key], key)
+	}
+	if os.Geteuid() == 0 {
+		require.Equal("0000000000000000", status["CapBnd"])
+	}
+}
+
+func TestNewSandboxWrapsCmd(t *testing.T) {
+	require := require.New(t)
+
+	cmd := NewCmd("/plugins/vm", "--flag")
+	_, err := newSandbox(logging.NoLog{}, cmd, &SandboxConfig{
+		ReadOnlyRoot: true,
+	}, nil)
+	require.NoError(err)
+
+	require.Equal("/proc/self/exe", cmd.Path)
+	require.Len(cmd.Args, 5)
+	require.Equal(sandboxInitName, cmd.Args[0])
+	require.Equal([]string{"/plugins/vm", "/plugins/vm", "--flag"}, cmd.Args[2:])
+	require.NotZero(cmd.SysProcAttr.Cloneflags & syscall.CLONE_NEWNS)
+	require.Equal(syscall.SIGTERM, cmd.SysProcAttr.Pdeathsig)
+}
+
+func TestReadMounts(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "mountinfo")
+	require.NoError(os.WriteFile(path, []byte(
+		"22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n"+
+			"23 22 0:5 / /dev rw,nosuid,noexec shared:2 - devtmpfs udev rw\n"+
+			"24 22 0:6 / /mnt/with\\040space ro,nodev - tmpfs tmpfs rw\n",
+	), 0o600))
+
+	mounts, err := readMounts(path)
+	require.NoError(err)
+	require.Equal([]mount{
+		{
+			point: "/",
+			flags: syscall.MS_RELATIME,
+		},
+		{
+			point: "/dev",
+			flags: syscall.MS_NOSUID | syscall.MS_NOEXEC,
+		},
+		{
+			point: "/mnt/with space",
+			flags: syscall.MS_NODEV,
+		},
+	}, mounts)
+}
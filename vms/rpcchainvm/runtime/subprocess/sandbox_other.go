@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !linux
+// +build !linux
+
+package subprocess
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func verifySandbox(config *SandboxConfig) error {
+	if config.hasCgroup() || config.hasMountNamespace() || len(config.SyscallAllowlist) > 0 {
+		return ErrSandboxUnsupported
+	}
+	return nil
+}
+
+func newSandbox(logging.Logger, *exec.Cmd, *SandboxConfig, []string) (*Sandbox, error) {
+	return nil, ErrSandboxUnsupported
+}
+
+// RunSandboxInit is only supported on Linux. It never returns.
+func RunSandboxInit() {
+	fmt.Fprintf(os.Stderr, "failed to sandbox vm: %s\n", ErrSandboxUnsupported)
+	os.Exit(1)
+}
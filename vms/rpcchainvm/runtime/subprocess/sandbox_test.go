@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subprocess
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func writeMemoryEvents(t *testing.T, dir string, events string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.events"), []byte(events), 0o600))
+}
+
+func TestSandboxHealth(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	sandbox := &Sandbox{
+		log:       logging.NoLog{},
+		memoryMax: 1024,
+		cgroupDir: dir,
+	}
+
+	writeMemoryEvents(t, dir, "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n")
+	require.NoError(sandbox.Health())
+
+	// Reaching the limit causes memory to be reclaimed, which isn't reported.
+	writeMemoryEvents(t, dir, "low 0\nhigh 0\nmax 3\noom 0\noom_kill 0\n")
+	require.NoError(sandbox.Health())
+
+	// Running out of memory is only reported once.
+	writeMemoryEvents(t, dir, "low 0\nhigh 0\nmax 4\noom 1\noom_kill 0\n")
+	err := sandbox.Health()
+	require.ErrorIs(err, ErrMemoryLimitExceeded)
+	require.NoError(sandbox.Health())
+
+	writeMemoryEvents(t, dir, "low 0\nhigh 0\nmax 5\noom 2\noom_kill 1\n")
+	err = sandbox.Health()
+	require.ErrorIs(err, ErrMemoryLimitExceeded)
+
+	// The OOM kill is still reported once the process has exited.
+	sandbox.close()
+	err = sandbox.Health()
+	require.ErrorIs(err, ErrMemoryLimitExceeded)
+}
+
+func TestSandboxHealthWithoutMemoryLimit(t *testing.T) {
+	sandbox := &Sandbox{
+		log: logging.NoLog{},
+	}
+	require.NoError(t, sandbox.Health())
+}
+
+func TestReadCgroupKVs(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    string
+		expected    map[string]uint64
+		expectedErr error
+	}{
+		{
+			name:     "valid",
+			contents: "max 1\noom_kill 2\n",
+			expected: map[string]uint64{
+				"max":      1,
+				"oom_kill": 2,
+			},
+		},
+		{
+			name:        "missing value",
+			contents:    "max\n",
+			expectedErr: errMalformedCgroupKV,
+		},
+		{
+			name:        "invalid value",
+			contents:    "max -1\n",
+			expectedErr: errMalformedCgroupKV,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(t.TempDir(), "memory.events")
+			require.NoError(os.WriteFile(path, []byte(test.contents), 0o600))
+
+			kvs, err := readCgroupKVs(path)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expected, kvs)
+			}
+		})
+	}
+}
@@ -8,25 +8,54 @@ import (
 	"os/exec"
 	"sync"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
+// NewStopper returns a stopper for the started [cmd]. The stopper reaps the
+// process once it exits.
 func NewStopper(logger logging.Logger, cmd *exec.Cmd) runtime.Stopper {
-	return &stopper{
+	return newStopper(logger, cmd)
+}
+
+func newStopper(logger logging.Logger, cmd *exec.Cmd) *stopper {
+	s := &stopper{
 		cmd:    cmd,
 		logger: logger,
+		exited: make(chan struct{}),
 	}
+	go s.wait()
+	return s
 }
 
 type stopper struct {
 	once   sync.Once
 	cmd    *exec.Cmd
 	logger logging.Logger
+
+	// exited is closed once the process has exited
+	exited chan struct{}
 }
 
 func (s *stopper) Stop(ctx context.Context) {
 	s.once.Do(func() {
-		stop(ctx, s.logger, s.cmd)
+		stop(ctx, s.logger, s.cmd, s.exited)
 	})
 }
+
+func (s *stopper) wait() {
+	defer close(s.exited)
+
+	state, err := s.cmd.Process.Wait()
+	if err != nil {
+		s.logger.Error("failed to wait for subprocess",
+			zap.Error(err),
+		)
+		return
+	}
+	s.logger.Debug("subprocess exited",
+		zap.Stringer("state", state),
+	)
+}
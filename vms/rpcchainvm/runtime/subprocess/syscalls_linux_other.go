@@ -0,0 +1,12 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux && !amd64 && !arm64
+// +build linux,!amd64,!arm64
+
+package subprocess
+
+// auditArch is 0 on architectures that syscall filtering isn't supported on.
+const auditArch = 0
+
+var syscalls map[string]uint32
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const (
+	// Payloads whose total size is at least [sharedPayloadsThreshold] bytes
+	// are shared through the directory of the Unix domain sockets, rather than
+	// being sent over gRPC.
+	sharedPayloadsThreshold = 256 * units.KiB
+
+	sharedPayloadsFilePrefix = "payloads-"
+	sharedPayloadsBufferSize = 64 * units.KiB
+)
+
+var (
+	errSharedPayloadsUnsupported    = errors.New("shared payloads aren't supported")
+	errInvalidSharedPayloadsName    = errors.New("invalid shared payloads name")
+	errSharedPayloadsLengthMismatch = errors.New("shared payloads length mismatch")
+)
+
+// shouldSharePayloads returns true if [payloads] are large enough to be
+// shared, rather than sent over gRPC, when [dir] is non-empty.
+func shouldSharePayloads(dir string, payloads [][]byte) bool {
+	if dir == "" {
+		return false
+	}
+	size := 0
+	for _, payload := range payloads {
+		size += len(payload)
+	}
+	return size >= sharedPayloadsThreshold
+}
+
+// writeSharedPayloads writes [payloads] to a new file in [dir]. The returned
+// reference must be passed to readSharedPayloads, which removes the file.
+//
+// Sharing payloads skips encoding them with protobuf and copying them through
+// the Unix domain socket.
+func writeSharedPayloads(dir string, payloads [][]byte) (*vmpb.SharedPayloads, error) {
+	file, err := os.CreateTemp(dir, sharedPayloadsFilePrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shared payloads: %w", err)
+	}
+
+	lengths := make([]uint32, len(payloads))
+	writer := bufio.NewWriterSize(file, sharedPayloadsBufferSize)
+	for i, payload := range payloads {
+		lengths[i] = uint32(len(payload))
+		if _, err = writer.Write(payload); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to write shared payloads: %w", err)
+	}
+	return &vmpb.SharedPayloads{
+		Name:    filepath.Base(file.Name()),
+		Lengths: lengths,
+	}, nil
+}
+
+// readSharedPayloads reads and removes the payloads written by
+// writeSharedPayloads. The payloads are read with a single allocation.
+func readSharedPayloads(dir string, shared *vmpb.SharedPayloads) ([][]byte, error) {
+	if dir == "" {
+		return nil, errSharedPayloadsUnsupported
+	}
+
+	// The name is provided by the other process, so it must not be able to
+	// cause files outside of [dir] to be read or removed.
+	name := shared.Name
+	if filepath.Base(name) != name || !strings.HasPrefix(name, sharedPayloadsFilePrefix) {
+		return nil, fmt.Errorf("%w: %q", errInvalidSharedPayloadsName, name)
+	}
+
+	path := filepath.Join(dir, name)
+	bytes, err := os.ReadFile(path)
+	_ = os.Remove(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shared payloads: %w", err)
+	}
+
+	payloads := make([][]byte, len(shared.Lengths))
+	for i, length := range shared.Lengths {
+		if uint64(length) > uint64(len(bytes)) {
+			return nil, errSharedPayloadsLengthMismatch
+		}
+		payloads[i] = bytes[:length:length]
+		bytes = bytes[length:]
+	}
+	if len(bytes) != 0 {
+		return nil, errSharedPayloadsLengthMismatch
+	}
+	return payloads, nil
+}
+
+// removeSharedPayloads removes the payloads written by writeSharedPayloads
+// if they weren't read.
+func removeSharedPayloads(dir string, shared *vmpb.SharedPayloads) {
+	_ = os.Remove(filepath.Join(dir, shared.Name))
+}
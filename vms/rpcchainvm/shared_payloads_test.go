@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+func TestSharedPayloads(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	payloads := [][]byte{
+		{1, 2, 3},
+		{},
+		{4, 5},
+	}
+	shared, err := writeSharedPayloads(dir, payloads)
+	require.NoError(err)
+	require.Equal([]uint32{3, 0, 2}, shared.Lengths)
+
+	readPayloads, err := readSharedPayloads(dir, shared)
+	require.NoError(err)
+	require.Equal(payloads, readPayloads)
+
+	// The payloads are removed once they are read.
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Empty(entries)
+}
+
+func TestReadSharedPayloadsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		dir         string
+		shared      func(t *testing.T) *vmpb.SharedPayloads
+		expectedErr error
+	}{
+		{
+			name: "unsupported",
+			dir:  "",
+			shared: func(*testing.T) *vmpb.SharedPayloads {
+				return &vmpb.SharedPayloads{
+					Name: sharedPayloadsFilePrefix,
+				}
+			},
+			expectedErr: errSharedPayloadsUnsupported,
+		},
+		{
+			name: "outside of directory",
+			dir:  dir,
+			shared: func(*testing.T) *vmpb.SharedPayloads {
+				return &vmpb.SharedPayloads{
+					Name: "../" + sharedPayloadsFilePrefix,
+				}
+			},
+			expectedErr: errInvalidSharedPayloadsName,
+		},
+		{
+			name: "not shared payloads",
+			dir:  dir,
+			shared: func(*testing.T) *vmpb.SharedPayloads {
+				return &vmpb.SharedPayloads{
+					Name: "vm.sock",
+				}
+			},
+			expectedErr: errInvalidSharedPayloadsName,
+		},
+		{
+			name: "too short",
+			dir:  dir,
+			shared: func(t *testing.T) *vmpb.SharedPayloads {
+				shared, err := writeSharedPayloads(dir, [][]byte{{1}})
+				require.NoError(t, err)
+				shared.Lengths = []uint32{2}
+				return shared
+			},
+			expectedErr: errSharedPayloadsLengthMismatch,
+		},
+		{
+			name: "too long",
+			dir:  dir,
+			shared: func(t *testing.T) *vmpb.SharedPayloads {
+				shared, err := writeSharedPayloads(dir, [][]byte{{1, 2}})
+				require.NoError(t, err)
+				shared.Lengths = []uint32{1}
+				return shared
+			},
+			expectedErr: errSharedPayloadsLengthMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readSharedPayloads(test.dir, test.shared(t))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestVMClientSharedPayloads(t *testing.T) {
+	require := require.New(t)
+
+	blksBytes := make([][]byte, 4)
+	for i := range blksBytes {
+		blksBytes[i] = make([]byte, sharedPayloadsThreshold/2)
+		blksBytes[i][0] = byte(i)
+	}
+	var parsed [][]byte
+	vm := &benchmarkVM{
+		TestVM: &block.TestVM{
+			ParseBlockF: func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+				parsed = append(parsed, blkBytes)
+				return &snowman.TestBlock{
+					TestDecidable: choices.TestDecidable{
+						IDV:     ids.GenerateTestID(),
+						StatusV: choices.Processing,
+					},
+					ParentV:    ids.GenerateTestID(),
+					TimestampV: time.Unix(1, 0),
+				}, nil
+			},
+		},
+		TestBatchedVM: &block.TestBatchedVM{
+			GetAncestorsF: func(context.Context, ids.ID, int, int, time.Duration) ([][]byte, error) {
+				return blksBytes, nil
+			},
+		},
+	}
+	transport := &grpcutils.Transport{
+		UnixSocketDir: t.TempDir(),
+	}
+	client := setupBenchmarkClient(t, vm, transport, true)
+
+	ancestors, err := client.GetAncestors(
+		context.Background(),
+		ids.Empty,
+		len(blksBytes),
+		constants.MaxContainersLen,
+		time.Minute,
+	)
+	require.NoError(err)
+	require.Equal(blksBytes, ancestors)
+
+	blks, err := client.batchedParseBlock(context.Background(), blksBytes)
+	require.NoError(err)
+	require.Len(blks, len(blksBytes))
+	require.Equal(blksBytes, parsed)
+
+	// Only the socket of the VM server is left in the directory.
+	entries, err := os.ReadDir(transport.UnixSocketDir)
+	require.NoError(err)
+	require.Len(entries, 1)
+}
@@ -0,0 +1,296 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+)
+
+var (
+	errVMProcessDown = errors.New("vm process is down")
+	errCrashLooping  = errors.New("vm process is crash looping")
+)
+
+type supervisorConfig struct {
+	// Duration to wait before the first restart within the crash loop window.
+	InitialBackoff time.Duration
+	// Maximum duration to wait before a restart.
+	MaxBackoff time.Duration
+	// Duration over which restarts are counted.
+	CrashLoopWindow time.Duration
+	// Number of restarts within [CrashLoopWindow] after which the VM is
+	// reported as unhealthy.
+	CrashLoopThreshold int
+	// If non-nil, the VM process isn't restarted once it exits. Instead,
+	// [OnExit] is called and the VM is reported as down until its process is
+	// replaced.
+	OnExit func()
+}
+
+var defaultSupervisorConfig = supervisorConfig{
+	InitialBackoff:     runtime.DefaultRestartInitialBackoff,
+	MaxBackoff:         runtime.DefaultRestartMaxBackoff,
+	CrashLoopWindow:    runtime.DefaultCrashLoopWindow,
+	CrashLoopThreshold: runtime.DefaultCrashLoopThreshold,
+}
+
+// restartFunc starts a new VM process to replace the one that exited. It
+// returns a channel that is closed once the new process exits.
+type restartFunc func(ctx context.Context) (<-chan struct{}, error)
+
+// replaceFunc replaces the running VM process. It returns a channel that is
+// closed once the VM process that is running afterwards exits, or nil if no
+// VM process is running.
+type replaceFunc func(ctx context.Context) (<-chan struct{}, error)
+
+type replaceRequest struct {
+	ctx     context.Context
+	replace replaceFunc
+	result  chan error
+}
+
+// supervisor restarts the VM process, with exponential backoff, whenever it
+// exits before the VM is shutdown.
+type supervisor struct {
+	config  supervisorConfig
+	log     logging.Logger
+	restart restartFunc
+
+	restarts        prometheus.Counter
+	restartFailures prometheus.Counter
+
+	replacements chan *replaceRequest
+
+	lock sync.Mutex
+	// true if the VM process has exited and hasn't been replaced yet
+	down bool
+	// times of the restart attempts within the crash loop window
+	attempts []time.Time
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newSupervisor(
+	config supervisorConfig,
+	log logging.Logger,
+	restart restartFunc,
+	reg prometheus.Registerer,
+) (*supervisor, error) {
+	s := &supervisor{
+		config:  config,
+		log:     log,
+		restart: restart,
+		restarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "restarts",
+			Help: "Number of times the VM process was restarted after exiting unexpectedly",
+		}),
+		restartFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "restart_failures",
+			Help: "Number of times restarting the VM process failed",
+		}),
+		replacements: make(chan *replaceRequest),
+		closed:       make(chan struct{}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(s.restarts),
+		reg.Register(s.restartFailures),
+	)
+	return s, errs.Err
+}
+
+// run restarts the VM process every time it exits until the supervisor is
+// closed. [exited] must be closed once the current VM process exits.
+func (s *supervisor) run(exited <-chan struct{}) {
+	for {
+		select {
+		case <-exited:
+			// The process may have exited because the VM is being shutdown.
+			if s.isClosed() {
+				return
+			}
+			s.log.Warn("vm process exited unexpectedly")
+		case req := <-s.replacements:
+			replacementExited, err := req.replace(req.ctx)
+			req.result <- err
+			if replacementExited != nil {
+				s.setDown(false)
+				exited = replacementExited
+				continue
+			}
+			if s.isClosed() {
+				return
+			}
+			s.log.Warn("vm process is down after it failed to be replaced")
+		case <-s.closed:
+			return
+		}
+
+		s.setDown(true)
+
+		if s.config.OnExit != nil {
+			s.config.OnExit()
+			// Only replacements can bring the VM process back.
+			exited = nil
+			continue
+		}
+
+		var ok bool
+		exited, ok = s.restartWithBackoff()
+		if !ok {
+			return
+		}
+		s.setDown(false)
+	}
+}
+
+// restartWithBackoff attempts to restart the VM process until it succeeds or
+// the supervisor is closed. Returns false if the supervisor was closed.
+func (s *supervisor) restartWithBackoff() (<-chan struct{}, bool) {
+	for {
+		delay := s.recordAttempt(time.Now())
+		s.log.Info("restarting vm process",
+			zap.Duration("delay", delay),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-s.closed:
+			timer.Stop()
+			return nil, false
+		}
+
+		exited, err := s.restart(context.TODO())
+		if err == nil {
+			s.restarts.Inc()
+			s.log.Info("restarted vm process")
+			return exited, true
+		}
+		if s.isClosed() {
+			return nil, false
+		}
+
+		s.restartFailures.Inc()
+		s.log.Error("failed to restart vm process",
+			zap.Error(err),
+		)
+	}
+}
+
+// replace runs [replace] while no VM process is being restarted. The VM
+// process is restarted if [replace] leaves no VM process running.
+func (s *supervisor) replace(ctx context.Context, replace replaceFunc) error {
+	req := &replaceRequest{
+		ctx:     ctx,
+		replace: replace,
+		result:  make(chan error, 1),
+	}
+	select {
+	case s.replacements <- req:
+	case <-s.closed:
+		return errShutdown
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-req.result
+}
+
+// recordAttempt records a restart attempt at [now] and returns how long to
+// wait before attempting the restart.
+func (s *supervisor) recordAttempt(now time.Time) time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pruneAttempts(now)
+	delay := s.config.InitialBackoff
+	for i := 0; i < len(s.attempts) && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	s.attempts = append(s.attempts, now)
+	return delay
+}
+
+// pruneAttempts removes the attempts that are outside of the crash loop
+// window.
+//
+// Assumes [s.lock] is held.
+func (s *supervisor) pruneAttempts(now time.Time) {
+	cutoff := now.Add(-s.config.CrashLoopWindow)
+	i := 0
+	for i < len(s.attempts) && !s.attempts[i].After(cutoff) {
+		i++
+	}
+	s.attempts = s.attempts[i:]
+}
+
+func (s *supervisor) setDown(down bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.down = down
+}
+
+// isDown returns true if the VM process has exited and hasn't been replaced
+// yet.
+func (s *supervisor) isDown() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.down
+}
+
+// health returns an error if the VM process is down or has been restarted
+// too many times recently.
+func (s *supervisor) health() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.down {
+		return errVMProcessDown
+	}
+
+	s.pruneAttempts(time.Now())
+	if numAttempts := len(s.attempts); numAttempts >= s.config.CrashLoopThreshold {
+		return fmt.Errorf("%w: %d restarts in the last %s",
+			errCrashLooping,
+			numAttempts,
+			s.config.CrashLoopWindow,
+		)
+	}
+	return nil
+}
+
+// close stops any future restarts. It is safe to call close multiple times.
+func (s *supervisor) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+func (s *supervisor) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTestRestart = errors.New("non-nil error")
+
+var testSupervisorConfig = supervisorConfig{
+	InitialBackoff:     time.Millisecond,
+	MaxBackoff:         10 * time.Millisecond,
+	CrashLoopWindow:    time.Hour,
+	CrashLoopThreshold: 2,
+}
+
+func TestSupervisorRestarts(t *testing.T) {
+	require := require.New(t)
+
+	exited := make(chan struct{})
+	restarted := make(chan struct{})
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			close(restarted)
+			return make(chan struct{}), nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(exited)
+	defer s.close()
+
+	require.NoError(s.health())
+
+	close(exited)
+	<-restarted
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(s.health())
+	require.Zero(counterValue(s.restartFailures))
+}
+
+func TestSupervisorCrashLoop(t *testing.T) {
+	require := require.New(t)
+
+	processes := []chan struct{}{
+		make(chan struct{}),
+		make(chan struct{}),
+		make(chan struct{}),
+	}
+	numStarted := 1
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			exited := processes[numStarted]
+			numStarted++
+			return exited, nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(processes[0])
+	defer s.close()
+
+	close(processes[0])
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(s.health())
+
+	close(processes[1])
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 2
+	}, 5*time.Second, time.Millisecond)
+	require.ErrorIs(s.health(), errCrashLooping)
+}
+
+func TestSupervisorRestartFailure(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		exited     = make(chan struct{})
+		numFailed  = 0
+		allowStart = make(chan struct{})
+	)
+	s, err := newSupervisor(
+		supervisorConfig{
+			InitialBackoff:     time.Millisecond,
+			MaxBackoff:         time.Millisecond,
+			CrashLoopWindow:    time.Hour,
+			CrashLoopThreshold: 10,
+		},
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			if numFailed < 3 {
+				numFailed++
+				return nil, errTestRestart
+			}
+			<-allowStart
+			return make(chan struct{}), nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(exited)
+	defer s.close()
+
+	close(exited)
+	require.Eventually(func() bool {
+		return counterValue(s.restartFailures) == 3
+	}, 5*time.Second, time.Millisecond)
+	require.ErrorIs(s.health(), errVMProcessDown)
+
+	close(allowStart)
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(s.health())
+}
+
+func TestSupervisorClose(t *testing.T) {
+	require := require.New(t)
+
+	exited := make(chan struct{})
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			require.FailNow("unexpectedly restarted")
+			return nil, nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		s.run(exited)
+		close(done)
+	}()
+
+	s.close()
+	s.close()
+	close(exited)
+	<-done
+}
+
+func TestSupervisorReplace(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		exited     = make(chan struct{})
+		replaced   = make(chan struct{})
+		restarted  = make(chan struct{})
+		errReplace = errors.New("non-nil replace error")
+	)
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			close(restarted)
+			return make(chan struct{}), nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(exited)
+	defer s.close()
+
+	// The replacement process is supervised once the replacement succeeded.
+	err = s.replace(context.Background(), func(context.Context) (<-chan struct{}, error) {
+		return replaced, nil
+	})
+	require.NoError(err)
+	close(exited)
+
+	// A failed replacement that leaves a process running isn't restarted.
+	err = s.replace(context.Background(), func(context.Context) (<-chan struct{}, error) {
+		return replaced, errReplace
+	})
+	require.ErrorIs(err, errReplace)
+	require.NoError(s.health())
+	require.Zero(counterValue(s.restarts))
+
+	close(replaced)
+	<-restarted
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 1
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestSupervisorReplaceFailure(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		exited     = make(chan struct{})
+		restarted  = make(chan struct{})
+		errReplace = errors.New("non-nil replace error")
+	)
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			close(restarted)
+			return make(chan struct{}), nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(exited)
+	defer s.close()
+
+	// If no process is left running, the process is restarted.
+	err = s.replace(context.Background(), func(context.Context) (<-chan struct{}, error) {
+		return nil, errReplace
+	})
+	require.ErrorIs(err, errReplace)
+	<-restarted
+	require.Eventually(func() bool {
+		return counterValue(s.restarts) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(s.health())
+}
+
+func TestSupervisorOnExit(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		exited       = make(chan struct{})
+		reportedExit = make(chan struct{})
+		config       = testSupervisorConfig
+	)
+	config.OnExit = func() {
+		close(reportedExit)
+	}
+	s, err := newSupervisor(
+		config,
+		logging.NoLog{},
+		func(context.Context) (<-chan struct{}, error) {
+			require.FailNow("unexpectedly restarted")
+			return nil, nil
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go s.run(exited)
+	defer s.close()
+
+	// The process isn't restarted once it exits.
+	close(exited)
+	<-reportedExit
+	require.ErrorIs(s.health(), errVMProcessDown)
+	require.Zero(counterValue(s.restarts))
+
+	// The VM process can still be replaced.
+	err = s.replace(context.Background(), func(context.Context) (<-chan struct{}, error) {
+		return make(chan struct{}), nil
+	})
+	require.NoError(err)
+	require.NoError(s.health())
+}
+
+func TestSupervisorReplaceAfterClose(t *testing.T) {
+	s, err := newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		nil,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	s.close()
+
+	err = s.replace(context.Background(), func(context.Context) (<-chan struct{}, error) {
+		require.FailNow(t, "unexpectedly replaced")
+		return nil, nil
+	})
+	require.ErrorIs(t, err, errShutdown)
+}
+
+func TestSupervisorBackoff(t *testing.T) {
+	require := require.New(t)
+
+	s, err := newSupervisor(
+		supervisorConfig{
+			InitialBackoff:     time.Second,
+			MaxBackoff:         5 * time.Second,
+			CrashLoopWindow:    time.Minute,
+			CrashLoopThreshold: 3,
+		},
+		logging.NoLog{},
+		nil,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	now := time.Unix(0, 0)
+	require.Equal(time.Second, s.recordAttempt(now))
+	require.Equal(2*time.Second, s.recordAttempt(now))
+	require.Equal(4*time.Second, s.recordAttempt(now))
+	require.Equal(5*time.Second, s.recordAttempt(now))
+
+	// Attempts outside of the window no longer increase the backoff.
+	now = now.Add(time.Minute)
+	require.Equal(time.Second, s.recordAttempt(now))
+	require.Len(s.attempts, 1)
+}
+
+func counterValue(counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	if err := counter.Write(metric); err != nil {
+		return 0
+	}
+	return metric.GetCounter().GetValue()
+}
@@ -18,11 +18,13 @@ import (
 
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/events"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+	eventspb "github.com/ava-labs/avalanchego/proto/pb/vm/events"
 	runtimepb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
 )
 
@@ -33,32 +35,24 @@ const defaultRuntimeDialTimeout = 5 * time.Second
 //
 // Serve starts the RPC Chain VM server and performs a handshake with the VM runtime service.
 func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption) error {
-	signals := make(chan os.Signal, 2)
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	// If a listen address is provided, the VM is managed outside of
+	// AvalancheGo and AvalancheGo connects to it.
+	if listenAddr := os.Getenv(runtime.ListenAddressKey); listenAddr != "" {
+		return serveRemote(ctx, vm, listenAddr, opts...)
+	}
 
-	server := newVMServer(vm, opts...)
-	go func(ctx context.Context) {
-		defer func() {
-			server.GracefulStop()
-			fmt.Println("vm server: graceful termination success")
-		}()
-
-		for {
-			select {
-			case s := <-signals:
-				switch s {
-				case syscall.SIGINT:
-					fmt.Println("runtime engine: ignoring signal: SIGINT")
-				case syscall.SIGTERM:
-					fmt.Println("runtime engine: received shutdown signal: SIGTERM")
-					return
-				}
-			case <-ctx.Done():
-				fmt.Println("runtime engine: context has been cancelled")
-				return
-			}
+	// If AvalancheGo supports Unix domain sockets, they are used rather than
+	// TCP loopback connections.
+	var transport *grpcutils.Transport
+	if unixSocketDir := os.Getenv(runtime.UnixSocketDirKey); unixSocketDir != "" {
+		transport = &grpcutils.Transport{
+			UnixSocketDir: unixSocketDir,
 		}
-	}(ctx)
+	}
+
+	vmServer := newServer(vm, transport)
+	server := newVMServer(vmServer, opts...)
+	go stopOnSignal(ctx, server)
 
 	// address of Runtime server from ENV
 	runtimeAddr := os.Getenv(runtime.EngineAddressKey)
@@ -73,18 +67,24 @@ func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption
 
 	client := gruntime.NewClient(runtimepb.NewRuntimeClient(clientConn))
 
-	listener, err := grpcutils.NewListener()
+	listener, err := transport.NewListener()
 	if err != nil {
 		return fmt.Errorf("failed to create new listener: %w", err)
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, defaultRuntimeDialTimeout)
 	defer cancel()
-	err = client.Initialize(ctx, version.RPCChainVMProtocol, listener.Addr().String())
+	protocolVersion, err := client.Initialize(
+		ctx,
+		version.RPCChainVMProtocolMin,
+		version.RPCChainVMProtocol,
+		listener.Addr().String(),
+	)
 	if err != nil {
 		_ = listener.Close()
 		return fmt.Errorf("failed to initialize vm runtime: %w", err)
 	}
+	vmServer.protocolVersion.Set(protocolVersion)
 
 	// start RPC Chain VM server
 	grpcutils.Serve(listener, server)
@@ -92,10 +92,39 @@ func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption
 	return nil
 }
 
+// stopOnSignal gracefully stops [server] once SIGTERM is received or [ctx] is
+// cancelled.
+func stopOnSignal(ctx context.Context, server *grpc.Server) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	defer func() {
+		server.GracefulStop()
+		fmt.Println("vm server: graceful termination success")
+	}()
+
+	for {
+		select {
+		case s := <-signals:
+			switch s {
+			case syscall.SIGINT:
+				fmt.Println("runtime engine: ignoring signal: SIGINT")
+			case syscall.SIGTERM:
+				fmt.Println("runtime engine: received shutdown signal: SIGTERM")
+				return
+			}
+		case <-ctx.Done():
+			fmt.Println("runtime engine: context has been cancelled")
+			return
+		}
+	}
+}
+
 // Returns an RPC Chain VM server serving health and VM services.
-func newVMServer(vm block.ChainVM, opts ...grpcutils.ServerOption) *grpc.Server {
+func newVMServer(vmServer *VMServer, opts ...grpcutils.ServerOption) *grpc.Server {
 	server := grpcutils.NewServer(opts...)
-	vmpb.RegisterVMServer(server, NewServer(vm))
+	vmpb.RegisterVMServer(server, vmServer)
+	registerEventsServer(server, vmServer.vm)
 
 	health := health.NewServer()
 	health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
@@ -103,3 +132,11 @@ func newVMServer(vm block.ChainVM, opts ...grpcutils.ServerOption) *grpc.Server
 
 	return server
 }
+
+// registerEventsServer streams the events published by [vm] from [server] if
+// [vm] publishes events.
+func registerEventsServer(server *grpc.Server, vm block.ChainVM) {
+	if publisher, ok := vm.(events.Publisher); ok {
+		eventspb.RegisterEventsServer(server, events.NewServer(publisher))
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -19,7 +22,9 @@ import (
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -32,23 +37,26 @@ import (
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ids/galiasreader"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/appsender"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
-	"github.com/ava-labs/avalanchego/snow/validators/gvalidators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/gwarp"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/messenger"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 
 	aliasreaderpb "github.com/ava-labs/avalanchego/proto/pb/aliasreader"
 	appsenderpb "github.com/ava-labs/avalanchego/proto/pb/appsender"
@@ -71,6 +79,13 @@ const (
 
 var (
 	errUnsupportedFXs                       = errors.New("unsupported feature extensions")
+	errLastAcceptedMismatch                 = errors.New("last accepted block mismatch")
+	errShutdown                             = errors.New("vm is shutting down")
+	errProtocolVersionChanged               = errors.New("protocol version changed")
+	errUpgradeUnsupported                   = errors.New("vm isn't started from a plugin binary")
+	errUpgradeFailed                        = errors.New("vm upgrade failed")
+	errUpgradeRestartFailed                 = errors.New("vm failed to be restarted after a failed upgrade")
+	errProcessExited                        = status.Error(codes.Unavailable, "vm process exited")
 	errBatchedParseBlockWrongNumberOfBlocks = errors.New("BatchedParseBlock returned different number of blocks than expected")
 
 	_ block.ChainVM                      = (*VMClient)(nil)
@@ -79,6 +94,8 @@ var (
 	_ block.HeightIndexedChainVM         = (*VMClient)(nil)
 	_ block.StateSyncableVM              = (*VMClient)(nil)
 	_ prometheus.Gatherer                = (*VMClient)(nil)
+	_ vms.Upgrader                       = (*VMClient)(nil)
+	_ vms.TxStreamer                     = (*VMClient)(nil)
 
 	_ snowman.Block           = (*blockClient)(nil)
 	_ block.WithVerifyContext = (*blockClient)(nil)
@@ -89,29 +106,103 @@ var (
 // VMClient is an implementation of a VM that talks over RPC.
 type VMClient struct {
 	*chain.State
-	client         vmpb.VMClient
+	// client and replacing are only modified while holding both the chain's
+	// context lock and [clientLock]. [clientLock] only needs to be held by
+	// readers that don't hold the context lock, which must use getClient.
+	clientLock sync.RWMutex
+	client     vmpb.VMClient
+	// replacing is true while the VM process is being replaced.
+	replacing      bool
 	runtime        runtime.Stopper
 	pid            int
 	processTracker resource.ProcessTracker
+	// transport is nil unless the VM is managed outside of AvalancheGo.
+	transport *grpcutils.Transport
+	// protocolVersion is the protocol version negotiated with the VM.
+	protocolVersion uint
+	// sharedPayloadsDir is the directory that large payloads are shared with
+	// the VM process through. It is empty if the VM process doesn't support
+	// shared payloads.
+	sharedPayloadsDir string
+	// sandbox the current VM process was started in. Holds nil if the VM
+	// process isn't sandboxed.
+	sandbox utils.Atomic[*subprocess.Sandbox]
+	// onShutdown, if non-nil, is called once the VM has been shutdown or has
+	// failed to be initialized.
+	onShutdown func()
+
+	// The fields below are only populated if the VM process is supervised.
+	// They are used to restore the VM after its process is restarted.
+	launch     func(ctx context.Context) (*process, error)
+	supervisor *supervisor
+	// onExit, if non-nil, is called instead of restarting the VM process when
+	// it exits unexpectedly.
+	onExit      func()
+	clientConn  *grpc.ClientConn
+	exited      <-chan struct{}
+	instanceID  string
+	chainCtx    *snow.Context
+	initRequest *vmpb.InitializeRequest
+	state       *snow.State
+	preference  ids.ID
+	connected   map[ids.NodeID]*version.Application
+	// Block ID --> Blocks that have been verified but not yet decided
+	verifiedBlocks map[ids.ID]*blockClient
+
+	// pluginPath is the path of the plugin the VM was registered with. It is
+	// empty if the VM process isn't started by AvalancheGo.
+	pluginPath string
+	// launchUpgrade starts a VM process from the plugin at [pluginPath]. Once
+	// the process replaced the current one, [commit] must be called for the
+	// VM process to be restarted from the same binary. It is nil if the VM
+	// process isn't started by AvalancheGo.
+	launchUpgrade func(ctx context.Context) (p *process, commit func() error, err error)
+
+	handlersLock sync.RWMutex
+	handlers     map[string]http.Handler
+
+	// publishesEvents is true if the VM published events when it was
+	// initialized. Logs are then published to [pubsub], which is served under
+	// the "/events" handler.
+	publishesEvents bool
+	pubsub          *pubsub.Server
+	// txAcceptor, if non-nil, is notified of the transactions of accepted
+	// blocks.
+	txAcceptor utils.Atomic[*txAcceptor]
+	// cancelEvents closes the event stream of the current VM process.
+	cancelEvents context.CancelFunc
+	// eventsHeight is the height of the last block whose events were
+	// forwarded. The events of later blocks are replayed when the events of
+	// the VM process are resubscribed to. Once transactions are streamed, it
+	// is also persisted so that it survives a restart of the node.
+	eventsHeight utils.Atomic[uint64]
+	// eventsErr is the error that caused the event stream to fail, if the
+	// events haven't been resubscribed to since.
+	eventsErr utils.Atomic[error]
 
 	messenger            *messenger.Server
 	keystore             *gkeystore.Server
 	sharedMemory         *gsharedmemory.Server
 	bcLookup             *galiasreader.Server
 	appSender            *appsender.Server
-	validatorStateServer *gvalidators.Server
+	validatorStateServer validatorstatepb.ValidatorStateServer
 	warpSignerServer     *gwarp.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
 
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
+	upgrades          prometheus.Counter
+	upgradeFailures   prometheus.Counter
 }
 
 // NewClient returns a VM connected to a remote VM
 func NewClient(client vmpb.VMClient) *VMClient {
 	return &VMClient{
-		client: client,
+		client:          client,
+		protocolVersion: version.RPCChainVMProtocol,
+		connected:       make(map[ids.NodeID]*version.Application),
+		verifiedBlocks:  make(map[ids.ID]*blockClient),
 	}
 }
 
@@ -123,6 +214,423 @@ func (vm *VMClient) SetProcess(runtime runtime.Stopper, pid int, processTracker
 	processTracker.TrackProcess(vm.pid)
 }
 
+// process is a running instance of the VM.
+type process struct {
+	conn    *grpc.ClientConn
+	runtime runtime.Stopper
+	// addr is the address of the VM server
+	addr string
+	pid  int
+	// exited is closed once the process exits
+	exited <-chan struct{}
+	// instanceID identifies a remote VM server. It is empty for VM processes
+	// started by AvalancheGo.
+	instanceID string
+	// protocolVersion is the protocol version negotiated with the process.
+	protocolVersion uint
+	// sandbox the process was started in. Nil if the process isn't
+	// sandboxed.
+	sandbox *subprocess.Sandbox
+}
+
+// client returns a client of the VM process whose calls fail once the
+// process exits.
+func (p *process) client() vmpb.VMClient {
+	return vmpb.NewVMClient(&exitConn{
+		ClientConnInterface: p.conn,
+		exited:              p.exited,
+	})
+}
+
+// exitConn is a connection to a VM process whose calls are cancelled once the
+// process exits.
+//
+// Calls wait for the connection to be ready, so a call made to an exited
+// process would otherwise block until the process is replaced. As the engine
+// calls the VM while holding the chain's context lock, which is required to
+// replace the process, the chain would deadlock.
+type exitConn struct {
+	grpc.ClientConnInterface
+	exited <-chan struct{}
+}
+
+func (c *exitConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	select {
+	case <-c.exited:
+		return errProcessExited
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.exited:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	select {
+	case <-c.exited:
+		if err != nil {
+			return errProcessExited
+		}
+	default:
+	}
+	return err
+}
+
+// supervise causes [p] to be replaced by a process returned from [launch] if
+// it exits before the VM is shutdown. Supervision starts once the VM is
+// initialized.
+func (vm *VMClient) supervise(p *process, launch func(ctx context.Context) (*process, error)) {
+	vm.clientConn = p.conn
+	vm.exited = p.exited
+	vm.instanceID = p.instanceID
+	vm.protocolVersion = p.protocolVersion
+	vm.sandbox.Set(p.sandbox)
+	vm.launch = launch
+}
+
+// getClient returns the client of the current VM process. Returns false if
+// the VM process is down or being replaced, in which case the client may not
+// be able to serve requests.
+func (vm *VMClient) getClient() (vmpb.VMClient, bool) {
+	vm.clientLock.RLock()
+	defer vm.clientLock.RUnlock()
+
+	available := !vm.replacing && (vm.supervisor == nil || !vm.supervisor.isDown())
+	return vm.client, available
+}
+
+// setReplacing marks whether the VM process is being replaced.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) setReplacing(replacing bool) {
+	vm.clientLock.Lock()
+	defer vm.clientLock.Unlock()
+
+	vm.replacing = replacing
+}
+
+// setClient replaces the client of the VM process.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) setClient(client vmpb.VMClient) {
+	vm.clientLock.Lock()
+	defer vm.clientLock.Unlock()
+
+	vm.client = client
+}
+
+// appMsgErr returns the error that should be reported for an app message that
+// failed to be delivered with [err]. Messages that couldn't be delivered
+// because the supervised VM process exited are dropped, as the process will
+// be restarted rather than the chain stopped.
+func (vm *VMClient) appMsgErr(err error) error {
+	if err == nil || vm.supervisor == nil || status.Code(err) != codes.Unavailable {
+		return err
+	}
+	vm.chainCtx.Log.Debug("dropping app message",
+		zap.Error(err),
+	)
+	return nil
+}
+
+// restart replaces the exited VM process with a new one and restores the
+// state of the VM in the new process.
+func (vm *VMClient) restart(ctx context.Context) (<-chan struct{}, error) {
+	p, err := vm.launch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.chainCtx.Lock.Lock()
+	defer vm.chainCtx.Lock.Unlock()
+
+	// If the VM was shutdown while the process was being started, the new
+	// process must not be used.
+	if vm.supervisor.isClosed() {
+		p.runtime.Stop(ctx)
+		return nil, errShutdown
+	}
+
+	vm.setReplacing(true)
+	defer vm.setReplacing(false)
+
+	if err := vm.replaceProcess(ctx, p); err != nil {
+		return nil, err
+	}
+	return p.exited, nil
+}
+
+// Upgrade replaces the VM process with one started from the plugin the VM
+// was registered with, which may have been replaced in the plugin directory
+// since the VM process was started. The new process is started before the
+// engine is paused, so the chain keeps running on the previous process if the
+// new process can't be started. The engine is only paused while the previous
+// process is shutdown and the new process is restored from the same
+// databases. The new process must resume from the same last accepted block
+// and pass its health check. Otherwise, the upgrade is rolled back by
+// restarting the VM process from the binary the previous process was started
+// from, and an error is returned.
+func (vm *VMClient) Upgrade(ctx context.Context) error {
+	if vm.launchUpgrade == nil || vm.supervisor == nil {
+		return errUpgradeUnsupported
+	}
+	return vm.supervisor.replace(ctx, vm.upgrade)
+}
+
+// upgrade is called by the supervisor, so no VM process is being restarted
+// concurrently. Returns the channel that is closed once the VM process that
+// is left running exits.
+func (vm *VMClient) upgrade(ctx context.Context) (<-chan struct{}, error) {
+	if vm.supervisor.isClosed() {
+		return vm.exited, errShutdown
+	}
+
+	vm.chainCtx.Log.Info("upgrading vm",
+		zap.String("path", vm.pluginPath),
+	)
+	p, commit, err := vm.launchUpgrade(ctx)
+	if err == nil && p.protocolVersion != vm.protocolVersion {
+		p.runtime.Stop(ctx)
+		err = fmt.Errorf("%w: expected %d but negotiated %d",
+			errProtocolVersionChanged,
+			vm.protocolVersion,
+			p.protocolVersion,
+		)
+	}
+	if err != nil {
+		vm.upgradeFailures.Inc()
+		vm.chainCtx.Log.Warn("failed to start upgraded vm process",
+			zap.String("path", vm.pluginPath),
+			zap.Error(err),
+		)
+		return vm.exited, fmt.Errorf("%w: %v", errUpgradeFailed, err)
+	}
+
+	// Holding the context lock prevents the engine from calling the VM until
+	// the processes have been swapped.
+	vm.chainCtx.Lock.Lock()
+	defer vm.chainCtx.Lock.Unlock()
+
+	if vm.supervisor.isClosed() {
+		p.runtime.Stop(ctx)
+		return vm.exited, errShutdown
+	}
+
+	vm.setReplacing(true)
+	defer vm.setReplacing(false)
+
+	if _, err := vm.client.Shutdown(ctx, &emptypb.Empty{}); err != nil {
+		vm.chainCtx.Log.Warn("failed to shutdown vm process before upgrade",
+			zap.Error(err),
+		)
+	}
+
+	upgradeErr := vm.replaceProcess(ctx, p)
+	if upgradeErr == nil {
+		if _, err := vm.client.Health(ctx, &emptypb.Empty{}); err != nil {
+			vm.runtime.Stop(ctx)
+			upgradeErr = fmt.Errorf("health check failed: %w", err)
+		}
+	}
+	if upgradeErr == nil {
+		// The upgrade already succeeded, so if the binary can't be kept, the
+		// VM process is restarted from the previous binary if it exits.
+		if err := commit(); err != nil {
+			vm.chainCtx.Log.Error("failed to keep upgraded vm binary",
+				zap.Error(err),
+			)
+		}
+		vm.upgrades.Inc()
+		vm.chainCtx.Log.Info("upgraded vm",
+			zap.String("path", vm.pluginPath),
+		)
+		return vm.exited, nil
+	}
+
+	// The previous process was shutdown, so the VM process is restarted from
+	// the binary the previous process was started from.
+	vm.upgradeFailures.Inc()
+	vm.chainCtx.Log.Warn("rolling back failed vm upgrade",
+		zap.String("path", vm.pluginPath),
+		zap.Error(upgradeErr),
+	)
+	p, err = vm.launch(ctx)
+	if err == nil {
+		err = vm.replaceProcess(ctx, p)
+	}
+	if err != nil {
+		vm.chainCtx.Log.Error("failed to restart vm after failed upgrade",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %v: %v", errUpgradeRestartFailed, upgradeErr, err)
+	}
+	return vm.exited, fmt.Errorf("%w: %v", errUpgradeFailed, upgradeErr)
+}
+
+// replaceProcess releases the resources of the current VM process, which
+// must no longer be serving, and restores the state of the VM in [p]. If the
+// state can't be restored, [p] is stopped.
+//
+// Assumes the chain's context lock is held and the VM is marked as replacing.
+func (vm *VMClient) replaceProcess(ctx context.Context, p *process) error {
+	// The servers exposed to the VM were created for the protocol version
+	// negotiated with the original process.
+	if p.protocolVersion != vm.protocolVersion {
+		p.runtime.Stop(ctx)
+		return fmt.Errorf("%w: expected %d but negotiated %d",
+			errProtocolVersionChanged,
+			vm.protocolVersion,
+			p.protocolVersion,
+		)
+	}
+
+	// If the connection to a remote VM server was re-established without the
+	// server being restarted, the server still has all of its state.
+	if p.instanceID != "" && p.instanceID == vm.instanceID {
+		vm.closeEvents()
+		vm.runtime.Stop(ctx)
+		vm.setClient(p.client())
+		vm.clientConn = p.conn
+		vm.runtime = p.runtime
+		vm.exited = p.exited
+		if _, err := vm.subscribeEvents(); err != nil {
+			p.runtime.Stop(ctx)
+			return err
+		}
+		return nil
+	}
+
+	// Release the resources of the previous process.
+	vm.closeEvents()
+	vm.runtime.Stop(ctx)
+	if vm.processTracker != nil {
+		vm.processTracker.UntrackProcess(vm.pid)
+	}
+	errs := wrappers.Errs{}
+	errs.Add(vm.clientConn.Close())
+	for _, conn := range vm.conns {
+		errs.Add(conn.Close())
+	}
+	vm.conns = nil
+	if errs.Errored() {
+		vm.chainCtx.Log.Debug("failed to close connections to previous vm process",
+			zap.Error(errs.Err),
+		)
+	}
+
+	vm.setClient(p.client())
+	vm.clientConn = p.conn
+	vm.exited = p.exited
+	vm.instanceID = p.instanceID
+	vm.sandbox.Set(p.sandbox)
+	if vm.processTracker != nil {
+		vm.SetProcess(p.runtime, p.pid, vm.processTracker)
+	} else {
+		vm.runtime = p.runtime
+	}
+
+	if err := vm.restore(ctx); err != nil {
+		p.runtime.Stop(ctx)
+		return err
+	}
+	return nil
+}
+
+// restore re-initializes the VM process with the same databases and
+// configuration that were originally provided and replays the state that was
+// communicated to the previous process.
+//
+// Assumes the chain's context lock is held.
+func (vm *VMClient) restore(ctx context.Context) error {
+	resp, err := vm.client.Initialize(ctx, vm.initRequest)
+	if err != nil {
+		return err
+	}
+	vm.sharedPayloadsDir = vm.getSharedPayloadsDir(resp)
+
+	if err := vm.restoreState(ctx, resp.LastAcceptedId); err != nil {
+		return err
+	}
+
+	for nodeID, nodeVersion := range vm.connected {
+		if err := vm.Connected(ctx, nodeID, nodeVersion); err != nil {
+			return err
+		}
+	}
+
+	// Blocks must be re-verified in order so that their parents are known to
+	// the new process.
+	blks := make([]*blockClient, 0, len(vm.verifiedBlocks))
+	for _, blk := range vm.verifiedBlocks {
+		blks = append(blks, blk)
+	}
+	sort.Slice(blks, func(i, j int) bool {
+		return blks[i].height < blks[j].height
+	})
+	for _, blk := range blks {
+		if err := blk.reverify(ctx); err != nil {
+			return fmt.Errorf("failed to re-verify block %s: %w", blk.id, err)
+		}
+	}
+
+	if vm.preference != ids.Empty {
+		if err := vm.SetPreference(ctx, vm.preference); err != nil {
+			return err
+		}
+	}
+
+	if _, err := vm.subscribeEvents(); err != nil {
+		return err
+	}
+
+	if vm.handlers == nil {
+		return nil
+	}
+	handlers, err := vm.createHandlers(ctx)
+	if err != nil {
+		return err
+	}
+	vm.setHandlers(handlers)
+	return nil
+}
+
+// restoreState verifies that the new process resumed from the last accepted
+// block and restores the state of the previous process.
+func (vm *VMClient) restoreState(ctx context.Context, lastAcceptedIDBytes []byte) error {
+	lastAcceptedID, err := ids.ToID(lastAcceptedIDBytes)
+	if err != nil {
+		return err
+	}
+	if vm.state != nil {
+		resp, err := vm.client.SetState(ctx, &vmpb.SetStateRequest{
+			State: vmpb.State(*vm.state),
+		})
+		if err != nil {
+			return err
+		}
+		lastAcceptedID, err = ids.ToID(resp.LastAcceptedId)
+		if err != nil {
+			return err
+		}
+	}
+
+	expectedLastAcceptedID := vm.State.LastAcceptedBlock().ID()
+	if lastAcceptedID != expectedLastAcceptedID {
+		return fmt.Errorf("%w: expected %s but got %s",
+			errLastAcceptedMismatch,
+			expectedLastAcceptedID,
+			lastAcceptedID,
+		)
+	}
+	return nil
+}
+
 func (vm *VMClient) Initialize(
 	ctx context.Context,
 	chainCtx *snow.Context,
@@ -133,6 +641,39 @@ func (vm *VMClient) Initialize(
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
 	appSender common.AppSender,
+) error {
+	err := vm.initialize(
+		ctx,
+		chainCtx,
+		dbManager,
+		genesisBytes,
+		upgradeBytes,
+		configBytes,
+		toEngine,
+		fxs,
+		appSender,
+	)
+	if err != nil {
+		// A VM that failed to be initialized isn't shutdown, so the resources
+		// of the VM process are released here.
+		if vm.supervisor != nil {
+			vm.supervisor.close()
+		}
+		_ = vm.release(ctx)
+	}
+	return err
+}
+
+func (vm *VMClient) initialize(
+	ctx context.Context,
+	chainCtx *snow.Context,
+	dbManager manager.Manager,
+	genesisBytes []byte,
+	upgradeBytes []byte,
+	configBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+	appSender common.AppSender,
 ) error {
 	if len(fxs) != 0 {
 		return errUnsupportedFXs
@@ -158,7 +699,7 @@ func (vm *VMClient) Initialize(
 	versionedDBServers := make([]*vmpb.VersionedDBServer, len(versionedDBs))
 	for i, semDB := range versionedDBs {
 		dbVersion := semDB.Version.String()
-		serverListener, err := grpcutils.NewListener()
+		serverListener, err := vm.transport.NewListener()
 		if err != nil {
 			return err
 		}
@@ -181,10 +722,11 @@ func (vm *VMClient) Initialize(
 	vm.sharedMemory = gsharedmemory.NewServer(chainCtx.SharedMemory, dbManager.Current().Database)
 	vm.bcLookup = galiasreader.NewServer(chainCtx.BCLookup)
 	vm.appSender = appsender.NewServer(appSender)
-	vm.validatorStateServer = gvalidators.NewServer(chainCtx.ValidatorState)
+	vm.validatorStateServer = newValidatorStateServer(vm.protocolVersion, chainCtx.ValidatorState)
 	vm.warpSignerServer = gwarp.NewServer(chainCtx.WarpSigner)
+	vm.chainCtx = chainCtx
 
-	serverListener, err := grpcutils.NewListener()
+	serverListener, err := vm.transport.NewListener()
 	if err != nil {
 		return err
 	}
@@ -195,7 +737,7 @@ func (vm *VMClient) Initialize(
 		zap.String("address", serverAddr),
 	)
 
-	resp, err := vm.client.Initialize(ctx, &vmpb.InitializeRequest{
+	vm.initRequest = &vmpb.InitializeRequest{
 		NetworkId:    chainCtx.NetworkID,
 		SubnetId:     chainCtx.SubnetID[:],
 		ChainId:      chainCtx.ChainID[:],
@@ -210,10 +752,12 @@ func (vm *VMClient) Initialize(
 		ConfigBytes:  configBytes,
 		DbServers:    versionedDBServers,
 		ServerAddr:   serverAddr,
-	})
+	}
+	resp, err := vm.client.Initialize(ctx, vm.initRequest)
 	if err != nil {
 		return err
 	}
+	vm.sharedPayloadsDir = vm.getSharedPayloadsDir(resp)
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -261,11 +805,52 @@ func (vm *VMClient) Initialize(
 	}
 	vm.State = chainState
 
+	vm.pubsub = pubsub.New(chainCtx.Log)
+	vm.eventsHeight.Set(lastAcceptedBlk.height)
+	vm.publishesEvents, err = vm.subscribeEvents()
+	if err != nil {
+		return err
+	}
+
+	if vm.launch != nil {
+		supervisorConfig := defaultSupervisorConfig
+		supervisorConfig.OnExit = vm.onExit
+		vm.supervisor, err = newSupervisor(
+			supervisorConfig,
+			chainCtx.Log,
+			vm.restart,
+			registerer,
+		)
+		if err != nil {
+			return err
+		}
+		go vm.supervisor.run(vm.exited)
+	}
+
+	if len(vm.pluginPath) != 0 {
+		vm.upgrades = prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "upgrades",
+			Help: "Number of times the VM was upgraded to a new plugin binary",
+		})
+		vm.upgradeFailures = prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "upgrade_failures",
+			Help: "Number of times upgrading the VM to a new plugin binary failed",
+		})
+		errs := wrappers.Errs{}
+		errs.Add(
+			registerer.Register(vm.upgrades),
+			registerer.Register(vm.upgradeFailures),
+		)
+		if errs.Errored() {
+			return errs.Err
+		}
+	}
+
 	return chainCtx.Metrics.Register(multiGatherer)
 }
 
 func (vm *VMClient) newDBServer(db database.Database) *grpc.Server {
-	server := grpcutils.NewServer(
+	server := vm.transport.NewServer(
 		grpcutils.WithUnaryInterceptor(vm.grpcServerMetrics.UnaryServerInterceptor()),
 		grpcutils.WithStreamInterceptor(vm.grpcServerMetrics.StreamServerInterceptor()),
 	)
@@ -287,7 +872,7 @@ func (vm *VMClient) newDBServer(db database.Database) *grpc.Server {
 }
 
 func (vm *VMClient) newInitServer() *grpc.Server {
-	server := grpcutils.NewServer(
+	server := vm.transport.NewServer(
 		grpcutils.WithUnaryInterceptor(vm.grpcServerMetrics.UnaryServerInterceptor()),
 		grpcutils.WithStreamInterceptor(vm.grpcServerMetrics.StreamServerInterceptor()),
 	)
@@ -321,6 +906,7 @@ func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
 	if err != nil {
 		return err
 	}
+	vm.state = &state
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -351,22 +937,77 @@ func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
 }
 
 func (vm *VMClient) Shutdown(ctx context.Context) error {
+	// Stop supervising the process before it is stopped so that it isn't
+	// restarted.
+	if vm.supervisor != nil {
+		vm.supervisor.close()
+	}
+
 	errs := wrappers.Errs{}
 	_, err := vm.client.Shutdown(ctx, &emptypb.Empty{})
-	errs.Add(err)
+	errs.Add(
+		err,
+		vm.release(ctx),
+	)
+	return errs.Err
+}
 
+// release stops the VM process and releases the resources that were
+// allocated to serve it.
+func (vm *VMClient) release(ctx context.Context) error {
+	errs := wrappers.Errs{}
+	vm.closeEvents()
 	vm.serverCloser.Stop()
 	for _, conn := range vm.conns {
 		errs.Add(conn.Close())
 	}
+	if vm.clientConn != nil {
+		errs.Add(vm.clientConn.Close())
+	}
 
-	vm.runtime.Stop(ctx)
+	if vm.runtime != nil {
+		vm.runtime.Stop(ctx)
+	}
 
-	vm.processTracker.UntrackProcess(vm.pid)
+	if vm.processTracker != nil {
+		vm.processTracker.UntrackProcess(vm.pid)
+	}
+	if vm.onShutdown != nil {
+		vm.onShutdown()
+	}
 	return errs.Err
 }
 
+// CreateHandlers returns handlers that forward requests to the current VM
+// process, so that they remain valid if the VM process is restarted. If the VM
+// publishes events, its logs are served under [eventsEndpoint].
 func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]*common.HTTPHandler, error) {
+	handlers, err := vm.createHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vm.setHandlers(handlers)
+
+	forwardingHandlers := make(map[string]*common.HTTPHandler, len(handlers))
+	for prefix, handler := range handlers {
+		forwardingHandlers[prefix] = &common.HTTPHandler{
+			LockOptions: handler.LockOptions,
+			Handler: &forwardingHandler{
+				vm:     vm,
+				prefix: prefix,
+			},
+		}
+	}
+	if _, ok := forwardingHandlers[eventsEndpoint]; vm.publishesEvents && !ok {
+		forwardingHandlers[eventsEndpoint] = &common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		}
+	}
+	return forwardingHandlers, nil
+}
+
+func (vm *VMClient) createHandlers(ctx context.Context) (map[string]*common.HTTPHandler, error) {
 	resp, err := vm.client.CreateHandlers(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -374,7 +1015,7 @@ func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]*common.HTTP
 
 	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
 	for _, handler := range resp.Handlers {
-		clientConn, err := grpcutils.Dial(handler.ServerAddr)
+		clientConn, err := vm.transport.Dial(handler.ServerAddr)
 		if err != nil {
 			return nil, err
 		}
@@ -382,12 +1023,41 @@ func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]*common.HTTP
 		vm.conns = append(vm.conns, clientConn)
 		handlers[handler.Prefix] = &common.HTTPHandler{
 			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn), vm.transport),
 		}
 	}
 	return handlers, nil
 }
 
+func (vm *VMClient) setHandlers(handlers map[string]*common.HTTPHandler) {
+	vm.handlersLock.Lock()
+	defer vm.handlersLock.Unlock()
+
+	vm.handlers = make(map[string]http.Handler, len(handlers))
+	for prefix, handler := range handlers {
+		vm.handlers[prefix] = handler.Handler
+	}
+}
+
+// forwardingHandler forwards requests to the handler registered by the current
+// VM process under [prefix].
+type forwardingHandler struct {
+	vm     *VMClient
+	prefix string
+}
+
+func (h *forwardingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.vm.handlersLock.RLock()
+	handler, ok := h.vm.handlers[h.prefix]
+	h.vm.handlersLock.RUnlock()
+
+	if !ok {
+		http.Error(w, errVMProcessDown.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
 func (vm *VMClient) CreateStaticHandlers(ctx context.Context) (map[string]*common.HTTPHandler, error) {
 	resp, err := vm.client.CreateStaticHandlers(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -396,7 +1066,7 @@ func (vm *VMClient) CreateStaticHandlers(ctx context.Context) (map[string]*commo
 
 	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
 	for _, handler := range resp.Handlers {
-		clientConn, err := grpcutils.Dial(handler.ServerAddr)
+		clientConn, err := vm.transport.Dial(handler.ServerAddr)
 		if err != nil {
 			return nil, err
 		}
@@ -404,13 +1074,14 @@ func (vm *VMClient) CreateStaticHandlers(ctx context.Context) (map[string]*commo
 		vm.conns = append(vm.conns, clientConn)
 		handlers[handler.Prefix] = &common.HTTPHandler{
 			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn), vm.transport),
 		}
 	}
 	return handlers, nil
 }
 
 func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	vm.connected[nodeID] = nodeVersion
 	_, err := vm.client.Connected(ctx, &vmpb.ConnectedRequest{
 		NodeId:  nodeID[:],
 		Version: nodeVersion.String(),
@@ -419,6 +1090,7 @@ func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersio
 }
 
 func (vm *VMClient) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	delete(vm.connected, nodeID)
 	_, err := vm.client.Disconnected(ctx, &vmpb.DisconnectedRequest{
 		NodeId: nodeID[:],
 	})
@@ -519,6 +1191,7 @@ func (vm *VMClient) getBlock(ctx context.Context, blkID ids.ID) (snowman.Block,
 }
 
 func (vm *VMClient) SetPreference(ctx context.Context, blkID ids.ID) error {
+	vm.preference = blkID
 	_, err := vm.client.SetPreference(ctx, &vmpb.SetPreferenceRequest{
 		Id: blkID[:],
 	})
@@ -526,7 +1199,23 @@ func (vm *VMClient) SetPreference(ctx context.Context, blkID ids.ID) error {
 }
 
 func (vm *VMClient) HealthCheck(ctx context.Context) (interface{}, error) {
-	health, err := vm.client.Health(ctx, &emptypb.Empty{})
+	if vm.supervisor != nil {
+		if err := vm.supervisor.health(); err != nil {
+			return nil, fmt.Errorf("health check failed: %w", err)
+		}
+	}
+	if sandbox := vm.sandbox.Get(); sandbox != nil {
+		if err := sandbox.Health(); err != nil {
+			return nil, fmt.Errorf("health check failed: %w", err)
+		}
+	}
+	if err := vm.eventsErr.Get(); err != nil {
+		return nil, fmt.Errorf("health check failed: %w: %v", errEventStream, err)
+	}
+
+	// HealthCheck is called without holding the context lock.
+	client, _ := vm.getClient()
+	health, err := client.Health(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
@@ -535,7 +1224,8 @@ func (vm *VMClient) HealthCheck(ctx context.Context) (interface{}, error) {
 }
 
 func (vm *VMClient) Version(ctx context.Context) (string, error) {
-	resp, err := vm.client.Version(ctx, &emptypb.Empty{})
+	client, _ := vm.getClient()
+	resp, err := client.Version(ctx, &emptypb.Empty{})
 	if err != nil {
 		return "", err
 	}
@@ -543,7 +1233,13 @@ func (vm *VMClient) Version(ctx context.Context) (string, error) {
 }
 
 func (vm *VMClient) CrossChainAppRequest(ctx context.Context, chainID ids.ID, requestID uint32, deadline time.Time, request []byte) error {
-	_, err := vm.client.CrossChainAppRequest(
+	// App messages are handled without holding the context lock. Messages
+	// received while the VM process is being replaced are dropped.
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.CrossChainAppRequest(
 		ctx,
 		&vmpb.CrossChainAppRequestMsg{
 			ChainId:   chainID[:],
@@ -552,22 +1248,30 @@ func (vm *VMClient) CrossChainAppRequest(ctx context.Context, chainID ids.ID, re
 			Request:   request,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) CrossChainAppRequestFailed(ctx context.Context, chainID ids.ID, requestID uint32) error {
-	_, err := vm.client.CrossChainAppRequestFailed(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.CrossChainAppRequestFailed(
 		ctx,
 		&vmpb.CrossChainAppRequestFailedMsg{
 			ChainId:   chainID[:],
 			RequestId: requestID,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) CrossChainAppResponse(ctx context.Context, chainID ids.ID, requestID uint32, response []byte) error {
-	_, err := vm.client.CrossChainAppResponse(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.CrossChainAppResponse(
 		ctx,
 		&vmpb.CrossChainAppResponseMsg{
 			ChainId:   chainID[:],
@@ -575,11 +1279,15 @@ func (vm *VMClient) CrossChainAppResponse(ctx context.Context, chainID ids.ID, r
 			Response:  response,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
-	_, err := vm.client.AppRequest(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.AppRequest(
 		ctx,
 		&vmpb.AppRequestMsg{
 			NodeId:    nodeID[:],
@@ -588,11 +1296,15 @@ func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID
 			Deadline:  grpcutils.TimestampFromTime(deadline),
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
-	_, err := vm.client.AppResponse(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.AppResponse(
 		ctx,
 		&vmpb.AppResponseMsg{
 			NodeId:    nodeID[:],
@@ -600,39 +1312,60 @@ func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestI
 			Response:  response,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
-	_, err := vm.client.AppRequestFailed(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.AppRequestFailed(
 		ctx,
 		&vmpb.AppRequestFailedMsg{
 			NodeId:    nodeID[:],
 			RequestId: requestID,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
-	_, err := vm.client.AppGossip(
+	client, ok := vm.getClient()
+	if !ok {
+		return nil
+	}
+	_, err := client.AppGossip(
 		ctx,
 		&vmpb.AppGossipMsg{
 			NodeId: nodeID[:],
 			Msg:    msg,
 		},
 	)
-	return err
+	return vm.appMsgErr(err)
 }
 
 func (vm *VMClient) Gather() ([]*dto.MetricFamily, error) {
-	resp, err := vm.client.Gather(context.Background(), &emptypb.Empty{})
+	// Gather is called without holding the context lock.
+	client, _ := vm.getClient()
+
+	resp, err := client.Gather(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, err
 	}
 	return resp.MetricFamilies, nil
 }
 
+// getSharedPayloadsDir returns the directory that payloads can be shared with
+// the VM process through, given its response to Initialize. Returns the empty
+// string if payloads can't be shared.
+func (vm *VMClient) getSharedPayloadsDir(resp *vmpb.InitializeResponse) string {
+	if !resp.SharedPayloads || vm.transport == nil {
+		return ""
+	}
+	return vm.transport.UnixSocketDir
+}
+
 func (vm *VMClient) GetAncestors(
 	ctx context.Context,
 	blkID ids.ID,
@@ -645,17 +1378,33 @@ func (vm *VMClient) GetAncestors(
 		MaxBlocksNum:          int32(maxBlocksNum),
 		MaxBlocksSize:         int32(maxBlocksSize),
 		MaxBlocksRetrivalTime: int64(maxBlocksRetrivalTime),
+		SharedResponse:        vm.sharedPayloadsDir != "",
 	})
 	if err != nil {
 		return nil, err
 	}
+	if resp.SharedBlksBytes != nil {
+		return readSharedPayloads(vm.sharedPayloadsDir, resp.SharedBlksBytes)
+	}
 	return resp.BlksBytes, nil
 }
 
 func (vm *VMClient) batchedParseBlock(ctx context.Context, blksBytes [][]byte) ([]snowman.Block, error) {
-	resp, err := vm.client.BatchedParseBlock(ctx, &vmpb.BatchedParseBlockRequest{
-		Request: blksBytes,
-	})
+	req := &vmpb.BatchedParseBlockRequest{}
+	if shouldSharePayloads(vm.sharedPayloadsDir, blksBytes) {
+		shared, err := writeSharedPayloads(vm.sharedPayloadsDir, blksBytes)
+		if err != nil {
+			return nil, err
+		}
+		// The payloads are removed by the VM process once it read them, but
+		// may never have been read if the request failed.
+		defer removeSharedPayloads(vm.sharedPayloadsDir, shared)
+		req.SharedRequest = shared
+	} else {
+		req.Request = blksBytes
+	}
+
+	resp, err := vm.client.BatchedParseBlock(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -850,6 +1599,10 @@ type blockClient struct {
 	height              uint64
 	time                time.Time
 	shouldVerifyWithCtx bool
+
+	// pChainHeight is the P-chain height this block was verified with, if it
+	// was verified with context.
+	pChainHeight *uint64
 }
 
 func (b *blockClient) ID() ids.ID {
@@ -858,6 +1611,7 @@ func (b *blockClient) ID() ids.ID {
 
 func (b *blockClient) Accept(ctx context.Context) error {
 	b.status = choices.Accepted
+	delete(b.vm.verifiedBlocks, b.id)
 	_, err := b.vm.client.BlockAccept(ctx, &vmpb.BlockAcceptRequest{
 		Id: b.id[:],
 	})
@@ -866,6 +1620,7 @@ func (b *blockClient) Accept(ctx context.Context) error {
 
 func (b *blockClient) Reject(ctx context.Context) error {
 	b.status = choices.Rejected
+	delete(b.vm.verifiedBlocks, b.id)
 	_, err := b.vm.client.BlockReject(ctx, &vmpb.BlockRejectRequest{
 		Id: b.id[:],
 	})
@@ -881,13 +1636,22 @@ func (b *blockClient) Parent() ids.ID {
 }
 
 func (b *blockClient) Verify(ctx context.Context) error {
+	b.pChainHeight = nil
+	return b.reverify(ctx)
+}
+
+// reverify verifies the block with the same context that it was last verified
+// with.
+func (b *blockClient) reverify(ctx context.Context) error {
 	resp, err := b.vm.client.BlockVerify(ctx, &vmpb.BlockVerifyRequest{
-		Bytes: b.bytes,
+		Bytes:        b.bytes,
+		PChainHeight: b.pChainHeight,
 	})
 	if err != nil {
 		return err
 	}
 
+	b.vm.verifiedBlocks[b.id] = b
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
 	return err
 }
@@ -909,16 +1673,9 @@ func (b *blockClient) ShouldVerifyWithContext(context.Context) (bool, error) {
 }
 
 func (b *blockClient) VerifyWithContext(ctx context.Context, blockCtx *block.Context) error {
-	resp, err := b.vm.client.BlockVerify(ctx, &vmpb.BlockVerifyRequest{
-		Bytes:        b.bytes,
-		PChainHeight: &blockCtx.PChainHeight,
-	})
-	if err != nil {
-		return err
-	}
-
-	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
-	return err
+	pChainHeight := blockCtx.PChainHeight
+	b.pChainHeight = &pChainHeight
+	return b.reverify(ctx)
 }
 
 type summaryClient struct {
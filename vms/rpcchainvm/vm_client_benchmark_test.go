@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const benchmarkNumBlocks = 64
+
+var benchmarkBlockSizes = []int{
+	units.KiB,
+	64 * units.KiB,
+	512 * units.KiB,
+}
+
+type benchmarkVM struct {
+	*block.TestVM
+	*block.TestBatchedVM
+}
+
+func newBenchmarkVM(blkSize int) *benchmarkVM {
+	blksBytes := make([][]byte, benchmarkNumBlocks)
+	for i := range blksBytes {
+		blksBytes[i] = make([]byte, blkSize)
+	}
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV:    ids.GenerateTestID(),
+		TimestampV: time.Unix(1, 0),
+	}
+	return &benchmarkVM{
+		TestVM: &block.TestVM{
+			ParseBlockF: func(context.Context, []byte) (snowman.Block, error) {
+				return blk, nil
+			},
+		},
+		TestBatchedVM: &block.TestBatchedVM{
+			GetAncestorsF: func(context.Context, ids.ID, int, int, time.Duration) ([][]byte, error) {
+				return blksBytes, nil
+			},
+		},
+	}
+}
+
+// setupBenchmarkClient returns a VMClient connected to [vm] with [transport].
+// If [sharedPayloads] is true, large payloads are shared through the Unix
+// socket directory of [transport].
+func setupBenchmarkClient(tb testing.TB, vm block.ChainVM, transport *grpcutils.Transport, sharedPayloads bool) *VMClient {
+	require := require.New(tb)
+
+	listener, err := transport.NewListener()
+	require.NoError(err)
+
+	server := transport.NewServer()
+	vmpb.RegisterVMServer(server, newServer(vm, transport))
+	go grpcutils.Serve(listener, server)
+
+	conn, err := transport.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	tb.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+
+	client := NewClient(vmpb.NewVMClient(conn))
+	client.transport = transport
+	if sharedPayloads {
+		client.sharedPayloadsDir = transport.UnixSocketDir
+	}
+	return client
+}
+
+type benchmarkTransport struct {
+	transport      *grpcutils.Transport
+	sharedPayloads bool
+}
+
+func benchmarkTransports(b *testing.B) map[string]benchmarkTransport {
+	unixTransport := &grpcutils.Transport{
+		UnixSocketDir: b.TempDir(),
+	}
+	return map[string]benchmarkTransport{
+		"tcp": {},
+		"unix": {
+			transport: unixTransport,
+		},
+		"unix_shared": {
+			transport:      unixTransport,
+			sharedPayloads: true,
+		},
+	}
+}
+
+func BenchmarkGetAncestors(b *testing.B) {
+	for name, bt := range benchmarkTransports(b) {
+		for _, blkSize := range benchmarkBlockSizes {
+			b.Run(fmt.Sprintf("%s_%d_bytes", name, blkSize), func(b *testing.B) {
+				require := require.New(b)
+
+				client := setupBenchmarkClient(b, newBenchmarkVM(blkSize), bt.transport, bt.sharedPayloads)
+				b.SetBytes(int64(benchmarkNumBlocks * blkSize))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := client.GetAncestors(
+						context.Background(),
+						ids.Empty,
+						benchmarkNumBlocks,
+						constants.MaxContainersLen,
+						time.Minute,
+					)
+					require.NoError(err)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBatchedParseBlock(b *testing.B) {
+	for name, bt := range benchmarkTransports(b) {
+		for _, blkSize := range benchmarkBlockSizes {
+			b.Run(fmt.Sprintf("%s_%d_bytes", name, blkSize), func(b *testing.B) {
+				require := require.New(b)
+
+				client := setupBenchmarkClient(b, newBenchmarkVM(blkSize), bt.transport, bt.sharedPayloads)
+				blksBytes := make([][]byte, benchmarkNumBlocks)
+				for i := range blksBytes {
+					blksBytes[i] = make([]byte, blkSize)
+				}
+				b.SetBytes(int64(benchmarkNumBlocks * blkSize))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := client.batchedParseBlock(context.Background(), blksBytes)
+					require.NoError(err)
+				}
+			})
+		}
+	}
+}
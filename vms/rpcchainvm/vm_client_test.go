@@ -0,0 +1,379 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const testInstanceID = "test"
+
+var errTestLaunch = errors.New("non-nil error")
+
+type noopStopper struct{}
+
+func (noopStopper) Stop(context.Context) {}
+
+// serveTestVM serves [vm] in process and returns a connection to it.
+func serveTestVM(t *testing.T, vm block.ChainVM) *grpc.ClientConn {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+
+	server := grpcutils.NewServer()
+	vmpb.RegisterVMServer(server, newServer(vm, nil))
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return conn
+}
+
+// Tests that app messages can be sent while the VM process is being replaced
+// without racing with the replacement.
+func TestRestartConcurrentAppMessages(t *testing.T) {
+	require := require.New(t)
+
+	appVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+			AppGossipF: func(context.Context, ids.NodeID, []byte) error {
+				return nil
+			},
+			AppRequestF: func(context.Context, ids.NodeID, uint32, time.Time, []byte) error {
+				return nil
+			},
+			CrossChainAppRequestF: func(context.Context, ids.ID, uint32, time.Time, []byte) error {
+				return nil
+			},
+		},
+	}
+
+	vm := NewClient(vmpb.NewVMClient(serveTestVM(t, appVM)))
+	vm.chainCtx = snow.DefaultContextTest()
+	vm.runtime = noopStopper{}
+	vm.instanceID = testInstanceID
+	vm.launch = func(context.Context) (*process, error) {
+		return &process{
+			conn:            serveTestVM(t, appVM),
+			runtime:         noopStopper{},
+			exited:          make(chan struct{}),
+			instanceID:      testInstanceID,
+			protocolVersion: version.RPCChainVMProtocol,
+		}, nil
+	}
+
+	var err error
+	vm.supervisor, err = newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		vm.restart,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	defer vm.supervisor.close()
+
+	var (
+		ctx    = context.Background()
+		done   = make(chan struct{})
+		wg     sync.WaitGroup
+		errsMu sync.Mutex
+		errs   []error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			nodeID := ids.GenerateTestNodeID()
+			for _, err := range []error{
+				vm.AppGossip(ctx, nodeID, nil),
+				vm.AppRequest(ctx, nodeID, 0, time.Now().Add(time.Minute), nil),
+				vm.CrossChainAppRequest(ctx, ids.GenerateTestID(), 0, time.Now().Add(time.Minute), nil),
+			} {
+				if err != nil {
+					errsMu.Lock()
+					errs = append(errs, err)
+					errsMu.Unlock()
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, err := vm.restart(ctx)
+		require.NoError(err)
+	}
+	close(done)
+	wg.Wait()
+
+	require.Empty(errs)
+}
+
+// Tests that the chain keeps running on the previous VM process, without the
+// engine being paused, if the upgraded VM process can't be started.
+func TestUpgradeLaunchFailure(t *testing.T) {
+	require := require.New(t)
+
+	appVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+			AppGossipF: func(context.Context, ids.NodeID, []byte) error {
+				return nil
+			},
+		},
+	}
+
+	vm := NewClient(vmpb.NewVMClient(serveTestVM(t, appVM)))
+	vm.chainCtx = snow.DefaultContextTest()
+	vm.runtime = noopStopper{}
+	vm.pluginPath = "plugin"
+	vm.exited = make(chan struct{})
+	vm.launchUpgrade = func(context.Context) (*process, func() error, error) {
+		return nil, nil, errTestLaunch
+	}
+	vm.upgradeFailures = prometheus.NewCounter(prometheus.CounterOpts{})
+
+	var err error
+	vm.supervisor, err = newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		vm.restart,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go vm.supervisor.run(vm.exited)
+	defer vm.supervisor.close()
+
+	// The upgrade must not wait for the context lock if the new process can't
+	// be started.
+	vm.chainCtx.Lock.Lock()
+	err = vm.Upgrade(context.Background())
+	vm.chainCtx.Lock.Unlock()
+	require.ErrorIs(err, errUpgradeFailed)
+	require.ErrorContains(err, errTestLaunch.Error())
+	require.Equal(float64(1), counterValue(vm.upgradeFailures))
+
+	require.NoError(vm.AppGossip(context.Background(), ids.GenerateTestNodeID(), nil))
+}
+
+// Tests that the VM process is restarted from the previous binary if the
+// upgraded VM process fails its health check.
+func TestUpgradeHealthCheckFailureRollsBack(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		previousGossips int
+		upgradedGossips int
+	)
+	newPreviousVM := func() *block.TestVM {
+		return &block.TestVM{
+			TestVM: common.TestVM{
+				T: t,
+				AppGossipF: func(context.Context, ids.NodeID, []byte) error {
+					previousGossips++
+					return nil
+				},
+				ShutdownF: func(context.Context) error {
+					return nil
+				},
+			},
+		}
+	}
+	upgradedVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+			AppGossipF: func(context.Context, ids.NodeID, []byte) error {
+				upgradedGossips++
+				return nil
+			},
+			HealthCheckF: func(context.Context) (interface{}, error) {
+				return nil, errTestLaunch
+			},
+		},
+	}
+	newProcess := func(vm block.ChainVM) *process {
+		return &process{
+			conn:            serveTestVM(t, vm),
+			runtime:         noopStopper{},
+			exited:          make(chan struct{}),
+			instanceID:      testInstanceID,
+			protocolVersion: version.RPCChainVMProtocol,
+		}
+	}
+
+	p := newProcess(newPreviousVM())
+	vm := NewClient(p.client())
+	vm.chainCtx = snow.DefaultContextTest()
+	vm.runtime = noopStopper{}
+	vm.pluginPath = "plugin"
+	vm.supervise(p, func(context.Context) (*process, error) {
+		return newProcess(newPreviousVM()), nil
+	})
+	committed := false
+	vm.launchUpgrade = func(context.Context) (*process, func() error, error) {
+		return newProcess(upgradedVM), func() error {
+			committed = true
+			return nil
+		}, nil
+	}
+	vm.upgrades = prometheus.NewCounter(prometheus.CounterOpts{})
+	vm.upgradeFailures = prometheus.NewCounter(prometheus.CounterOpts{})
+
+	var err error
+	vm.supervisor, err = newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		vm.restart,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go vm.supervisor.run(vm.exited)
+	defer vm.supervisor.close()
+
+	err = vm.Upgrade(context.Background())
+	require.ErrorIs(err, errUpgradeFailed)
+	require.ErrorContains(err, errTestLaunch.Error())
+	require.False(committed)
+	require.Zero(counterValue(vm.upgrades))
+	require.Equal(float64(1), counterValue(vm.upgradeFailures))
+
+	require.NoError(vm.AppGossip(context.Background(), ids.GenerateTestNodeID(), nil))
+	require.Equal(1, previousGossips)
+	require.Zero(upgradedGossips)
+}
+
+// Tests that a call the engine makes while holding the context lock fails once
+// the VM process exits, so that the process can be restarted.
+func TestProcessExitDuringVerify(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		verifying = make(chan struct{})
+		release   = make(chan struct{})
+	)
+	defer close(release)
+	blockingVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+		},
+		ParseBlockF: func(context.Context, []byte) (snowman.Block, error) {
+			close(verifying)
+			<-release
+			return nil, errTestLaunch
+		},
+	}
+
+	exited := make(chan struct{})
+	p := &process{
+		conn:            serveTestVM(t, blockingVM),
+		runtime:         noopStopper{},
+		exited:          exited,
+		instanceID:      testInstanceID,
+		protocolVersion: version.RPCChainVMProtocol,
+	}
+	vm := NewClient(p.client())
+	vm.chainCtx = snow.DefaultContextTest()
+	vm.runtime = noopStopper{}
+	vm.supervise(p, func(context.Context) (*process, error) {
+		return &process{
+			conn:            serveTestVM(t, &block.TestVM{}),
+			runtime:         noopStopper{},
+			exited:          make(chan struct{}),
+			instanceID:      testInstanceID,
+			protocolVersion: version.RPCChainVMProtocol,
+		}, nil
+	})
+
+	var err error
+	vm.supervisor, err = newSupervisor(
+		testSupervisorConfig,
+		logging.NoLog{},
+		vm.restart,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go vm.supervisor.run(vm.exited)
+	defer vm.supervisor.close()
+
+	blk := &blockClient{
+		vm: vm,
+		id: ids.GenerateTestID(),
+	}
+
+	vm.chainCtx.Lock.Lock()
+	go func() {
+		<-verifying
+		close(exited)
+	}()
+	err = blk.Verify(context.Background())
+	require.ErrorIs(err, errProcessExited)
+
+	// Calls made after the process exited must not wait for it to be
+	// replaced.
+	require.ErrorIs(vm.SetPreference(context.Background(), blk.id), errProcessExited)
+	vm.chainCtx.Lock.Unlock()
+
+	require.Eventually(func() bool {
+		return counterValue(vm.supervisor.restarts) == 1 && !vm.supervisor.isDown()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// Tests that the resources of the VM process are released if the VM fails to
+// be initialized, as the VM isn't shutdown afterwards.
+func TestInitializeFailureReleasesProcess(t *testing.T) {
+	require := require.New(t)
+
+	vm := NewClient(vmpb.NewVMClient(serveTestVM(t, &block.TestVM{})))
+	released := false
+	vm.onShutdown = func() {
+		released = true
+	}
+
+	err := vm.Initialize(
+		context.Background(),
+		snow.DefaultContextTest(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		[]*common.Fx{{}},
+		nil,
+	)
+	require.ErrorIs(err, errUnsupportedFXs)
+	require.True(released)
+}
@@ -31,7 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/appsender"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
-	"github.com/ava-labs/avalanchego/snow/validators/gvalidators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -48,7 +48,6 @@ import (
 	messengerpb "github.com/ava-labs/avalanchego/proto/pb/messenger"
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 	sharedmemorypb "github.com/ava-labs/avalanchego/proto/pb/sharedmemory"
-	validatorstatepb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 	warppb "github.com/ava-labs/avalanchego/proto/pb/warp"
 )
@@ -80,21 +79,36 @@ type VMServer struct {
 	serverCloser grpcutils.ServerCloser
 	connCloser   wrappers.Closer
 
+	// transport is used to connect to AvalancheGo and to expose the servers
+	// created by this VM.
+	transport *grpcutils.Transport
+	// onShutdown, if non-nil, is called once the VM has been shutdown.
+	onShutdown func()
+	// protocolVersion is the protocol version negotiated with AvalancheGo.
+	protocolVersion utils.Atomic[uint]
+
 	ctx    *snow.Context
 	closed chan struct{}
 }
 
 // NewServer returns a vm instance connected to a remote vm instance
 func NewServer(vm block.ChainVM) *VMServer {
+	return newServer(vm, nil)
+}
+
+func newServer(vm block.ChainVM, transport *grpcutils.Transport) *VMServer {
 	bVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
-	return &VMServer{
-		vm:   vm,
-		bVM:  bVM,
-		hVM:  hVM,
-		ssVM: ssVM,
+	vmServer := &VMServer{
+		vm:        vm,
+		bVM:       bVM,
+		hVM:       hVM,
+		ssVM:      ssVM,
+		transport: transport,
 	}
+	vmServer.protocolVersion.Set(version.RPCChainVMProtocol)
+	return vmServer
 }
 
 func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest) (*vmpb.InitializeResponse, error) {
@@ -160,7 +174,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 			return nil, err
 		}
 
-		clientConn, err := grpcutils.Dial(
+		clientConn, err := vm.transport.Dial(
 			vDBReq.ServerAddr,
 			grpcutils.WithChainUnaryInterceptor(grpcClientMetrics.UnaryClientInterceptor()),
 			grpcutils.WithChainStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()),
@@ -195,7 +209,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		),
 	)
 
-	clientConn, err := grpcutils.Dial(
+	clientConn, err := vm.transport.Dial(
 		req.ServerAddr,
 		grpcutils.WithChainUnaryInterceptor(grpcClientMetrics.UnaryClientInterceptor()),
 		grpcutils.WithChainStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()),
@@ -213,7 +227,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	sharedMemoryClient := gsharedmemory.NewClient(sharedmemorypb.NewSharedMemoryClient(clientConn))
 	bcLookupClient := galiasreader.NewClient(aliasreaderpb.NewAliasReaderClient(clientConn))
 	appSenderClient := appsender.NewClient(appsenderpb.NewAppSenderClient(clientConn))
-	validatorStateClient := gvalidators.NewClient(validatorstatepb.NewValidatorStateClient(clientConn))
+	validatorStateClient := newValidatorStateClient(vm.protocolVersion.Get(), clientConn)
 	warpSignerClient := gwarp.NewClient(warppb.NewSignerClient(clientConn))
 
 	toEngine := make(chan common.Message, 1)
@@ -290,9 +304,20 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		Height:               blk.Height(),
 		Bytes:                blk.Bytes(),
 		Timestamp:            grpcutils.TimestampFromTime(blk.Timestamp()),
+		SharedPayloads:       vm.sharedPayloadsDir() != "",
 	}, nil
 }
 
+// sharedPayloadsDir returns the directory that payloads can be shared with
+// AvalancheGo through. Returns the empty string if payloads can't be shared,
+// which is the case unless the VM is connected over Unix domain sockets.
+func (vm *VMServer) sharedPayloadsDir() string {
+	if vm.transport == nil {
+		return ""
+	}
+	return vm.transport.UnixSocketDir
+}
+
 func (vm *VMServer) SetState(ctx context.Context, stateReq *vmpb.SetStateRequest) (*vmpb.SetStateResponse, error) {
 	err := vm.vm.SetState(ctx, snow.State(stateReq.State))
 	if err != nil {
@@ -328,6 +353,9 @@ func (vm *VMServer) Shutdown(ctx context.Context, _ *emptypb.Empty) (*emptypb.Em
 	close(vm.closed)
 	vm.serverCloser.Stop()
 	errs.Add(vm.connCloser.Close())
+	if vm.onShutdown != nil {
+		vm.onShutdown()
+	}
 	return &emptypb.Empty{}, errs.Err
 }
 
@@ -340,13 +368,13 @@ func (vm *VMServer) CreateHandlers(ctx context.Context, _ *emptypb.Empty) (*vmpb
 	for prefix, h := range handlers {
 		handler := h
 
-		serverListener, err := grpcutils.NewListener()
+		serverListener, err := vm.transport.NewListener()
 		if err != nil {
 			return nil, err
 		}
-		server := grpcutils.NewServer()
+		server := vm.transport.NewServer()
 		vm.serverCloser.Add(server)
-		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler))
+		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler, vm.transport))
 
 		// Start HTTP service
 		go grpcutils.Serve(serverListener, server)
@@ -369,13 +397,13 @@ func (vm *VMServer) CreateStaticHandlers(ctx context.Context, _ *emptypb.Empty)
 	for prefix, h := range handlers {
 		handler := h
 
-		serverListener, err := grpcutils.NewListener()
+		serverListener, err := vm.transport.NewListener()
 		if err != nil {
 			return nil, err
 		}
-		server := grpcutils.NewServer()
+		server := vm.transport.NewServer()
 		vm.serverCloser.Add(server)
-		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler))
+		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler, vm.transport))
 
 		// Start HTTP service
 		go grpcutils.Serve(serverListener, server)
@@ -662,17 +690,38 @@ func (vm *VMServer) GetAncestors(ctx context.Context, req *vmpb.GetAncestorsRequ
 		maxBlksSize,
 		maxBlocksRetrivalTime,
 	)
+	if err != nil || !req.SharedResponse || !shouldSharePayloads(vm.sharedPayloadsDir(), blocks) {
+		return &vmpb.GetAncestorsResponse{
+			BlksBytes: blocks,
+		}, err
+	}
+
+	// If AvalancheGo doesn't receive the response, the payloads are removed
+	// along with the directory once the VM is shutdown.
+	shared, err := writeSharedPayloads(vm.sharedPayloadsDir(), blocks)
+	if err != nil {
+		return nil, err
+	}
 	return &vmpb.GetAncestorsResponse{
-		BlksBytes: blocks,
-	}, err
+		SharedBlksBytes: shared,
+	}, nil
 }
 
 func (vm *VMServer) BatchedParseBlock(
 	ctx context.Context,
 	req *vmpb.BatchedParseBlockRequest,
 ) (*vmpb.BatchedParseBlockResponse, error) {
-	blocks := make([]*vmpb.ParseBlockResponse, len(req.Request))
-	for i, blockBytes := range req.Request {
+	blksBytes := req.Request
+	if req.SharedRequest != nil {
+		var err error
+		blksBytes, err = readSharedPayloads(vm.sharedPayloadsDir(), req.SharedRequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocks := make([]*vmpb.ParseBlockResponse, len(blksBytes))
+	for i, blockBytes := range blksBytes {
 		block, err := vm.ParseBlock(ctx, &vmpb.ParseBlockRequest{
 			Bytes: blockBytes,
 		})
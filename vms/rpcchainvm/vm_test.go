@@ -18,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -206,3 +208,53 @@ func TestRuntimeSubprocessBootstrap(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeSubprocessBootstrapUnixSocket(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	vm := mocks.NewMockChainVM(ctrl)
+	defer ctrl.Finish()
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+
+	unixSocketDir := t.TempDir()
+	t.Setenv(runtime.EngineAddressKey, listener.Addr().String())
+	t.Setenv(runtime.UnixSocketDirKey, unixSocketDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Serve(ctx, vm)
+	}()
+
+	status, stopper, err := subprocess.Bootstrap(
+		context.Background(),
+		listener,
+		helperProcess("dummy"),
+		&subprocess.Config{
+			Stderr:           logging.NoLog{},
+			Stdout:           logging.NoLog{},
+			Log:              logging.NoLog{},
+			HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+			UnixSocketDir:    unixSocketDir,
+		},
+	)
+	require.NoError(err)
+	defer stopper.Stop(ctx)
+
+	// The VM opts into Unix domain sockets by serving on one.
+	require.True(grpcutils.IsUnixAddr(status.Addr))
+
+	conn, err := grpcutils.Dial(status.Addr)
+	require.NoError(err)
+	defer conn.Close()
+
+	healthCtx, healthCancel := context.WithTimeout(ctx, runtime.DefaultHandshakeTimeout)
+	defer healthCancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(healthCtx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	require.Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
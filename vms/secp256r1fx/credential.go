@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import "github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+type Credential struct {
+	Sigs []Signature `serialize:"true" json:"signatures"`
+}
+
+func (cr *Credential) Verify() error {
+	switch {
+	case cr == nil:
+		return secp256k1fx.ErrNilCredential
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var (
+	_ vms.Factory = (*Factory)(nil)
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'s', 'e', 'c', 'p', '2', '5', '6', 'r', '1', 'f', 'x'}
+)
+
+type Factory struct {
+	// ActivationTime is the time after which the fx may be used
+	ActivationTime time.Time
+}
+
+func (f *Factory) New(logging.Logger) (interface{}, error) {
+	return &Fx{activationTime: f.ActivationTime}, nil
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// TypeIDOffset is the codec type ID of the first type of this fx when it is
+// added to a chain that already exists. The P-chain and the X-chain both
+// register the fx's types starting at this ID, so that its UTXOs can be moved
+// between them through shared memory.
+const TypeIDOffset = 36
+
+// Fx describes the secp256r1 feature extension. Its outputs are owned by P-256
+// keys, such as device passkeys, and are spent with either plain P-256
+// signatures or WebAuthn assertions.
+//
+// The fx doesn't enforce its activation time, as it doesn't have access to
+// the chain time. The VM must reject its use before [Fx.ActivationTime].
+type Fx struct {
+	VM             secp256k1fx.VM
+	activationTime time.Time
+	bootstrapped   bool
+}
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing secp256r1 fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&MintOutput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&MintOperation{}),
+		c.RegisterType(&Credential{}),
+		c.RegisterType(&OutputOwners{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) InitializeVM(vmIntf interface{}) error {
+	vm, ok := vmIntf.(secp256k1fx.VM)
+	if !ok {
+		return secp256k1fx.ErrWrongVMType
+	}
+	fx.VM = vm
+	return nil
+}
+
+func (*Fx) Bootstrapping() error {
+	return nil
+}
+
+func (fx *Fx) Bootstrapped() error {
+	fx.bootstrapped = true
+	return nil
+}
+
+// ActivationTime returns the time after which the fx may be used
+func (fx *Fx) ActivationTime() time.Time {
+	return fx.activationTime
+}
+
+// TypeIDOffset returns the codec type ID of the first type of this fx
+func (*Fx) TypeIDOffset() uint32 {
+	return TypeIDOffset
+}
+
+// VerifyPermission returns nil iff [credIntf] proves that [ownerIntf] assents
+// to [txIntf]
+func (fx *Fx) VerifyPermission(txIntf, inIntf, credIntf, ownerIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return secp256k1fx.ErrWrongTxType
+	}
+	in, ok := inIntf.(*secp256k1fx.Input)
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return secp256k1fx.ErrWrongCredentialType
+	}
+	owner, ok := ownerIntf.(*OutputOwners)
+	if !ok {
+		return secp256k1fx.ErrWrongOwnerType
+	}
+	if err := verify.All(in, cred, owner); err != nil {
+		return err
+	}
+	return fx.VerifyCredentials(tx, in, cred, owner)
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return secp256k1fx.ErrWrongTxType
+	}
+	op, ok := opIntf.(*MintOperation)
+	if !ok {
+		return secp256k1fx.ErrWrongOpType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return secp256k1fx.ErrWrongCredentialType
+	}
+	if len(utxosIntf) != 1 {
+		return secp256k1fx.ErrWrongNumberOfUTXOs
+	}
+	out, ok := utxosIntf[0].(*MintOutput)
+	if !ok {
+		return secp256k1fx.ErrWrongUTXOType
+	}
+	return fx.verifyOperation(tx, op, cred, out)
+}
+
+func (fx *Fx) verifyOperation(tx secp256k1fx.UnsignedTx, op *MintOperation, cred *Credential, utxo *MintOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	if !utxo.Equals(&op.MintOutput.OutputOwners) {
+		return secp256k1fx.ErrWrongMintCreated
+	}
+	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return secp256k1fx.ErrWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return secp256k1fx.ErrWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return secp256k1fx.ErrWrongUTXOType
+	}
+	return fx.VerifySpend(tx, in, cred, out)
+}
+
+// VerifySpend ensures that the utxo can be sent to any address
+func (fx *Fx) VerifySpend(utx secp256k1fx.UnsignedTx, in *TransferInput, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(utxo, in, cred); err != nil {
+		return err
+	} else if utxo.Amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", secp256k1fx.ErrMismatchedAmounts, utxo.Amt, in.Amt)
+	}
+
+	return fx.VerifyCredentials(utx, &in.Input, cred, &utxo.OutputOwners)
+}
+
+// VerifyCredentials ensures that the output can be spent by the input with the
+// credential. A nil return values means the output can be spent.
+func (fx *Fx) VerifyCredentials(utx secp256k1fx.UnsignedTx, in *secp256k1fx.Input, cred *Credential, out *OutputOwners) error {
+	numSigs := len(in.SigIndices)
+	switch {
+	case out.Locktime > fx.VM.Clock().Unix():
+		return secp256k1fx.ErrTimelocked
+	case out.Threshold < uint32(numSigs):
+		return secp256k1fx.ErrTooManySigners
+	case out.Threshold > uint32(numSigs):
+		return secp256k1fx.ErrTooFewSigners
+	case numSigs != len(cred.Sigs):
+		return secp256k1fx.ErrInputCredentialSignersMismatch
+	case !fx.bootstrapped: // disable signature verification during bootstrapping
+		return nil
+	}
+
+	txHash := hashing.ComputeHash256(utx.Bytes())
+	for i, index := range in.SigIndices {
+		// Make sure the input references an address that exists
+		if index >= uint32(len(out.Addrs)) {
+			return secp256k1fx.ErrInputOutputIndexOutOfBounds
+		}
+		// Make sure each signature in the signature list is from an owner of
+		// the output being consumed
+		sig := &cred.Sigs[i]
+		if expectedAddress, addr := out.Addrs[index], sig.Address(); expectedAddress != addr {
+			return fmt.Errorf("%w: expected signature from %s but got from %s",
+				secp256k1fx.ErrWrongSig,
+				expectedAddress,
+				addr,
+			)
+		}
+		if err := sig.verify(txHash); err != nil {
+			return fmt.Errorf("%w: %s", secp256k1fx.ErrWrongSig, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateOutput creates a new output with the provided control group worth
+// the specified amount
+func (*Fx) CreateOutput(amount uint64, ownerIntf interface{}) (interface{}, error) {
+	owner, ok := ownerIntf.(*OutputOwners)
+	if !ok {
+		return nil, secp256k1fx.ErrWrongOwnerType
+	}
+	if err := owner.Verify(); err != nil {
+		return nil, err
+	}
+	return &TransferOutput{
+		Amt:          amount,
+		OutputOwners: *owner,
+	}, nil
+}
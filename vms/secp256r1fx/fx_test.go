@@ -0,0 +1,401 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var txBytes = []byte{0, 1, 2, 3, 4, 5}
+
+// testAuthenticator produces WebAuthn assertions with a P-256 key
+type testAuthenticator struct {
+	key         *PrivateKey
+	flags       byte
+	clientType  string
+	challengeFn func([]byte) []byte
+}
+
+func (a *testAuthenticator) GetAssertion(challenge []byte) ([]byte, []byte, []byte, error) {
+	authenticatorData := make([]byte, authenticatorDataMinLen)
+	authenticatorData[flagsIndex] = a.flags
+
+	if a.challengeFn != nil {
+		challenge = a.challengeFn(challenge)
+	}
+	clientDataJSON, err := json.Marshal(map[string]string{
+		"type":      a.clientType,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    "https://wallet.example",
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signedBytes := append(authenticatorData, hashing.ComputeHash256(clientDataJSON)...)
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key.sk, hashing.ComputeHash256(signedBytes))
+	return authenticatorData[:authenticatorDataMinLen], clientDataJSON, sig, err
+}
+
+func newTestFx(t *testing.T) (*secp256k1fx.TestVM, *Fx) {
+	require := require.New(t)
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	vm.Clk.Set(time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC))
+	fx := &Fx{}
+	require.NoError(fx.Initialize(vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+	return vm, fx
+}
+
+func newTestKey(t *testing.T) *PrivateKey {
+	key, err := NewPrivateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newTransfer(addr ids.ShortID) (*TransferInput, *TransferOutput) {
+	in := &TransferInput{
+		TransferInput: secp256k1fx.TransferInput{
+			Amt: 1,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+	out := &TransferOutput{
+		Amt: 1,
+		OutputOwners: OutputOwners{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	return in, out
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	err := fx.Initialize(nil)
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongVMType)
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+	require.False(sig.IsWebAuthn())
+	cred := &Credential{Sigs: []Signature{*sig}}
+	require.NoError(fx.VerifyTransfer(tx, in, cred, out))
+
+	// The signature must commit to the tx
+	otherTx := &secp256k1fx.TestTx{UnsignedBytes: []byte{1}}
+	err = fx.VerifyTransfer(otherTx, in, cred, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+}
+
+func TestFxVerifyTransferWebAuthn(t *testing.T) {
+	key := newTestKey(t)
+	tests := []struct {
+		name          string
+		authenticator *testAuthenticator
+		expectedErr   error
+	}{
+		{
+			name: "valid assertion",
+			authenticator: &testAuthenticator{
+				key:        key,
+				flags:      userPresentFlag,
+				clientType: webAuthnGetType,
+			},
+		},
+		{
+			name: "user not present",
+			authenticator: &testAuthenticator{
+				key:        key,
+				clientType: webAuthnGetType,
+			},
+			expectedErr: ErrUserNotPresent,
+		},
+		{
+			name: "registration instead of assertion",
+			authenticator: &testAuthenticator{
+				key:        key,
+				flags:      userPresentFlag,
+				clientType: "webauthn.create",
+			},
+			expectedErr: ErrInvalidClientData,
+		},
+		{
+			name: "challenge of another tx",
+			authenticator: &testAuthenticator{
+				key:        key,
+				flags:      userPresentFlag,
+				clientType: webAuthnGetType,
+				challengeFn: func([]byte) []byte {
+					return hashing.ComputeHash256([]byte{1})
+				},
+			},
+			expectedErr: ErrWrongChallenge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			signer, err := NewWebAuthnSigner(key.PublicKey(), test.authenticator)
+			require.NoError(err)
+			require.Equal(key.Address(), signer.Address())
+
+			_, err = signer.Sign(txBytes)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			// The fx must also reject the signature when the signer doesn't
+			// verify it before returning it.
+			_, fx := newTestFx(t)
+			tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+			in, out := newTransfer(key.Address())
+			sig, err := signer.Sign(txBytes)
+			require.NoError(err)
+			require.True(sig.IsWebAuthn())
+			require.NoError(fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out))
+
+			sig.ClientDataJSON = append(sig.ClientDataJSON, ' ')
+			err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+			require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+		})
+	}
+}
+
+func TestFxVerifyTransferHighS(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+
+	// Replacing s with N - s produces another valid ECDSA signature, which
+	// must be rejected so that the tx ID can't be changed.
+	s := new(big.Int).SetBytes(sig.Sig[scalarLen:])
+	s.Sub(curve.Params().N, s)
+	s.FillBytes(sig.Sig[scalarLen:])
+
+	err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+}
+
+func TestFxVerifyTransferWrongSigner(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+
+	sig, err := otherKey.Sign(txBytes)
+	require.NoError(err)
+	err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+
+	// Claiming the owner's public key with another key's signature must fail
+	sig.PublicKey = key.PublicKey()
+	err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+}
+
+func TestFxVerifyTransferInvalidPublicKey(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	sig := Signature{}
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(sig.Address())
+
+	err := fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+}
+
+func TestFxVerifyTransferBootstrapping(t *testing.T) {
+	require := require.New(t)
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	require.NoError(fx.Initialize(vm))
+	require.NoError(fx.Bootstrapping())
+
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+
+	// Signatures aren't verified during bootstrapping
+	require.NoError(fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{{}}}, out))
+}
+
+func TestFxVerifyTransferTimelocked(t *testing.T) {
+	require := require.New(t)
+	vm, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+	out.Locktime = uint64(vm.Clk.Unix()) + 1
+
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+	err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrTimelocked)
+}
+
+func TestFxVerifyTransferWrongAmounts(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+	out.Amt = 2
+
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+	err = fx.VerifyTransfer(tx, in, &Credential{Sigs: []Signature{*sig}}, out)
+	require.ErrorIs(err, secp256k1fx.ErrMismatchedAmounts)
+}
+
+func TestFxVerifyTransferWrongTypes(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+	in, out := newTransfer(key.Address())
+	cred := &Credential{}
+
+	err := fx.VerifyTransfer(tx, &in.TransferInput, cred, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongInputType)
+
+	err = fx.VerifyTransfer(tx, in, &secp256k1fx.Credential{}, out)
+	require.ErrorIs(err, secp256k1fx.ErrWrongCredentialType)
+
+	err = fx.VerifyTransfer(tx, in, cred, &secp256k1fx.TransferOutput{})
+	require.ErrorIs(err, secp256k1fx.ErrWrongUTXOType)
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+
+	owners := OutputOwners{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{key.Address()},
+		},
+	}
+	utxo := &MintOutput{OutputOwners: owners}
+	op := &MintOperation{
+		MintInput: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		MintOutput: MintOutput{OutputOwners: owners},
+		TransferOutput: TransferOutput{
+			Amt:          1,
+			OutputOwners: owners,
+		},
+	}
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+	cred := &Credential{Sigs: []Signature{*sig}}
+	require.NoError(fx.VerifyOperation(tx, op, cred, []interface{}{utxo}))
+
+	op.MintOutput.Threshold = 2
+	op.MintOutput.Addrs = []ids.ShortID{key.Address(), ids.GenerateTestShortID()}
+	op.MintOutput.Sort()
+	err = fx.VerifyOperation(tx, op, cred, []interface{}{utxo})
+	require.ErrorIs(err, secp256k1fx.ErrWrongMintCreated)
+}
+
+func TestFxVerifyPermission(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	key := newTestKey(t)
+	tx := &secp256k1fx.TestTx{UnsignedBytes: txBytes}
+
+	owner := &OutputOwners{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{key.Address()},
+		},
+	}
+	in := &secp256k1fx.Input{SigIndices: []uint32{0}}
+	sig, err := key.Sign(txBytes)
+	require.NoError(err)
+	require.NoError(fx.VerifyPermission(tx, in, &Credential{Sigs: []Signature{*sig}}, owner))
+
+	err = fx.VerifyPermission(tx, in, &Credential{Sigs: []Signature{*sig}}, &owner.OutputOwners)
+	require.ErrorIs(err, secp256k1fx.ErrWrongOwnerType)
+}
+
+func TestFxCreateOutput(t *testing.T) {
+	require := require.New(t)
+	_, fx := newTestFx(t)
+	owner := &OutputOwners{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	}
+	outIntf, err := fx.CreateOutput(1, owner)
+	require.NoError(err)
+	require.Equal(&TransferOutput{Amt: 1, OutputOwners: *owner}, outIntf)
+
+	_, err = fx.CreateOutput(1, &owner.OutputOwners)
+	require.ErrorIs(err, secp256k1fx.ErrWrongOwnerType)
+}
+
+// Ensure P-256 addresses can't collide with secp256k1 addresses of the same
+// public key bytes
+func TestPublicKeyToAddressDomainSeparation(t *testing.T) {
+	require := require.New(t)
+
+	key := newTestKey(t)
+	pk := key.PublicKey()
+	addr := PublicKeyToAddress(pk)
+	require.Equal(key.Address(), addr)
+
+	secp256k1Addr, err := ids.ToShortID(hashing.PubkeyBytesToAddress(pk[:]))
+	require.NoError(err)
+	require.NotEqual(secp256k1Addr, addr)
+
+	prefixedAddr, err := ids.ToShortID(hashing.PubkeyBytesToAddress(append([]byte("secp256r1"), pk[:]...)))
+	require.NoError(err)
+	require.Equal(prefixedAddr, addr)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errCantSpend = errors.New("unable to spend this UTXO")
+
+// Signer produces signatures for a single address
+type Signer interface {
+	Address() ids.ShortID
+	// Sign returns a signature of the tx whose unsigned bytes are
+	// [unsignedTxBytes].
+	Sign(unsignedTxBytes []byte) (*Signature, error)
+}
+
+// Keychain is a collection of signers that can be used to spend outputs
+type Keychain struct {
+	signers map[ids.ShortID]Signer
+
+	// Addrs can be used to iterate over. However, it should not be modified
+	// externally.
+	Addrs set.Set[ids.ShortID]
+}
+
+// NewKeychain returns a new keychain containing [signers]
+func NewKeychain(signers ...Signer) *Keychain {
+	kc := &Keychain{
+		signers: make(map[ids.ShortID]Signer),
+	}
+	for _, signer := range signers {
+		kc.Add(signer)
+	}
+	return kc
+}
+
+// Add a new signer to the keychain
+func (kc *Keychain) Add(signer Signer) {
+	addr := signer.Address()
+	if _, ok := kc.signers[addr]; !ok {
+		kc.signers[addr] = signer
+		kc.Addrs.Add(addr)
+	}
+}
+
+// Get a signer from the keychain. Also returns a boolean telling whether the
+// signer is known.
+func (kc *Keychain) Get(addr ids.ShortID) (Signer, bool) {
+	signer, ok := kc.signers[addr]
+	return signer, ok
+}
+
+// Addresses returns the set of addresses this keychain manages
+func (kc *Keychain) Addresses() set.Set[ids.ShortID] {
+	return kc.Addrs
+}
+
+// Spend attempts to create an input
+func (kc *Keychain) Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []Signer, error) {
+	switch out := out.(type) {
+	case *MintOutput:
+		if sigIndices, signers, able := kc.Match(&out.OutputOwners, time); able {
+			return &secp256k1fx.Input{
+				SigIndices: sigIndices,
+			}, signers, nil
+		}
+		return nil, nil, errCantSpend
+	case *TransferOutput:
+		if sigIndices, signers, able := kc.Match(&out.OutputOwners, time); able {
+			return &TransferInput{
+				TransferInput: secp256k1fx.TransferInput{
+					Amt: out.Amt,
+					Input: secp256k1fx.Input{
+						SigIndices: sigIndices,
+					},
+				},
+			}, signers, nil
+		}
+		return nil, nil, errCantSpend
+	}
+	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+// Match attempts to match a list of addresses up to the provided threshold
+func (kc *Keychain) Match(owners *OutputOwners, time uint64) ([]uint32, []Signer, bool) {
+	if time < owners.Locktime {
+		return nil, nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	signers := make([]Signer, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(signers)) < owners.Threshold; i++ {
+		if signer, exists := kc.signers[owners.Addrs[i]]; exists {
+			sigs = append(sigs, i)
+			signers = append(signers, signer)
+		}
+	}
+	return sigs, signers, uint32(len(signers)) == owners.Threshold
+}
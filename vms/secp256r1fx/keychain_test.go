@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestKeychainSpend(t *testing.T) {
+	require := require.New(t)
+	key0 := newTestKey(t)
+	key1 := newTestKey(t)
+	kc := NewKeychain(key0)
+	addrSet := kc.Addresses()
+	require.True(addrSet.Contains(key0.Address()))
+
+	signer, ok := kc.Get(key0.Address())
+	require.True(ok)
+	require.Equal(key0, signer)
+	_, ok = kc.Get(key1.Address())
+	require.False(ok)
+
+	addrs := []ids.ShortID{key0.Address(), key1.Address()}
+	utils.Sort(addrs)
+	out := &TransferOutput{
+		Amt: 5,
+		OutputOwners: OutputOwners{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  10,
+				Threshold: 2,
+				Addrs:     addrs,
+			},
+		},
+	}
+
+	_, _, err := kc.Spend(out, 10)
+	require.ErrorIs(err, errCantSpend)
+
+	kc.Add(key1)
+	_, _, err = kc.Spend(out, 9)
+	require.ErrorIs(err, errCantSpend)
+
+	inIntf, signers, err := kc.Spend(out, 10)
+	require.NoError(err)
+	require.Equal(&TransferInput{
+		TransferInput: secp256k1fx.TransferInput{
+			Amt: 5,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0, 1},
+			},
+		},
+	}, inIntf)
+	require.Len(signers, 2)
+	require.Equal(addrs[0], signers[0].Address())
+	require.Equal(addrs[1], signers[1].Address())
+}
+
+func TestPrivateKeyBytes(t *testing.T) {
+	require := require.New(t)
+	key := newTestKey(t)
+
+	parsedKey, err := ToPrivateKey(key.Bytes())
+	require.NoError(err)
+	require.Equal(key.PublicKey(), parsedKey.PublicKey())
+	require.Equal(key.Address(), parsedKey.Address())
+
+	_, err = ToPrivateKey(make([]byte, PrivateKeyLen))
+	require.ErrorIs(err, ErrInvalidPrivateKey)
+}
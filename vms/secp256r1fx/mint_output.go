@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var _ verify.State = (*MintOutput)(nil)
+
+type MintOutput struct {
+	verify.IsState `json:"-"`
+
+	OutputOwners `serialize:"true"`
+}
+
+func (out *MintOutput) Verify() error {
+	switch {
+	case out == nil:
+		return secp256k1fx.ErrNilOutput
+	default:
+		return out.OutputOwners.Verify()
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import "github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+// OutputOwners has the same layout as [secp256k1fx.OutputOwners], but its
+// addresses are derived from P-256 public keys.
+type OutputOwners struct {
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
+
+// Equals returns true if [out] and [other] are the same set of owners
+func (out *OutputOwners) Equals(other *OutputOwners) bool {
+	if out == other {
+		return true
+	}
+	if out == nil || other == nil {
+		return false
+	}
+	return out.OutputOwners.Equals(&other.OutputOwners)
+}
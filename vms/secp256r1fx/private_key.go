@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// PrivateKeyLen is the length of a P-256 private key scalar
+const PrivateKeyLen = 32
+
+var (
+	_ Signer = (*PrivateKey)(nil)
+
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+)
+
+// PrivateKey is a P-256 key that signs the SHA-256 hash of unsigned txs
+// directly, without a WebAuthn assertion.
+type PrivateKey struct {
+	sk   *ecdsa.PrivateKey
+	pk   [PublicKeyLen]byte
+	addr ids.ShortID
+}
+
+// NewPrivateKey returns a newly generated private key
+func NewPrivateKey() (*PrivateKey, error) {
+	sk, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(sk), nil
+}
+
+// ToPrivateKey parses the big-endian scalar [b] as a private key
+func ToPrivateKey(b []byte) (*PrivateKey, error) {
+	d := new(big.Int).SetBytes(b)
+	if len(b) != PrivateKeyLen || d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	sk := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve},
+		D:         d,
+	}
+	sk.X, sk.Y = curve.ScalarBaseMult(b)
+	return newPrivateKey(sk), nil
+}
+
+func newPrivateKey(sk *ecdsa.PrivateKey) *PrivateKey {
+	k := &PrivateKey{sk: sk}
+	copy(k.pk[:], elliptic.MarshalCompressed(curve, sk.X, sk.Y))
+	k.addr = PublicKeyToAddress(k.pk)
+	return k
+}
+
+// Bytes returns the big-endian scalar of the private key
+func (k *PrivateKey) Bytes() []byte {
+	return k.sk.D.FillBytes(make([]byte, PrivateKeyLen))
+}
+
+// PublicKey returns the compressed public key
+func (k *PrivateKey) PublicKey() [PublicKeyLen]byte {
+	return k.pk
+}
+
+func (k *PrivateKey) Address() ids.ShortID {
+	return k.addr
+}
+
+func (k *PrivateKey) Sign(unsignedTxBytes []byte) (*Signature, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, k.sk, hashing.ComputeHash256(unsignedTxBytes))
+	if err != nil {
+		return nil, err
+	}
+	sig := newSignature(k.pk, r, s)
+	return &sig, nil
+}
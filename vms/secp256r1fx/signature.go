@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	// PublicKeyLen is the length of a compressed P-256 public key
+	PublicKeyLen = 33
+	// SignatureLen is the length of a P-256 signature encoded as r || s
+	SignatureLen = 64
+
+	scalarLen = SignatureLen / 2
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrHighS            = errors.New("signature s value is not in the lower half of the curve order")
+
+	// addressPrefix is hashed in front of the public key of an address, so
+	// that P-256 addresses are in a different namespace than secp256k1
+	// addresses. Compressed public keys of both curves have the same length,
+	// so the same bytes could otherwise be a key of either curve.
+	addressPrefix = []byte("secp256r1")
+
+	curve     = elliptic.P256()
+	halfOrder = new(big.Int).Rsh(curve.Params().N, 1)
+)
+
+// Signature is a P-256 signature of a tx. If [AuthenticatorData] and
+// [ClientDataJSON] are empty, [Sig] signs the SHA-256 hash of the unsigned
+// tx. Otherwise, [Sig] is a WebAuthn assertion signature whose challenge is
+// the SHA-256 hash of the unsigned tx.
+//
+// The public key is included because, unlike secp256k1, it can't be recovered
+// from a P-256 signature.
+type Signature struct {
+	PublicKey         [PublicKeyLen]byte `serialize:"true" json:"publicKey"`
+	Sig               [SignatureLen]byte `serialize:"true" json:"signature"`
+	AuthenticatorData []byte             `serialize:"true" json:"authenticatorData"`
+	ClientDataJSON    []byte             `serialize:"true" json:"clientDataJSON"`
+}
+
+// MarshalJSON marshals [s] to JSON
+// The string representation of each field is created using the hex formatter
+func (s *Signature) MarshalJSON() ([]byte, error) {
+	fields := map[string][]byte{
+		"publicKey":         s.PublicKey[:],
+		"signature":         s.Sig[:],
+		"authenticatorData": s.AuthenticatorData,
+		"clientDataJSON":    s.ClientDataJSON,
+	}
+	jsonFieldMap := make(map[string]string, len(fields))
+	for name, value := range fields {
+		str, err := formatting.Encode(formatting.HexNC, value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't convert %s to string: %w", name, err)
+		}
+		jsonFieldMap[name] = str
+	}
+	return json.Marshal(jsonFieldMap)
+}
+
+// Address returns the address of the public key that produced [s]
+func (s *Signature) Address() ids.ShortID {
+	return PublicKeyToAddress(s.PublicKey)
+}
+
+// IsWebAuthn returns true if [s] is a WebAuthn assertion
+func (s *Signature) IsWebAuthn() bool {
+	return len(s.AuthenticatorData) != 0 || len(s.ClientDataJSON) != 0
+}
+
+// verify returns nil iff [s] is a valid signature of the tx whose unsigned
+// bytes hash to [txHash]
+func (s *Signature) verify(txHash []byte) error {
+	pk, err := parsePublicKey(s.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	r := new(big.Int).SetBytes(s.Sig[:scalarLen])
+	sVal := new(big.Int).SetBytes(s.Sig[scalarLen:])
+	// Only accept the low-s form so that a third party can't change the ID
+	// of a tx by replacing s with N - s.
+	if sVal.Cmp(halfOrder) > 0 {
+		return ErrHighS
+	}
+
+	digest := txHash
+	if s.IsWebAuthn() {
+		digest, err = webAuthnDigest(txHash, s.AuthenticatorData, s.ClientDataJSON)
+		if err != nil {
+			return err
+		}
+	}
+	if !ecdsa.Verify(pk, digest, r, sVal) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// PublicKeyToAddress returns the address of the compressed public key [pk].
+// The address is the RIPEMD-160 hash of the SHA-256 hash of [addressPrefix]
+// followed by [pk].
+func PublicKeyToAddress(pk [PublicKeyLen]byte) ids.ShortID {
+	prefixedPK := make([]byte, 0, len(addressPrefix)+PublicKeyLen)
+	prefixedPK = append(prefixedPK, addressPrefix...)
+	prefixedPK = append(prefixedPK, pk[:]...)
+	addr, _ := ids.ToShortID(hashing.PubkeyBytesToAddress(prefixedPK))
+	return addr
+}
+
+func parsePublicKey(pkBytes [PublicKeyLen]byte) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(curve, pkBytes[:])
+	if x == nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}, nil
+}
+
+// newSignature encodes [r] and [s] as a signature, normalizing [s] to its
+// low-s form.
+func newSignature(pk [PublicKeyLen]byte, r, s *big.Int) Signature {
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(curve.Params().N, s)
+	}
+	sig := Signature{PublicKey: pk}
+	r.FillBytes(sig.Sig[:scalarLen])
+	s.FillBytes(sig.Sig[scalarLen:])
+	return sig
+}
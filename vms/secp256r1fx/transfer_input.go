@@ -0,0 +1,12 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import "github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+// TransferInput has the same layout as [secp256k1fx.TransferInput]. It is a
+// distinct type so that the VM can route it to this fx.
+type TransferInput struct {
+	secp256k1fx.TransferInput `serialize:"true"`
+}
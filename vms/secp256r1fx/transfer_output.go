@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var _ verify.State = (*TransferOutput)(nil)
+
+type TransferOutput struct {
+	verify.IsState `json:"-"`
+
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	OutputOwners `serialize:"true"`
+}
+
+// MarshalJSON marshals Amt and the embedded OutputOwners struct
+// into a JSON readable format
+func (out *TransferOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	result["amount"] = out.Amt
+	return json.Marshal(result)
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *TransferOutput) Amount() uint64 {
+	return out.Amt
+}
+
+func (out *TransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return secp256k1fx.ErrNilOutput
+	case out.Amt == 0:
+		return secp256k1fx.ErrNoValueOutput
+	default:
+		return out.OutputOwners.Verify()
+	}
+}
+
+func (out *TransferOutput) Owners() interface{} {
+	return &out.OutputOwners
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestOutputVerifyNil(t *testing.T) {
+	out := (*TransferOutput)(nil)
+	err := out.Verify()
+	require.ErrorIs(t, err, secp256k1fx.ErrNilOutput)
+}
+
+func TestOutputVerifyNoValue(t *testing.T) {
+	out := TransferOutput{
+		OutputOwners: OutputOwners{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.ShortEmpty},
+			},
+		},
+	}
+	err := out.Verify()
+	require.ErrorIs(t, err, secp256k1fx.ErrNoValueOutput)
+}
+
+func TestOutputSerialize(t *testing.T) {
+	require := require.New(t)
+	c := linearcodec.NewDefault()
+	m := codec.NewDefaultManager()
+	require.NoError(m.RegisterCodec(0, c))
+
+	// The serialized owners are identical to the secp256k1fx owners
+	expected := []byte{
+		// Codec version
+		0x00, 0x00,
+		// amount:
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x39,
+		// locktime:
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xd4, 0x31,
+		// threshold:
+		0x00, 0x00, 0x00, 0x01,
+		// number of addresses:
+		0x00, 0x00, 0x00, 0x01,
+		// addrs[0]:
+		0x51, 0x02, 0x5c, 0x61, 0xfb, 0xcf, 0xc0, 0x78,
+		0xf6, 0x93, 0x34, 0xf8, 0x34, 0xbe, 0x6d, 0xd2,
+		0x6d, 0x55, 0xa9, 0x55,
+	}
+	out := &TransferOutput{
+		Amt: 12345,
+		OutputOwners: OutputOwners{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  54321,
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					{
+						0x51, 0x02, 0x5c, 0x61, 0xfb, 0xcf, 0xc0, 0x78,
+						0xf6, 0x93, 0x34, 0xf8, 0x34, 0xbe, 0x6d, 0xd2,
+						0x6d, 0x55, 0xa9, 0x55,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(out.Verify())
+
+	result, err := m.Marshal(0, out)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	parsed := &TransferOutput{}
+	_, err = m.Unmarshal(result, parsed)
+	require.NoError(err)
+	require.Equal(out, parsed)
+}
+
+func TestOutputState(t *testing.T) {
+	intf := interface{}(&TransferOutput{})
+	_, ok := intf.(verify.State)
+	require.True(t, ok)
+}
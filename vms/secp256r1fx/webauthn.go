@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	// authenticatorDataMinLen is the length of the RP ID hash, the flags and
+	// the signature counter that begin all authenticator data.
+	authenticatorDataMinLen = 37
+	flagsIndex              = 32
+	userPresentFlag         = 0x01
+
+	webAuthnGetType = "webauthn.get"
+)
+
+var (
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	ErrUserNotPresent           = errors.New("user presence flag not set")
+	ErrInvalidClientData        = errors.New("invalid client data")
+	ErrWrongChallenge           = errors.New("wrong challenge")
+)
+
+// clientData is the subset of the WebAuthn CollectedClientData that is
+// verified. The relying party is not checked, so that a passkey of any origin
+// can own funds.
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+// webAuthnDigest verifies that [clientDataJSON] is an assertion over [txHash]
+// and returns the digest signed by the authenticator.
+func webAuthnDigest(txHash, authenticatorData, clientDataJSON []byte) ([]byte, error) {
+	if len(authenticatorData) < authenticatorDataMinLen {
+		return nil, fmt.Errorf("%w: length %d < %d",
+			ErrInvalidAuthenticatorData,
+			len(authenticatorData),
+			authenticatorDataMinLen,
+		)
+	}
+	if authenticatorData[flagsIndex]&userPresentFlag == 0 {
+		return nil, ErrUserNotPresent
+	}
+
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidClientData, err)
+	}
+	if data.Type != webAuthnGetType {
+		return nil, fmt.Errorf("%w: unexpected type %q", ErrInvalidClientData, data.Type)
+	}
+	if expected := base64.RawURLEncoding.EncodeToString(txHash); data.Challenge != expected {
+		return nil, fmt.Errorf("%w: expected %q but got %q", ErrWrongChallenge, expected, data.Challenge)
+	}
+
+	signedBytes := make([]byte, 0, len(authenticatorData)+hashing.HashLen)
+	signedBytes = append(signedBytes, authenticatorData...)
+	signedBytes = append(signedBytes, hashing.ComputeHash256(clientDataJSON)...)
+	return hashing.ComputeHash256(signedBytes), nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1fx
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var _ Signer = (*WebAuthnSigner)(nil)
+
+// Authenticator produces WebAuthn assertions, for example by prompting the
+// user for a device passkey.
+type Authenticator interface {
+	// GetAssertion returns the authenticator data, the client data JSON and
+	// the ASN.1 DER encoded signature of an assertion over [challenge].
+	GetAssertion(challenge []byte) (authenticatorData []byte, clientDataJSON []byte, signature []byte, err error)
+}
+
+// WebAuthnSigner signs txs with WebAuthn assertions of a passkey
+type WebAuthnSigner struct {
+	pk            [PublicKeyLen]byte
+	addr          ids.ShortID
+	authenticator Authenticator
+}
+
+// NewWebAuthnSigner returns a signer for the passkey with the compressed
+// public key [pk], whose assertions are produced by [authenticator].
+func NewWebAuthnSigner(pk [PublicKeyLen]byte, authenticator Authenticator) (*WebAuthnSigner, error) {
+	if _, err := parsePublicKey(pk); err != nil {
+		return nil, err
+	}
+	return &WebAuthnSigner{
+		pk:            pk,
+		addr:          PublicKeyToAddress(pk),
+		authenticator: authenticator,
+	}, nil
+}
+
+func (s *WebAuthnSigner) Address() ids.ShortID {
+	return s.addr
+}
+
+func (s *WebAuthnSigner) Sign(unsignedTxBytes []byte) (*Signature, error) {
+	txHash := hashing.ComputeHash256(unsignedTxBytes)
+	authenticatorData, clientDataJSON, derSig, err := s.authenticator.GetAssertion(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get assertion: %w", err)
+	}
+
+	var rs struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(derSig, &rs); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: malformed DER encoding", ErrInvalidSignature)
+	}
+	if rs.R.Sign() <= 0 || rs.S.Sign() <= 0 || rs.R.BitLen() > 8*scalarLen || rs.S.BitLen() > 8*scalarLen {
+		return nil, fmt.Errorf("%w: scalar out of range", ErrInvalidSignature)
+	}
+
+	sig := newSignature(s.pk, rs.R, rs.S)
+	sig.AuthenticatorData = authenticatorData
+	sig.ClientDataJSON = clientDataJSON
+	// Verify the assertion here so that a misbehaving authenticator is
+	// reported before the tx is issued.
+	if err := sig.verify(txHash); err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
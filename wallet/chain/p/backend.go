@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
@@ -42,13 +43,33 @@ type backend struct {
 	txsLock sync.RWMutex
 	// txID -> tx
 	txs map[ids.ID]*txs.Tx
+	// subnetID -> owner
+	subnetOwners map[ids.ID]fx.Owner
 }
 
-func NewBackend(ctx Context, utxos ChainUTXOs, txs map[ids.ID]*txs.Tx) Backend {
+// NewBackend returns a backend with the provided UTXOs and txs.
+// [acceptedTxs] must be in the order they were accepted. The owners of the
+// subnets are taken from the CreateSubnetTxs in [acceptedTxs], unless
+// [acceptedTxs] includes TransferSubnetOwnershipTxs of the subnet. In that
+// case the subnet's owner is taken from the last one.
+func NewBackend(ctx Context, utxos ChainUTXOs, acceptedTxs []*txs.Tx) Backend {
+	txMap := make(map[ids.ID]*txs.Tx, len(acceptedTxs))
+	subnetOwners := make(map[ids.ID]fx.Owner)
+	for _, tx := range acceptedTxs {
+		txID := tx.ID()
+		txMap[txID] = tx
+		switch utx := tx.Unsigned.(type) {
+		case *txs.CreateSubnetTx:
+			subnetOwners[txID] = utx.Owner
+		case *txs.TransferSubnetOwnershipTx:
+			subnetOwners[utx.Subnet] = utx.Owner
+		}
+	}
 	return &backend{
-		Context:    ctx,
-		ChainUTXOs: utxos,
-		txs:        txs,
+		Context:      ctx,
+		ChainUTXOs:   utxos,
+		txs:          txMap,
+		subnetOwners: subnetOwners,
 	}
 }
 
@@ -94,6 +115,24 @@ func (b *backend) removeUTXOs(ctx stdcontext.Context, sourceChain ids.ID, utxoID
 	return nil
 }
 
+func (b *backend) GetSubnetOwner(_ stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	b.txsLock.RLock()
+	defer b.txsLock.RUnlock()
+
+	owner, exists := b.subnetOwners[subnetID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	return owner, nil
+}
+
+func (b *backend) setSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	b.txsLock.Lock()
+	defer b.txsLock.Unlock()
+
+	b.subnetOwners[subnetID] = owner
+}
+
 func (b *backend) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
 	b.txsLock.RLock()
 	defer b.txsLock.RUnlock()
@@ -16,16 +16,17 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
 var (
 	errNoChangeAddress           = errors.New("no possible change address")
-	errWrongTxType               = errors.New("wrong tx type")
 	errUnknownOwnerType          = errors.New("unknown owner type")
 	errInsufficientAuthorization = errors.New("insufficient authorization")
 	errInsufficientFunds         = errors.New("insufficient funds")
@@ -95,6 +96,27 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.RemoveSubnetValidatorTx, error)
 
+	// NewSetSubnetValidatorWeightTx sets the weight of [nodeID] in the
+	// validator set [subnetID] to [weight].
+	NewSetSubnetValidatorWeightTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		options ...common.Option,
+	) (*txs.SetSubnetValidatorWeightTx, error)
+
+	// NewTransferSubnetOwnershipTx transfers the ownership of [subnetID] to
+	// [owner].
+	//
+	// - [subnetID] specifies the subnet whose ownership is transferred.
+	// - [owner] specifies who has the ability to create new chains and add new
+	//   validators to the subnet once the transfer is accepted.
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.TransferSubnetOwnershipTx, error)
+
 	// NewAddDelegatorTx creates a new delegator to a validator on the primary
 	// network.
 	//
@@ -250,7 +272,7 @@ type Builder interface {
 type BuilderBackend interface {
 	Context
 	UTXOs(ctx stdcontext.Context, sourceChainID ids.ID) ([]*avax.UTXO, error)
-	GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error)
 }
 
 type builder struct {
@@ -424,6 +446,77 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	}, nil
 }
 
+func (b *builder) NewSetSubnetValidatorWeightTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	options ...common.Option,
+) (*txs.SetSubnetValidatorWeightTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.SetSubnetValidatorWeightTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		Weight:     weight,
+		SubnetAuth: subnetAuth,
+	}, nil
+}
+
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Sort(owner.Addrs)
+	return &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}, nil
+}
+
 func (b *builder) NewAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -547,12 +640,12 @@ func (b *builder) NewImportTx(
 	)
 	// Iterate over the unlocked UTXOs
 	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(utxo.Out)
 		if !ok {
 			continue
 		}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputSigIndices, ok := common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -561,16 +654,11 @@ func (b *builder) NewImportTx(
 		importedInputs = append(importedInputs, &avax.TransferableInput{
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
-				},
-			},
+			In:     common.NewTransferInput(utxo.Out, amount, inputSigIndices),
 		})
 
 		assetID := utxo.AssetID()
-		newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
+		newImportedAmount, err := math.Add64(importedAmounts[assetID], amount)
 		if err != nil {
 			return nil, err
 		}
@@ -839,19 +927,19 @@ func (b *builder) getBalance(
 			outIntf = lockedOut.TransferableOut
 		}
 
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(outIntf)
 		if !ok {
 			return nil, errUnknownOutputType
 		}
 
-		_, ok = common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		_, ok = common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
 		}
 
 		assetID := utxo.AssetID()
-		balance[assetID], err = math.Add64(balance[assetID], out.Amt)
+		balance[assetID], err = math.Add64(balance[assetID], amount)
 		if err != nil {
 			return nil, err
 		}
@@ -921,12 +1009,13 @@ func (b *builder) spend(
 			continue
 		}
 
-		out, ok := lockedOut.TransferableOut.(*secp256k1fx.TransferOutput)
+		out := lockedOut.TransferableOut
+		owners, amount, ok := common.TransferOwners(out)
 		if !ok {
 			return nil, nil, nil, errUnknownOutputType
 		}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputSigIndices, ok := common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -936,45 +1025,34 @@ func (b *builder) spend(
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
 			In: &stakeable.LockIn{
-				Locktime: lockedOut.Locktime,
-				TransferableIn: &secp256k1fx.TransferInput{
-					Amt: out.Amt,
-					Input: secp256k1fx.Input{
-						SigIndices: inputSigIndices,
-					},
-				},
+				Locktime:       lockedOut.Locktime,
+				TransferableIn: common.NewTransferInput(out, amount, inputSigIndices),
 			},
 		})
 
 		// Stake any value that should be staked
 		amountToStake := math.Min(
 			remainingAmountToStake, // Amount we still need to stake
-			out.Amt,                // Amount available to stake
+			amount,                 // Amount available to stake
 		)
 
 		// Add the output to the staked outputs
 		stakeOutputs = append(stakeOutputs, &avax.TransferableOutput{
 			Asset: utxo.Asset,
 			Out: &stakeable.LockOut{
-				Locktime: lockedOut.Locktime,
-				TransferableOut: &secp256k1fx.TransferOutput{
-					Amt:          amountToStake,
-					OutputOwners: out.OutputOwners,
-				},
+				Locktime:        lockedOut.Locktime,
+				TransferableOut: common.NewTransferOutput(out, amountToStake, owners),
 			},
 		})
 
 		amountsToStake[assetID] -= amountToStake
-		if remainingAmount := out.Amt - amountToStake; remainingAmount > 0 {
+		if remainingAmount := amount - amountToStake; remainingAmount > 0 {
 			// This input had extra value, so some of it must be returned
 			changeOutputs = append(changeOutputs, &avax.TransferableOutput{
 				Asset: utxo.Asset,
 				Out: &stakeable.LockOut{
-					Locktime: lockedOut.Locktime,
-					TransferableOut: &secp256k1fx.TransferOutput{
-						Amt:          remainingAmount,
-						OutputOwners: out.OutputOwners,
-					},
+					Locktime:        lockedOut.Locktime,
+					TransferableOut: common.NewTransferOutput(out, remainingAmount, owners),
 				},
 			})
 		}
@@ -1002,12 +1080,12 @@ func (b *builder) spend(
 			outIntf = lockedOut.TransferableOut
 		}
 
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(outIntf)
 		if !ok {
 			return nil, nil, nil, errUnknownOutputType
 		}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputSigIndices, ok := common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -1016,22 +1094,17 @@ func (b *builder) spend(
 		inputs = append(inputs, &avax.TransferableInput{
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
-				},
-			},
+			In:     common.NewTransferInput(outIntf, amount, inputSigIndices),
 		})
 
 		// Burn any value that should be burned
 		amountToBurn := math.Min(
 			remainingAmountToBurn, // Amount we still need to burn
-			out.Amt,               // Amount available to burn
+			amount,                // Amount available to burn
 		)
 		amountsToBurn[assetID] -= amountToBurn
 
-		amountAvalibleToStake := out.Amt - amountToBurn
+		amountAvalibleToStake := amount - amountToBurn
 		// Burn any value that should be burned
 		amountToStake := math.Min(
 			remainingAmountToStake, // Amount we still need to stake
@@ -1042,20 +1115,14 @@ func (b *builder) spend(
 			// Some of this input was put for staking
 			stakeOutputs = append(stakeOutputs, &avax.TransferableOutput{
 				Asset: utxo.Asset,
-				Out: &secp256k1fx.TransferOutput{
-					Amt:          amountToStake,
-					OutputOwners: *changeOwner,
-				},
+				Out:   common.NewChangeOutput(outIntf, amountToStake, changeOwner),
 			})
 		}
 		if remainingAmount := amountAvalibleToStake - amountToStake; remainingAmount > 0 {
 			// This input had extra value, so some of it must be returned
 			changeOutputs = append(changeOutputs, &avax.TransferableOutput{
 				Asset: utxo.Asset,
-				Out: &secp256k1fx.TransferOutput{
-					Amt:          remainingAmount,
-					OutputOwners: *changeOwner,
-				},
+				Out:   common.NewChangeOutput(outIntf, remainingAmount, changeOwner),
 			})
 		}
 	}
@@ -1088,21 +1155,21 @@ func (b *builder) spend(
 }
 
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
-	subnetTx, err := b.backend.GetTx(options.Context(), subnetID)
+	ownerIntf, err := b.backend.GetSubnetOwner(options.Context(), subnetID)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to fetch subnet %q: %w",
+			"failed to fetch subnet owner for %q: %w",
 			subnetID,
 			err,
 		)
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return nil, errWrongTxType
-	}
-
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
-	if !ok {
+	var owner *secp256k1fx.OutputOwners
+	switch ownerImpl := ownerIntf.(type) {
+	case *secp256k1fx.OutputOwners:
+		owner = ownerImpl
+	case *secp256r1fx.OutputOwners:
+		owner = &ownerImpl.OutputOwners
+	default:
 		return nil, errUnknownOwnerType
 	}
 
@@ -9,7 +9,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 var _ Signer = (*txSigner)(nil)
@@ -21,17 +23,30 @@ type Signer interface {
 
 type SignerBackend interface {
 	GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*avax.UTXO, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error)
 }
 
 type txSigner struct {
 	kc      keychain.Keychain
+	r1kc    *secp256r1fx.Keychain
 	backend SignerBackend
 }
 
 func NewSigner(kc keychain.Keychain, backend SignerBackend) Signer {
+	return NewSignerWithSecp256r1Keychain(kc, secp256r1fx.NewKeychain(), backend)
+}
+
+// NewSignerWithSecp256r1Keychain returns a signer that uses [kc] to sign
+// secp256k1fx inputs and [r1kc] to sign secp256r1fx inputs.
+func NewSignerWithSecp256r1Keychain(
+	kc keychain.Keychain,
+	r1kc *secp256r1fx.Keychain,
+	backend SignerBackend,
+) Signer {
 	return &txSigner{
 		kc:      kc,
+		r1kc:    r1kc,
 		backend: backend,
 	}
 }
@@ -44,6 +59,7 @@ func (s *txSigner) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*tx
 func (s *txSigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
 	return tx.Unsigned.Visit(&signerVisitor{
 		kc:      s.kc,
+		r1kc:    s.r1kc,
 		backend: s.backend,
 		ctx:     ctx,
 		tx:      tx,
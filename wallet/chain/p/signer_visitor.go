@@ -17,9 +17,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
 var (
@@ -30,6 +33,8 @@ var (
 	errUnknownCredentialType = errors.New("unknown credential type")
 	errUnknownOutputType     = errors.New("unknown output type")
 	errUnknownSubnetAuthType = errors.New("unknown subnet auth type")
+	errUnknownStakerAuthType = errors.New("unknown staker auth type")
+	errUnknownStakerTxType   = errors.New("unknown staker tx type")
 	errInvalidUTXOSigIndex   = errors.New("invalid UTXO signature index")
 
 	emptySig [secp256k1.SignatureLen]byte
@@ -38,6 +43,7 @@ var (
 // signerVisitor handles signing transactions for the signer
 type signerVisitor struct {
 	kc      keychain.Keychain
+	r1kc    *secp256r1fx.Keychain
 	backend SignerBackend
 	ctx     stdcontext.Context
 	tx      *txs.Tx
@@ -52,119 +58,167 @@ func (*signerVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
 }
 
 func (s *signerVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	subnetAuthSigners, err := s.getSubnetSigners(tx.SubnetValidator.Subnet, tx.SubnetAuth)
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.SubnetValidator.Subnet, tx.SubnetAuth)
 	if err != nil {
 		return err
 	}
+	txCreds = append(txCreds, subnetAuthCred)
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	subnetAuthSigners, err := s.getSubnetSigners(tx.SubnetID, tx.SubnetAuth)
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.SubnetID, tx.SubnetAuth)
 	if err != nil {
 		return err
 	}
+	txCreds = append(txCreds, subnetAuthCred)
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) ImportTx(tx *txs.ImportTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	txImportSigners, err := s.getSigners(tx.SourceChain, tx.ImportedInputs)
+	txImportCreds, txImportSigners, err := s.getSigners(tx.SourceChain, tx.ImportedInputs)
 	if err != nil {
 		return err
 	}
+	txCreds = append(txCreds, txImportCreds...)
 	txSigners = append(txSigners, txImportSigners...)
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) ExportTx(tx *txs.ExportTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return sign(s.tx, false, txCreds, txSigners)
 }
 
 func (s *signerVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
 	if err != nil {
 		return err
 	}
+	txCreds = append(txCreds, subnetAuthCred)
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return sign(s.tx, true, txCreds, txSigners)
+}
+
+func (s *signerVisitor) SetAutoRenewTx(tx *txs.SetAutoRenewTx) error {
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	stakerAuthCred, stakerAuthSigners, err := s.getStakerSigners(tx.StakerTxID, tx.StakerAuth)
+	if err != nil {
+		return err
+	}
+	txCreds = append(txCreds, stakerAuthCred)
+	txSigners = append(txSigners, stakerAuthSigners)
+	return sign(s.tx, true, txCreds, txSigners)
+}
+
+func (s *signerVisitor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txCreds = append(txCreds, subnetAuthCred)
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txCreds, txSigners)
+}
+
+func (s *signerVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txCreds = append(txCreds, subnetAuthCred)
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txCreds, txSigners)
 }
 
 func (s *signerVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	subnetAuthCred, subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
 	if err != nil {
 		return err
 	}
+	txCreds = append(txCreds, subnetAuthCred)
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return sign(s.tx, true, txCreds, txSigners)
 }
 
 func (s *signerVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return sign(s.tx, true, txCreds, txSigners)
 }
 
 func (s *signerVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
-	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	txCreds, txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return sign(s.tx, true, txCreds, txSigners)
 }
 
-func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.TransferableInput) ([][]keychain.Signer, error) {
+func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.TransferableInput) ([]verify.Verifiable, [][]keychain.Signer, error) {
+	txCreds := make([]verify.Verifiable, len(ins))
 	txSigners := make([][]keychain.Signer, len(ins))
 	for credIndex, transferInput := range ins {
 		inIntf := transferInput.In
@@ -172,9 +226,16 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 			inIntf = stakeableIn.TransferableIn
 		}
 
-		input, ok := inIntf.(*secp256k1fx.TransferInput)
-		if !ok {
-			return nil, errUnknownInputType
+		var input *secp256k1fx.TransferInput
+		switch in := inIntf.(type) {
+		case *secp256k1fx.TransferInput:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = in
+		case *secp256r1fx.TransferInput:
+			txCreds[credIndex] = &secp256r1fx.Credential{}
+			input = &in.TransferInput
+		default:
+			return nil, nil, errUnknownInputType
 		}
 
 		inputSigners := make([]keychain.Signer, len(input.SigIndices))
@@ -188,7 +249,7 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 			continue
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		outIntf := utxo.Out
@@ -196,18 +257,18 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 			outIntf = stakeableOut.TransferableOut
 		}
 
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		out, _, ok := common.TransferOwners(outIntf)
 		if !ok {
-			return nil, errUnknownOutputType
+			return nil, nil, errUnknownOutputType
 		}
 
 		for sigIndex, addrIndex := range input.SigIndices {
 			if addrIndex >= uint32(len(out.Addrs)) {
-				return nil, errInvalidUTXOSigIndex
+				return nil, nil, errInvalidUTXOSigIndex
 			}
 
 			addr := out.Addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
+			key, ok := s.getSigner(txCreds[credIndex], addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
 				// transaction. However, we can attempt to partially sign it.
@@ -216,41 +277,77 @@ func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*avax.Transferabl
 			inputSigners[sigIndex] = key
 		}
 	}
-	return txSigners, nil
+	return txCreds, txSigners, nil
 }
 
-func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable) ([]keychain.Signer, error) {
+func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable) (verify.Verifiable, []keychain.Signer, error) {
 	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
 	if !ok {
-		return nil, errUnknownSubnetAuthType
+		return nil, nil, errUnknownSubnetAuthType
 	}
 
-	subnetTx, err := s.backend.GetTx(s.ctx, subnetID)
+	ownerIntf, err := s.backend.GetSubnetOwner(s.ctx, subnetID)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to fetch subnet %q: %w",
+		return nil, nil, fmt.Errorf(
+			"failed to fetch subnet owner for %q: %w",
 			subnetID,
 			err,
 		)
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	return s.getAuthSigners(ownerIntf, subnetInput)
+}
+
+func (s *signerVisitor) getStakerSigners(stakerTxID ids.ID, stakerAuth verify.Verifiable) (verify.Verifiable, []keychain.Signer, error) {
+	stakerInput, ok := stakerAuth.(*secp256k1fx.Input)
 	if !ok {
-		return nil, errWrongTxType
+		return nil, nil, errUnknownStakerAuthType
 	}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
-	if !ok {
-		return nil, errUnknownOwnerType
+	stakerTx, err := s.backend.GetTx(s.ctx, stakerTxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to fetch staker tx %q: %w",
+			stakerTxID,
+			err,
+		)
 	}
 
-	authSigners := make([]keychain.Signer, len(subnetInput.SigIndices))
-	for sigIndex, addrIndex := range subnetInput.SigIndices {
+	var ownerIntf fx.Owner
+	switch utx := stakerTx.Unsigned.(type) {
+	case *txs.AddPermissionlessValidatorTx:
+		ownerIntf = utx.ValidatorRewardsOwner
+	case *txs.AddPermissionlessDelegatorTx:
+		ownerIntf = utx.DelegationRewardsOwner
+	default:
+		return nil, nil, errUnknownStakerTxType
+	}
+	return s.getAuthSigners(ownerIntf, stakerInput)
+}
+
+func (s *signerVisitor) getAuthSigners(ownerIntf fx.Owner, authInput *secp256k1fx.Input) (verify.Verifiable, []keychain.Signer, error) {
+	var (
+		cred  verify.Verifiable
+		owner *secp256k1fx.OutputOwners
+	)
+	switch ownerImpl := ownerIntf.(type) {
+	case *secp256k1fx.OutputOwners:
+		cred = &secp256k1fx.Credential{}
+		owner = ownerImpl
+	case *secp256r1fx.OutputOwners:
+		cred = &secp256r1fx.Credential{}
+		owner = &ownerImpl.OutputOwners
+	default:
+		return nil, nil, errUnknownOwnerType
+	}
+
+	authSigners := make([]keychain.Signer, len(authInput.SigIndices))
+	for sigIndex, addrIndex := range authInput.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
-			return nil, errInvalidUTXOSigIndex
+			return nil, nil, errInvalidUTXOSigIndex
 		}
 
 		addr := owner.Addrs[addrIndex]
-		key, ok := s.kc.Get(addr)
+		key, ok := s.getSigner(cred, addr)
 		if !ok {
 			// If we don't have access to the key, then we can't sign this
 			// transaction. However, we can attempt to partially sign it.
@@ -258,11 +355,24 @@ func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Veri
 		}
 		authSigners[sigIndex] = key
 	}
-	return authSigners, nil
+	return cred, authSigners, nil
+}
+
+// getSigner returns the signer of [addr] from the keychain that is able to
+// populate [cred].
+func (s *signerVisitor) getSigner(cred verify.Verifiable, addr ids.ShortID) (keychain.Signer, bool) {
+	if _, ok := cred.(*secp256r1fx.Credential); ok {
+		signer, ok := s.r1kc.Get(addr)
+		if !ok {
+			return nil, false
+		}
+		return &common.Secp256r1Signer{Signer: signer}, true
+	}
+	return s.kc.Get(addr)
 }
 
 // TODO: remove [signHash] after the ledger supports signing all transactions.
-func sign(tx *txs.Tx, signHash bool, txSigners [][]keychain.Signer) error {
+func sign(tx *txs.Tx, signHash bool, creds []verify.Verifiable, txSigners [][]keychain.Signer) error {
 	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal unsigned tx: %w", err)
@@ -274,15 +384,24 @@ func sign(tx *txs.Tx, signHash bool, txSigners [][]keychain.Signer) error {
 	}
 
 	sigCache := make(map[ids.ShortID][secp256k1.SignatureLen]byte)
+	r1SigCache := make(map[ids.ShortID]secp256r1fx.Signature)
 	for credIndex, inputSigners := range txSigners {
 		credIntf := tx.Creds[credIndex]
 		if credIntf == nil {
-			credIntf = &secp256k1fx.Credential{}
+			credIntf = creds[credIndex]
 			tx.Creds[credIndex] = credIntf
 		}
 
-		cred, ok := credIntf.(*secp256k1fx.Credential)
-		if !ok {
+		var cred *secp256k1fx.Credential
+		switch credImpl := credIntf.(type) {
+		case *secp256k1fx.Credential:
+			cred = credImpl
+		case *secp256r1fx.Credential:
+			if err := common.SignSecp256r1Credential(credImpl, inputSigners, unsignedBytes, r1SigCache); err != nil {
+				return err
+			}
+			continue
+		default:
 			return errUnknownCredentialType
 		}
 		if expectedLen := len(inputSigners); expectedLen != len(cred.Sigs) {
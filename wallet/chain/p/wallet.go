@@ -80,6 +80,30 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueSetSubnetValidatorWeightTx creates, signs, and issues a transaction
+	// that sets the weight of a validator of a subnet.
+	//
+	// - [nodeID] is the validator whose weight on [subnetID] is set.
+	// - [weight] is the new weight of the validator.
+	IssueSetSubnetValidatorWeightTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueTransferSubnetOwnershipTx creates, signs, and issues a transaction
+	// that transfers the ownership of a subnet.
+	//
+	// - [subnetID] specifies the subnet whose ownership is transferred.
+	// - [owner] specifies who has the ability to create new chains and add new
+	//   validators to the subnet once the transfer is accepted.
+	IssueTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueAddDelegatorTx creates, signs, and issues a new delegator to a
 	// validator on the primary network.
 	//
@@ -320,6 +344,31 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueSetSubnetValidatorWeightTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewSetSubnetValidatorWeightTx(nodeID, subnetID, weight, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -23,8 +24,12 @@ import (
 )
 
 var (
-	errNoChangeAddress   = errors.New("no possible change address")
-	errInsufficientFunds = errors.New("insufficient funds")
+	errNoChangeAddress           = errors.New("no possible change address")
+	errInsufficientFunds         = errors.New("insufficient funds")
+	errInsufficientAuthorization = errors.New("insufficient authorization")
+	errUnknownUTXO               = errors.New("unknown UTXO")
+	errNotHTLC                   = errors.New("UTXO isn't hash time locked")
+	errNoPreimage                = errors.New("no preimage provided")
 
 	_ Builder = (*builder)(nil)
 )
@@ -146,6 +151,30 @@ type Builder interface {
 		outputs []*avax.TransferableOutput,
 		options ...common.Option,
 	) (*txs.ExportTx, error)
+
+	// NewClaimHTLCTx creates a transaction that claims a hash time locked UTXO
+	// before its timeout by revealing the preimage of its hash lock.
+	//
+	// - [utxoID] specifies the hash time locked UTXO to claim.
+	// - [preimage] specifies the preimage of the UTXO's hash lock.
+	// - [to] specifies where to send the claimed funds to.
+	NewClaimHTLCTx(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
+	// NewRefundHTLCTx creates a transaction that refunds a hash time locked
+	// UTXO once its timeout has passed.
+	//
+	// - [utxoID] specifies the hash time locked UTXO to refund.
+	// - [to] specifies where to send the refunded funds to.
+	NewRefundHTLCTx(
+		utxoID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
 }
 
 // BuilderBackend specifies the required information needed to build unsigned
@@ -365,13 +394,13 @@ func (b *builder) NewImportTx(
 	)
 	// Iterate over the unlocked UTXOs
 	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(utxo.Out)
 		if !ok {
 			// Can't import an unknown transfer output type
 			continue
 		}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputSigIndices, ok := common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -380,16 +409,11 @@ func (b *builder) NewImportTx(
 		importedInputs = append(importedInputs, &avax.TransferableInput{
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
-				},
-			},
+			In:     common.NewTransferInput(utxo.Out, amount, inputSigIndices),
 		})
 
 		assetID := utxo.AssetID()
-		newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
+		newImportedAmount, err := math.Add64(importedAmounts[assetID], amount)
 		if err != nil {
 			return nil, err
 		}
@@ -486,6 +510,125 @@ func (b *builder) NewExportTx(
 	}, nil
 }
 
+func (b *builder) NewClaimHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	if len(preimage) == 0 {
+		return nil, errNoPreimage
+	}
+	ops := common.NewOptions(options)
+	return b.spendHTLC(utxoID, preimage, to, ops)
+}
+
+func (b *builder) NewRefundHTLCTx(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	ops := common.NewOptions(options)
+	return b.spendHTLC(utxoID, nil, to, ops)
+}
+
+// spendHTLC consumes the hash time locked UTXO [utxoID] and sends its funds to
+// [to]. If [preimage] is provided, the UTXO is claimed. Otherwise, it is
+// refunded.
+func (b *builder) spendHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options *common.Options,
+) (*txs.BaseTx, error) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	var utxo *avax.UTXO
+	for _, u := range utxos {
+		if u.InputID() == utxoID {
+			utxo = u
+			break
+		}
+	}
+	if utxo == nil {
+		return nil, fmt.Errorf("%w: %s", errUnknownUTXO, utxoID)
+	}
+	out, ok := utxo.Out.(*htlcfx.TransferOutput)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotHTLC, utxoID)
+	}
+
+	htlcIn := &htlcfx.TransferInput{
+		TransferInput: secp256k1fx.TransferInput{
+			Amt: out.Amt,
+		},
+		Preimage: preimage,
+	}
+	addrs := options.Addresses(b.addrs)
+	minIssuanceTime := options.MinIssuanceTime()
+	inputSigIndices, ok := common.MatchOwners(out.Owners(htlcIn), addrs, minIssuanceTime)
+	if !ok {
+		// We can't sign for the UTXO along the requested path
+		return nil, errInsufficientAuthorization
+	}
+	htlcIn.SigIndices = inputSigIndices
+
+	var (
+		avaxAssetID = b.backend.AVAXAssetID()
+		txFee       = b.backend.BaseTxFee()
+		assetID     = utxo.AssetID()
+		amount      = out.Amt
+
+		inputs  []*avax.TransferableInput
+		outputs []*avax.TransferableOutput
+	)
+	switch {
+	case assetID == avaxAssetID && amount > txFee:
+		// The tx fee can be paid out of the UTXO
+		amount -= txFee
+	case assetID == avaxAssetID:
+		inputs, outputs, err = b.spend(map[ids.ID]uint64{
+			avaxAssetID: txFee - amount,
+		}, options)
+		amount = 0
+	default:
+		inputs, outputs, err = b.spend(map[ids.ID]uint64{
+			avaxAssetID: txFee,
+		}, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	inputs = append(inputs, &avax.TransferableInput{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In:     htlcIn,
+	})
+	if amount > 0 {
+		outputs = append(outputs, &avax.TransferableOutput{
+			Asset: utxo.Asset,
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: *to,
+			},
+		})
+	}
+
+	utils.Sort(inputs)
+	avax.SortTransferableOutputs(outputs, Parser.Codec())
+	return &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.backend.NetworkID(),
+		BlockchainID: b.backend.BlockchainID(),
+		Ins:          inputs,
+		Outs:         outputs,
+		Memo:         options.Memo(),
+	}}, nil
+}
+
 func (b *builder) getBalance(
 	chainID ids.ID,
 	options *common.Options,
@@ -504,21 +647,21 @@ func (b *builder) getBalance(
 
 	// Iterate over the UTXOs
 	for _, utxo := range utxos {
-		outIntf := utxo.Out
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(utxo.Out)
 		if !ok {
-			// We only support [secp256k1fx.TransferOutput]s.
+			// We only support [secp256k1fx.TransferOutput]s and
+			// [secp256r1fx.TransferOutput]s.
 			continue
 		}
 
-		_, ok = common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		_, ok = common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
 		}
 
 		assetID := utxo.AssetID()
-		balance[assetID], err = math.Add64(balance[assetID], out.Amt)
+		balance[assetID], err = math.Add64(balance[assetID], amount)
 		if err != nil {
 			return nil, err
 		}
@@ -562,14 +705,14 @@ func (b *builder) spend(
 			continue
 		}
 
-		outIntf := utxo.Out
-		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		owners, amount, ok := common.TransferOwners(utxo.Out)
 		if !ok {
-			// We only support burning [secp256k1fx.TransferOutput]s.
+			// We only support burning [secp256k1fx.TransferOutput]s and
+			// [secp256r1fx.TransferOutput]s.
 			continue
 		}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputSigIndices, ok := common.MatchOwners(owners, addrs, minIssuanceTime)
 		if !ok {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -578,28 +721,20 @@ func (b *builder) spend(
 		inputs = append(inputs, &avax.TransferableInput{
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
-				},
-			},
+			In:     common.NewTransferInput(utxo.Out, amount, inputSigIndices),
 		})
 
 		// Burn any value that should be burned
 		amountToBurn := math.Min(
 			remainingAmountToBurn, // Amount we still need to burn
-			out.Amt,               // Amount available to burn
+			amount,                // Amount available to burn
 		)
 		amountsToBurn[assetID] -= amountToBurn
-		if remainingAmount := out.Amt - amountToBurn; remainingAmount > 0 {
+		if remainingAmount := amount - amountToBurn; remainingAmount > 0 {
 			// This input had extra value, so some of it must be returned
 			outputs = append(outputs, &avax.TransferableOutput{
 				Asset: utxo.Asset,
-				Out: &secp256k1fx.TransferOutput{
-					Amt:          remainingAmount,
-					OutputOwners: *changeOwner,
-				},
+				Out:   common.NewChangeOutput(utxo.Out, remainingAmount, changeOwner),
 			})
 		}
 	}
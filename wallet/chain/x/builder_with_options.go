@@ -156,3 +156,29 @@ func (b *builderWithOptions) NewExportTx(
 		common.UnionOptions(b.options, options)...,
 	)
 }
+
+func (b *builderWithOptions) NewClaimHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewClaimHTLCTx(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewRefundHTLCTx(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewRefundHTLCTx(
+		utxoID,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
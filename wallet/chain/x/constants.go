@@ -6,15 +6,19 @@ package x
 import (
 	"github.com/ava-labs/avalanchego/vms/avm/blocks"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 const (
 	SECP256K1FxIndex = 0
 	NFTFxIndex       = 1
 	PropertyFxIndex  = 2
+	SECP256R1FxIndex = 3
+	HTLCFxIndex      = 4
 )
 
 // Parser to support serialization and deserialization
@@ -26,6 +30,8 @@ func init() {
 		&secp256k1fx.Fx{},
 		&nftfx.Fx{},
 		&propertyfx.Fx{},
+		&secp256r1fx.Fx{},
+		&htlcfx.Fx{},
 	})
 	if err != nil {
 		panic(err)
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
 )
 
 var _ Signer = (*signer)(nil)
@@ -25,12 +26,24 @@ type SignerBackend interface {
 
 type signer struct {
 	kc      keychain.Keychain
+	r1kc    *secp256r1fx.Keychain
 	backend SignerBackend
 }
 
 func NewSigner(kc keychain.Keychain, backend SignerBackend) Signer {
+	return NewSignerWithSecp256r1Keychain(kc, secp256r1fx.NewKeychain(), backend)
+}
+
+// NewSignerWithSecp256r1Keychain returns a signer that uses [kc] to sign
+// secp256k1fx inputs and [r1kc] to sign secp256r1fx inputs.
+func NewSignerWithSecp256r1Keychain(
+	kc keychain.Keychain,
+	r1kc *secp256r1fx.Keychain,
+	backend SignerBackend,
+) Signer {
 	return &signer{
 		kc:      kc,
+		r1kc:    r1kc,
 		backend: backend,
 	}
 }
@@ -43,6 +56,7 @@ func (s *signer) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*txs.
 func (s *signer) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
 	return tx.Unsigned.Visit(&signerVisitor{
 		kc:      s.kc,
+		r1kc:    s.r1kc,
 		backend: s.backend,
 		ctx:     ctx,
 		tx:      tx,
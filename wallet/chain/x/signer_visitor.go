@@ -17,9 +17,12 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/htlcfx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
 var (
@@ -38,6 +41,7 @@ var (
 // signerVisitor handles signing transactions for the signer
 type signerVisitor struct {
 	kc      keychain.Keychain
+	r1kc    *secp256r1fx.Keychain
 	backend SignerBackend
 	ctx     stdcontext.Context
 	tx      *txs.Tx
@@ -99,9 +103,18 @@ func (s *signerVisitor) getSigners(ctx stdcontext.Context, sourceChainID ids.ID,
 	txCreds := make([]verify.Verifiable, len(ins))
 	txSigners := make([][]keychain.Signer, len(ins))
 	for credIndex, transferInput := range ins {
-		txCreds[credIndex] = &secp256k1fx.Credential{}
-		input, ok := transferInput.In.(*secp256k1fx.TransferInput)
-		if !ok {
+		var input *secp256k1fx.TransferInput
+		switch in := transferInput.In.(type) {
+		case *secp256k1fx.TransferInput:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = in
+		case *secp256r1fx.TransferInput:
+			txCreds[credIndex] = &secp256r1fx.Credential{}
+			input = &in.TransferInput
+		case *htlcfx.TransferInput:
+			txCreds[credIndex] = &htlcfx.Credential{}
+			input = &in.TransferInput
+		default:
 			return nil, nil, errUnknownInputType
 		}
 
@@ -119,7 +132,7 @@ func (s *signerVisitor) getSigners(ctx stdcontext.Context, sourceChainID ids.ID,
 			return nil, nil, err
 		}
 
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		out, ok := getOwners(transferInput.In, utxo.Out)
 		if !ok {
 			return nil, nil, errUnknownOutputType
 		}
@@ -130,7 +143,7 @@ func (s *signerVisitor) getSigners(ctx stdcontext.Context, sourceChainID ids.ID,
 			}
 
 			addr := out.Addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
+			key, ok := s.getSigner(txCreds[credIndex], addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
 				// transaction. However, we can attempt to partially sign it.
@@ -151,6 +164,9 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 		case *secp256k1fx.MintOperation:
 			txCreds[credIndex] = &secp256k1fx.Credential{}
 			input = &op.MintInput
+		case *secp256r1fx.MintOperation:
+			txCreds[credIndex] = &secp256r1fx.Credential{}
+			input = &op.MintInput
 		case *nftfx.MintOperation:
 			txCreds[credIndex] = &nftfx.Credential{}
 			input = &op.MintInput
@@ -188,6 +204,8 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.MintOutput:
 			addrs = out.Addrs
+		case *secp256r1fx.MintOutput:
+			addrs = out.Addrs
 		case *nftfx.MintOutput:
 			addrs = out.Addrs
 		case *nftfx.TransferOutput:
@@ -206,7 +224,7 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 			}
 
 			addr := addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
+			key, ok := s.getSigner(txCreds[credIndex], addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
 				// transaction. However, we can attempt to partially sign it.
@@ -218,6 +236,32 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 	return txCreds, txSigners, nil
 }
 
+// getOwners returns the owners that must sign [in] to spend [out]
+func getOwners(in avax.TransferableIn, out verify.State) (*secp256k1fx.OutputOwners, bool) {
+	if out, ok := out.(*htlcfx.TransferOutput); ok {
+		in, ok := in.(*htlcfx.TransferInput)
+		if !ok {
+			return nil, false
+		}
+		return out.Owners(in), true
+	}
+	owners, _, ok := common.TransferOwners(out)
+	return owners, ok
+}
+
+// getSigner returns the signer of [addr] from the keychain that is able to
+// populate [cred].
+func (s *signerVisitor) getSigner(cred verify.Verifiable, addr ids.ShortID) (keychain.Signer, bool) {
+	if _, ok := cred.(*secp256r1fx.Credential); ok {
+		signer, ok := s.r1kc.Get(addr)
+		if !ok {
+			return nil, false
+		}
+		return &common.Secp256r1Signer{Signer: signer}, true
+	}
+	return s.kc.Get(addr)
+}
+
 func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer) error {
 	codec := Parser.Codec()
 	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &tx.Unsigned)
@@ -230,6 +274,7 @@ func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer)
 	}
 
 	sigCache := make(map[ids.ShortID][secp256k1.SignatureLen]byte)
+	r1SigCache := make(map[ids.ShortID]secp256r1fx.Signature)
 	for credIndex, inputSigners := range txSigners {
 		fxCred := tx.Creds[credIndex]
 		if fxCred == nil {
@@ -244,12 +289,19 @@ func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer)
 
 		var cred *secp256k1fx.Credential
 		switch credImpl := credIntf.(type) {
+		case *secp256r1fx.Credential:
+			if err := common.SignSecp256r1Credential(credImpl, inputSigners, unsignedBytes, r1SigCache); err != nil {
+				return err
+			}
+			continue
 		case *secp256k1fx.Credential:
 			cred = credImpl
 		case *nftfx.Credential:
 			cred = &credImpl.Credential
 		case *propertyfx.Credential:
 			cred = &credImpl.Credential
+		case *htlcfx.Credential:
+			cred = &credImpl.Credential
 		default:
 			return errUnknownCredentialType
 		}
@@ -131,6 +131,31 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueClaimHTLCTx creates, signs, and issues a transaction that claims a
+	// hash time locked UTXO before its timeout by revealing the preimage of its
+	// hash lock.
+	//
+	// - [utxoID] specifies the hash time locked UTXO to claim.
+	// - [preimage] specifies the preimage of the UTXO's hash lock.
+	// - [to] specifies where to send the claimed funds to.
+	IssueClaimHTLCTx(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueRefundHTLCTx creates, signs, and issues a transaction that refunds a
+	// hash time locked UTXO once its timeout has passed.
+	//
+	// - [utxoID] specifies the hash time locked UTXO to refund.
+	// - [to] specifies where to send the refunded funds to.
+	IssueRefundHTLCTx(
+		utxoID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueUnsignedTx signs and issues the unsigned tx.
 	IssueUnsignedTx(
 		utx txs.UnsignedTx,
@@ -280,6 +305,31 @@ func (w *wallet) IssueExportTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueClaimHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewClaimHTLCTx(utxoID, preimage, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueRefundHTLCTx(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewRefundHTLCTx(utxoID, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
@@ -142,6 +142,32 @@ func (w *walletWithOptions) IssueExportTx(
 	)
 }
 
+func (w *walletWithOptions) IssueClaimHTLCTx(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueClaimHTLCTx(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueRefundHTLCTx(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueRefundHTLCTx(
+		utxoID,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/secp256r1fx"
+)
+
+var (
+	_ keychain.Signer = (*Secp256r1Signer)(nil)
+
+	ErrNotSecp256k1Signer = errors.New("signer can't produce secp256k1 signatures")
+	ErrNotSecp256r1Signer = errors.New("signer can't produce secp256r1 signatures")
+
+	emptySecp256r1PublicKey [secp256r1fx.PublicKeyLen]byte
+)
+
+// Secp256r1Signer allows a [secp256r1fx.Signer] to be passed around alongside
+// secp256k1 signers. It is unable to produce secp256k1 signatures, so callers
+// must use SignSecp256r1 when populating a [secp256r1fx.Credential].
+type Secp256r1Signer struct {
+	Signer secp256r1fx.Signer
+}
+
+func (s *Secp256r1Signer) Address() ids.ShortID {
+	return s.Signer.Address()
+}
+
+func (*Secp256r1Signer) SignHash([]byte) ([]byte, error) {
+	return nil, ErrNotSecp256k1Signer
+}
+
+func (*Secp256r1Signer) Sign([]byte) ([]byte, error) {
+	return nil, ErrNotSecp256k1Signer
+}
+
+// SignSecp256r1 returns the signature of the tx whose unsigned bytes are
+// [unsignedTxBytes].
+func (s *Secp256r1Signer) SignSecp256r1(unsignedTxBytes []byte) (*secp256r1fx.Signature, error) {
+	return s.Signer.Sign(unsignedTxBytes)
+}
+
+// SignSecp256r1Credential populates the signatures of [cred] using
+// [inputSigners]. Signatures that are already present are left untouched.
+// [sigCache] is used to avoid signing [unsignedTxBytes] more than once with
+// the same key.
+func SignSecp256r1Credential(
+	cred *secp256r1fx.Credential,
+	inputSigners []keychain.Signer,
+	unsignedTxBytes []byte,
+	sigCache map[ids.ShortID]secp256r1fx.Signature,
+) error {
+	if expectedLen := len(inputSigners); expectedLen != len(cred.Sigs) {
+		cred.Sigs = make([]secp256r1fx.Signature, expectedLen)
+	}
+
+	for sigIndex, signer := range inputSigners {
+		if signer == nil {
+			// If we don't have access to the key, then we can't sign this
+			// transaction. However, we can attempt to partially sign it.
+			continue
+		}
+		addr := signer.Address()
+		if sig := cred.Sigs[sigIndex]; sig.PublicKey != emptySecp256r1PublicKey {
+			// If this signature has already been populated, we can just copy
+			// the needed signature for the future.
+			sigCache[addr] = sig
+			continue
+		}
+
+		if sig, exists := sigCache[addr]; exists {
+			// If this key has already produced a signature, we can just copy
+			// the previous signature.
+			cred.Sigs[sigIndex] = sig
+			continue
+		}
+
+		r1Signer, ok := signer.(*Secp256r1Signer)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrNotSecp256r1Signer, addr)
+		}
+		sig, err := r1Signer.SignSecp256r1(unsignedTxBytes)
+		if err != nil {
+			return fmt.Errorf("problem signing tx: %w", err)
+		}
+		cred.Sigs[sigIndex] = *sig
+		sigCache[addr] = *sig
+	}
+	return nil
+}
+
+// TransferOwners returns the owners and amount of [out] if it is a transfer
+// output that the wallet knows how to spend.
+func TransferOwners(out interface{}) (*secp256k1fx.OutputOwners, uint64, bool) {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		return &out.OutputOwners, out.Amt, true
+	case *secp256r1fx.TransferOutput:
+		return &out.OutputOwners.OutputOwners, out.Amt, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// NewTransferInput returns an input of the same fx as [out] that consumes
+// [amount] using the signatures at [sigIndices].
+func NewTransferInput(out interface{}, amount uint64, sigIndices []uint32) avax.TransferableIn {
+	in := secp256k1fx.TransferInput{
+		Amt: amount,
+		Input: secp256k1fx.Input{
+			SigIndices: sigIndices,
+		},
+	}
+	if _, ok := out.(*secp256r1fx.TransferOutput); ok {
+		return &secp256r1fx.TransferInput{TransferInput: in}
+	}
+	return &in
+}
+
+// NewTransferOutput returns an output of the same fx as [out] that sends
+// [amount] to [owners].
+func NewTransferOutput(out interface{}, amount uint64, owners *secp256k1fx.OutputOwners) avax.TransferableOut {
+	if _, ok := out.(*secp256r1fx.TransferOutput); ok {
+		return &secp256r1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256r1fx.OutputOwners{
+				OutputOwners: *owners,
+			},
+		}
+	}
+	return &secp256k1fx.TransferOutput{
+		Amt:          amount,
+		OutputOwners: *owners,
+	}
+}
+
+// NewChangeOutput returns an output of the same fx as [out] that returns
+// [amount] to the spender.
+//
+// secp256k1 change is sent to [changeOwner]. Because secp256r1 and secp256k1
+// addresses can't be used interchangeably, secp256r1 change is returned to the
+// owners of [out] instead.
+func NewChangeOutput(out interface{}, amount uint64, changeOwner *secp256k1fx.OutputOwners) avax.TransferableOut {
+	if out, ok := out.(*secp256r1fx.TransferOutput); ok {
+		changeOwner = &secp256k1fx.OutputOwners{
+			Threshold: out.Threshold,
+			Addrs:     out.Addrs,
+		}
+	}
+	return NewTransferOutput(out, amount, changeOwner)
+}
@@ -61,7 +61,9 @@ func NewWalletWithTxs(ctx context.Context, uri string, kc keychain.Keychain, pre
 	if err != nil {
 		return nil, err
 	}
-	pTXs := make(map[ids.ID]*txs.Tx)
+	// Each subnet is followed by the most recent transfer of its ownership,
+	// so that the transfer sets the owner of the subnet.
+	pTXs := make([]*txs.Tx, 0, len(preloadTXs))
 	pClient := platformvm.NewClient(uri)
 	for _, id := range preloadTXs {
 		txBytes, err := pClient.GetTx(ctx, id)
@@ -72,7 +74,30 @@ func NewWalletWithTxs(ctx context.Context, uri string, kc keychain.Keychain, pre
 		if err != nil {
 			return nil, err
 		}
-		pTXs[id] = tx
+		pTXs = append(pTXs, tx)
+
+		if _, ok := tx.Unsigned.(*txs.CreateSubnetTx); !ok {
+			continue
+		}
+
+		// If the ownership of the subnet was transferred, the current owner
+		// is set by the most recent transfer.
+		owner, _, err := pClient.GetSubnetOwner(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if owner.TxID == id {
+			continue
+		}
+		txBytes, err = pClient.GetTx(ctx, owner.TxID)
+		if err != nil {
+			return nil, err
+		}
+		tx, err = txs.Parse(txs.Codec, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		pTXs = append(pTXs, tx)
 	}
 	return NewWalletWithTxsAndState(uri, pCTX, xCTX, utxos, kc, pTXs), nil
 }
@@ -84,7 +109,7 @@ func NewWalletWithTxsAndState(
 	xCTX x.Context,
 	utxos UTXOs,
 	kc keychain.Keychain,
-	pTXs map[ids.ID]*txs.Tx,
+	pTXs []*txs.Tx,
 ) Wallet {
 	addrs := kc.Addresses()
 	pUTXOs := NewChainUTXOs(constants.PlatformChainID, utxos)
@@ -114,8 +139,7 @@ func NewWalletWithState(
 	utxos UTXOs,
 	kc keychain.Keychain,
 ) Wallet {
-	pTXs := make(map[ids.ID]*txs.Tx)
-	return NewWalletWithTxsAndState(uri, pCTX, xCTX, utxos, kc, pTXs)
+	return NewWalletWithTxsAndState(uri, pCTX, xCTX, utxos, kc, nil)
 }
 
 // Creates a Wallet with the given set of options